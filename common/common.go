@@ -31,6 +31,15 @@ const (
 	EnvVarDebugLog = "DEBUG_LOG"
 	// EnvImagePullPolicy is the env var to set container's ImagePullPolicy
 	EnvImagePullPolicy = "IMAGE_PULL_POLICY"
+	// EnvVarSecretCacheTTL is the env var to set how long the secrets read from the volumes are cached, e.g. "30s", "0" disables the cache
+	EnvVarSecretCacheTTL = "SECRET_CACHE_TTL"
+	// EnvVarLogLevel is the env var to set the log level, i.e. debug, info, warn or error, it takes precedence over DEBUG_LOG
+	EnvVarLogLevel = "LOG_LEVEL"
+	// EnvVarLogLevelFile is the env var to set the path of a file holding the log level, e.g. a key of a mounted ConfigMap,
+	// it takes precedence over LOG_LEVEL and is read again on SIGHUP
+	EnvVarLogLevelFile = "LOG_LEVEL_FILE"
+	// EnvVarLogFormat is the env var to set the format of the logs, either json or console, it takes precedence over DEBUG_LOG
+	EnvVarLogFormat = "LOG_FORMAT"
 )
 
 // EventBus related
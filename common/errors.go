@@ -0,0 +1,69 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrAuthFailed classifies the failures to authenticate to a source, e.g. bad credentials, which retrying doesn't
+	// fix. Connect doesn't retry them.
+	ErrAuthFailed = errors.New("authentication failed")
+	// ErrConnectionFailed classifies the failures to reach a source, e.g. a broker down, which are worth retrying
+	ErrConnectionFailed = errors.New("connection failed")
+)
+
+// classifiedError is an error classified by a sentinel error, errors.Is matches both the sentinel and the
+// errors it wraps.
+type classifiedError struct {
+	err   error
+	class error
+}
+
+func (e *classifiedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *classifiedError) Unwrap() error {
+	return e.err
+}
+
+func (e *classifiedError) Is(target error) bool {
+	return target == e.class
+}
+
+// NewAuthError classifies the error as an authentication failure, see ErrAuthFailed
+func NewAuthError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &classifiedError{err: err, class: ErrAuthFailed}
+}
+
+// NewConnectionError classifies the error as a failure to reach the source, see ErrConnectionFailed
+func NewConnectionError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &classifiedError{err: err, class: ErrConnectionFailed}
+}
+
+// IsRetryable tells whether retrying what failed with the error may succeed, i.e. it isn't an authentication failure
+func IsRetryable(err error) bool {
+	return !errors.Is(err, ErrAuthFailed)
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifiedErrors(t *testing.T) {
+	cause := errors.New("bad user name or password")
+	err := errors.Wrap(NewAuthError(cause), "failed to connect")
+	assert.ErrorIs(t, err, ErrAuthFailed)
+	assert.ErrorIs(t, err, cause)
+	assert.NotErrorIs(t, err, ErrConnectionFailed)
+	assert.Equal(t, "failed to connect: bad user name or password", err.Error())
+	assert.False(t, IsRetryable(err))
+
+	err = NewConnectionError(errors.New("connection refused"))
+	assert.ErrorIs(t, err, ErrConnectionFailed)
+	assert.True(t, IsRetryable(err))
+	assert.True(t, IsRetryable(errors.New("unclassified")))
+
+	assert.NoError(t, NewAuthError(nil))
+	assert.NoError(t, NewConnectionError(nil))
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/argoproj/argo-events/common"
+)
+
+// Formats of the logs
+const (
+	// LogFormatJSON logs a JSON object per line, for the log aggregation
+	LogFormatJSON = "json"
+	// LogFormatConsole logs human-readable lines, the fields following the message as a JSON object
+	LogFormatConsole = "console"
+)
+
+// invalidFormatOnce warns once about an invalid LOG_FORMAT, rather than every time a logger is built
+var invalidFormatOnce sync.Once
+
+// ConfiguredFormat returns the log format set by LOG_FORMAT, or else console if DEBUG_LOG is true, or else json.
+func ConfiguredFormat() (string, error) {
+	if f, ok := os.LookupEnv(common.EnvVarLogFormat); ok && f != "" {
+		switch format := strings.ToLower(strings.TrimSpace(f)); format {
+		case LogFormatJSON, LogFormatConsole:
+			return format, nil
+		default:
+			return LogFormatJSON, errors.Errorf("invalid log format %q, it must be either json or console", strings.TrimSpace(f))
+		}
+	}
+	if debugMode, ok := os.LookupEnv(common.EnvVarDebugLog); ok && debugMode == "true" {
+		return LogFormatConsole, nil
+	}
+	return LogFormatJSON, nil
+}
+
+// setFormat sets the encoding of the logs along with the encoder configuration fitting it: the JSON logs keep the
+// keys and the epoch timestamps of the production configuration, the console logs print ISO8601 timestamps.
+func setFormat(config *zap.Config, format string) {
+	config.Encoding = format
+	if format == LogFormatConsole {
+		config.EncoderConfig = zap.NewDevelopmentEncoderConfig()
+		return
+	}
+	config.EncoderConfig = zap.NewProductionEncoderConfig()
+}
+
+// warnInvalidFormat warns with the logger built in the json format, the fallback, that the configured format is invalid
+func warnInvalidFormat(log *zap.SugaredLogger, err error) {
+	log.Warnw("falling back to the json log format", zap.Error(err))
+}
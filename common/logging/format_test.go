@@ -0,0 +1,105 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/argoproj/argo-events/common"
+)
+
+func TestConfiguredFormat(t *testing.T) {
+	format, err := ConfiguredFormat()
+	assert.NoError(t, err)
+	assert.Equal(t, LogFormatJSON, format)
+
+	t.Setenv(common.EnvVarDebugLog, "true")
+	format, err = ConfiguredFormat()
+	assert.NoError(t, err)
+	assert.Equal(t, LogFormatConsole, format)
+
+	t.Setenv(common.EnvVarLogFormat, "JSON")
+	format, err = ConfiguredFormat()
+	assert.NoError(t, err)
+	assert.Equal(t, LogFormatJSON, format)
+
+	t.Setenv(common.EnvVarLogFormat, "text")
+	format, err = ConfiguredFormat()
+	assert.Error(t, err)
+	assert.Equal(t, `invalid log format "text", it must be either json or console`, err.Error())
+	assert.Equal(t, LogFormatJSON, format)
+}
+
+// logLine builds a logger in the format writing to a file and returns the line logged with the fields
+func logLine(t *testing.T, format string, fields ...interface{}) string {
+	config := zap.NewProductionConfig()
+	setFormat(&config, format)
+	path := filepath.Join(t.TempDir(), "log")
+	config.OutputPaths = []string{path}
+	logger, err := config.Build()
+	assert.NoError(t, err)
+	logger.Sugar().With(LabelEventSourceType, "emitter", LabelEventName, "example").Infow("dispatching event on data channel...", fields...)
+	_ = logger.Sync()
+	content, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	return strings.TrimSpace(string(content))
+}
+
+func TestFormatFields(t *testing.T) {
+	fields := []interface{}{zap.String("type", "message"), zap.Int("bytes", 42), zap.Any("topicParams", map[string]string{"id": "42"})}
+
+	var entry map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(logLine(t, LogFormatJSON, fields...)), &entry))
+	assert.Equal(t, "info", entry["level"])
+	assert.Equal(t, "dispatching event on data channel...", entry["msg"])
+	assert.Equal(t, "emitter", entry[LabelEventSourceType])
+	assert.Equal(t, "example", entry[LabelEventName])
+	assert.Equal(t, "message", entry["type"])
+	assert.Equal(t, float64(42), entry["bytes"])
+	assert.Equal(t, map[string]interface{}{"id": "42"}, entry["topicParams"])
+
+	line := logLine(t, LogFormatConsole, fields...)
+	parts := strings.Split(line, "\t")
+	assert.Equal(t, "INFO", parts[1])
+	assert.Equal(t, "dispatching event on data channel...", parts[3])
+	// the fields follow the message as a JSON object
+	entry = nil
+	assert.NoError(t, json.Unmarshal([]byte(parts[len(parts)-1]), &entry))
+	assert.Equal(t, "emitter", entry[LabelEventSourceType])
+	assert.Equal(t, float64(42), entry["bytes"])
+	assert.Equal(t, map[string]interface{}{"id": "42"}, entry["topicParams"])
+}
+
+func TestWarnInvalidFormat(t *testing.T) {
+	t.Setenv(common.EnvVarLogFormat, "text")
+	_, err := ConfiguredFormat()
+	assert.Error(t, err)
+	core, logs := observer.New(zap.WarnLevel)
+	warnInvalidFormat(zap.New(core).Sugar(), err)
+	assert.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	assert.Equal(t, "falling back to the json log format", entry.Message)
+	assert.Contains(t, entry.ContextMap()["error"], `invalid log format "text"`)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/argoproj/argo-events/common"
+)
+
+var (
+	// level is the level shared by the loggers, so that changing it applies to all of them at runtime
+	level     = zap.NewAtomicLevel()
+	levelOnce sync.Once
+)
+
+// sharedLevel returns the level shared by the loggers, set to the configured level the first time
+func sharedLevel() zap.AtomicLevel {
+	levelOnce.Do(func() {
+		l, err := ConfiguredLevel()
+		if err != nil {
+			// the loggers aren't built yet, the level falls back to info
+			l = zapcore.InfoLevel
+		}
+		level.SetLevel(l)
+	})
+	return level
+}
+
+// ConfiguredLevel returns the log level read from the file set by LOG_LEVEL_FILE, or else set by LOG_LEVEL,
+// or else debug if DEBUG_LOG is true, or else info.
+func ConfiguredLevel() (zapcore.Level, error) {
+	if path, ok := os.LookupEnv(common.EnvVarLogLevelFile); ok && path != "" {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return zapcore.InfoLevel, errors.Wrapf(err, "failed to read the log level file %s", path)
+		}
+		return parseLevel(string(content))
+	}
+	if l, ok := os.LookupEnv(common.EnvVarLogLevel); ok && l != "" {
+		return parseLevel(l)
+	}
+	if debugMode, ok := os.LookupEnv(common.EnvVarDebugLog); ok && debugMode == "true" {
+		return zapcore.DebugLevel, nil
+	}
+	return zapcore.InfoLevel, nil
+}
+
+func parseLevel(s string) (zapcore.Level, error) {
+	var l zapcore.Level
+	if err := l.UnmarshalText([]byte(strings.TrimSpace(s))); err != nil {
+		return zapcore.InfoLevel, errors.Errorf("invalid log level %q, it must be one of debug, info, warn or error", strings.TrimSpace(s))
+	}
+	return l, nil
+}
+
+// ReloadLevelOnSignal reads the configured log level again each time the process receives a SIGHUP and applies
+// it to all the loggers, until the context is done. A level which fails to be read leaves the current one.
+func ReloadLevelOnSignal(ctx context.Context, logger *zap.SugaredLogger) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	go func() {
+		defer signal.Stop(signals)
+		for {
+			select {
+			case <-signals:
+				reloadLevel(logger)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func reloadLevel(logger *zap.SugaredLogger) {
+	l, err := ConfiguredLevel()
+	if err != nil {
+		logger.Errorw("failed to reload the log level, keeping the current one", zap.Stringer("level", sharedLevel().Level()), zap.Error(err))
+		return
+	}
+	previous := sharedLevel().Level()
+	sharedLevel().SetLevel(l)
+	logger.Infow("reloaded the log level", zap.Stringer("previous", previous), zap.Stringer("level", l))
+}
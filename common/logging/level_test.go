@@ -0,0 +1,76 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/argoproj/argo-events/common"
+)
+
+func TestConfiguredLevel(t *testing.T) {
+	t.Setenv(common.EnvVarDebugLog, "true")
+	l, err := ConfiguredLevel()
+	assert.NoError(t, err)
+	assert.Equal(t, zapcore.DebugLevel, l)
+
+	t.Setenv(common.EnvVarLogLevel, "warn")
+	l, err = ConfiguredLevel()
+	assert.NoError(t, err)
+	assert.Equal(t, zapcore.WarnLevel, l)
+
+	path := filepath.Join(t.TempDir(), "level")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("error\n"), 0644))
+	t.Setenv(common.EnvVarLogLevelFile, path)
+	l, err = ConfiguredLevel()
+	assert.NoError(t, err)
+	assert.Equal(t, zapcore.ErrorLevel, l)
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte("verbose"), 0644))
+	_, err = ConfiguredLevel()
+	assert.Error(t, err)
+	assert.Equal(t, `invalid log level "verbose", it must be one of debug, info, warn or error`, err.Error())
+}
+
+func TestReloadLevel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "level")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("info"), 0644))
+	t.Setenv(common.EnvVarLogLevelFile, path)
+	reloadLevel(zap.NewNop().Sugar())
+	logger := NewArgoEventsLogger().With(LabelEventName, "test")
+	assert.False(t, logger.Desugar().Core().Enabled(zapcore.DebugLevel))
+
+	// the loggers already built follow the reloaded level
+	assert.NoError(t, ioutil.WriteFile(path, []byte("debug"), 0644))
+	reloadLevel(zap.NewNop().Sugar())
+	assert.True(t, logger.Desugar().Core().Enabled(zapcore.DebugLevel))
+
+	// an invalid level keeps the current one
+	assert.NoError(t, ioutil.WriteFile(path, []byte("verbose"), 0644))
+	reloadLevel(zap.NewNop().Sugar())
+	assert.True(t, logger.Desugar().Core().Enabled(zapcore.DebugLevel))
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte("info"), 0644))
+	reloadLevel(zap.NewNop().Sugar())
+	assert.False(t, logger.Desugar().Core().Enabled(zapcore.DebugLevel))
+}
@@ -51,11 +51,24 @@ func NewArgoEventsLogger() *zap.SugaredLogger {
 	}
 	// Config customization goes here if any
 	config.OutputPaths = []string{"stdout"}
+	format, formatErr := ConfiguredFormat()
+	if formatErr != nil {
+		// the logger isn't built yet, the format falls back to json and the error is logged once it is
+		format = LogFormatJSON
+	}
+	setFormat(&config, format)
+	config.Level = sharedLevel()
 	logger, err := config.Build()
 	if err != nil {
 		panic(err)
 	}
-	return logger.Named("argo-events").Sugar()
+	log := logger.Named("argo-events").Sugar()
+	if formatErr != nil {
+		invalidFormatOnce.Do(func() {
+			warnInvalidFormat(log, formatErr)
+		})
+	}
+	return log
 }
 
 type loggerKey struct{}
@@ -0,0 +1,87 @@
+/*
+Copyright 2020 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"fmt"
+
+	zap "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/argoproj/argo-events/common"
+)
+
+// WithRedaction returns a logger which scrubs the sensitive values from the messages and the fields it logs,
+// see common.Redact. The fields named like a password, a token or a key are replaced altogether.
+func WithRedaction(logger *zap.SugaredLogger) *zap.SugaredLogger {
+	return logger.Desugar().WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &redactingCore{Core: core}
+	})).Sugar()
+}
+
+// redactingCore redacts the entries before writing them to the wrapped core
+type redactingCore struct {
+	zapcore.Core
+}
+
+func (c *redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactingCore{Core: c.Core.With(redactFields(fields))}
+}
+
+func (c *redactingCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *redactingCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	entry.Message = common.Redact(entry.Message)
+	return c.Core.Write(entry, redactFields(fields))
+}
+
+// redactFields redacts the values of the string, error and stringer fields, the other ones, e.g. the numbers
+// and the booleans, can't hold a secret.
+func redactFields(fields []zapcore.Field) []zapcore.Field {
+	redacted := make([]zapcore.Field, 0, len(fields))
+	for _, field := range fields {
+		var value string
+		switch field.Type {
+		case zapcore.StringType:
+			value = field.String
+		case zapcore.ErrorType:
+			err, ok := field.Interface.(error)
+			if !ok || err == nil {
+				redacted = append(redacted, field)
+				continue
+			}
+			value = err.Error()
+		case zapcore.StringerType:
+			value = fmt.Sprint(field.Interface)
+		default:
+			redacted = append(redacted, field)
+			continue
+		}
+		if common.IsSensitiveKey(field.Key) {
+			value = common.Redacted
+		} else {
+			value = common.Redact(value)
+		}
+		redacted = append(redacted, zap.String(field.Key, value))
+	}
+	return redacted
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package common
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -38,6 +39,9 @@ var (
 		Factor:   &defaultFactor,
 		Jitter:   &defaultJitter,
 	}
+
+	// ErrMaxElapsedTimeExceeded is the terminal error of the retries which exceeded the max elapsed time of the backoff
+	ErrMaxElapsedTimeExceeded = errors.New("max elapsed time of the backoff exceeded")
 )
 
 // IsRetryableKubeAPIError returns if the error is a retryable kubernetes error
@@ -57,15 +61,11 @@ func Convert2WaitBackoff(backoff *apicommon.Backoff) (*wait.Backoff, error) {
 	if d == nil {
 		d = &defaultDuration
 	}
-	if d.Type == apicommon.Int64 {
-		result.Duration = time.Duration(d.Int64Value())
-	} else {
-		parsedDuration, err := time.ParseDuration(d.StrVal)
-		if err != nil {
-			return nil, err
-		}
-		result.Duration = parsedDuration
+	duration, err := toDuration(d)
+	if err != nil {
+		return nil, err
 	}
+	result.Duration = duration
 
 	factor := backoff.Factor
 	if factor == nil {
@@ -95,7 +95,59 @@ func Convert2WaitBackoff(backoff *apicommon.Backoff) (*wait.Backoff, error) {
 	return &result, nil
 }
 
+// toDuration converts a duration in nanoseconds or a string like "1s"
+func toDuration(d *apicommon.Int64OrString) (time.Duration, error) {
+	if d.Type == apicommon.Int64 {
+		return time.Duration(d.Int64Value()), nil
+	}
+	return time.ParseDuration(d.StrVal)
+}
+
+// ConnectionObserver records the connection attempts of ConnectWithContext, e.g. as metrics.
+type ConnectionObserver interface {
+	// ConnectionAttempt records an attempt and whether it succeeded
+	ConnectionAttempt(eventSourceName, eventName string, success bool)
+	// ConnectionLatency records how long a successful attempt took to connect, in seconds
+	ConnectionLatency(eventSourceName, eventName string, seconds float64)
+}
+
+type connectionObserverKey struct{}
+
+type connectionObserver struct {
+	ConnectionObserver
+	eventSourceName string
+	eventName       string
+}
+
+// WithConnectionObserver returns a copy of the context whose connection attempts made by ConnectWithContext are
+// recorded by the observer for the event source and event names.
+func WithConnectionObserver(ctx context.Context, observer ConnectionObserver, eventSourceName, eventName string) context.Context {
+	return context.WithValue(ctx, connectionObserverKey{}, &connectionObserver{
+		ConnectionObserver: observer,
+		eventSourceName:    eventSourceName,
+		eventName:          eventName,
+	})
+}
+
+// Connect retries conn with the given backoff until it succeeds or the steps are exhausted.
+// Each retry interval is randomized by the backoff jitter so that many clients restarting
+// at once do not reconnect in lockstep. If the backoff has a max elapsed time, it gives up with
+// ErrMaxElapsedTimeExceeded once exceeded, which the outer retries don't retry either.
+// An error classified as ErrAuthFailed isn't retried, so that a source isn't hammered with bad credentials.
 func Connect(backoff *apicommon.Backoff, conn func() error) error {
+	return ConnectWithContext(context.Background(), backoff, conn)
+}
+
+// ConnectWithContext is like Connect, but stops retrying as soon as the context is cancelled
+// and returns ctx.Err() in that case. Each attempt is recorded by the observer of the context, if any.
+func ConnectWithContext(ctx context.Context, backoff *apicommon.Backoff, conn func() error) error {
+	return ConnectWithMaxDelay(ctx, backoff, 0, conn)
+}
+
+// ConnectWithMaxDelay is like ConnectWithContext, but the factor of the backoff doesn't grow the delay between the
+// attempts beyond maxDelay, or beyond the duration of the backoff if longer, before the jitter, e.g. so that unlimited
+// steps don't end up waiting for ever. The delay isn't capped if maxDelay isn't positive.
+func ConnectWithMaxDelay(ctx context.Context, backoff *apicommon.Backoff, maxDelay time.Duration, conn func() error) error {
 	if backoff == nil {
 		backoff = &DefaultBackoff
 	}
@@ -103,13 +155,45 @@ func Connect(backoff *apicommon.Backoff, conn func() error) error {
 	if err != nil {
 		return errors.Wrap(err, "invalid backoff configuration")
 	}
-	if waitErr := wait.ExponentialBackoff(*b, func() (bool, error) {
-		if err = conn(); err != nil {
+	var maxElapsedTime time.Duration
+	if backoff.MaxElapsedTime != nil {
+		if maxElapsedTime, err = toDuration(backoff.MaxElapsedTime); err != nil {
+			return errors.Wrap(err, "invalid backoff configuration")
+		}
+	}
+	observer, _ := ctx.Value(connectionObserverKey{}).(*connectionObserver)
+	start := time.Now()
+	if waitErr := exponentialBackoff(ctx, *b, maxDelay, func() (bool, error) {
+		attemptStart := time.Now()
+		err = conn()
+		if observer != nil {
+			observer.ConnectionAttempt(observer.eventSourceName, observer.eventName, err == nil)
+			if err == nil {
+				observer.ConnectionLatency(observer.eventSourceName, observer.eventName, time.Since(attemptStart).Seconds())
+			}
+		}
+		if err != nil {
+			if errors.Is(err, ErrMaxElapsedTimeExceeded) {
+				// a nested retry gave up, retrying it again would bypass its ceiling
+				return false, err
+			}
+			if !IsRetryable(err) {
+				return false, err
+			}
+			if elapsed := time.Since(start); maxElapsedTime > 0 && elapsed >= maxElapsedTime {
+				return false, fmt.Errorf("%w after %v: %v", ErrMaxElapsedTimeExceeded, elapsed.Round(time.Millisecond), err)
+			}
 			// return "false, err" will cover waitErr
 			return false, nil
 		}
 		return true, nil
 	}); waitErr != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if errors.Is(waitErr, ErrMaxElapsedTimeExceeded) || !IsRetryable(waitErr) {
+			return waitErr
+		}
 		if err != nil {
 			return fmt.Errorf("%v: %v", waitErr, err)
 		} else {
@@ -118,3 +202,64 @@ func Connect(backoff *apicommon.Backoff, conn func() error) error {
 	}
 	return nil
 }
+
+// exponentialBackoff is wait.ExponentialBackoffWithContext whose delay stops growing at maxDelay, if positive. The
+// Cap of the wait backoff doesn't fit, it stops the retries once reached.
+func exponentialBackoff(ctx context.Context, backoff wait.Backoff, maxDelay time.Duration, condition wait.ConditionFunc) error {
+	if maxDelay <= 0 {
+		return wait.ExponentialBackoffWithContext(ctx, backoff, condition)
+	}
+	if backoff.Duration > maxDelay {
+		maxDelay = backoff.Duration
+	}
+	for backoff.Steps > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if ok, err := condition(); err != nil || ok {
+			return err
+		}
+		if backoff.Steps == 1 {
+			break
+		}
+		delay := backoff.Step()
+		if backoff.Duration > maxDelay {
+			backoff.Duration = maxDelay
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return wait.ErrWaitTimeout
+}
+
+// ReconnectWithContext is like ConnectWithContext, but waits for the first duration of the backoff before the first
+// attempt, so that a connection lost right after it succeeded, e.g. to a server closing it right away, isn't
+// retried in a hot loop.
+func ReconnectWithContext(ctx context.Context, backoff *apicommon.Backoff, conn func() error) error {
+	if backoff == nil {
+		backoff = &DefaultBackoff
+	}
+	b, err := Convert2WaitBackoff(backoff)
+	if err != nil {
+		return errors.Wrap(err, "invalid backoff configuration")
+	}
+	delay := b.Duration
+	if b.Jitter > 0 {
+		delay = wait.Jitter(delay, b.Jitter)
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+	}
+	return ConnectWithContext(ctx, backoff, conn)
+}
@@ -16,6 +16,7 @@ limitations under the License.
 package common
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
@@ -99,3 +100,194 @@ func TestConnectRetry(t *testing.T) {
 	assert.Equal(t, 0, count)
 	assert.True(t, elapsed >= 2*time.Second)
 }
+
+func TestConnectAuthFailed(t *testing.T) {
+	attempts := 0
+	err := Connect(&DefaultBackoff, func() error {
+		attempts++
+		return NewAuthError(fmt.Errorf("bad user name or password"))
+	})
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, ErrAuthFailed)
+	assert.Equal(t, "bad user name or password", err.Error())
+	assert.Equal(t, 1, attempts)
+}
+
+func TestConvert2WaitBackoffJitter(t *testing.T) {
+	factor := apicommon.NewAmount("1.0")
+	duration := apicommon.FromString("100ms")
+
+	jitter := apicommon.NewAmount("0")
+	b, err := Convert2WaitBackoff(&apicommon.Backoff{Duration: &duration, Factor: &factor, Jitter: &jitter, Steps: 5})
+	assert.NoError(t, err)
+	for i := 0; i < 4; i++ {
+		assert.Equal(t, 100*time.Millisecond, b.Step())
+	}
+
+	jitter = apicommon.NewAmount("0.5")
+	b, err = Convert2WaitBackoff(&apicommon.Backoff{Duration: &duration, Factor: &factor, Jitter: &jitter, Steps: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, 0.5, b.Jitter)
+	for i := 0; i < 4; i++ {
+		d := b.Step()
+		assert.True(t, d >= 100*time.Millisecond)
+		assert.True(t, d <= 150*time.Millisecond)
+	}
+}
+
+func TestConnectWithContext(t *testing.T) {
+	err := ConnectWithContext(context.Background(), nil, func() error {
+		return nil
+	})
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	duration := apicommon.FromString("1h")
+	backoff := apicommon.Backoff{Duration: &duration, Steps: 5}
+	count := 0
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		cancel()
+	}()
+	start := time.Now()
+	err = ConnectWithContext(ctx, &backoff, func() error {
+		count++
+		return fmt.Errorf("new error")
+	})
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 1, count)
+	assert.True(t, time.Since(start) < time.Minute)
+}
+
+func TestReconnectWithContext(t *testing.T) {
+	duration := apicommon.FromString("100ms")
+	jitter := apicommon.NewAmount("0")
+	backoff := apicommon.Backoff{Duration: &duration, Jitter: &jitter, Steps: 5}
+	count := 0
+	start := time.Now()
+	err := ReconnectWithContext(context.Background(), &backoff, func() error {
+		count++
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+
+	// the context cancelled while waiting stops the reconnection before any attempt
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = ReconnectWithContext(ctx, &backoff, func() error {
+		count++
+		return nil
+	})
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestConnectWithMaxDelay(t *testing.T) {
+	duration := apicommon.FromString("10ms")
+	factor := apicommon.NewAmount("10")
+	jitter := apicommon.NewAmount("0")
+	backoff := apicommon.Backoff{Duration: &duration, Factor: &factor, Jitter: &jitter, Steps: 6}
+	count := 0
+	start := time.Now()
+	// the delays are 10ms then 20ms, rather than 10ms, 100ms, 1s, 10s and 100s
+	err := ConnectWithMaxDelay(context.Background(), &backoff, 20*time.Millisecond, func() error {
+		count++
+		return fmt.Errorf("broker unreachable")
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "broker unreachable")
+	assert.Equal(t, 6, count)
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	// the attempts still stop once the context is cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = ConnectWithMaxDelay(ctx, &backoff, 20*time.Millisecond, func() error {
+		count++
+		return nil
+	})
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 6, count)
+}
+
+func TestConnectMaxElapsedTime(t *testing.T) {
+	duration := apicommon.FromString("10ms")
+	maxElapsedTime := apicommon.FromString("50ms")
+	factor := apicommon.NewAmount("1")
+	jitter := apicommon.NewAmount("0")
+	backoff := apicommon.Backoff{Duration: &duration, Factor: &factor, Jitter: &jitter, Steps: 1000, MaxElapsedTime: &maxElapsedTime}
+	count := 0
+	start := time.Now()
+	err := Connect(&backoff, func() error {
+		count++
+		return fmt.Errorf("broker unreachable")
+	})
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, ErrMaxElapsedTimeExceeded)
+	assert.Contains(t, err.Error(), "broker unreachable")
+	assert.True(t, count > 1)
+	assert.True(t, time.Since(start) < time.Second)
+
+	// an outer retry gives up as soon as a nested one exceeded its max elapsed time
+	outer := 0
+	err = Connect(&apicommon.Backoff{Duration: &duration, Factor: &factor, Jitter: &jitter, Steps: 5}, func() error {
+		outer++
+		return fmt.Errorf("failed to start: %w", err)
+	})
+	assert.ErrorIs(t, err, ErrMaxElapsedTimeExceeded)
+	assert.Equal(t, 1, outer)
+
+	// no ceiling by default
+	count = 0
+	err = Connect(&apicommon.Backoff{Duration: &duration, Factor: &factor, Jitter: &jitter, Steps: 3}, func() error {
+		count++
+		return fmt.Errorf("broker unreachable")
+	})
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrMaxElapsedTimeExceeded)
+	assert.Equal(t, 3, count)
+}
+
+func TestConnectInvalidMaxElapsedTime(t *testing.T) {
+	maxElapsedTime := apicommon.FromString("soon")
+	err := Connect(&apicommon.Backoff{MaxElapsedTime: &maxElapsedTime}, func() error {
+		return nil
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid backoff configuration")
+}
+
+type fakeConnectionObserver struct {
+	attempts  []bool
+	latencies []float64
+}
+
+func (o *fakeConnectionObserver) ConnectionAttempt(eventSourceName, eventName string, success bool) {
+	o.attempts = append(o.attempts, success)
+}
+
+func (o *fakeConnectionObserver) ConnectionLatency(eventSourceName, eventName string, seconds float64) {
+	o.latencies = append(o.latencies, seconds)
+}
+
+func TestConnectWithConnectionObserver(t *testing.T) {
+	observer := &fakeConnectionObserver{}
+	ctx := WithConnectionObserver(context.Background(), observer, "test-source", "test-event")
+	duration := apicommon.FromString("1ms")
+	count := 0
+	err := ConnectWithContext(ctx, &apicommon.Backoff{Duration: &duration, Steps: 5}, func() error {
+		count++
+		if count < 3 {
+			return fmt.Errorf("new error")
+		}
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{false, false, true}, observer.attempts)
+	assert.Len(t, observer.latencies, 1)
+	assert.GreaterOrEqual(t, observer.latencies[0], 0.01)
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// DefaultSecretCacheTTL is the default duration the secrets read from the volumes are cached
+const DefaultSecretCacheTTL = 10 * time.Second
+
+var secretCache = newSecretCacheFromEnv()
+
+// cachedSecret is a secret value along with its expiration time
+type cachedSecret struct {
+	value     string
+	expiresAt time.Time
+}
+
+// ttlCache caches the values read by key until their TTL elapses, it is goroutine-safe.
+type ttlCache struct {
+	lock    sync.RWMutex
+	ttl     time.Duration
+	entries map[string]cachedSecret
+}
+
+func newTTLCache(ttl time.Duration) *ttlCache {
+	return &ttlCache{
+		ttl:     ttl,
+		entries: make(map[string]cachedSecret),
+	}
+}
+
+// newSecretCacheFromEnv returns the secret cache with the TTL set by the env var, if any and valid
+func newSecretCacheFromEnv() *ttlCache {
+	ttl := DefaultSecretCacheTTL
+	if x, ok := os.LookupEnv(EnvVarSecretCacheTTL); ok {
+		if d, err := time.ParseDuration(x); err == nil && d >= 0 {
+			ttl = d
+		}
+	}
+	return newTTLCache(ttl)
+}
+
+// get returns the cached value of the key, or loads it if missing or expired. Load errors are not cached.
+func (c *ttlCache) get(key string, load func() (string, error)) (string, error) {
+	c.lock.RLock()
+	ttl := c.ttl
+	entry, ok := c.entries[key]
+	c.lock.RUnlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.value, nil
+	}
+
+	value, err := load()
+	if err != nil {
+		return "", err
+	}
+	if ttl > 0 {
+		c.lock.Lock()
+		c.entries[key] = cachedSecret{value: value, expiresAt: time.Now().Add(ttl)}
+		c.lock.Unlock()
+	}
+	return value, nil
+}
+
+// invalidate removes the key from the cache, or all the keys if key is empty
+func (c *ttlCache) invalidate(key string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if key == "" {
+		c.entries = make(map[string]cachedSecret)
+		return
+	}
+	delete(c.entries, key)
+}
+
+// setTTL changes the TTL of the cache and drops the cached values
+func (c *ttlCache) setTTL(ttl time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.ttl = ttl
+	c.entries = make(map[string]cachedSecret)
+}
+
+// SetSecretCacheTTL sets how long the secrets read by GetSecretFromVolume are cached, 0 disables the cache.
+// It defaults to DefaultSecretCacheTTL, or to the value of the SECRET_CACHE_TTL env var.
+func SetSecretCacheTTL(ttl time.Duration) {
+	secretCache.setTTL(ttl)
+}
+
+// InvalidateSecretCache drops the cached value of the secret, e.g. once it has been rotated,
+// so that the next read gets the new value. A nil selector drops all the cached secrets.
+func InvalidateSecretCache(selector *v1.SecretKeySelector) {
+	if selector == nil {
+		secretCache.invalidate("")
+		return
+	}
+	filePath, err := GetSecretVolumePath(selector)
+	if err != nil {
+		return
+	}
+	secretCache.invalidate(filePath)
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestTTLCache(t *testing.T) {
+	c := newTTLCache(time.Hour)
+	loads := 0
+	load := func() (string, error) {
+		loads++
+		return fmt.Sprintf("value-%d", loads), nil
+	}
+
+	value, err := c.get("key", load)
+	assert.NoError(t, err)
+	assert.Equal(t, "value-1", value)
+	value, err = c.get("key", load)
+	assert.NoError(t, err)
+	assert.Equal(t, "value-1", value)
+	assert.Equal(t, 1, loads)
+
+	c.invalidate("key")
+	value, _ = c.get("key", load)
+	assert.Equal(t, "value-2", value)
+
+	c.invalidate("")
+	value, _ = c.get("key", load)
+	assert.Equal(t, "value-3", value)
+
+	t.Run("test errors are not cached", func(t *testing.T) {
+		c := newTTLCache(time.Hour)
+		_, err := c.get("key", func() (string, error) {
+			return "", fmt.Errorf("not mounted yet")
+		})
+		assert.Error(t, err)
+		value, err := c.get("key", func() (string, error) {
+			return "value", nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "value", value)
+	})
+
+	t.Run("test expiration", func(t *testing.T) {
+		c := newTTLCache(10 * time.Millisecond)
+		value, _ := c.get("key", func() (string, error) { return "old", nil })
+		assert.Equal(t, "old", value)
+		time.Sleep(20 * time.Millisecond)
+		value, _ = c.get("key", func() (string, error) { return "new", nil })
+		assert.Equal(t, "new", value)
+	})
+
+	t.Run("test disabled", func(t *testing.T) {
+		c := newTTLCache(time.Hour)
+		c.setTTL(0)
+		value, _ := c.get("key", func() (string, error) { return "old", nil })
+		assert.Equal(t, "old", value)
+		value, _ = c.get("key", func() (string, error) { return "new", nil })
+		assert.Equal(t, "new", value)
+	})
+
+	t.Run("test concurrent reads", func(t *testing.T) {
+		c := newTTLCache(time.Hour)
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				value, err := c.get("key", func() (string, error) { return "value", nil })
+				assert.NoError(t, err)
+				assert.Equal(t, "value", value)
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+func TestInvalidateSecretCache(t *testing.T) {
+	selector := &corev1.SecretKeySelector{
+		Key: "fake-key",
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: "fake-name",
+		},
+	}
+	path, _ := GetSecretVolumePath(selector)
+	_, _ = secretCache.get(path, func() (string, error) { return "value", nil })
+	InvalidateSecretCache(selector)
+	secretCache.lock.RLock()
+	_, ok := secretCache.entries[path]
+	secretCache.lock.RUnlock()
+	assert.False(t, ok)
+}
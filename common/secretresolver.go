@@ -0,0 +1,56 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+)
+
+// SecretResolver resolves the value of the secret referenced by a secret key selector
+type SecretResolver interface {
+	Resolve(ref *v1.SecretKeySelector) (string, error)
+	// Invalidate drops the cached value of the secret, e.g. once the source rejected it, so that the next
+	// resolution reads the rotated value
+	Invalidate(ref *v1.SecretKeySelector)
+}
+
+// VolumeSecretResolver resolves the secrets from the volumes the K8s secrets are mounted to, see GetSecretFromVolume
+type VolumeSecretResolver struct{}
+
+// Resolve reads the secret from its volume
+func (VolumeSecretResolver) Resolve(ref *v1.SecretKeySelector) (string, error) {
+	return GetSecretFromVolume(ref)
+}
+
+// Invalidate drops the cached value of the secret, see InvalidateSecretCache
+func (VolumeSecretResolver) Invalidate(ref *v1.SecretKeySelector) {
+	if ref != nil {
+		InvalidateSecretCache(ref)
+	}
+}
+
+// NewSecretResolver returns the resolver of the secret backend, or a VolumeSecretResolver if no backend is set.
+// The resolver doesn't connect to the backend until the first secret is resolved.
+func NewSecretResolver(backend *apicommon.SecretBackend, log *zap.SugaredLogger) SecretResolver {
+	if backend == nil || backend.Vault == nil {
+		return VolumeSecretResolver{}
+	}
+	return NewVaultSecretResolver(backend.Vault, log)
+}
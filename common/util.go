@@ -30,6 +30,7 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -145,19 +146,22 @@ func GenerateEnvFromSecretSpec(selector *v1.SecretKeySelector) v1.EnvFromSource
 }
 
 // GetSecretFromVolume retrieves the value of mounted secret volume
-// "/argo-events/secrets/${secretRef.name}/${secretRef.key}" is expected to be the file path
+// "/argo-events/secrets/${secretRef.name}/${secretRef.key}" is expected to be the file path.
+// The value is cached for the secret cache TTL, see SetSecretCacheTTL and InvalidateSecretCache.
 func GetSecretFromVolume(selector *v1.SecretKeySelector) (string, error) {
 	filePath, err := GetSecretVolumePath(selector)
 	if err != nil {
 		return "", err
 	}
-	data, err := ioutil.ReadFile(filePath)
-	if err != nil {
-		return "", errors.Wrapf(err, "failed to get secret value of name: %s, key: %s", selector.Name, selector.Key)
-	}
-	// Secrets edited by tools like "vim" always have an extra invisible "\n" in the end,
-	// and it's often neglected, but it makes differences for some of the applications.
-	return strings.TrimSuffix(string(data), "\n"), nil
+	return secretCache.get(filePath, func() (string, error) {
+		data, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to get secret value of name: %s, key: %s", selector.Name, selector.Key)
+		}
+		// Secrets edited by tools like "vim" always have an extra invisible "\n" in the end,
+		// and it's often neglected, but it makes differences for some of the applications.
+		return strings.TrimSuffix(string(data), "\n"), nil
+	})
 }
 
 // GetSecretVolumePath returns the path of the mounted secret
@@ -210,70 +214,112 @@ func GenerateEnvFromConfigMapSpec(selector *v1.ConfigMapKeySelector) v1.EnvFromS
 	}
 }
 
-// GetTLSConfig returns a tls configuration for given cert and key or skips the certs if InsecureSkipVerify is true.
-func GetTLSConfig(config *apicommon.TLSConfig) (*tls.Config, error) {
+// GetTLSConfig returns a tls configuration for given cert and key or skips the certs if InsecureSkipVerify is true,
+// which is warned about with the logger. The certificate of the server is verified against the ServerName if set,
+// rather than the host connected to.
+func GetTLSConfig(config *apicommon.TLSConfig, log *zap.SugaredLogger) (*tls.Config, error) {
 	if config == nil {
 		return nil, errors.New("TLSConfig is nil")
 	}
 
+	minVersion, err := apicommon.TLSVersion(config.MinVersion)
+	if err != nil {
+		return nil, err
+	}
+	cipherSuites, err := apicommon.TLSCipherSuites(config.CipherSuites)
+	if err != nil {
+		return nil, err
+	}
+
 	if config.InsecureSkipVerify {
+		if config.ServerName != "" {
+			return nil, errors.New("invalid tls config, serverName can't be set along with insecureSkipVerify, the certificate isn't verified against it")
+		}
+		log.Warn("insecureSkipVerify is set, the TLS certificate of the server is NOT verified, which must not be used in production")
 		tlsConfig := &tls.Config{
 			InsecureSkipVerify: true,
 			ClientAuth:         0,
+			MinVersion:         minVersion,
+			CipherSuites:       cipherSuites,
 		}
 		return tlsConfig, nil
 	}
 
+	// inline PEM data takes precedence over the mounted secret of the same item
 	var caCertPath, clientCertPath, clientKeyPath string
-	var err error
-	if config.CACertSecret != nil {
+	if config.CACertSecret != nil && len(config.CACertData) == 0 {
 		caCertPath, err = GetSecretVolumePath(config.CACertSecret)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	if config.ClientCertSecret != nil {
+	if config.ClientCertSecret != nil && len(config.ClientCertData) == 0 {
 		clientCertPath, err = GetSecretVolumePath(config.ClientCertSecret)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	if config.ClientKeySecret != nil {
+	if config.ClientKeySecret != nil && len(config.ClientKeyData) == 0 {
 		clientKeyPath, err = GetSecretVolumePath(config.ClientKeySecret)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	if len(caCertPath)+len(clientCertPath)+len(clientKeyPath) == 0 {
-		// None of 3 is configured
+	caCertSet := len(caCertPath) > 0 || len(config.CACertData) > 0
+	clientCertSet := len(clientCertPath) > 0 || len(config.ClientCertData) > 0
+	clientKeySet := len(clientKeyPath) > 0 || len(config.ClientKeyData) > 0
+
+	if !caCertSet && !clientCertSet && !clientKeySet && config.ServerName == "" {
+		// None of 3 is configured, nor the server name verified against the system CAs
 		return nil, errors.New("invalid tls config, neither of caCertSecret, clientCertSecret and clientKeySecret is configured")
 	}
 
-	if len(clientCertPath)+len(clientKeyPath) > 0 && len(clientCertPath)*len(clientKeyPath) == 0 {
-		// Only one of clientCertSecret and clientKeySecret is configured
+	if clientCertSet != clientKeySet {
+		// Only one of client cert and client key is configured
 		return nil, errors.New("invalid tls config, both of clientCertSecret and clientKeySecret need to be configured")
 	}
 
-	c := &tls.Config{}
-	if len(caCertPath) > 0 {
-		caCert, err := ioutil.ReadFile(caCertPath)
-		if err != nil {
-			return nil, errors.Wrapf(err, "failed to read ca cert file %s", caCertPath)
+	c := &tls.Config{
+		MinVersion:   minVersion,
+		CipherSuites: cipherSuites,
+		ServerName:   config.ServerName,
+	}
+	if caCertSet {
+		caCert := config.CACertData
+		if len(caCert) == 0 {
+			caCert, err = ioutil.ReadFile(caCertPath)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to read ca cert file %s", caCertPath)
+			}
 		}
 		pool := x509.NewCertPool()
 		pool.AppendCertsFromPEM(caCert)
 		c.RootCAs = pool
 	}
 
-	if len(clientCertPath) > 0 && len(clientKeyPath) > 0 {
-		clientCert, err := tls.LoadX509KeyPair(clientCertPath, clientKeyPath)
+	if clientCertSet && clientKeySet {
+		clientCert := config.ClientCertData
+		if len(clientCert) == 0 {
+			clientCert, err = ioutil.ReadFile(clientCertPath)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to load client cert key pair, failed to read client cert file %s", clientCertPath)
+			}
+		}
+		clientKey := config.ClientKeyData
+		if len(clientKey) == 0 {
+			clientKey, err = ioutil.ReadFile(clientKeyPath)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to load client cert key pair, failed to read client key file %s", clientKeyPath)
+			}
+		}
+		cert, err := tls.X509KeyPair(clientCert, clientKey)
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to load client cert key pair %s", caCertPath)
+			return nil, errors.Wrap(err, "failed to load client cert key pair")
 		}
-		c.Certificates = []tls.Certificate{clientCert}
+		c.Certificates = []tls.Certificate{cert}
 	}
 	return c, nil
 }
@@ -358,36 +404,36 @@ func findTypesRecursive(result *[]interface{}, obj reflect.Value, t reflect.Type
 func GenerateSecretVolumeSpecs(selector *v1.SecretKeySelector) (v1.Volume, v1.VolumeMount) {
 	volName := strings.ReplaceAll("secret-"+selector.Name, "_", "-")
 	return v1.Volume{
-			Name: volName,
-			VolumeSource: v1.VolumeSource{
-				Secret: &v1.SecretVolumeSource{
-					SecretName: selector.Name,
-				},
+		Name: volName,
+		VolumeSource: v1.VolumeSource{
+			Secret: &v1.SecretVolumeSource{
+				SecretName: selector.Name,
 			},
-		}, v1.VolumeMount{
-			Name:      volName,
-			ReadOnly:  true,
-			MountPath: "/argo-events/secrets/" + selector.Name,
-		}
+		},
+	}, v1.VolumeMount{
+		Name:      volName,
+		ReadOnly:  true,
+		MountPath: "/argo-events/secrets/" + selector.Name,
+	}
 }
 
 // GenerateConfigMapVolumeSpecs builds a "volume" and "volumeMount"spec with a configMapKeySelector
 func GenerateConfigMapVolumeSpecs(selector *v1.ConfigMapKeySelector) (v1.Volume, v1.VolumeMount) {
 	volName := strings.ReplaceAll("cm-"+selector.Name, "_", "-")
 	return v1.Volume{
-			Name: volName,
-			VolumeSource: v1.VolumeSource{
-				ConfigMap: &v1.ConfigMapVolumeSource{
-					LocalObjectReference: v1.LocalObjectReference{
-						Name: selector.Name,
-					},
+		Name: volName,
+		VolumeSource: v1.VolumeSource{
+			ConfigMap: &v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{
+					Name: selector.Name,
 				},
 			},
-		}, v1.VolumeMount{
-			Name:      volName,
-			ReadOnly:  true,
-			MountPath: "/argo-events/config/" + selector.Name,
-		}
+		},
+	}, v1.VolumeMount{
+		Name:      volName,
+		ReadOnly:  true,
+		MountPath: "/argo-events/config/" + selector.Name,
+	}
 }
 
 func uniqueVolumes(vols []v1.Volume) []v1.Volume {
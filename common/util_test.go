@@ -17,12 +17,23 @@ limitations under the License.
 package common
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -207,7 +218,7 @@ func fakeTLSConfig(t *testing.T, insecureSkipVerify bool) *apicommon.TLSConfig {
 func TestGetTLSConfig(t *testing.T) {
 	t.Run("test empty", func(t *testing.T) {
 		c := &apicommon.TLSConfig{}
-		_, err := GetTLSConfig(c)
+		_, err := GetTLSConfig(c, zap.NewNop().Sugar())
 		assert.NotNil(t, err)
 		assert.True(t, strings.Contains(err.Error(), "neither of caCertSecret, clientCertSecret and clientKeySecret is configured"))
 	})
@@ -216,7 +227,7 @@ func TestGetTLSConfig(t *testing.T) {
 		c := fakeTLSConfig(t, false)
 		c.CACertSecret = nil
 		c.ClientCertSecret = nil
-		_, err := GetTLSConfig(c)
+		_, err := GetTLSConfig(c, zap.NewNop().Sugar())
 		assert.NotNil(t, err)
 		assert.True(t, strings.Contains(err.Error(), "both of clientCertSecret and clientKeySecret need to be configured"))
 	})
@@ -225,7 +236,7 @@ func TestGetTLSConfig(t *testing.T) {
 		c := fakeTLSConfig(t, false)
 		c.ClientCertSecret = nil
 		c.ClientKeySecret = nil
-		_, err := GetTLSConfig(c)
+		_, err := GetTLSConfig(c, zap.NewNop().Sugar())
 		assert.NotNil(t, err)
 		assert.True(t, strings.Contains(err.Error(), "failed to read ca cert file"))
 	})
@@ -233,19 +244,150 @@ func TestGetTLSConfig(t *testing.T) {
 	t.Run("test clientCertSecret and clientKeySecret are set", func(t *testing.T) {
 		c := fakeTLSConfig(t, false)
 		c.CACertSecret = nil
-		_, err := GetTLSConfig(c)
+		_, err := GetTLSConfig(c, zap.NewNop().Sugar())
 		assert.NotNil(t, err)
 		assert.True(t, strings.Contains(err.Error(), "failed to load client cert key pair"))
 	})
 
 	t.Run("test all of 3 are set", func(t *testing.T) {
 		c := fakeTLSConfig(t, false)
-		_, err := GetTLSConfig(c)
+		_, err := GetTLSConfig(c, zap.NewNop().Sugar())
 		assert.NotNil(t, err)
 		assert.True(t, strings.Contains(err.Error(), "failed to read ca cert file"))
 	})
 }
 
+func fakePEMCertKey(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "fake"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+func TestGetTLSConfigInlineData(t *testing.T) {
+	cert, key := fakePEMCertKey(t)
+
+	t.Run("test all of 3 are inline", func(t *testing.T) {
+		c := &apicommon.TLSConfig{CACertData: cert, ClientCertData: cert, ClientKeyData: key}
+		tlsConfig, err := GetTLSConfig(c, zap.NewNop().Sugar())
+		assert.NoError(t, err)
+		assert.NotNil(t, tlsConfig.RootCAs)
+		assert.Len(t, tlsConfig.Certificates, 1)
+	})
+
+	t.Run("test inline data takes precedence over secrets", func(t *testing.T) {
+		c := fakeTLSConfig(t, false)
+		c.CACertData = cert
+		c.ClientCertData = cert
+		c.ClientKeyData = key
+		tlsConfig, err := GetTLSConfig(c, zap.NewNop().Sugar())
+		assert.NoError(t, err)
+		assert.Len(t, tlsConfig.Certificates, 1)
+	})
+
+	t.Run("test inline client cert without client key", func(t *testing.T) {
+		c := &apicommon.TLSConfig{ClientCertData: cert}
+		_, err := GetTLSConfig(c, zap.NewNop().Sugar())
+		assert.NotNil(t, err)
+		assert.True(t, strings.Contains(err.Error(), "both of clientCertSecret and clientKeySecret need to be configured"))
+	})
+
+	t.Run("test invalid inline client key", func(t *testing.T) {
+		c := &apicommon.TLSConfig{ClientCertData: cert, ClientKeyData: []byte("invalid")}
+		_, err := GetTLSConfig(c, zap.NewNop().Sugar())
+		assert.NotNil(t, err)
+		assert.True(t, strings.Contains(err.Error(), "failed to load client cert key pair"))
+	})
+}
+
+func TestGetTLSConfigServerName(t *testing.T) {
+	cert, _ := fakePEMCertKey(t)
+
+	t.Run("test server name along with ca cert", func(t *testing.T) {
+		c := &apicommon.TLSConfig{CACertData: cert, ServerName: "broker.example.com"}
+		tlsConfig, err := GetTLSConfig(c, zap.NewNop().Sugar())
+		assert.NoError(t, err)
+		assert.Equal(t, "broker.example.com", tlsConfig.ServerName)
+		assert.NotNil(t, tlsConfig.RootCAs)
+	})
+
+	t.Run("test server name verified against the system cas", func(t *testing.T) {
+		c := &apicommon.TLSConfig{ServerName: "broker.example.com"}
+		tlsConfig, err := GetTLSConfig(c, zap.NewNop().Sugar())
+		assert.NoError(t, err)
+		assert.Equal(t, "broker.example.com", tlsConfig.ServerName)
+		assert.Nil(t, tlsConfig.RootCAs)
+		assert.False(t, tlsConfig.InsecureSkipVerify)
+	})
+
+	t.Run("test server name along with insecureSkipVerify", func(t *testing.T) {
+		c := &apicommon.TLSConfig{ServerName: "broker.example.com", InsecureSkipVerify: true}
+		_, err := GetTLSConfig(c, zap.NewNop().Sugar())
+		assert.NotNil(t, err)
+		assert.True(t, strings.Contains(err.Error(), "serverName can't be set along with insecureSkipVerify"))
+	})
+}
+
+func TestGetTLSConfigInsecureWarning(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	log := zap.New(core).Sugar()
+
+	_, err := GetTLSConfig(fakeTLSConfig(t, false), log)
+	assert.Error(t, err)
+	assert.Equal(t, 0, logs.Len())
+
+	_, err = GetTLSConfig(fakeTLSConfig(t, true), log)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, logs.Len())
+	assert.Contains(t, logs.All()[0].Message, "insecureSkipVerify is set")
+}
+
+func TestGetTLSConfigVersionAndCiphers(t *testing.T) {
+	t.Run("test min version and cipher suites", func(t *testing.T) {
+		c := fakeTLSConfig(t, true)
+		c.MinVersion = "1.3"
+		c.CipherSuites = []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_AES_128_GCM_SHA256"}
+		tlsConfig, err := GetTLSConfig(c, zap.NewNop().Sugar())
+		assert.NoError(t, err)
+		assert.Equal(t, uint16(tls.VersionTLS13), tlsConfig.MinVersion)
+		assert.Equal(t, []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_AES_128_GCM_SHA256}, tlsConfig.CipherSuites)
+	})
+
+	t.Run("test defaults", func(t *testing.T) {
+		tlsConfig, err := GetTLSConfig(fakeTLSConfig(t, true), zap.NewNop().Sugar())
+		assert.NoError(t, err)
+		assert.Equal(t, uint16(0), tlsConfig.MinVersion)
+		assert.Nil(t, tlsConfig.CipherSuites)
+	})
+
+	t.Run("test unknown min version", func(t *testing.T) {
+		c := fakeTLSConfig(t, true)
+		c.MinVersion = "TLS1.3"
+		_, err := GetTLSConfig(c, zap.NewNop().Sugar())
+		assert.NotNil(t, err)
+		assert.True(t, strings.Contains(err.Error(), "unknown minVersion"))
+	})
+
+	t.Run("test unknown cipher suite", func(t *testing.T) {
+		c := fakeTLSConfig(t, false)
+		c.CipherSuites = []string{"TLS_FAKE_CIPHER"}
+		_, err := GetTLSConfig(c, zap.NewNop().Sugar())
+		assert.NotNil(t, err)
+		assert.True(t, strings.Contains(err.Error(), "unknown cipher suite \"TLS_FAKE_CIPHER\""))
+	})
+}
+
 func TestElementsMatch(t *testing.T) {
 	assert.True(t, ElementsMatch(nil, nil))
 	assert.True(t, ElementsMatch([]string{"hello"}, []string{"hello"}))
@@ -0,0 +1,301 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+)
+
+const (
+	defaultVaultMountPath = "secret"
+	defaultVaultAuthPath  = "kubernetes"
+	// serviceAccountTokenPath is the path of the service account token of the pod
+	serviceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+)
+
+// vaultAuth is the auth section of the Vault login and token renewal responses
+type vaultAuth struct {
+	ClientToken   string `json:"client_token"`
+	LeaseDuration int64  `json:"lease_duration"`
+	Renewable     bool   `json:"renewable"`
+}
+
+// vaultResponse is the body of the Vault API responses
+type vaultResponse struct {
+	Auth   *vaultAuth             `json:"auth"`
+	Data   map[string]interface{} `json:"data"`
+	Errors []string               `json:"errors"`
+}
+
+// vaultStatusError is the error of a Vault API request which didn't succeed
+type vaultStatusError struct {
+	status int
+	errors []string
+}
+
+func (e *vaultStatusError) Error() string {
+	return fmt.Sprintf("vault responded with status %d: %s", e.status, strings.Join(e.errors, ", "))
+}
+
+// VaultSecretResolver resolves the secrets from a HashiCorp Vault KV secrets engine. It logs in on the first
+// resolution, then renews its token once two thirds of its lease elapsed, or logs in again if the renewal fails
+// or the token isn't renewable. The resolved values are cached like the ones read from the volumes.
+type VaultSecretResolver struct {
+	config *apicommon.VaultSecretBackend
+	log    *zap.SugaredLogger
+	// tokenPath is the path of the service account token used to log in with the Kubernetes auth method
+	tokenPath string
+	now       func() time.Time
+
+	clientOnce sync.Once
+	client     *http.Client
+	clientErr  error
+
+	lock      sync.Mutex
+	token     string
+	renewable bool
+	renewAt   time.Time
+	expiresAt time.Time
+}
+
+// NewVaultSecretResolver returns a resolver of the secrets of the Vault backend
+func NewVaultSecretResolver(config *apicommon.VaultSecretBackend, log *zap.SugaredLogger) *VaultSecretResolver {
+	return &VaultSecretResolver{
+		config:    config,
+		log:       log,
+		tokenPath: serviceAccountTokenPath,
+		now:       time.Now,
+	}
+}
+
+// Resolve reads the field named by the key of the selector from the secret at the path named by its name
+func (r *VaultSecretResolver) Resolve(ref *v1.SecretKeySelector) (string, error) {
+	if ref == nil {
+		return "", errors.New("secret key selector is nil")
+	}
+	return secretCache.get(vaultCacheKey(ref), func() (string, error) {
+		value, err := r.read(ref)
+		var statusErr *vaultStatusError
+		if errors.As(err, &statusErr) && statusErr.status == http.StatusForbidden {
+			// the token may have been revoked, log in again
+			r.resetToken()
+			value, err = r.read(ref)
+		}
+		return value, err
+	})
+}
+
+// Invalidate drops the cached value of the secret
+func (r *VaultSecretResolver) Invalidate(ref *v1.SecretKeySelector) {
+	if ref != nil {
+		secretCache.invalidate(vaultCacheKey(ref))
+	}
+}
+
+// vaultCacheKey returns the key of the secret in the secret cache, apart from the keys of the volumes
+func vaultCacheKey(ref *v1.SecretKeySelector) string {
+	return fmt.Sprintf("vault:%s#%s", ref.Name, ref.Key)
+}
+
+// read reads the field of the secret from the KV secrets engine
+func (r *VaultSecretResolver) read(ref *v1.SecretKeySelector) (string, error) {
+	token, err := r.getToken()
+	if err != nil {
+		return "", err
+	}
+	mountPath := strings.Trim(r.config.MountPath, "/")
+	if mountPath == "" {
+		mountPath = defaultVaultMountPath
+	}
+	path := fmt.Sprintf("%s/data/%s", mountPath, ref.Name)
+	if r.config.KVVersion == 1 {
+		path = fmt.Sprintf("%s/%s", mountPath, ref.Name)
+	}
+	resp, err := r.do(http.MethodGet, path, token, nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read the vault secret %s", ref.Name)
+	}
+	data := resp.Data
+	if r.config.KVVersion != 1 {
+		nested, ok := data["data"].(map[string]interface{})
+		if !ok {
+			return "", errors.Errorf("the vault secret %s has no data", ref.Name)
+		}
+		data = nested
+	}
+	value, ok := data[ref.Key]
+	if !ok {
+		return "", errors.Errorf("key %s not found in the vault secret %s", ref.Key, ref.Name)
+	}
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to marshal the key %s of the vault secret %s", ref.Key, ref.Name)
+	}
+	return string(b), nil
+}
+
+// getToken returns the current token, renewing it or logging in again if needed
+func (r *VaultSecretResolver) getToken() (string, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.now()
+	if r.token != "" && (r.renewAt.IsZero() || now.Before(r.renewAt)) {
+		return r.token, nil
+	}
+	var renewErr error
+	if r.token != "" && r.renewable && now.Before(r.expiresAt) {
+		resp, err := r.do(http.MethodPost, "auth/token/renew-self", r.token, map[string]interface{}{})
+		if err == nil && resp.Auth != nil {
+			r.setAuth(resp.Auth)
+			return r.token, nil
+		}
+		renewErr = err
+	}
+	if err := r.login(); err != nil {
+		if renewErr != nil {
+			return "", errors.Wrapf(err, "failed to log in to vault after failing to renew the token, %v", renewErr)
+		}
+		return "", err
+	}
+	return r.token, nil
+}
+
+// login gets a new token, either by logging in with the Kubernetes auth method or from the token secret
+func (r *VaultSecretResolver) login() error {
+	if r.config.Role != "" {
+		jwt, err := ioutil.ReadFile(r.tokenPath)
+		if err != nil {
+			return errors.Wrap(err, "failed to read the service account token")
+		}
+		authPath := strings.Trim(r.config.AuthPath, "/")
+		if authPath == "" {
+			authPath = defaultVaultAuthPath
+		}
+		resp, err := r.do(http.MethodPost, fmt.Sprintf("auth/%s/login", authPath), "", map[string]string{
+			"role": r.config.Role,
+			"jwt":  strings.TrimSpace(string(jwt)),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to log in to vault with the role %s", r.config.Role)
+		}
+		if resp.Auth == nil || resp.Auth.ClientToken == "" {
+			return errors.New("vault login returned no token")
+		}
+		r.setAuth(resp.Auth)
+		return nil
+	}
+
+	token, err := GetSecretFromVolume(r.config.TokenSecret)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the vault token")
+	}
+	resp, err := r.do(http.MethodGet, "auth/token/lookup-self", token, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to look up the vault token")
+	}
+	ttl, _ := resp.Data["ttl"].(float64)
+	renewable, _ := resp.Data["renewable"].(bool)
+	r.setAuth(&vaultAuth{ClientToken: token, LeaseDuration: int64(ttl), Renewable: renewable})
+	return nil
+}
+
+// setAuth sets the token along with its renewal and expiration times, a token without lease never expires
+func (r *VaultSecretResolver) setAuth(auth *vaultAuth) {
+	now := r.now()
+	r.token = auth.ClientToken
+	r.renewable = auth.Renewable
+	r.renewAt = time.Time{}
+	r.expiresAt = time.Time{}
+	if auth.LeaseDuration > 0 {
+		lease := time.Duration(auth.LeaseDuration) * time.Second
+		r.renewAt = now.Add(lease * 2 / 3)
+		r.expiresAt = now.Add(lease)
+	}
+}
+
+// resetToken drops the current token so that the next resolution logs in again
+func (r *VaultSecretResolver) resetToken() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.token = ""
+}
+
+// do sends a request to the Vault API
+func (r *VaultSecretResolver) do(method, path, token string, body interface{}) (*vaultResponse, error) {
+	r.clientOnce.Do(func() {
+		r.client = &http.Client{Timeout: 30 * time.Second}
+		if r.config.TLS != nil {
+			tlsConfig, err := GetTLSConfig(r.config.TLS, r.log)
+			if err != nil {
+				r.clientErr = errors.Wrap(err, "failed to get the tls configuration")
+				return
+			}
+			r.client.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+		}
+	})
+	if r.clientErr != nil {
+		return nil, r.clientErr
+	}
+
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, fmt.Sprintf("%s/v1/%s", strings.TrimSuffix(r.config.Address, "/"), path), reader)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &vaultResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil && err != io.EOF {
+		return nil, errors.Wrap(err, "failed to decode the vault response")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, &vaultStatusError{status: resp.StatusCode, errors: result.Errors}
+	}
+	return result, nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+)
+
+// fakeVault serves the Kubernetes login, token renewal and KV v2 read endpoints of Vault
+type fakeVault struct {
+	logins   int32
+	renewals int32
+	revoked  int32
+}
+
+func (f *fakeVault) handler(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/auth/kubernetes/login", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "argo-events", body["role"])
+		assert.Equal(t, "jwt", body["jwt"])
+		atomic.AddInt32(&f.logins, 1)
+		atomic.StoreInt32(&f.revoked, 0)
+		_, _ = w.Write([]byte(`{"auth": {"client_token": "token", "lease_duration": 60, "renewable": true}}`))
+	})
+	mux.HandleFunc("/v1/auth/token/renew-self", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token", r.Header.Get("X-Vault-Token"))
+		atomic.AddInt32(&f.renewals, 1)
+		_, _ = w.Write([]byte(`{"auth": {"client_token": "token", "lease_duration": 60, "renewable": true}}`))
+	})
+	mux.HandleFunc("/v1/secret/data/emitter", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" || atomic.LoadInt32(&f.revoked) == 1 {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors": ["permission denied"]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {"data": {"username": "admin", "port": 1883}}}`))
+	})
+	return mux
+}
+
+func TestVaultSecretResolver(t *testing.T) {
+	SetSecretCacheTTL(0)
+	defer SetSecretCacheTTL(DefaultSecretCacheTTL)
+
+	vault := &fakeVault{}
+	server := httptest.NewServer(vault.handler(t))
+	defer server.Close()
+
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, ioutil.WriteFile(tokenPath, []byte("jwt\n"), 0600))
+
+	now := time.Now()
+	r := NewVaultSecretResolver(&apicommon.VaultSecretBackend{Address: server.URL, Role: "argo-events"}, zap.NewNop().Sugar())
+	r.tokenPath = tokenPath
+	r.now = func() time.Time { return now }
+
+	ref := func(key string) *corev1.SecretKeySelector {
+		return &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "emitter"}, Key: key}
+	}
+
+	t.Run("read", func(t *testing.T) {
+		value, err := r.Resolve(ref("username"))
+		assert.NoError(t, err)
+		assert.Equal(t, "admin", value)
+		value, err = r.Resolve(ref("port"))
+		assert.NoError(t, err)
+		assert.Equal(t, "1883", value)
+		_, err = r.Resolve(ref("password"))
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&vault.logins))
+	})
+
+	t.Run("renew", func(t *testing.T) {
+		now = now.Add(50 * time.Second)
+		_, err := r.Resolve(ref("username"))
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&vault.renewals))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&vault.logins))
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		now = now.Add(2 * time.Minute)
+		_, err := r.Resolve(ref("username"))
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&vault.renewals))
+		assert.Equal(t, int32(2), atomic.LoadInt32(&vault.logins))
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		atomic.StoreInt32(&vault.revoked, 1)
+		value, err := r.Resolve(ref("username"))
+		assert.NoError(t, err)
+		assert.Equal(t, "admin", value)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&vault.logins))
+	})
+}
+
+func TestNewSecretResolver(t *testing.T) {
+	assert.IsType(t, VolumeSecretResolver{}, NewSecretResolver(nil, zap.NewNop().Sugar()))
+	assert.IsType(t, VolumeSecretResolver{}, NewSecretResolver(&apicommon.SecretBackend{}, zap.NewNop().Sugar()))
+	assert.IsType(t, &VaultSecretResolver{}, NewSecretResolver(&apicommon.SecretBackend{Vault: &apicommon.VaultSecretBackend{Address: "http://vault:8200"}}, zap.NewNop().Sugar()))
+}
@@ -248,7 +248,7 @@ func buildDeployment(args *AdaptorArgs, eventBus *eventbusv1alpha1.EventBus) (*a
 	if len(oldVolMounts) > 0 {
 		volMounts = append(volMounts, oldVolMounts...)
 	}
-	volSecrets, volSecretMounts := common.VolumesFromSecretsOrConfigMaps(eventSourceCopy, common.SecretKeySelectorType)
+	volSecrets, volSecretMounts := common.VolumesFromSecretsOrConfigMaps(withoutVaultSecrets(eventSourceCopy), common.SecretKeySelectorType)
 	if len(volSecrets) > 0 {
 		vols = append(vols, volSecrets...)
 	}
@@ -401,3 +401,22 @@ func mergeLabels(eventBusLabels, given map[string]string) map[string]string {
 func labelSelector(labelMap map[string]string) labels.Selector {
 	return labels.SelectorFromSet(labelMap)
 }
+
+// withoutVaultSecrets returns the event source without the secret key selectors resolved from Vault,
+// which have no K8s secret to mount.
+func withoutVaultSecrets(eventSource *v1alpha1.EventSource) *v1alpha1.EventSource {
+	if backend := eventSource.Spec.SecretBackend; backend == nil || backend.Vault == nil {
+		return eventSource
+	}
+	result := eventSource.DeepCopy()
+	for k, v := range result.Spec.Emitter {
+		v.Username = nil
+		v.Password = nil
+		v.ConnectionStringSecret = nil
+		if v.KeyGen != nil {
+			v.KeyGen.MasterKey = nil
+		}
+		result.Spec.Emitter[k] = v
+	}
+	return result
+}
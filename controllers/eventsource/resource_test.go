@@ -12,6 +12,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/argoproj/argo-events/common/logging"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
 	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
 )
 
@@ -69,6 +70,45 @@ func Test_BuildDeployment(t *testing.T) {
 		assert.True(t, secretRefs > 0)
 		assert.Equal(t, deployment.Spec.Template.Spec.PriorityClassName, "test-class")
 	})
+
+	t.Run("test build with vault secret backend", func(t *testing.T) {
+		es := testEventSource.DeepCopy()
+		es.Spec.SecretBackend = &apicommon.SecretBackend{Vault: &apicommon.VaultSecretBackend{
+			Address:     "http://vault:8200",
+			TokenSecret: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "vault-token"}, Key: "token"},
+		}}
+		es.Spec.Emitter = map[string]v1alpha1.EmitterEventSource{
+			"test": {
+				Broker:                 "tcp://broker:4000",
+				ChannelName:            "hello",
+				Username:               &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "brokers/emitter"}, Key: "username"},
+				ConnectionStringSecret: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "brokers/emitter"}, Key: "uri"},
+				KeyGen: &v1alpha1.EmitterKeyGen{
+					MasterKey: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "brokers/emitter"}, Key: "master-key"},
+				},
+			},
+		}
+		args := &AdaptorArgs{
+			Image:       testImage,
+			EventSource: es,
+			Labels:      testLabels,
+		}
+		deployment, err := buildDeployment(args, fakeEventBus)
+		assert.Nil(t, err)
+		hasTokenVolume := false
+		for _, vol := range deployment.Spec.Template.Spec.Volumes {
+			if vol.Secret == nil {
+				continue
+			}
+			assert.NotEqual(t, "brokers/emitter", vol.Secret.SecretName)
+			if vol.Secret.SecretName == "vault-token" {
+				hasTokenVolume = true
+			}
+		}
+		assert.True(t, hasTokenVolume)
+		assert.NotNil(t, es.Spec.Emitter["test"].Username)
+		assert.NotNil(t, es.Spec.Emitter["test"].KeyGen.MasterKey)
+	})
 }
 
 func TestResourceReconcile(t *testing.T) {
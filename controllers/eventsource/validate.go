@@ -3,6 +3,7 @@ package eventsource
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -18,6 +19,16 @@ func ValidateEventSource(eventSource *v1alpha1.EventSource) error {
 		recreateTypes[esType] = true
 	}
 
+	if err := apicommon.ValidateSecretBackend(eventSource.Spec.SecretBackend); err != nil {
+		eventSource.Status.MarkSourcesNotProvided("InvalidEventSource", fmt.Sprintf("Invalid spec: %s", err))
+		return err
+	}
+
+	if err := validateTerminationGracePeriod(eventSource.Spec.TerminationGracePeriod); err != nil {
+		eventSource.Status.MarkSourcesNotProvided("InvalidEventSource", fmt.Sprintf("Invalid spec: %s", err))
+		return err
+	}
+
 	servers, _ := eventsources.GetEventingServers(eventSource, nil)
 
 	eventNames := make(map[string]bool)
@@ -57,3 +68,18 @@ func ValidateEventSource(eventSource *v1alpha1.EventSource) error {
 	eventSource.Status.MarkSourcesProvided()
 	return nil
 }
+
+// validateTerminationGracePeriod validates the termination grace period of the event sources, if set
+func validateTerminationGracePeriod(gracePeriod string) error {
+	if gracePeriod == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(gracePeriod)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse the termination grace period %s", gracePeriod)
+	}
+	if d <= 0 {
+		return errors.New("terminationGracePeriod must be positive")
+	}
+	return nil
+}
@@ -39,4 +39,19 @@ func TestValidate(t *testing.T) {
 		assert.Error(t, err)
 		assert.Equal(t, "more than one \"test\" found in the spec", err.Error())
 	})
+
+	t.Run("validate termination grace period", func(t *testing.T) {
+		testEventSource := fakeEmptyEventSource()
+		testEventSource.Spec.Calendar = fakeCalendarEventSourceMap("test")
+		testEventSource.Spec.TerminationGracePeriod = "20s"
+		assert.NoError(t, ValidateEventSource(testEventSource))
+
+		testEventSource.Spec.TerminationGracePeriod = "0s"
+		err := ValidateEventSource(testEventSource)
+		assert.Error(t, err)
+		assert.Equal(t, "terminationGracePeriod must be positive", err.Error())
+
+		testEventSource.Spec.TerminationGracePeriod = "soon"
+		assert.Error(t, ValidateEventSource(testEventSource))
+	})
 }
@@ -57,6 +57,7 @@ func Start() {
 
 	logger = logger.With(logging.LabelEventSourceName, eventSource.Name)
 	ctx := logging.WithLogger(signals.SetupSignalHandler(), logger)
+	logging.ReloadLevelOnSignal(ctx, logger)
 	m := metrics.NewMetrics(eventSource.Namespace)
 	go m.Run(ctx, fmt.Sprintf(":%d", common.EventSourceMetricsPort))
 
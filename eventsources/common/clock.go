@@ -0,0 +1,65 @@
+package common
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the time the event sources stamp the events and measure their processing with, so that the tests
+// can control it.
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+	// Since returns the time elapsed since t
+	Since(t time.Time) time.Duration
+}
+
+// RealClock is the Clock of the system time.
+type RealClock struct{}
+
+// Now returns the current system time
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+// Since returns the system time elapsed since t
+func (RealClock) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
+
+// FakeClock is a Clock whose time only moves when it is set or advanced, it is goroutine-safe.
+type FakeClock struct {
+	lock sync.Mutex
+	now  time.Time
+}
+
+// NewFakeClock returns a FakeClock set to now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the time of the clock
+func (c *FakeClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+// Since returns the time elapsed on the clock since t
+func (c *FakeClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// Set sets the time of the clock
+func (c *FakeClock) Set(now time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.now = now
+}
+
+// Advance moves the time of the clock forward by d
+func (c *FakeClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.now = c.now.Add(d)
+}
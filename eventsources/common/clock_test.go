@@ -0,0 +1,29 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRealClock(t *testing.T) {
+	var clock Clock = RealClock{}
+	start := clock.Now()
+	time.Sleep(10 * time.Millisecond)
+	assert.GreaterOrEqual(t, int64(clock.Since(start)), int64(10*time.Millisecond))
+}
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	clock := NewFakeClock(start)
+	assert.Equal(t, start, clock.Now())
+	assert.Equal(t, time.Duration(0), clock.Since(start))
+
+	clock.Advance(250 * time.Millisecond)
+	assert.Equal(t, start.Add(250*time.Millisecond), clock.Now())
+	assert.Equal(t, 250*time.Millisecond, clock.Since(start))
+
+	clock.Set(start.Add(-time.Second))
+	assert.Equal(t, -time.Second, clock.Since(start))
+}
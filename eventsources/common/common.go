@@ -1,6 +1,12 @@
 package common
 
-import "github.com/cloudevents/sdk-go/v2/event"
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/google/uuid"
+)
 
 type Options func(*event.Event) error
 
@@ -11,3 +17,71 @@ func WithID(id string) Options {
 		return nil
 	}
 }
+
+// PartitionKeyExtension is the CloudEvents extension attribute carrying the partition key of an event, as defined by
+// the partitioning extension, see https://github.com/cloudevents/spec/blob/main/cloudevents/extensions/partitioning.md
+const PartitionKeyExtension = "partitionkey"
+
+// WithPartitionKey sets the partition key of the event, so that the events of a same key are delivered in order
+// by the event buses supporting partitions. The other event buses deliver the events in the order they are
+// published, on a best effort basis.
+func WithPartitionKey(key string) Options {
+	return func(e *event.Event) error {
+		if key == "" {
+			return nil
+		}
+		e.SetExtension(PartitionKeyExtension, key)
+		return nil
+	}
+}
+
+// PartitionKey returns the partition key of the event, empty if not set.
+func PartitionKey(e event.Event) string {
+	key, _ := e.Extensions()[PartitionKeyExtension].(string)
+	return key
+}
+
+// BatchCountExtension is the CloudEvents extension attribute carrying the number of events of a batch event, whose
+// payload is the JSON array of the batched events.
+const BatchCountExtension = "batchcount"
+
+// WithBatchCount sets the number of events of a batch event.
+func WithBatchCount(count int) Options {
+	return func(e *event.Event) error {
+		e.SetExtension(BatchCountExtension, count)
+		return nil
+	}
+}
+
+// Strategies to generate the event IDs
+const (
+	// IDStrategyRandom generates a random ID (UUIDv4) for each event, the default
+	IDStrategyRandom = "random"
+	// IDStrategyDeterministic derives the ID (UUIDv5) from the event source, the topic and the payload,
+	// so that a replayed message gets the ID of its first delivery
+	IDStrategyDeterministic = "deterministic"
+)
+
+// eventIDNamespace is the namespace of the deterministic event IDs
+var eventIDNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://argoproj.github.io/argo-events"))
+
+// EventID returns the ID of an event according to the strategy. A deterministic ID is derived from the names of the
+// event source and of the event, the topic the event originates from and the SHA-256 hash of the payload.
+func EventID(strategy, eventSourceName, eventName, topic string, payload []byte) string {
+	if strategy != IDStrategyDeterministic {
+		return uuid.New().String()
+	}
+	hash := sha256.Sum256(payload)
+	name := fmt.Sprintf("%s\x00%s\x00%s\x00%x", eventSourceName, eventName, topic, hash)
+	return uuid.NewSHA1(eventIDNamespace, []byte(name)).String()
+}
+
+// ValidateIDStrategy validates the strategy to generate the event IDs, empty defaults to random
+func ValidateIDStrategy(strategy string) error {
+	switch strategy {
+	case "", IDStrategyRandom, IDStrategyDeterministic:
+		return nil
+	default:
+		return fmt.Errorf("idStrategy must be either %s or %s", IDStrategyRandom, IDStrategyDeterministic)
+	}
+}
@@ -0,0 +1,48 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventID(t *testing.T) {
+	t.Run("random", func(t *testing.T) {
+		id := EventID(IDStrategyRandom, "es", "ev", "topic", []byte("payload"))
+		_, err := uuid.Parse(id)
+		assert.NoError(t, err)
+		assert.NotEqual(t, id, EventID(IDStrategyRandom, "es", "ev", "topic", []byte("payload")))
+		assert.NotEqual(t, id, EventID("", "es", "ev", "topic", []byte("payload")))
+	})
+
+	t.Run("deterministic", func(t *testing.T) {
+		id := EventID(IDStrategyDeterministic, "es", "ev", "topic", []byte("payload"))
+		assert.Equal(t, id, EventID(IDStrategyDeterministic, "es", "ev", "topic", []byte("payload")))
+		parsed, err := uuid.Parse(id)
+		assert.NoError(t, err)
+		assert.Equal(t, uuid.Version(5), parsed.Version())
+		assert.NotEqual(t, id, EventID(IDStrategyDeterministic, "other", "ev", "topic", []byte("payload")))
+		assert.NotEqual(t, id, EventID(IDStrategyDeterministic, "es", "other", "topic", []byte("payload")))
+		assert.NotEqual(t, id, EventID(IDStrategyDeterministic, "es", "ev", "other", []byte("payload")))
+		assert.NotEqual(t, id, EventID(IDStrategyDeterministic, "es", "ev", "topic", []byte("other")))
+	})
+}
+
+func TestValidateIDStrategy(t *testing.T) {
+	assert.NoError(t, ValidateIDStrategy(""))
+	assert.NoError(t, ValidateIDStrategy(IDStrategyRandom))
+	assert.NoError(t, ValidateIDStrategy(IDStrategyDeterministic))
+	assert.EqualError(t, ValidateIDStrategy("sequential"), "idStrategy must be either random or deterministic")
+}
+
+func TestWithPartitionKey(t *testing.T) {
+	e := event.New()
+	assert.Empty(t, PartitionKey(e))
+	assert.NoError(t, WithPartitionKey("")(&e))
+	assert.Empty(t, PartitionKey(e))
+	assert.NoError(t, WithPartitionKey("sensor/kitchen/")(&e))
+	assert.Equal(t, "sensor/kitchen/", PartitionKey(e))
+	assert.Equal(t, "sensor/kitchen/", e.Extensions()[PartitionKeyExtension])
+}
@@ -0,0 +1,124 @@
+package common
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	metrics "github.com/argoproj/argo-events/metrics"
+)
+
+// ErrDispatchTimeout is the error of a dispatch which didn't complete within the dispatch timeout
+var ErrDispatchTimeout = errors.New("dispatch timed out")
+
+// ErrDispatchPending is the error of a dispatch which timed out while still running, the eventbus may still accept
+// the event. It must not be retried, the event would be dispatched twice.
+var ErrDispatchPending = errors.Wrap(ErrDispatchTimeout, "the dispatch is still running")
+
+// ErrEventTooLarge is the error of an event rejected rather than dispatched because it exceeds the maximum event size
+var ErrEventTooLarge = errors.New("event too large")
+
+// DispatchTimeout returns the dispatch timeout of the spec, or the default timeout of the event source if not set
+func DispatchTimeout(timeout string, defaultTimeout time.Duration) (time.Duration, error) {
+	if timeout == "" {
+		return defaultTimeout, nil
+	}
+	d, err := time.ParseDuration(timeout)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to parse the dispatch timeout %s", timeout)
+	}
+	if d <= 0 {
+		return 0, errors.New("dispatchTimeout must be positive")
+	}
+	return d, nil
+}
+
+// DispatchWithTimeout wraps dispatch so that a call fails once the timeout elapses, rather than blocking the event
+// source while the eventbus hangs. The eventbus can't be interrupted, the dispatch timing out completes in the
+// background and fails with ErrDispatchPending, so the event may still be delivered. The next dispatches wait for
+// it to complete, failing with ErrDispatchTimeout without being run if it doesn't within their timeout, so that the
+// dispatches don't pile up on a hanging eventbus. It returns dispatch as is if there is no timeout.
+func DispatchWithTimeout(timeout time.Duration, dispatch func([]byte, ...Options) error) func([]byte, ...Options) error {
+	if timeout <= 0 {
+		return dispatch
+	}
+	var lock sync.Mutex
+	// pending counts the dispatches which timed out and are still running, idle is closed once there are none
+	pending := 0
+	idle := make(chan struct{})
+	close(idle)
+	return func(data []byte, opts ...Options) error {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		lock.Lock()
+		wait := idle
+		lock.Unlock()
+		select {
+		case <-wait:
+		case <-timer.C:
+			return errors.Wrapf(ErrDispatchTimeout, "an earlier dispatch was still running after %s", timeout)
+		}
+		done := make(chan error, 1)
+		go func() {
+			done <- dispatch(data, opts...)
+		}()
+		select {
+		case err := <-done:
+			return err
+		case <-timer.C:
+		}
+		lock.Lock()
+		if pending == 0 {
+			idle = make(chan struct{})
+		}
+		pending++
+		lock.Unlock()
+		go func() {
+			<-done
+			lock.Lock()
+			defer lock.Unlock()
+			pending--
+			if pending == 0 {
+				close(idle)
+			}
+		}()
+		return errors.Wrapf(ErrDispatchPending, "the event was not dispatched within %s", timeout)
+	}
+}
+
+// CheckEventSize fails with ErrEventTooLarge if the size of the event exceeds the maximum size, unless there is
+// no maximum.
+func CheckEventSize(size int, maxBytes int64) error {
+	if maxBytes > 0 && int64(size) > maxBytes {
+		return errors.Wrapf(ErrEventTooLarge, "the event of %d bytes exceeds the maximum of %d bytes", size, maxBytes)
+	}
+	return nil
+}
+
+// DispatchWithMaxSize wraps dispatch so that the events exceeding the maximum size fail with ErrEventTooLarge
+// rather than being dispatched, so that an oversized event doesn't exhaust the memory of the eventbus or of the
+// sensors. It returns dispatch as is if there is no maximum.
+func DispatchWithMaxSize(maxBytes int64, dispatch func([]byte, ...Options) error) func([]byte, ...Options) error {
+	if maxBytes <= 0 {
+		return dispatch
+	}
+	return func(data []byte, opts ...Options) error {
+		if err := CheckEventSize(len(data), maxBytes); err != nil {
+			return err
+		}
+		return dispatch(data, opts...)
+	}
+}
+
+// DispatchFailureReason classifies the failure of a dispatch, either a timeout, an event too large or any other
+// failure
+func DispatchFailureReason(err error) metrics.FailureReason {
+	if errors.Is(err, ErrDispatchTimeout) {
+		return metrics.FailureReasonDispatchTimeout
+	}
+	if errors.Is(err, ErrEventTooLarge) {
+		return metrics.FailureReasonTooLarge
+	}
+	return metrics.FailureReasonDispatch
+}
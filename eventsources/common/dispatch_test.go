@@ -0,0 +1,102 @@
+package common
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	metrics "github.com/argoproj/argo-events/metrics"
+)
+
+func TestDispatchTimeout(t *testing.T) {
+	timeout, err := DispatchTimeout("", 10*time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Second, timeout)
+
+	timeout, err = DispatchTimeout("2s", 10*time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, 2*time.Second, timeout)
+
+	_, err = DispatchTimeout("0s", 10*time.Second)
+	assert.EqualError(t, err, "dispatchTimeout must be positive")
+
+	_, err = DispatchTimeout("soon", 10*time.Second)
+	assert.Error(t, err)
+}
+
+func TestDispatchWithTimeout(t *testing.T) {
+	var dispatched []string
+	dispatch := DispatchWithTimeout(time.Second, func(data []byte, opts ...Options) error {
+		dispatched = append(dispatched, string(data))
+		return nil
+	})
+	assert.NoError(t, dispatch([]byte("hello")))
+	assert.Equal(t, []string{"hello"}, dispatched)
+
+	dispatch = DispatchWithTimeout(time.Second, func(data []byte, opts ...Options) error {
+		return errors.New("eventbus unavailable")
+	})
+	err := dispatch([]byte("hello"))
+	assert.EqualError(t, err, "eventbus unavailable")
+	assert.Equal(t, metrics.FailureReasonDispatch, DispatchFailureReason(err))
+
+	var lock sync.Mutex
+	release := make(chan struct{})
+	dispatched = nil
+	dispatch = DispatchWithTimeout(10*time.Millisecond, func(data []byte, opts ...Options) error {
+		lock.Lock()
+		dispatched = append(dispatched, string(data))
+		lock.Unlock()
+		if string(data) == "hello" {
+			<-release
+		}
+		return nil
+	})
+	err = dispatch([]byte("hello"))
+	assert.True(t, errors.Is(err, ErrDispatchPending))
+	assert.True(t, errors.Is(err, ErrDispatchTimeout))
+	assert.Equal(t, metrics.FailureReasonDispatchTimeout, DispatchFailureReason(errors.Wrap(err, "failed to dispatch")))
+
+	// the next dispatches are not run while the one timing out is still running
+	err = dispatch([]byte("world"))
+	assert.True(t, errors.Is(err, ErrDispatchTimeout))
+	assert.False(t, errors.Is(err, ErrDispatchPending))
+	close(release)
+	assert.Eventually(t, func() bool {
+		return dispatch([]byte("world")) == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, []string{"hello", "world"}, dispatched)
+
+	// the dispatch is left as is without timeout
+	dispatch = DispatchWithTimeout(0, func(data []byte, opts ...Options) error {
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	})
+	assert.NoError(t, dispatch([]byte("hello")))
+}
+
+func TestDispatchWithMaxSize(t *testing.T) {
+	var dispatched []string
+	dispatch := func(data []byte, opts ...Options) error {
+		dispatched = append(dispatched, string(data))
+		return nil
+	}
+	assert.NoError(t, DispatchWithMaxSize(0, dispatch)([]byte("hello")))
+
+	limited := DispatchWithMaxSize(5, dispatch)
+	assert.NoError(t, limited([]byte("hello")))
+	err := limited([]byte("hello!"))
+	assert.True(t, errors.Is(err, ErrEventTooLarge))
+	assert.EqualError(t, err, "the event of 6 bytes exceeds the maximum of 5 bytes: event too large")
+	assert.Equal(t, metrics.FailureReasonTooLarge, DispatchFailureReason(errors.Wrap(err, "failed to dispatch")))
+	assert.Equal(t, []string{"hello", "hello"}, dispatched)
+
+	assert.NoError(t, CheckEventSize(1<<20, 0))
+	assert.NoError(t, CheckEventSize(5, 5))
+	assert.Error(t, CheckEventSize(6, 5))
+}
@@ -0,0 +1,29 @@
+package common
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/argoproj/argo-events/pkg/apis/events"
+)
+
+// NewEventEnvelope wraps the data specific to an event source in an envelope of the version
+func NewEventEnvelope(version, eventSourceName, eventName string, t time.Time, data interface{}) *events.EventEnvelope {
+	return &events.EventEnvelope{
+		SpecVersion: version,
+		Source:      eventSourceName,
+		EventName:   eventName,
+		Time:        t.UTC(),
+		Data:        data,
+	}
+}
+
+// ValidateEnvelopeVersion validates the version of the envelope the events are wrapped in, empty for no envelope
+func ValidateEnvelopeVersion(version string) error {
+	switch version {
+	case "", events.EventEnvelopeSpecVersion:
+		return nil
+	default:
+		return fmt.Errorf("envelopeVersion must be %s", events.EventEnvelopeSpecVersion)
+	}
+}
@@ -0,0 +1,34 @@
+package common
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-events/pkg/apis/events"
+)
+
+func TestNewEventEnvelope(t *testing.T) {
+	at := time.Date(2021, 3, 4, 5, 6, 7, 0, time.FixedZone("CET", 3600))
+	envelope := NewEventEnvelope(events.EventEnvelopeSpecVersion, "es", "ev", at, &events.EmitterEventData{Type: "message", Topic: "sensor/"})
+	body, err := json.Marshal(envelope)
+	assert.NoError(t, err)
+
+	var payload map[string]interface{}
+	assert.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, "1.0", payload["specVersion"])
+	assert.Equal(t, "es", payload["source"])
+	assert.Equal(t, "ev", payload["eventName"])
+	assert.Equal(t, "2021-03-04T04:06:07Z", payload["time"])
+	data := payload["data"].(map[string]interface{})
+	assert.Equal(t, "message", data["type"])
+	assert.Equal(t, "sensor/", data["topic"])
+}
+
+func TestValidateEnvelopeVersion(t *testing.T) {
+	assert.NoError(t, ValidateEnvelopeVersion(""))
+	assert.NoError(t, ValidateEnvelopeVersion(events.EventEnvelopeSpecVersion))
+	assert.EqualError(t, ValidateEnvelopeVersion("2.0"), "envelopeVersion must be 1.0")
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"strings"
+
+	"github.com/argoproj/argo-events/pkg/apis/events"
 )
 
 // Event represents a single file system notification.
@@ -12,10 +14,107 @@ type Event struct {
 	Name string `json:"name"`
 	// File operation that triggered the event.
 	Op Op `json:"op"`
+	// OpName is the name the operation maps to per the op name mapping of the event source, e.g. created for
+	// CREATE, only set when a mapping is specified.
+	OpName string `json:"opName,omitempty"`
+	// OldPath is the path the file was moved from, only set for MOVE events.
+	OldPath string `json:"oldPath,omitempty"`
+	// NewPath is the path the file was moved to, only set for MOVE events.
+	NewPath string `json:"newPath,omitempty"`
 	// User metadata
 	Metadata map[string]string `json:"metadata"`
+	// Content of the file, base64 encoded for binary files.
+	Content string `json:"content,omitempty"`
+	// ContentEncoding is the encoding of the content, either text or base64.
+	ContentEncoding string `json:"contentEncoding,omitempty"`
+	// Checksum is the hex encoded SHA-256 checksum of the whole file.
+	Checksum string `json:"checksum,omitempty"`
+	// Truncated tells whether the content was cut to the maximum size.
+	Truncated bool `json:"truncated,omitempty"`
+	// Error is the reason the content could not be read, e.g. the file was removed right after the notification.
+	Error string `json:"error,omitempty"`
+	// Synthetic is true for the CREATE events replayed for the files existing when the event source started,
+	// rather than reported by the watcher.
+	Synthetic bool `json:"synthetic,omitempty"`
+	// OldMode is the mode of the file before a CHMOD event, in octal, e.g. 0644. Only set if the mode was known.
+	OldMode string `json:"oldMode,omitempty"`
+	// NewMode is the mode of the file after a CHMOD event, in octal, e.g. 0600. It is 0000 if the stat failed.
+	NewMode string `json:"newMode,omitempty"`
+	// StatFailed tells whether the file could not be read after a CHMOD event, e.g. it was removed in between.
+	StatFailed bool `json:"statFailed,omitempty"`
+	// WatchPath is the name of the watch path that produced the event, only set when the event source watches
+	// several paths.
+	WatchPath string `json:"watchPath,omitempty"`
+	// Type is heartbeat for the heartbeat events, which are not about a file, empty for the file events.
+	Type string `json:"type,omitempty"`
+	// Heartbeat holds the heartbeat, only set for the heartbeat events.
+	Heartbeat *events.HeartbeatData `json:"heartbeat,omitempty"`
+	// Change is how a WRITE event changed the file, either Appended or Truncated. Only set when the offset of the
+	// files is tracked.
+	Change string `json:"change,omitempty"`
+	// RangeStart is the offset the range of the change starts at: the previous size of the file for the data
+	// appended, the new size for the data truncated.
+	RangeStart int64 `json:"rangeStart,omitempty"`
+	// RangeEnd is the offset the range of the change ends at, excluded: the new size of the file for the data
+	// appended, the previous size for the data truncated.
+	RangeEnd int64 `json:"rangeEnd,omitempty"`
+	// Rotated tells whether the file was recreated since the previous WRITE event, e.g. by a log rotation, the data
+	// appended then starts from the beginning of the file.
+	Rotated bool `json:"rotated,omitempty"`
+	// Files are the files of the projected volume, e.g. a mounted ConfigMap, once updated. Only set for UPDATE events.
+	Files []VolumeFile `json:"files,omitempty"`
+	// Digest lists the files of the watched paths, only set for the digest events.
+	Digest []DigestFile `json:"digest,omitempty"`
+	// Ownership is the owner of the file, only set for CREATE and WRITE events when the ownership is included.
+	Ownership *Ownership `json:"ownership,omitempty"`
+}
+
+// Ownership is the owner of a file on the platforms with Unix ownership.
+type Ownership struct {
+	// UID is the ID of the user owning the file
+	UID uint32 `json:"uid"`
+	// GID is the ID of the group owning the file
+	GID uint32 `json:"gid"`
+	// User is the name of the user, empty if it could not be resolved, e.g. a UID unknown to the container.
+	User string `json:"user,omitempty"`
+	// Group is the name of the group, empty if it could not be resolved.
+	Group string `json:"group,omitempty"`
+}
+
+// DigestFile is a file listed by a digest of the watched paths.
+type DigestFile struct {
+	// Name is the path of the file, e.g. /etc/config/app.yaml
+	Name string `json:"name"`
+	// Size of the file in bytes
+	Size int64 `json:"size"`
+	// Checksum is the hex encoded SHA-256 checksum of the file.
+	Checksum string `json:"checksum"`
+	// WatchPath is the name of the watch path of the file, only set when the event source watches several paths.
+	WatchPath string `json:"watchPath,omitempty"`
+}
+
+// VolumeFile is a file of a projected volume, e.g. a key of a mounted ConfigMap or Secret.
+type VolumeFile struct {
+	// Name is the path of the file in the volume, e.g. /etc/config/app.yaml
+	Name string `json:"name"`
+	// Target is the path the file resolves to, within the timestamped data directory of the volume.
+	Target string `json:"target"`
+	// Size of the file in bytes
+	Size int64 `json:"size"`
 }
 
+// Possible values of the content encoding
+const (
+	ContentEncodingText   = "text"
+	ContentEncodingBase64 = "base64"
+)
+
+// Possible values of the change of a WRITE event
+const (
+	ChangeAppended  = "Appended"
+	ChangeTruncated = "Truncated"
+)
+
 // Op describes a set of file operations.
 type Op uint32
 
@@ -26,6 +125,8 @@ const (
 	Remove
 	Rename
 	Chmod
+	Move
+	Update
 )
 
 func (op Op) String() string {
@@ -47,6 +148,12 @@ func (op Op) String() string {
 	if op&Chmod == Chmod {
 		buffer.WriteString("|CHMOD")
 	}
+	if op&Move == Move {
+		buffer.WriteString("|MOVE")
+	}
+	if op&Update == Update {
+		buffer.WriteString("|UPDATE")
+	}
 	if buffer.Len() == 0 {
 		return ""
 	}
@@ -68,6 +175,10 @@ func NewOp(s string) Op {
 			op |= Rename
 		case "CHMOD":
 			op |= Chmod
+		case "MOVE":
+			op |= Move
+		case "UPDATE":
+			op |= Update
 		}
 	}
 	return op
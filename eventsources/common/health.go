@@ -0,0 +1,150 @@
+package common
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultHealthStaleness is how long an event source can go without an event or a successful ping
+// before it is reported unhealthy, unless the probe sets the staleness query parameter.
+const DefaultHealthStaleness = 2 * time.Minute
+
+// HealthReporter is implemented by the event sources able to report whether they are healthy
+type HealthReporter interface {
+	// HealthStatus returns a snapshot of the health of the event source
+	HealthStatus() HealthStatus
+}
+
+// HealthStatus is the health of an event source at a point in time
+type HealthStatus struct {
+	// Started is true once the event source started listening, a standby replica never starts
+	Started bool `json:"started"`
+	// Connected tells whether the event source is connected to the service it listens to
+	Connected bool `json:"connected"`
+	// LastEventTime is the time the last event was received
+	LastEventTime time.Time `json:"lastEventTime,omitempty"`
+	// LastPingTime is the time of the last successful ping of the service
+	LastPingTime time.Time `json:"lastPingTime,omitempty"`
+	// LastError is the last error the event source faced, if any
+	LastError string `json:"lastError,omitempty"`
+}
+
+// Healthy tells whether the event source is connected and received an event or a successful ping within
+// the staleness window, a zero staleness disabling the check. An event source which has not started is healthy.
+func (s HealthStatus) Healthy(now time.Time, staleness time.Duration) bool {
+	if !s.Started {
+		return true
+	}
+	if !s.Connected {
+		return false
+	}
+	if staleness <= 0 {
+		return true
+	}
+	last := s.LastEventTime
+	if s.LastPingTime.After(last) {
+		last = s.LastPingTime
+	}
+	return now.Sub(last) <= staleness
+}
+
+// HealthTracker records the health of an event source, it is goroutine-safe and its zero value is ready to use.
+// An event source embeds it to implement HealthReporter.
+type HealthTracker struct {
+	lock   sync.Mutex
+	status HealthStatus
+}
+
+// Started records that the event source started listening
+func (t *HealthTracker) Started() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.status.Started = true
+}
+
+// SetConnected records a connection state change, a connection counts as a successful ping
+func (t *HealthTracker) SetConnected(connected bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.status.Connected = connected
+	if connected {
+		t.status.LastPingTime = time.Now()
+	}
+}
+
+// EventReceived records the reception of an event
+func (t *HealthTracker) EventReceived() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.status.LastEventTime = time.Now()
+}
+
+// Pinged records a successful ping of the service
+func (t *HealthTracker) Pinged() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.status.LastPingTime = time.Now()
+}
+
+// SetError records the last error
+func (t *HealthTracker) SetError(err error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.status.LastError = err.Error()
+}
+
+// HealthStatus returns a snapshot of the recorded health
+func (t *HealthTracker) HealthStatus() HealthStatus {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.status
+}
+
+// healthResponse is the health of an event source as reported by the health handler
+type healthResponse struct {
+	HealthStatus
+	Healthy bool `json:"healthy"`
+}
+
+// NewHealthHandler returns the handler of the health probes of the event sources, keyed by event name.
+// /healthz reports all of them and /healthz/<event name> a single one, with the status 503 if any is unhealthy.
+// The staleness query parameter, e.g. /healthz?staleness=5m, overrides the default staleness window.
+func NewHealthHandler(reporters map[string]HealthReporter, staleness time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		window := staleness
+		if s := r.URL.Query().Get("staleness"); s != "" {
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				http.Error(w, "invalid staleness", http.StatusBadRequest)
+				return
+			}
+			window = d
+		}
+		selected := reporters
+		if name := strings.Trim(strings.TrimPrefix(r.URL.Path, "/healthz"), "/"); name != "" {
+			reporter, ok := reporters[name]
+			if !ok {
+				http.Error(w, "no health reported for "+name, http.StatusNotFound)
+				return
+			}
+			selected = map[string]HealthReporter{name: reporter}
+		}
+		now := time.Now()
+		status := http.StatusOK
+		response := make(map[string]healthResponse, len(selected))
+		for name, reporter := range selected {
+			s := reporter.HealthStatus()
+			healthy := s.Healthy(now, window)
+			if !healthy {
+				status = http.StatusServiceUnavailable
+			}
+			response[name] = healthResponse{HealthStatus: s, Healthy: healthy}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(response)
+	})
+}
@@ -0,0 +1,75 @@
+package common
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthStatusHealthy(t *testing.T) {
+	now := time.Now()
+	assert.True(t, HealthStatus{}.Healthy(now, time.Minute))
+	assert.False(t, HealthStatus{Started: true}.Healthy(now, time.Minute))
+	assert.True(t, HealthStatus{Started: true, Connected: true, LastPingTime: now.Add(-30 * time.Second)}.Healthy(now, time.Minute))
+	assert.True(t, HealthStatus{Started: true, Connected: true, LastEventTime: now.Add(-30 * time.Second)}.Healthy(now, time.Minute))
+	assert.False(t, HealthStatus{Started: true, Connected: true, LastPingTime: now.Add(-2 * time.Minute)}.Healthy(now, time.Minute))
+	assert.True(t, HealthStatus{Started: true, Connected: true, LastPingTime: now.Add(-2 * time.Minute)}.Healthy(now, 0))
+}
+
+func TestHealthTracker(t *testing.T) {
+	tracker := &HealthTracker{}
+	tracker.Started()
+	assert.False(t, tracker.HealthStatus().Healthy(time.Now(), time.Minute))
+	tracker.SetConnected(true)
+	assert.True(t, tracker.HealthStatus().Healthy(time.Now(), time.Minute))
+	tracker.EventReceived()
+	assert.False(t, tracker.HealthStatus().LastEventTime.IsZero())
+	tracker.SetConnected(false)
+	tracker.SetError(errors.New("connection lost"))
+	status := tracker.HealthStatus()
+	assert.False(t, status.Healthy(time.Now(), time.Minute))
+	assert.Equal(t, "connection lost", status.LastError)
+}
+
+func TestHealthHandler(t *testing.T) {
+	up := &HealthTracker{}
+	up.Started()
+	up.SetConnected(true)
+	down := &HealthTracker{}
+	down.Started()
+	handler := NewHealthHandler(map[string]HealthReporter{"up": up, "down": down}, time.Minute)
+
+	get := func(path string) (int, map[string]healthResponse) {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		var body map[string]healthResponse
+		_ = json.Unmarshal(w.Body.Bytes(), &body)
+		return w.Code, body
+	}
+
+	code, body := get("/healthz")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.True(t, body["up"].Healthy)
+	assert.False(t, body["down"].Healthy)
+
+	code, body = get("/healthz/up")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, body, 1)
+
+	code, _ = get("/healthz/down")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+
+	code, _ = get("/healthz/unknown")
+	assert.Equal(t, http.StatusNotFound, code)
+
+	code, _ = get("/healthz/up?staleness=1ns")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+
+	code, _ = get("/healthz?staleness=x")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
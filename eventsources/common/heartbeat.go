@@ -0,0 +1,61 @@
+package common
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// HeartbeatEventType is the type of the heartbeat events
+const HeartbeatEventType = "heartbeat"
+
+// defaultHeartbeatInterval is the interval between the heartbeat events if not set
+const defaultHeartbeatInterval = 30 * time.Second
+
+// HeartbeatInterval returns the interval between the heartbeat events, zero if they are disabled
+func HeartbeatInterval(heartbeat *v1alpha1.Heartbeat) (time.Duration, error) {
+	if heartbeat == nil || !heartbeat.Enabled {
+		return 0, nil
+	}
+	if heartbeat.Interval == "" {
+		return defaultHeartbeatInterval, nil
+	}
+	interval, err := time.ParseDuration(heartbeat.Interval)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to parse the heartbeat interval %s", heartbeat.Interval)
+	}
+	return interval, nil
+}
+
+// StartHeartbeat calls beat on every tick of the interval, from a goroutine, until the context is done or the
+// returned function is called. The returned function waits for the ticker to be stopped, so that no heartbeat
+// is dispatched once it returns.
+func StartHeartbeat(ctx context.Context, interval time.Duration, beat func(events.HeartbeatData)) func() {
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		var sequence uint64
+		for {
+			select {
+			case now := <-ticker.C:
+				sequence++
+				beat(events.HeartbeatData{Time: now.UTC(), Sequence: sequence})
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
@@ -0,0 +1,67 @@
+package common
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestHeartbeatInterval(t *testing.T) {
+	interval, err := HeartbeatInterval(nil)
+	assert.NoError(t, err)
+	assert.Zero(t, interval)
+	interval, err = HeartbeatInterval(&v1alpha1.Heartbeat{Interval: "10s"})
+	assert.NoError(t, err)
+	assert.Zero(t, interval)
+	interval, err = HeartbeatInterval(&v1alpha1.Heartbeat{Enabled: true})
+	assert.NoError(t, err)
+	assert.Equal(t, defaultHeartbeatInterval, interval)
+	interval, err = HeartbeatInterval(&v1alpha1.Heartbeat{Enabled: true, Interval: "10s"})
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Second, interval)
+	_, err = HeartbeatInterval(&v1alpha1.Heartbeat{Enabled: true, Interval: "10"})
+	assert.Error(t, err)
+}
+
+func TestStartHeartbeat(t *testing.T) {
+	var lock sync.Mutex
+	var beats []events.HeartbeatData
+	stop := StartHeartbeat(context.Background(), 10*time.Millisecond, func(beat events.HeartbeatData) {
+		lock.Lock()
+		defer lock.Unlock()
+		beats = append(beats, beat)
+	})
+	assert.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(beats) >= 3
+	}, 5*time.Second, 10*time.Millisecond)
+	stop()
+
+	lock.Lock()
+	stopped := len(beats)
+	for i, beat := range beats {
+		assert.Equal(t, uint64(i+1), beat.Sequence)
+		assert.False(t, beat.Time.IsZero())
+	}
+	lock.Unlock()
+	// no heartbeat once stopped
+	time.Sleep(50 * time.Millisecond)
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Len(t, beats, stopped)
+}
+
+func TestStartHeartbeatContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stop := StartHeartbeat(ctx, time.Hour, func(events.HeartbeatData) {})
+	cancel()
+	// the ticker is stopped along with the context, stopping it again returns right away
+	stop()
+}
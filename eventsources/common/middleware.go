@@ -0,0 +1,51 @@
+package common
+
+import (
+	"math/rand"
+
+	"github.com/pkg/errors"
+)
+
+// ErrSampledOut is the error of an event dropped by the sampling rather than dispatched. It is not a failure, the
+// event source must neither retry nor report it.
+var ErrSampledOut = errors.New("the event is sampled out")
+
+// DispatchFunc dispatches the payload of an event to the eventbus, it is the dispatch passed to the event sources
+type DispatchFunc func([]byte, ...Options) error
+
+// DispatchMiddleware wraps a dispatch with logic applied to every event whatever its event source, e.g. enrichment,
+// sampling or redaction. It calls next to pass the event on, possibly modified, or returns without calling it to
+// drop the event, with an error telling the event source why, e.g. ErrSampledOut.
+type DispatchMiddleware func(next DispatchFunc) DispatchFunc
+
+// ChainDispatch wraps the dispatch with the middlewares. The first middleware is the outermost one: it is the first
+// to see each event, and the last one calls the dispatch.
+func ChainDispatch(dispatch DispatchFunc, middlewares ...DispatchMiddleware) DispatchFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		dispatch = middlewares[i](dispatch)
+	}
+	return dispatch
+}
+
+// SampleDispatch returns a middleware which dispatches a random share of the events, the rate being between 0, none
+// of them, and 1, all of them. Only the events sampleable returns true for are sampled, all of them if nil, the
+// others are always dispatched. The events sampled out fail with ErrSampledOut, onDropped is called for each one if
+// not nil.
+func SampleDispatch(rate float64, sampleable func([]byte) bool, onDropped func()) DispatchMiddleware {
+	return sampleDispatch(rate, sampleable, rand.Float64, onDropped)
+}
+
+// sampleDispatch is SampleDispatch drawing the events to dispatch with random, which returns a number in [0, 1)
+func sampleDispatch(rate float64, sampleable func([]byte) bool, random func() float64, onDropped func()) DispatchMiddleware {
+	return func(next DispatchFunc) DispatchFunc {
+		return func(data []byte, opts ...Options) error {
+			if rate < 1 && (sampleable == nil || sampleable(data)) && random() >= rate {
+				if onDropped != nil {
+					onDropped()
+				}
+				return ErrSampledOut
+			}
+			return next(data, opts...)
+		}
+	}
+}
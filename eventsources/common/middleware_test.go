@@ -0,0 +1,81 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainDispatch(t *testing.T) {
+	var calls []string
+	middleware := func(name string) DispatchMiddleware {
+		return func(next DispatchFunc) DispatchFunc {
+			return func(data []byte, opts ...Options) error {
+				calls = append(calls, name+":"+string(data))
+				return next(append(data, name...), opts...)
+			}
+		}
+	}
+	var dispatched []string
+	dispatch := func(data []byte, opts ...Options) error {
+		dispatched = append(dispatched, string(data))
+		return nil
+	}
+
+	// the first middleware sees the event first, the last one passes it to the dispatch
+	chained := ChainDispatch(dispatch, middleware("a"), middleware("b"), middleware("c"))
+	assert.NoError(t, chained([]byte("event-")))
+	assert.Equal(t, []string{"a:event-", "b:event-a", "c:event-ab"}, calls)
+	assert.Equal(t, []string{"event-abc"}, dispatched)
+
+	// no middleware leaves the dispatch as is
+	assert.NoError(t, ChainDispatch(dispatch)([]byte("plain")))
+	assert.Equal(t, []string{"event-abc", "plain"}, dispatched)
+}
+
+func TestSampleDispatch(t *testing.T) {
+	dispatched := 0
+	dispatch := func(data []byte, opts ...Options) error {
+		dispatched++
+		return nil
+	}
+	dropped := 0
+	onDropped := func() { dropped++ }
+
+	draws := []float64{0.1, 0.5, 0.24, 0.9}
+	random := func() float64 {
+		draw := draws[0]
+		draws = draws[1:]
+		return draw
+	}
+	sampled := ChainDispatch(dispatch, sampleDispatch(0.25, nil, random, onDropped))
+	var sampledOut int
+	for i := 0; i < 4; i++ {
+		if err := sampled([]byte("event")); err != nil {
+			assert.Equal(t, ErrSampledOut, err)
+			sampledOut++
+		}
+	}
+	assert.Equal(t, 2, dispatched)
+	assert.Equal(t, 2, dropped)
+	assert.Equal(t, 2, sampledOut)
+
+	dispatched, dropped = 0, 0
+	all := ChainDispatch(dispatch, SampleDispatch(1, nil, onDropped))
+	none := ChainDispatch(dispatch, SampleDispatch(0, nil, nil))
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, all([]byte("event")))
+		assert.Equal(t, ErrSampledOut, none([]byte("event")))
+	}
+	assert.Equal(t, 10, dispatched)
+	assert.Equal(t, 0, dropped)
+
+	// the events which aren't sampleable are always dispatched
+	dispatched = 0
+	sampleable := func(data []byte) bool { return string(data) == "message" }
+	messages := ChainDispatch(dispatch, SampleDispatch(0, sampleable, onDropped))
+	assert.Equal(t, ErrSampledOut, messages([]byte("message")))
+	assert.NoError(t, messages([]byte("heartbeat")))
+	assert.Equal(t, 1, dispatched)
+	assert.Equal(t, 1, dropped)
+}
@@ -0,0 +1,78 @@
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Pool shares the clients of the event sources connecting to a same server with the same settings, e.g. the same
+// credentials, so that they share the underlying connection. A client is created by the first event source
+// acquiring it and closed once released by the last one.
+type Pool struct {
+	lock    sync.Mutex
+	entries map[string]*poolEntry
+}
+
+type poolEntry struct {
+	client io.Closer
+	refs   int
+}
+
+// NewPool returns an empty pool
+func NewPool() *Pool {
+	return &Pool{entries: make(map[string]*poolEntry)}
+}
+
+// PoolKey derives the key of a client from its settings. The key is a hash, so that it doesn't hold the credentials.
+func PoolKey(settings ...string) string {
+	hash := sha256.Sum256([]byte(strings.Join(settings, "\x00")))
+	return hex.EncodeToString(hash[:])
+}
+
+// Acquire returns the client of the key, created with create unless already shared, along with the function which
+// releases it. The client is closed when released by the last event source, releasing twice is a no-op.
+func (p *Pool) Acquire(key string, create func() (io.Closer, error)) (io.Closer, func() error, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	entry, ok := p.entries[key]
+	if !ok {
+		client, err := create()
+		if err != nil {
+			return nil, nil, err
+		}
+		entry = &poolEntry{client: client}
+		p.entries[key] = entry
+	}
+	entry.refs++
+	var once sync.Once
+	release := func() error {
+		var err error
+		once.Do(func() {
+			err = p.release(key, entry)
+		})
+		return err
+	}
+	return entry.client, release, nil
+}
+
+func (p *Pool) release(key string, entry *poolEntry) error {
+	p.lock.Lock()
+	entry.refs--
+	if entry.refs > 0 {
+		p.lock.Unlock()
+		return nil
+	}
+	delete(p.entries, key)
+	p.lock.Unlock()
+	return entry.client.Close()
+}
+
+// Len returns the number of clients in the pool
+func (p *Pool) Len() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return len(p.entries)
+}
@@ -0,0 +1,62 @@
+package common
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeCloser struct {
+	closed int
+}
+
+func (c *fakeCloser) Close() error {
+	c.closed++
+	return nil
+}
+
+func TestPool(t *testing.T) {
+	pool := NewPool()
+	created := 0
+	create := func() (io.Closer, error) {
+		created++
+		return &fakeCloser{}, nil
+	}
+	key := PoolKey("tcp://broker:4000", "user", "pass")
+	assert.NotContains(t, key, "pass")
+	assert.NotEqual(t, key, PoolKey("tcp://broker:4000", "user", "other"))
+
+	first, releaseFirst, err := pool.Acquire(key, create)
+	assert.NoError(t, err)
+	second, releaseSecond, err := pool.Acquire(key, create)
+	assert.NoError(t, err)
+	assert.Same(t, first, second)
+	assert.Equal(t, 1, created)
+	other, releaseOther, err := pool.Acquire(PoolKey("tcp://other:4000"), create)
+	assert.NoError(t, err)
+	assert.NotSame(t, first, other)
+	assert.Equal(t, 2, pool.Len())
+
+	// the client is closed once released by the last event source
+	assert.NoError(t, releaseFirst())
+	assert.NoError(t, releaseFirst())
+	assert.Equal(t, 0, first.(*fakeCloser).closed)
+	assert.NoError(t, releaseSecond())
+	assert.Equal(t, 1, first.(*fakeCloser).closed)
+	assert.NoError(t, releaseOther())
+	assert.Equal(t, 0, pool.Len())
+
+	// a client released by all is created again
+	_, release, err := pool.Acquire(key, create)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, created)
+	assert.NoError(t, release())
+
+	_, _, err = pool.Acquire(key, func() (io.Closer, error) {
+		return nil, errors.New("boom")
+	})
+	assert.EqualError(t, err, "boom")
+	assert.Equal(t, 0, pool.Len())
+}
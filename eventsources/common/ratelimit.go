@@ -0,0 +1,70 @@
+package common
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// TokenBucket is a token bucket rate limiter, it is goroutine-safe. The bucket holds up to burst tokens
+// and is refilled at rate tokens per second, it starts full.
+type TokenBucket struct {
+	lock   sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// NewTokenBucket returns a full bucket of burst tokens refilled at rate tokens per second.
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	return &TokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+		now:    time.Now,
+	}
+}
+
+// take takes a token if available, otherwise it returns how long to wait for the next token.
+func (b *TokenBucket) take() (bool, time.Duration) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	now := b.now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+// Allow takes a token if available.
+func (b *TokenBucket) Allow() bool {
+	ok, _ := b.take()
+	return ok
+}
+
+// Wait waits for a token to be available and takes it. It returns the error of the context if done first.
+// The lock is not held while waiting, so that the waiters don't block each other from being refilled.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	for {
+		ok, delay := b.take()
+		if ok {
+			return nil
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	b := NewTokenBucket(2, 2)
+	b.now = func() time.Time { return now }
+	b.last = now
+
+	assert.True(t, b.Allow())
+	assert.True(t, b.Allow())
+	assert.False(t, b.Allow())
+	_, delay := b.take()
+	assert.Equal(t, 500*time.Millisecond, delay)
+
+	now = now.Add(500 * time.Millisecond)
+	assert.True(t, b.Allow())
+	assert.False(t, b.Allow())
+
+	// the bucket doesn't hold more than the burst
+	now = now.Add(time.Hour)
+	assert.True(t, b.Allow())
+	assert.True(t, b.Allow())
+	assert.False(t, b.Allow())
+}
+
+func TestTokenBucketWait(t *testing.T) {
+	b := NewTokenBucket(100, 1)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := time.Now()
+	assert.NoError(t, b.Wait(ctx))
+	assert.NoError(t, b.Wait(ctx))
+	assert.True(t, time.Since(start) >= 5*time.Millisecond)
+
+	slow := NewTokenBucket(0.001, 1)
+	assert.True(t, slow.Allow())
+	cancelled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	assert.Equal(t, context.Canceled, slow.Wait(cancelled))
+}
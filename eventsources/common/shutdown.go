@@ -0,0 +1,156 @@
+package common
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+type shutdownCoordinatorKey struct{}
+
+// ShutdownCoordinator coordinates the shutdown of the event sources of an event source pod. When the parent context
+// is cancelled, on SIGTERM, it cancels the context of the sources and waits up to a grace period for them to return
+// from StartListening, so that the events being dispatched complete before the pod exits.
+type ShutdownCoordinator struct {
+	gracePeriod time.Duration
+	cancel      context.CancelFunc
+
+	lock     sync.Mutex
+	running  map[string]int
+	deadline time.Time
+
+	wg       sync.WaitGroup
+	once     sync.Once
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+// NewShutdownCoordinator returns a coordinator of the shutdown on the cancellation of ctx, and the context to run the
+// sources with. The sources context keeps the values of ctx but is only cancelled by the coordinator, once the
+// shutdown deadline is known, so that the sources can bound their own cleanup with ShutdownTimeout.
+func NewShutdownCoordinator(ctx context.Context, gracePeriod time.Duration) (*ShutdownCoordinator, context.Context) {
+	sourcesCtx, cancel := context.WithCancel(detachedContext{ctx})
+	c := &ShutdownCoordinator{
+		gracePeriod: gracePeriod,
+		cancel:      cancel,
+		running:     make(map[string]int),
+		done:        make(chan struct{}),
+	}
+	go func() {
+		<-ctx.Done()
+		c.begin()
+	}()
+	return c, context.WithValue(sourcesCtx, shutdownCoordinatorKey{}, c)
+}
+
+// Go runs a source in the background, tracked by its name until it returns.
+func (c *ShutdownCoordinator) Go(name string, run func()) {
+	c.lock.Lock()
+	c.running[name]++
+	c.lock.Unlock()
+	c.wg.Add(1)
+	go func() {
+		defer func() {
+			c.lock.Lock()
+			if c.running[name]--; c.running[name] == 0 {
+				delete(c.running, name)
+			}
+			c.lock.Unlock()
+			c.wg.Done()
+		}()
+		run()
+	}()
+}
+
+// Done returns a channel closed once all the sources returned. It must be called after the sources are started.
+func (c *ShutdownCoordinator) Done() <-chan struct{} {
+	c.doneOnce.Do(func() {
+		go func() {
+			c.wg.Wait()
+			close(c.done)
+		}()
+	})
+	return c.done
+}
+
+// Shutdown cancels the sources, if not done yet, and waits up to the grace period for them to return.
+// It returns the names of the sources which didn't return in time.
+func (c *ShutdownCoordinator) Shutdown() []string {
+	c.begin()
+	c.lock.Lock()
+	remaining := time.Until(c.deadline)
+	c.lock.Unlock()
+	timer := time.NewTimer(remaining)
+	defer timer.Stop()
+	select {
+	case <-c.Done():
+		return nil
+	case <-timer.C:
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	names := make([]string, 0, len(c.running))
+	for name := range c.running {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// begin sets the shutdown deadline, then cancels the sources.
+func (c *ShutdownCoordinator) begin() {
+	c.once.Do(func() {
+		c.lock.Lock()
+		c.deadline = time.Now().Add(c.gracePeriod)
+		c.lock.Unlock()
+		c.cancel()
+	})
+}
+
+// remaining returns the time left before the shutdown deadline, false if the shutdown didn't begin.
+func (c *ShutdownCoordinator) remaining() (time.Duration, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.deadline.IsZero() {
+		return 0, false
+	}
+	if d := time.Until(c.deadline); d > 0 {
+		return d, true
+	}
+	return 0, true
+}
+
+// ShutdownTimeout caps the timeout of the cleanup of a source on shutdown to the time left in the grace period of
+// the coordinated shutdown, if any.
+func ShutdownTimeout(ctx context.Context, timeout time.Duration) time.Duration {
+	c, ok := ctx.Value(shutdownCoordinatorKey{}).(*ShutdownCoordinator)
+	if !ok {
+		return timeout
+	}
+	if remaining, ok := c.remaining(); ok && remaining < timeout {
+		return remaining
+	}
+	return timeout
+}
+
+// detachedContext keeps the values of its parent but not its cancellation.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+func (d detachedContext) Value(key interface{}) interface{} {
+	return d.parent.Value(key)
+}
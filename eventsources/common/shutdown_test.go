@@ -0,0 +1,52 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testContextKey struct{}
+
+func TestShutdownCoordinator(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), testContextKey{}, "value"))
+	coordinator, sourcesCtx := NewShutdownCoordinator(ctx, time.Second)
+	assert.Equal(t, "value", sourcesCtx.Value(testContextKey{}))
+	assert.Equal(t, 10*time.Second, ShutdownTimeout(sourcesCtx, 10*time.Second))
+
+	drained := make(chan time.Duration, 1)
+	coordinator.Go("drains", func() {
+		<-sourcesCtx.Done()
+		drained <- ShutdownTimeout(sourcesCtx, 10*time.Second)
+	})
+	stuck := make(chan struct{})
+	defer close(stuck)
+	coordinator.Go("stuck", func() {
+		<-stuck
+	})
+
+	cancel()
+	assert.Equal(t, []string{"stuck"}, coordinator.Shutdown())
+	timeout := <-drained
+	assert.True(t, timeout > 0 && timeout <= time.Second)
+	assert.Equal(t, time.Duration(0), ShutdownTimeout(sourcesCtx, 10*time.Second))
+}
+
+func TestShutdownCoordinatorDrained(t *testing.T) {
+	coordinator, sourcesCtx := NewShutdownCoordinator(context.Background(), time.Minute)
+	coordinator.Go("source", func() {
+		<-sourcesCtx.Done()
+	})
+	assert.Empty(t, coordinator.Shutdown())
+	select {
+	case <-coordinator.Done():
+	default:
+		t.Fatal("the sources should have returned")
+	}
+}
+
+func TestShutdownTimeoutWithoutCoordinator(t *testing.T) {
+	assert.Equal(t, 5*time.Second, ShutdownTimeout(context.Background(), 5*time.Second))
+}
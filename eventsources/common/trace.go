@@ -0,0 +1,78 @@
+package common
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+)
+
+// TraceParentExtension is the CloudEvents extension attribute carrying the W3C trace context of an event, as defined
+// by the distributed tracing extension, see
+// https://github.com/cloudevents/spec/blob/main/cloudevents/extensions/distributed-tracing.md
+const TraceParentExtension = "traceparent"
+
+// traceParentPattern matches a traceparent of version 00, see https://www.w3.org/TR/trace-context/#traceparent-header
+var traceParentPattern = regexp.MustCompile(`^00-([0-9a-f]{32})-([0-9a-f]{16})-([0-9a-f]{2})$`)
+
+// TraceContext is the W3C trace context an event is part of, so that the sensors continue the trace of the message
+// the event originates from.
+type TraceContext struct {
+	// TraceID is the hex encoded 16-byte ID of the trace
+	TraceID string
+	// SpanID is the hex encoded 8-byte ID of the parent span
+	SpanID string
+	// Flags are the hex encoded trace flags, e.g. 01 for a sampled trace
+	Flags string
+}
+
+// String returns the traceparent of the trace context
+func (t TraceContext) String() string {
+	return fmt.Sprintf("00-%s-%s-%s", t.TraceID, t.SpanID, t.Flags)
+}
+
+// ParseTraceParent parses a traceparent of version 00, the IDs of which can't be all zeros.
+func ParseTraceParent(traceParent string) (TraceContext, error) {
+	m := traceParentPattern.FindStringSubmatch(strings.TrimSpace(traceParent))
+	if m == nil {
+		return TraceContext{}, fmt.Errorf("invalid traceparent %q", traceParent)
+	}
+	if strings.Trim(m[1], "0") == "" || strings.Trim(m[2], "0") == "" {
+		return TraceContext{}, fmt.Errorf("invalid traceparent %q, the trace and span IDs can't be all zeros", traceParent)
+	}
+	return TraceContext{TraceID: m[1], SpanID: m[2], Flags: m[3]}, nil
+}
+
+// NewRootTraceContext returns the sampled context of a new trace, with random trace and span IDs.
+func NewRootTraceContext() TraceContext {
+	traceID := make([]byte, 16)
+	spanID := make([]byte, 8)
+	// crypto/rand doesn't fail on the supported platforms
+	_, _ = rand.Read(traceID)
+	_, _ = rand.Read(spanID)
+	return TraceContext{TraceID: hex.EncodeToString(traceID), SpanID: hex.EncodeToString(spanID), Flags: "01"}
+}
+
+// WithTraceContext sets the trace context of the event.
+func WithTraceContext(t TraceContext) Options {
+	return func(e *event.Event) error {
+		e.SetExtension(TraceParentExtension, t.String())
+		return nil
+	}
+}
+
+// TraceContextOf returns the trace context of the event, false if it has none.
+func TraceContextOf(e event.Event) (TraceContext, bool) {
+	traceParent, ok := e.Extensions()[TraceParentExtension].(string)
+	if !ok {
+		return TraceContext{}, false
+	}
+	t, err := ParseTraceParent(traceParent)
+	if err != nil {
+		return TraceContext{}, false
+	}
+	return t, true
+}
@@ -0,0 +1,48 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	tc, err := ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	assert.NoError(t, err)
+	assert.Equal(t, TraceContext{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7", Flags: "01"}, tc)
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", tc.String())
+
+	for _, invalid := range []string{
+		"",
+		"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+	} {
+		_, err := ParseTraceParent(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestNewRootTraceContext(t *testing.T) {
+	tc := NewRootTraceContext()
+	parsed, err := ParseTraceParent(tc.String())
+	assert.NoError(t, err)
+	assert.Equal(t, tc, parsed)
+	assert.Equal(t, "01", tc.Flags)
+	assert.NotEqual(t, tc.TraceID, NewRootTraceContext().TraceID)
+}
+
+func TestWithTraceContext(t *testing.T) {
+	e := event.New()
+	_, ok := TraceContextOf(e)
+	assert.False(t, ok)
+	tc := NewRootTraceContext()
+	assert.NoError(t, WithTraceContext(tc)(&e))
+	assert.Equal(t, tc.String(), e.Extensions()[TraceParentExtension])
+	got, ok := TraceContextOf(e)
+	assert.True(t, ok)
+	assert.Equal(t, tc, got)
+}
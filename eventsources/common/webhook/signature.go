@@ -0,0 +1,112 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/http"
+	"strings"
+
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+const (
+	defaultSignatureAlgorithm = "sha256"
+	defaultSignatureEncoding  = "hex"
+)
+
+// signatureHash returns the hash constructor of the given algorithm
+func signatureHash(algorithm string) (func() hash.Hash, error) {
+	switch strings.ToLower(algorithm) {
+	case "sha1":
+		return sha1.New, nil
+	case "", defaultSignatureAlgorithm:
+		return sha256.New, nil
+	case "sha512":
+		return sha512.New, nil
+	default:
+		return nil, fmt.Errorf("unsupported signature algorithm %q", algorithm)
+	}
+}
+
+// decodeSignature decodes the signature from the header value
+func decodeSignature(encoding, value string) ([]byte, error) {
+	switch strings.ToLower(encoding) {
+	case "", defaultSignatureEncoding:
+		return hex.DecodeString(value)
+	case "base64":
+		return base64.StdEncoding.DecodeString(value)
+	default:
+		return nil, fmt.Errorf("unsupported signature encoding %q", encoding)
+	}
+}
+
+// validateSignature verifies that the signature in the request header is the HMAC of the body
+func validateSignature(sv *v1alpha1.WebhookSignatureValidation, key []byte, header http.Header, body []byte) error {
+	value := strings.TrimSpace(header.Get(sv.Header))
+	if value == "" {
+		return fmt.Errorf("signature header %s is missing", sv.Header)
+	}
+	algorithm := sv.Algorithm
+	if algorithm == "" {
+		algorithm = defaultSignatureAlgorithm
+	}
+	// signatures like GitHub's are prefixed with the algorithm, e.g. sha256=<signature>
+	if prefix := strings.ToLower(algorithm) + "="; len(value) > len(prefix) && strings.EqualFold(value[:len(prefix)], prefix) {
+		value = value[len(prefix):]
+	}
+	newHash, err := signatureHash(algorithm)
+	if err != nil {
+		return err
+	}
+	signature, err := decodeSignature(sv.Encoding, value)
+	if err != nil {
+		return fmt.Errorf("failed to decode the signature. err: %+v", err)
+	}
+	mac := hmac.New(newHash, key)
+	mac.Write(body)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return fmt.Errorf("signature mismatch")
+	}
+	return nil
+}
+
+// validateSignatureValidation validates the signature validation configuration
+func validateSignatureValidation(sv *v1alpha1.WebhookSignatureValidation) error {
+	if sv.Header == "" {
+		return fmt.Errorf("signature header can't be empty")
+	}
+	if sv.Secret == nil {
+		return fmt.Errorf("signature secret can't be nil")
+	}
+	if _, err := signatureHash(sv.Algorithm); err != nil {
+		return err
+	}
+	switch strings.ToLower(sv.Encoding) {
+	case "", defaultSignatureEncoding, "base64":
+	default:
+		return fmt.Errorf("unsupported signature encoding %q", sv.Encoding)
+	}
+	return nil
+}
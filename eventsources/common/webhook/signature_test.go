@@ -0,0 +1,91 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestValidateSignature(t *testing.T) {
+	key := []byte("secret")
+	body := []byte(`{"hello": "world"}`)
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+	sum := mac.Sum(nil)
+
+	sv := &v1alpha1.WebhookSignatureValidation{Header: "X-Hub-Signature-256"}
+	header := http.Header{}
+
+	t.Run("missing header", func(t *testing.T) {
+		assert.Error(t, validateSignature(sv, key, header, body))
+	})
+
+	t.Run("hex", func(t *testing.T) {
+		header.Set(sv.Header, hex.EncodeToString(sum))
+		assert.NoError(t, validateSignature(sv, key, header, body))
+	})
+
+	t.Run("algorithm prefix", func(t *testing.T) {
+		header.Set(sv.Header, "sha256="+hex.EncodeToString(sum))
+		assert.NoError(t, validateSignature(sv, key, header, body))
+	})
+
+	t.Run("base64", func(t *testing.T) {
+		b64 := &v1alpha1.WebhookSignatureValidation{Header: sv.Header, Encoding: "base64"}
+		header.Set(sv.Header, base64.StdEncoding.EncodeToString(sum))
+		assert.NoError(t, validateSignature(b64, key, header, body))
+	})
+
+	t.Run("sha512", func(t *testing.T) {
+		mac := hmac.New(sha512.New, key)
+		mac.Write(body)
+		s512 := &v1alpha1.WebhookSignatureValidation{Header: sv.Header, Algorithm: "sha512"}
+		header.Set(sv.Header, hex.EncodeToString(mac.Sum(nil)))
+		assert.NoError(t, validateSignature(s512, key, header, body))
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		header.Set(sv.Header, hex.EncodeToString(sum))
+		assert.Error(t, validateSignature(sv, []byte("other"), header, body))
+		assert.Error(t, validateSignature(sv, key, header, []byte("tampered")))
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		header.Set(sv.Header, "not-hex")
+		assert.Error(t, validateSignature(sv, key, header, body))
+	})
+}
+
+func TestValidateSignatureValidation(t *testing.T) {
+	secret := &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "webhook"}, Key: "hmac"}
+	assert.NoError(t, validateSignatureValidation(&v1alpha1.WebhookSignatureValidation{Header: "X-Signature", Secret: secret}))
+	assert.Error(t, validateSignatureValidation(&v1alpha1.WebhookSignatureValidation{Secret: secret}))
+	assert.Error(t, validateSignatureValidation(&v1alpha1.WebhookSignatureValidation{Header: "X-Signature"}))
+	assert.Error(t, validateSignatureValidation(&v1alpha1.WebhookSignatureValidation{Header: "X-Signature", Secret: secret, Algorithm: "md5"}))
+	assert.Error(t, validateSignatureValidation(&v1alpha1.WebhookSignatureValidation{Header: "X-Signature", Secret: secret, Encoding: "base32"}))
+}
@@ -40,6 +40,11 @@ func ValidateWebhookContext(context *v1alpha1.WebhookContext) error {
 			return fmt.Errorf("failed to parse server port %s. err: %+v", context.Port, err)
 		}
 	}
+	if context.SignatureValidation != nil {
+		if err := validateSignatureValidation(context.SignatureValidation); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -17,8 +17,10 @@ limitations under the License.
 package webhook
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"strings"
 
@@ -141,6 +143,26 @@ func startServer(router Router, controller *Controller) {
 					return
 				}
 			}
+			if sv := route.Context.SignatureValidation; sv != nil {
+				key, err := common.GetSecretFromVolume(sv.Secret)
+				if err != nil {
+					route.Logger.Errorw("failed to get signature secret from volume", "error", err)
+					common.SendInternalErrorResponse(writer, "Error loading signature secret")
+					return
+				}
+				body, err := ioutil.ReadAll(request.Body)
+				if err != nil {
+					route.Logger.Errorw("failed to read request body", "error", err)
+					common.SendErrorResponse(writer, err.Error())
+					return
+				}
+				request.Body = ioutil.NopCloser(bytes.NewReader(body))
+				if err := validateSignature(sv, []byte(key), request.Header, body); err != nil {
+					route.Logger.Errorw("invalid request signature", "error", err)
+					common.SendResponse(writer, http.StatusUnauthorized, "Invalid Signature")
+					return
+				}
+			}
 			if request.Header.Get("Authorization") != "" {
 				// Auth secret stops here
 				request.Header.Set("Authorization", "*** Masked Auth Secret ***")
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"net/http"
 	"strings"
 	"sync"
 	"time"
@@ -21,10 +22,12 @@ import (
 	"github.com/argoproj/argo-events/eventbus"
 	eventbusdriver "github.com/argoproj/argo-events/eventbus/driver"
 	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/eventsources/sources"
 	"github.com/argoproj/argo-events/eventsources/sources/amqp"
 	"github.com/argoproj/argo-events/eventsources/sources/awssns"
 	"github.com/argoproj/argo-events/eventsources/sources/awssqs"
 	"github.com/argoproj/argo-events/eventsources/sources/azureeventshub"
+	"github.com/argoproj/argo-events/eventsources/sources/azureservicebus"
 	"github.com/argoproj/argo-events/eventsources/sources/bitbucket"
 	"github.com/argoproj/argo-events/eventsources/sources/bitbucketserver"
 	"github.com/argoproj/argo-events/eventsources/sources/calendar"
@@ -34,25 +37,40 @@ import (
 	"github.com/argoproj/argo-events/eventsources/sources/generic"
 	"github.com/argoproj/argo-events/eventsources/sources/github"
 	"github.com/argoproj/argo-events/eventsources/sources/gitlab"
+	"github.com/argoproj/argo-events/eventsources/sources/grpc"
 	"github.com/argoproj/argo-events/eventsources/sources/hdfs"
+	"github.com/argoproj/argo-events/eventsources/sources/httppoll"
+	"github.com/argoproj/argo-events/eventsources/sources/jetstream"
 	"github.com/argoproj/argo-events/eventsources/sources/kafka"
 	"github.com/argoproj/argo-events/eventsources/sources/minio"
 	"github.com/argoproj/argo-events/eventsources/sources/mqtt"
+	"github.com/argoproj/argo-events/eventsources/sources/mqttv5"
 	"github.com/argoproj/argo-events/eventsources/sources/nats"
 	"github.com/argoproj/argo-events/eventsources/sources/nsq"
+	"github.com/argoproj/argo-events/eventsources/sources/postgres"
+	"github.com/argoproj/argo-events/eventsources/sources/prometheus"
 	"github.com/argoproj/argo-events/eventsources/sources/pulsar"
 	"github.com/argoproj/argo-events/eventsources/sources/redis"
+	"github.com/argoproj/argo-events/eventsources/sources/redisstream"
 	"github.com/argoproj/argo-events/eventsources/sources/resource"
 	"github.com/argoproj/argo-events/eventsources/sources/slack"
 	"github.com/argoproj/argo-events/eventsources/sources/storagegrid"
 	"github.com/argoproj/argo-events/eventsources/sources/stripe"
 	"github.com/argoproj/argo-events/eventsources/sources/webhook"
+	"github.com/argoproj/argo-events/eventsources/sources/websocket"
 	eventsourcemetrics "github.com/argoproj/argo-events/metrics"
 	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
 	eventbusv1alpha1 "github.com/argoproj/argo-events/pkg/apis/eventbus/v1alpha1"
 	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
 )
 
+const (
+	// panicRestartDelay is the delay before restarting an event source after its first panic, doubled on every panic
+	panicRestartDelay = time.Second
+	// maxPanicRestartDelay caps the delay before restarting an event source after a panic
+	maxPanicRestartDelay = time.Minute
+)
+
 // EventingServer is the server API for Eventing service.
 type EventingServer interface {
 
@@ -69,6 +87,22 @@ type EventingServer interface {
 	StartListening(ctx context.Context, dispatch func([]byte, ...eventsourcecommon.Options) error) error
 }
 
+// DispatchMiddlewares is implemented by the eventing servers whose dispatch is wrapped with middlewares, e.g. to
+// sample their events. The dispatch passed to StartListening is the wrapped one, the eventing server needn't call
+// the middlewares itself.
+type DispatchMiddlewares interface {
+	// DispatchMiddlewares returns the middlewares, the first one being the outermost
+	DispatchMiddlewares() []eventsourcecommon.DispatchMiddleware
+}
+
+// dispatchMiddlewares returns the middlewares the dispatch of the eventing server is wrapped with, if any
+func dispatchMiddlewares(s EventingServer) []eventsourcecommon.DispatchMiddleware {
+	if m, ok := s.(DispatchMiddlewares); ok {
+		return m.DispatchMiddlewares()
+	}
+	return nil
+}
+
 // GetEventingServers returns the mapping of event source type and list of eventing servers
 func GetEventingServers(eventSource *v1alpha1.EventSource, metrics *eventsourcemetrics.Metrics) (map[apicommon.EventSourceType][]EventingServer, map[string]*v1alpha1.EventSourceFilter) {
 	result := make(map[apicommon.EventSourceType][]EventingServer)
@@ -93,6 +127,16 @@ func GetEventingServers(eventSource *v1alpha1.EventSource, metrics *eventsourcem
 		}
 		result[apicommon.AzureEventsHub] = servers
 	}
+	if len(eventSource.Spec.AzureServiceBus) != 0 {
+		servers := []EventingServer{}
+		for k, v := range eventSource.Spec.AzureServiceBus {
+			if v.Filter != nil {
+				filters[k] = v.Filter
+			}
+			servers = append(servers, &azureservicebus.EventListener{EventSourceName: eventSource.Name, EventName: k, AzureServiceBusEventSource: v, Metrics: metrics})
+		}
+		result[apicommon.AzureServiceBusEvent] = servers
+	}
 	if len(eventSource.Spec.Bitbucket) != 0 {
 		servers := []EventingServer{}
 		for k, v := range eventSource.Spec.Bitbucket {
@@ -125,11 +169,12 @@ func GetEventingServers(eventSource *v1alpha1.EventSource, metrics *eventsourcem
 	}
 	if len(eventSource.Spec.Emitter) != 0 {
 		servers := []EventingServer{}
+		secretResolver := common.NewSecretResolver(eventSource.Spec.SecretBackend, logging.NewArgoEventsLogger().With(logging.LabelEventSourceName, eventSource.Name))
 		for k, v := range eventSource.Spec.Emitter {
 			if v.Filter != nil {
 				filters[k] = v.Filter
 			}
-			servers = append(servers, &emitter.EventListener{EventSourceName: eventSource.Name, EventName: k, EmitterEventSource: v, Metrics: metrics})
+			servers = append(servers, &emitter.EventListener{EventSourceName: eventSource.Name, EventName: k, EmitterEventSource: v, Metrics: metrics, SecretResolver: secretResolver})
 		}
 		result[apicommon.EmitterEvent] = servers
 	}
@@ -193,6 +238,46 @@ func GetEventingServers(eventSource *v1alpha1.EventSource, metrics *eventsourcem
 		}
 		result[apicommon.MQTTEvent] = servers
 	}
+	if len(eventSource.Spec.MQTTV5) != 0 {
+		servers := []EventingServer{}
+		for k, v := range eventSource.Spec.MQTTV5 {
+			if v.Filter != nil {
+				filters[k] = v.Filter
+			}
+			servers = append(servers, &mqttv5.EventListener{EventSourceName: eventSource.Name, EventName: k, MQTTV5EventSource: v, Metrics: metrics})
+		}
+		result[apicommon.MQTTV5Event] = servers
+	}
+	if len(eventSource.Spec.WebSocket) != 0 {
+		servers := []EventingServer{}
+		for k, v := range eventSource.Spec.WebSocket {
+			if v.Filter != nil {
+				filters[k] = v.Filter
+			}
+			servers = append(servers, &websocket.EventListener{EventSourceName: eventSource.Name, EventName: k, WebSocketEventSource: v, Metrics: metrics})
+		}
+		result[apicommon.WebSocketEvent] = servers
+	}
+	if len(eventSource.Spec.Postgres) != 0 {
+		servers := []EventingServer{}
+		for k, v := range eventSource.Spec.Postgres {
+			if v.Filter != nil {
+				filters[k] = v.Filter
+			}
+			servers = append(servers, &postgres.EventListener{EventSourceName: eventSource.Name, EventName: k, PostgresEventSource: v, Metrics: metrics})
+		}
+		result[apicommon.PostgresEvent] = servers
+	}
+	if len(eventSource.Spec.HTTPPoll) != 0 {
+		servers := []EventingServer{}
+		for k, v := range eventSource.Spec.HTTPPoll {
+			if v.Filter != nil {
+				filters[k] = v.Filter
+			}
+			servers = append(servers, &httppoll.EventListener{EventSourceName: eventSource.Name, EventName: k, HTTPPollEventSource: v, Metrics: metrics})
+		}
+		result[apicommon.HTTPPollEvent] = servers
+	}
 	if len(eventSource.Spec.Minio) != 0 {
 		servers := []EventingServer{}
 		for k, v := range eventSource.Spec.Minio {
@@ -318,6 +403,46 @@ func GetEventingServers(eventSource *v1alpha1.EventSource, metrics *eventsourcem
 		}
 		result[apicommon.GenericEvent] = servers
 	}
+	if len(eventSource.Spec.JetStream) != 0 {
+		servers := []EventingServer{}
+		for k, v := range eventSource.Spec.JetStream {
+			if v.Filter != nil {
+				filters[k] = v.Filter
+			}
+			servers = append(servers, &jetstream.EventListener{EventSourceName: eventSource.Name, EventName: k, JetStreamEventSource: v, Metrics: metrics})
+		}
+		result[apicommon.JetStreamEvent] = servers
+	}
+	if len(eventSource.Spec.RedisStream) != 0 {
+		servers := []EventingServer{}
+		for k, v := range eventSource.Spec.RedisStream {
+			if v.Filter != nil {
+				filters[k] = v.Filter
+			}
+			servers = append(servers, &redisstream.EventListener{EventSourceName: eventSource.Name, EventName: k, RedisStreamEventSource: v, Metrics: metrics})
+		}
+		result[apicommon.RedisStreamEvent] = servers
+	}
+	if len(eventSource.Spec.Prometheus) != 0 {
+		servers := []EventingServer{}
+		for k, v := range eventSource.Spec.Prometheus {
+			if v.Filter != nil {
+				filters[k] = v.Filter
+			}
+			servers = append(servers, &prometheus.EventListener{EventSourceName: eventSource.Name, EventName: k, PrometheusEventSource: v, Metrics: metrics})
+		}
+		result[apicommon.PrometheusEvent] = servers
+	}
+	if len(eventSource.Spec.GRPC) != 0 {
+		servers := []EventingServer{}
+		for k, v := range eventSource.Spec.GRPC {
+			if v.Filter != nil {
+				filters[k] = v.Filter
+			}
+			servers = append(servers, &grpc.EventListener{EventSourceName: eventSource.Name, EventName: k, GRPCEventSource: v, Metrics: metrics})
+		}
+		result[apicommon.GRPCEvent] = servers
+	}
 	return result, filters
 }
 
@@ -354,6 +479,10 @@ func (e *EventSourceAdaptor) Start(ctx context.Context) error {
 	}
 	isRecreatType := false
 	servers, filters := GetEventingServers(e.eventSource, e.metrics)
+	// the health probes are served along with the metrics
+	healthHandler := eventsourcecommon.NewHealthHandler(healthReporters(servers), eventsourcecommon.DefaultHealthStaleness)
+	http.Handle("/healthz", healthHandler)
+	http.Handle("/healthz/", healthHandler)
 	for k := range servers {
 		if _, ok := recreateTypes[k]; ok {
 			isRecreatType = true
@@ -440,7 +569,8 @@ func (e *EventSourceAdaptor) run(ctx context.Context, servers map[apicommon.Even
 		}
 	}()
 
-	wg := &sync.WaitGroup{}
+	// the sources are cancelled and drained by the coordinator on shutdown, within the termination grace period
+	coordinator, sourcesCtx := eventsourcecommon.NewShutdownCoordinator(ctx, e.eventSource.Spec.GetTerminationGracePeriod())
 	for _, ss := range servers {
 		for _, server := range ss {
 			// Validation has been done in eventsource-controller, it's harmless to do it again here.
@@ -451,9 +581,8 @@ func (e *EventSourceAdaptor) run(ctx context.Context, servers map[apicommon.Even
 				// Continue starting other event services instead of failing all of them
 				continue
 			}
-			wg.Add(1)
-			go func(s EventingServer) {
-				defer wg.Done()
+			s := server
+			coordinator.Go(s.GetEventName(), func() {
 				e.metrics.IncRunningServices(s.GetEventSourceName())
 				defer e.metrics.DecRunningServices(s.GetEventSourceName())
 				duration := apicommon.FromString("1s")
@@ -465,79 +594,71 @@ func (e *EventSourceAdaptor) run(ctx context.Context, servers map[apicommon.Even
 					Factor:   &factor,
 					Jitter:   &jitter,
 				}
-				if err = common.Connect(&backoff, func() error {
-					return s.StartListening(ctx, func(data []byte, opts ...eventsourcecommon.Options) error {
-						if filter, ok := filters[s.GetEventName()]; ok {
-							proceed, err := filterEvent(data, filter)
-							if err != nil {
-								logger.Errorw("Failed to filter event", zap.Error(err))
-								return nil
-							}
-							if !proceed {
-								logger.Debug("Do not publish event, filter condition not met")
-								return nil
+				// the connection attempts of the event source to its source are recorded in the metrics
+				listenCtx := common.WithConnectionObserver(sourcesCtx, e.metrics, s.GetEventSourceName(), s.GetEventName())
+				listen := func() (err error) {
+					defer sources.RecoverError(s.GetEventName(), &err)
+					return common.Connect(&backoff, func() error {
+						dispatch := func(data []byte, opts ...eventsourcecommon.Options) error {
+							if filter, ok := filters[s.GetEventName()]; ok {
+								proceed, err := filterEvent(data, filter)
+								if err != nil {
+									logger.Errorw("Failed to filter event", zap.Error(err))
+									return nil
+								}
+								if !proceed {
+									logger.Debug("Do not publish event, filter condition not met")
+									return nil
+								}
 							}
-						}
 
-						event := cloudevents.NewEvent()
-						event.SetID(fmt.Sprintf("%x", uuid.New()))
-						event.SetType(string(s.GetEventSourceType()))
-						event.SetSource(s.GetEventSourceName())
-						event.SetSubject(s.GetEventName())
-						event.SetTime(time.Now())
-						for _, opt := range opts {
-							err := opt(&event)
+							event, err := newEvent(s, data, opts...)
+							if err != nil {
+								return err
+							}
+							eventBody, err := json.Marshal(event)
 							if err != nil {
 								return err
 							}
-						}
-						err := event.SetData(cloudevents.ApplicationJSON, data)
-						if err != nil {
-							return err
-						}
-						eventBody, err := json.Marshal(event)
-						if err != nil {
-							return err
-						}
 
-						if e.eventBusConn == nil || e.eventBusConn.IsClosed() {
-							return errors.New("failed to publish event, eventbus connection closed")
-						}
-						if err = driver.Publish(e.eventBusConn, eventBody); err != nil {
-							logger.Errorw("failed to publish an event", zap.Error(err), zap.String(logging.LabelEventName,
-								s.GetEventName()), zap.Any(logging.LabelEventSourceType, s.GetEventSourceType()))
-							e.metrics.EventSentFailed(s.GetEventSourceName(), s.GetEventName())
-							return err
+							if e.eventBusConn == nil || e.eventBusConn.IsClosed() {
+								return errors.New("failed to publish event, eventbus connection closed")
+							}
+							if err = driver.Publish(e.eventBusConn, eventBody); err != nil {
+								logger.Errorw("failed to publish an event", zap.Error(err), zap.String(logging.LabelEventName,
+									s.GetEventName()), zap.Any(logging.LabelEventSourceType, s.GetEventSourceType()))
+								e.metrics.EventSentFailed(s.GetEventSourceName(), s.GetEventName())
+								return err
+							}
+							logger.Infow("succeeded to publish an event", zap.String(logging.LabelEventName,
+								s.GetEventName()), zap.Any(logging.LabelEventSourceType, s.GetEventSourceType()), zap.String("eventID", event.ID()))
+							e.metrics.EventSent(s.GetEventSourceName(), s.GetEventName())
+							return nil
 						}
-						logger.Infow("succeeded to publish an event", zap.String(logging.LabelEventName,
-							s.GetEventName()), zap.Any(logging.LabelEventSourceType, s.GetEventSourceType()), zap.String("eventID", event.ID()))
-						e.metrics.EventSent(s.GetEventSourceName(), s.GetEventName())
-						return nil
+						// the cross-cutting logic of the middlewares is applied to the events before they are published
+						return s.StartListening(listenCtx, eventsourcecommon.ChainDispatch(dispatch, dispatchMiddlewares(s)...))
 					})
-				}); err != nil {
+				}
+				if err := e.listenWithRestarts(sourcesCtx, s, listen); err != nil {
 					logger.Errorw("failed to start listening eventsource", zap.Any(logging.LabelEventSourceType,
 						s.GetEventSourceType()), zap.Any(logging.LabelEventName, s.GetEventName()), zap.Error(err))
 				}
-			}(server)
+			})
 		}
 	}
 	logger.Info("Eventing server started.")
 
-	eventServersWGDone := make(chan bool)
-	go func() {
-		wg.Wait()
-		close(eventServersWGDone)
-	}()
-
 	for {
 		select {
 		case <-ctx.Done():
-			logger.Info("Shutting down...")
+			logger.Infow("Shutting down...", zap.Duration("terminationGracePeriod", e.eventSource.Spec.GetTerminationGracePeriod()))
+			if stuck := coordinator.Shutdown(); len(stuck) > 0 {
+				logger.Errorw("termination grace period elapsed, event sources did not drain in time", zap.Strings("eventNames", stuck))
+			}
 			cancel()
-			<-eventServersWGDone
 			connWG.Wait()
 			return nil
-		case <-eventServersWGDone:
+		case <-coordinator.Done():
 			logger.Error("Erroring out, no active event server running")
 			cancel()
 			connWG.Wait()
@@ -546,6 +667,58 @@ func (e *EventSourceAdaptor) run(ctx context.Context, servers map[apicommon.Even
 	}
 }
 
+// listenWithRestarts runs the listen function of the event source, and runs it again after a backoff when the event
+// source panics and the event source spec sets RestartOnPanic, at most MaxPanicRestarts times.
+func (e *EventSourceAdaptor) listenWithRestarts(ctx context.Context, s EventingServer, listen func() error) error {
+	logger := logging.FromContext(ctx).With(logging.LabelEventSourceType, s.GetEventSourceType(), logging.LabelEventName, s.GetEventName())
+	delay := panicRestartDelay
+	for restarts := int32(0); ; restarts++ {
+		err := listen()
+		var panicErr *sources.PanicError
+		if !errors.As(err, &panicErr) {
+			return err
+		}
+		logger.Errorw("event source panicked", zap.Any("panic", panicErr.Value), zap.ByteString("stack", panicErr.Stack))
+		if !e.eventSource.Spec.RestartOnPanic {
+			return err
+		}
+		if restarts >= e.eventSource.Spec.GetMaxPanicRestarts() {
+			logger.Errorw("not restarting the event source, it panicked too many times", "restarts", restarts)
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		logger.Infow("restarting the event source after a panic", "restarts", restarts+1)
+		e.metrics.EventSourceRestarted(s.GetEventSourceName(), s.GetEventName())
+		delay *= 2
+		if delay > maxPanicRestartDelay {
+			delay = maxPanicRestartDelay
+		}
+	}
+}
+
+// newEvent wraps the data dispatched by the event source into a cloud event, customized by the options.
+func newEvent(s EventingServer, data []byte, opts ...eventsourcecommon.Options) (cloudevents.Event, error) {
+	event := cloudevents.NewEvent()
+	event.SetID(fmt.Sprintf("%x", uuid.New()))
+	event.SetType(string(s.GetEventSourceType()))
+	event.SetSource(s.GetEventSourceName())
+	event.SetSubject(s.GetEventName())
+	event.SetTime(time.Now())
+	for _, opt := range opts {
+		if err := opt(&event); err != nil {
+			return event, err
+		}
+	}
+	if err := event.SetData(cloudevents.ApplicationJSON, data); err != nil {
+		return event, err
+	}
+	return event, nil
+}
+
 func generateClientID(hostname string) string {
 	s1 := rand.NewSource(time.Now().UnixNano())
 	r1 := rand.New(s1)
@@ -567,3 +740,16 @@ func filterEvent(data []byte, filter *v1alpha1.EventSourceFilter) (bool, error)
 	env := expr.GetFuncMap(params)
 	return expr.EvalBool(filter.Expression, env)
 }
+
+// healthReporters returns the eventing servers reporting their health, keyed by event name
+func healthReporters(servers map[apicommon.EventSourceType][]EventingServer) map[string]eventsourcecommon.HealthReporter {
+	result := make(map[string]eventsourcecommon.HealthReporter)
+	for _, ss := range servers {
+		for _, server := range ss {
+			if reporter, ok := server.(eventsourcecommon.HealthReporter); ok {
+				result[server.GetEventName()] = reporter
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,132 @@
+package eventsources
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/eventsources/sources"
+	eventsourcemetrics "github.com/argoproj/argo-events/metrics"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+type fakeServer struct{}
+
+func (s *fakeServer) ValidateEventSource(context.Context) error { return nil }
+
+func (s *fakeServer) GetEventSourceName() string { return "es" }
+
+func (s *fakeServer) GetEventName() string { return "ev" }
+
+func (s *fakeServer) GetEventSourceType() apicommon.EventSourceType { return apicommon.EmitterEvent }
+
+func (s *fakeServer) StartListening(ctx context.Context, dispatch func([]byte, ...eventsourcecommon.Options) error) error {
+	return nil
+}
+
+// fakeMiddlewareServer wraps its dispatch with the middlewares
+type fakeMiddlewareServer struct {
+	fakeServer
+	middlewares []eventsourcecommon.DispatchMiddleware
+}
+
+func (s *fakeMiddlewareServer) DispatchMiddlewares() []eventsourcecommon.DispatchMiddleware {
+	return s.middlewares
+}
+
+func TestNewEvent(t *testing.T) {
+	event, err := newEvent(&fakeServer{}, []byte(`{"a":"b"}`), eventsourcecommon.WithID("id"))
+	assert.NoError(t, err)
+	assert.Equal(t, "id", event.ID())
+	assert.Equal(t, "es", event.Source())
+	assert.Equal(t, "ev", event.Subject())
+	assert.Equal(t, string(apicommon.EmitterEvent), event.Type())
+	assert.WithinDuration(t, time.Now(), event.Time(), time.Minute)
+	assert.JSONEq(t, `{"a":"b"}`, string(event.Data()))
+	assert.Empty(t, eventsourcecommon.PartitionKey(event))
+}
+
+func TestNewEventPartitionKey(t *testing.T) {
+	event, err := newEvent(&fakeServer{}, []byte(`{}`), eventsourcecommon.WithPartitionKey("sensor/kitchen/"))
+	assert.NoError(t, err)
+	body, err := json.Marshal(event)
+	assert.NoError(t, err)
+
+	// the partition key is carried by the published events
+	var published cloudevents.Event
+	assert.NoError(t, json.Unmarshal(body, &published))
+	assert.Equal(t, "sensor/kitchen/", eventsourcecommon.PartitionKey(published))
+}
+
+func TestListenWithRestarts(t *testing.T) {
+	panicking := func(panics int) (func() error, *int) {
+		calls := 0
+		return func() (err error) {
+			defer sources.RecoverError("ev", &err)
+			calls++
+			if calls <= panics {
+				func() {
+					defer sources.Recover("ev")
+					panic("boom")
+				}()
+			}
+			return nil
+		}, &calls
+	}
+	newAdaptor := func(restart bool, maxRestarts *int32) *EventSourceAdaptor {
+		return &EventSourceAdaptor{
+			eventSource: &v1alpha1.EventSource{Spec: v1alpha1.EventSourceSpec{RestartOnPanic: restart, MaxPanicRestarts: maxRestarts}},
+			metrics:     eventsourcemetrics.NewMetrics("test"),
+		}
+	}
+
+	t.Run("not restarted by default", func(t *testing.T) {
+		listen, calls := panicking(1)
+		err := newAdaptor(false, nil).listenWithRestarts(context.Background(), &fakeServer{}, listen)
+		var panicErr *sources.PanicError
+		assert.True(t, errors.As(err, &panicErr))
+		assert.Equal(t, "boom", panicErr.Value)
+		assert.NotEmpty(t, panicErr.Stack)
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("restarted after a panic", func(t *testing.T) {
+		listen, calls := panicking(1)
+		err := newAdaptor(true, nil).listenWithRestarts(context.Background(), &fakeServer{}, listen)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("bounded by the max restarts", func(t *testing.T) {
+		listen, calls := panicking(10)
+		maxRestarts := int32(1)
+		err := newAdaptor(true, &maxRestarts).listenWithRestarts(context.Background(), &fakeServer{}, listen)
+		assert.Error(t, err)
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("not restarted once stopped", func(t *testing.T) {
+		listen, calls := panicking(1)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := newAdaptor(true, nil).listenWithRestarts(ctx, &fakeServer{}, listen)
+		assert.Error(t, err)
+		assert.Equal(t, 1, *calls)
+	})
+}
+
+func TestDispatchMiddlewares(t *testing.T) {
+	assert.Empty(t, dispatchMiddlewares(&fakeServer{}))
+	drop := func(next eventsourcecommon.DispatchFunc) eventsourcecommon.DispatchFunc {
+		return func([]byte, ...eventsourcecommon.Options) error { return nil }
+	}
+	server := &fakeMiddlewareServer{middlewares: []eventsourcecommon.DispatchMiddleware{drop}}
+	assert.Len(t, dispatchMiddlewares(server), 1)
+}
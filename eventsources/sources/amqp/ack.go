@@ -0,0 +1,124 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package amqp
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	amqplib "github.com/streadway/amqp"
+	"go.uber.org/zap"
+
+	metrics "github.com/argoproj/argo-events/metrics"
+)
+
+const (
+	// deliveryCountHeader is the header the quorum queues count the previous deliveries of a message in
+	deliveryCountHeader = "x-delivery-count"
+	// maxTrackedRequeues bounds the number of messages whose requeues are counted by the event source
+	maxTrackedRequeues = 10000
+)
+
+// acknowledge acks the message once its event is dispatched. A message whose event fails to be dispatched is
+// requeued up to MaxRequeues times, then rejected along with the messages which fail to be converted into an event,
+// so that the broker dead-letters it if the queue has a dead letter exchange.
+func (el *EventListener) acknowledge(msg amqplib.Delivery, err error, requeues *requeueTracker, log *zap.SugaredLogger) {
+	if err == nil {
+		requeues.forget(msg)
+		if err := msg.Ack(false); err != nil {
+			log.Errorw("failed to acknowledge the message", zap.Uint64("delivery-tag", msg.DeliveryTag), zap.Error(err))
+			el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonAck)
+		}
+		return
+	}
+	requeue := metrics.ReasonOf(err) == metrics.FailureReasonDispatch && requeues.requeue(msg, el.AMQPEventSource.MaxRequeues)
+	if requeue {
+		log.Infow("requeuing the message", zap.Uint64("delivery-tag", msg.DeliveryTag))
+	} else {
+		log.Warnw("rejecting the message, it is dead-lettered if the queue has a dead letter exchange", zap.Uint64("delivery-tag", msg.DeliveryTag))
+	}
+	if err := msg.Nack(false, requeue); err != nil {
+		log.Errorw("failed to reject the message", zap.Uint64("delivery-tag", msg.DeliveryTag), zap.Error(err))
+		el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonAck)
+	}
+}
+
+// requeueTracker counts the requeues of the messages. The quorum queues count the previous deliveries of a message
+// in its x-delivery-count header, the requeues of the messages of the other queues are counted by the event source,
+// keyed by the ID of the message, or by its exchange, routing key and body if it has none.
+type requeueTracker struct {
+	counts map[string]int32
+}
+
+func newRequeueTracker() *requeueTracker {
+	return &requeueTracker{counts: make(map[string]int32)}
+}
+
+// requeue tells whether the message was requeued less than maxRequeues times, and counts its requeue if so.
+func (t *requeueTracker) requeue(msg amqplib.Delivery, maxRequeues int32) bool {
+	if count, ok := deliveryCount(msg); ok {
+		return count < int64(maxRequeues)
+	}
+	key := requeueKey(msg)
+	count := t.counts[key]
+	if count >= maxRequeues {
+		delete(t.counts, key)
+		return false
+	}
+	if len(t.counts) >= maxTrackedRequeues {
+		// the messages consumed by another consumer once requeued would never be forgotten otherwise
+		t.counts = make(map[string]int32)
+	}
+	t.counts[key] = count + 1
+	return true
+}
+
+// forget stops counting the requeues of the message.
+func (t *requeueTracker) forget(msg amqplib.Delivery) {
+	if len(t.counts) > 0 {
+		delete(t.counts, requeueKey(msg))
+	}
+}
+
+// deliveryCount returns the number of previous deliveries of the message counted by a quorum queue, if any.
+func deliveryCount(msg amqplib.Delivery) (int64, bool) {
+	switch count := msg.Headers[deliveryCountHeader].(type) {
+	case int64:
+		return count, true
+	case int32:
+		return int64(count), true
+	case int16:
+		return int64(count), true
+	case int:
+		return int64(count), true
+	default:
+		return 0, false
+	}
+}
+
+func requeueKey(msg amqplib.Delivery) string {
+	if msg.MessageId != "" {
+		return "id:" + msg.MessageId
+	}
+	hash := sha256.New()
+	hash.Write([]byte(msg.Exchange))
+	hash.Write([]byte{0})
+	hash.Write([]byte(msg.RoutingKey))
+	hash.Write([]byte{0})
+	hash.Write(msg.Body)
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package amqp
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	amqplib "github.com/streadway/amqp"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	metrics "github.com/argoproj/argo-events/metrics"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// settlement is the outcome of a delivery recorded by fakeAcknowledger
+type settlement struct {
+	tag     uint64
+	ack     bool
+	requeue bool
+}
+
+// fakeAcknowledger records the acks and nacks of the deliveries instead of sending them to the broker
+type fakeAcknowledger struct {
+	settlements []settlement
+}
+
+func (a *fakeAcknowledger) Ack(tag uint64, multiple bool) error {
+	a.settlements = append(a.settlements, settlement{tag: tag, ack: true})
+	return nil
+}
+
+func (a *fakeAcknowledger) Nack(tag uint64, multiple bool, requeue bool) error {
+	a.settlements = append(a.settlements, settlement{tag: tag, requeue: requeue})
+	return nil
+}
+
+func (a *fakeAcknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
+
+func TestAcknowledge(t *testing.T) {
+	el := &EventListener{
+		EventSourceName: "amqp",
+		EventName:       "example",
+		AMQPEventSource: v1alpha1.AMQPEventSource{
+			JSONBody:    true,
+			MaxRequeues: 2,
+			Consume:     &v1alpha1.AMQPConsumeConfig{AutoAck: false},
+		},
+		Metrics: metrics.NewMetrics("ns"),
+	}
+	acknowledger := &fakeAcknowledger{}
+	requeues := newRequeueTracker()
+	log := zap.NewNop().Sugar()
+	failing := func([]byte, ...eventsourcecommon.Options) error { return errors.New("eventbus unavailable") }
+	succeeding := func([]byte, ...eventsourcecommon.Options) error { return nil }
+
+	settle := func(tag uint64, body string, dispatch func([]byte, ...eventsourcecommon.Options) error) {
+		msg := amqplib.Delivery{Acknowledger: acknowledger, DeliveryTag: tag, MessageId: "m1", Body: []byte(body)}
+		el.acknowledge(msg, el.handleOne(&el.AMQPEventSource, msg, dispatch, log), requeues, log)
+	}
+	// requeued twice, then dead-lettered
+	settle(1, `{}`, failing)
+	settle(2, `{}`, failing)
+	settle(3, `{}`, failing)
+	// the count starts over once the message is rejected
+	settle(4, `{}`, failing)
+	settle(5, `{}`, succeeding)
+	// a message which can't be converted into an event isn't requeued
+	settle(6, `not json`, succeeding)
+	assert.Equal(t, []settlement{
+		{tag: 1, requeue: true},
+		{tag: 2, requeue: true},
+		{tag: 3},
+		{tag: 4, requeue: true},
+		{tag: 5, ack: true},
+		{tag: 6},
+	}, acknowledger.settlements)
+	assert.Empty(t, requeues.counts)
+}
+
+func TestRequeueDeliveryCount(t *testing.T) {
+	requeues := newRequeueTracker()
+	msg := amqplib.Delivery{Body: []byte("hello")}
+	assert.True(t, requeues.requeue(msg, 1))
+	assert.False(t, requeues.requeue(msg, 1))
+
+	// the quorum queues count the deliveries themselves
+	msg.Headers = amqplib.Table{deliveryCountHeader: int64(1)}
+	assert.True(t, requeues.requeue(msg, 2))
+	msg.Headers[deliveryCountHeader] = int64(2)
+	assert.False(t, requeues.requeue(msg, 2))
+	assert.Empty(t, requeues.counts)
+}
@@ -69,14 +69,48 @@ func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byt
 	defer sources.Recover(el.GetEventName())
 
 	amqpEventSource := &el.AMQPEventSource
+	log.Info("checking parameters and set defaults...")
+	setDefaults(amqpEventSource)
+
+	if amqpEventSource.JSONBody {
+		log.Info("assuming all events have a json body...")
+	}
+
+	requeues := newRequeueTracker()
+	// a lost connection is established again along with the exchange, the queue and the binding, so that the
+	// auto-deleted or exclusive ones are declared again.
+	for {
+		conn, delivery, err := el.connect(ctx, amqpEventSource, log)
+		if err != nil {
+			el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonConnection)
+			return errors.Wrapf(err, "failed to connect to amqp broker for the event source %s", el.GetEventName())
+		}
+
+		log.Info("listening to messages on channel...")
+		if el.consume(ctx, delivery, requeues, dispatch, log) {
+			if err := conn.Close(); err != nil {
+				log.Errorw("failed to close connection", zap.Error(err))
+			}
+			return nil
+		}
+		log.Error("the delivery channel was closed, the connection might have been lost, reconnecting...")
+		el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonConnection)
+		// the connection may be up with only the channel closed, e.g. by a channel exception
+		_ = conn.Close()
+	}
+}
+
+// connect connects to the broker, declares the exchange, the queue and the binding, and starts consuming the queue.
+func (el *EventListener) connect(ctx context.Context, amqpEventSource *v1alpha1.AMQPEventSource, log *zap.SugaredLogger) (*amqplib.Connection, <-chan amqplib.Delivery, error) {
 	var conn *amqplib.Connection
-	if err := common.Connect(amqpEventSource.ConnectionBackoff, func() error {
+	var delivery <-chan amqplib.Delivery
+	err := common.ConnectWithContext(ctx, amqpEventSource.ConnectionBackoff, func() error {
 		c := amqplib.Config{
 			Heartbeat: 10 * time.Second,
 			Locale:    "en_US",
 		}
 		if amqpEventSource.TLS != nil {
-			tlsConfig, err := common.GetTLSConfig(amqpEventSource.TLS)
+			tlsConfig, err := common.GetTLSConfig(amqpEventSource.TLS, log)
 			if err != nil {
 				return errors.Wrap(err, "failed to get the tls configuration")
 			}
@@ -106,52 +140,50 @@ func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byt
 		} else {
 			url = amqpEventSource.URL
 		}
-		conn, err = amqplib.DialConfig(url, c)
+		dialed, err := amqplib.DialConfig(url, c)
 		if err != nil {
 			return err
 		}
-		return nil
-	}); err != nil {
-		return errors.Wrapf(err, "failed to connect to amqp broker for the event source %s", el.GetEventName())
-	}
-
-	log.Info("opening the server channel...")
-	ch, err := conn.Channel()
-	if err != nil {
-		return errors.Wrapf(err, "failed to open the channel for the event source %s", el.GetEventName())
-	}
-
-	log.Info("checking parameters and set defaults...")
-	setDefaults(amqpEventSource)
 
-	log.Info("setting up the delivery channel...")
-	delivery, err := getDelivery(ch, amqpEventSource)
-	if err != nil {
-		return errors.Wrapf(err, "failed to get the delivery for the event source %s", el.GetEventName())
-	}
+		log.Info("opening the server channel...")
+		ch, err := dialed.Channel()
+		if err != nil {
+			_ = dialed.Close()
+			return errors.Wrapf(err, "failed to open the channel for the event source %s", el.GetEventName())
+		}
 
-	if amqpEventSource.JSONBody {
-		log.Info("assuming all events have a json body...")
-	}
+		log.Info("setting up the delivery channel...")
+		d, err := getDelivery(ch, amqpEventSource)
+		if err != nil {
+			_ = dialed.Close()
+			return errors.Wrapf(err, "failed to get the delivery for the event source %s", el.GetEventName())
+		}
+		conn, delivery = dialed, d
+		return nil
+	})
+	return conn, delivery, err
+}
 
-	log.Info("listening to messages on channel...")
+// consume processes the messages of the delivery channel until it is closed, or the context is done in which
+// case it returns true.
+func (el *EventListener) consume(ctx context.Context, delivery <-chan amqplib.Delivery, requeues *requeueTracker, dispatch func([]byte, ...eventsourcecommon.Options) error, log *zap.SugaredLogger) bool {
+	amqpEventSource := &el.AMQPEventSource
 	for {
 		select {
 		case msg, ok := <-delivery:
 			if !ok {
-				log.Error("failed to read a message, channel might have been closed")
-				return errors.New("channel might have been closed")
+				return false
 			}
-			if err := el.handleOne(amqpEventSource, msg, dispatch, log); err != nil {
+			err := el.handleOne(amqpEventSource, msg, dispatch, log)
+			if err != nil {
 				log.Errorw("failed to process an AMQP message", zap.Error(err))
-				el.Metrics.EventProcessingFailed(el.GetEventSourceName(), el.GetEventName())
+				el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.ReasonOf(err))
 			}
-		case <-ctx.Done():
-			err = conn.Close()
-			if err != nil {
-				log.Errorw("failed to close connection", zap.Error(err))
+			if !amqpEventSource.Consume.AutoAck {
+				el.acknowledge(msg, err, requeues, log)
 			}
-			return nil
+		case <-ctx.Done():
+			return true
 		}
 	}
 }
@@ -161,7 +193,7 @@ func (el *EventListener) handleOne(amqpEventSource *v1alpha1.AMQPEventSource, ms
 		el.Metrics.EventProcessingDuration(el.GetEventSourceName(), el.GetEventName(), float64(time.Since(start)/time.Millisecond))
 	}(time.Now())
 
-	log.Infow("received the message", zap.Any("message-id", msg.MessageId))
+	log.Infow("received the message", zap.Any("message-id", msg.MessageId), zap.Uint64("delivery-tag", msg.DeliveryTag))
 	body := &events.AMQPEventData{
 		ContentType:     msg.ContentType,
 		ContentEncoding: msg.ContentEncoding,
@@ -176,6 +208,9 @@ func (el *EventListener) handleOne(amqpEventSource *v1alpha1.AMQPEventSource, ms
 		AppId:           msg.AppId,
 		Exchange:        msg.Exchange,
 		RoutingKey:      msg.RoutingKey,
+		DeliveryTag:     msg.DeliveryTag,
+		Redelivered:     msg.Redelivered,
+		Headers:         msg.Headers,
 		Metadata:        amqpEventSource.Metadata,
 	}
 	if amqpEventSource.JSONBody {
@@ -186,12 +221,12 @@ func (el *EventListener) handleOne(amqpEventSource *v1alpha1.AMQPEventSource, ms
 
 	bodyBytes, err := json.Marshal(body)
 	if err != nil {
-		return errors.Wrapf(err, "failed to marshal the message, message-id: %s", msg.MessageId)
+		return metrics.WithReason(errors.Wrapf(err, "failed to marshal the message, message-id: %s", msg.MessageId), metrics.FailureReasonMarshal)
 	}
 
 	log.Info("dispatching event ...")
 	if err = dispatch(bodyBytes); err != nil {
-		return errors.Wrap(err, "failed to dispatch AMQP event")
+		return metrics.WithReason(errors.Wrap(err, "failed to dispatch AMQP event"), metrics.FailureReasonDispatch)
 	}
 	return nil
 }
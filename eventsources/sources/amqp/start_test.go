@@ -17,9 +17,17 @@ limitations under the License.
 package amqp
 
 import (
+	"encoding/json"
 	"testing"
 
+	amqplib "github.com/streadway/amqp"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	metrics "github.com/argoproj/argo-events/metrics"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
 )
 
 func TestParseYamlTable(t *testing.T) {
@@ -40,3 +48,31 @@ func TestParseYamlTable(t *testing.T) {
 	assert.Equal(t, "thing1", table["key-one"].(string))
 	assert.Equal(t, "thing2", table["key-two"].(string))
 }
+
+func TestHandleOne(t *testing.T) {
+	el := &EventListener{
+		EventSourceName: "amqp",
+		EventName:       "example",
+		AMQPEventSource: v1alpha1.AMQPEventSource{JSONBody: true},
+		Metrics:         metrics.NewMetrics("ns"),
+	}
+	msg := amqplib.Delivery{
+		ContentType: "application/json",
+		MessageId:   "m1",
+		DeliveryTag: 42,
+		Redelivered: true,
+		Headers:     amqplib.Table{"tenant": "acme", "x-delivery-count": int64(1)},
+		RoutingKey:  "hello",
+		Body:        []byte(`{"a": 1}`),
+	}
+	var data events.AMQPEventData
+	err := el.handleOne(&el.AMQPEventSource, msg, func(body []byte, _ ...eventsourcecommon.Options) error {
+		return json.Unmarshal(body, &data)
+	}, zap.NewNop().Sugar())
+	assert.NoError(t, err)
+	assert.Equal(t, "application/json", data.ContentType)
+	assert.Equal(t, uint64(42), data.DeliveryTag)
+	assert.True(t, data.Redelivered)
+	assert.Equal(t, map[string]interface{}{"tenant": "acme", "x-delivery-count": float64(1)}, data.Headers)
+	assert.Equal(t, map[string]interface{}{"a": float64(1)}, data.Body)
+}
@@ -46,6 +46,12 @@ func validate(eventSource *v1alpha1.AMQPEventSource) error {
 	if eventSource.ExchangeType == "" {
 		return errors.New("exchange type must be specified")
 	}
+	if eventSource.MaxRequeues < 0 {
+		return errors.New("maxRequeues must not be negative")
+	}
+	if eventSource.MaxRequeues > 0 && (eventSource.Consume == nil || eventSource.Consume.AutoAck) {
+		return errors.New("maxRequeues requires the manual acknowledgement, i.e. consume autoAck to be false")
+	}
 	if eventSource.TLS != nil {
 		return apicommon.ValidateTLSConfig(eventSource.TLS)
 	}
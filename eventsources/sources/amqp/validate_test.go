@@ -51,3 +51,24 @@ func TestValidateEventSource(t *testing.T) {
 		assert.NoError(t, err)
 	}
 }
+
+func TestValidateMaxRequeues(t *testing.T) {
+	eventSource := &v1alpha1.AMQPEventSource{
+		URL:          "amqp://rabbitmq-service.argo-events:5672/",
+		ExchangeName: "foo",
+		ExchangeType: "fanout",
+		RoutingKey:   "hello",
+		MaxRequeues:  -1,
+	}
+	err := validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "maxRequeues must not be negative", err.Error())
+
+	eventSource.MaxRequeues = 3
+	err = validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "maxRequeues requires the manual acknowledgement, i.e. consume autoAck to be false", err.Error())
+
+	eventSource.Consume = &v1alpha1.AMQPConsumeConfig{AutoAck: false}
+	assert.NoError(t, validate(eventSource))
+}
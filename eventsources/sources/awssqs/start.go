@@ -19,6 +19,7 @@ package awssqs
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -101,6 +102,11 @@ func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byt
 		log.Info("assuming all events have a json body...")
 	}
 
+	fifo := isFIFOQueue(sqsEventSource.Queue)
+	if fifo {
+		log.Info("preserving the ordering of the message groups of the FIFO queue...")
+	}
+
 	log.Info("listening for messages on the queue...")
 	for {
 		select {
@@ -115,8 +121,16 @@ func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byt
 			time.Sleep(2 * time.Second)
 			continue
 		}
+		// the messages of a FIFO group following a message which failed to be dispatched are left in the queue,
+		// they are received again in order along with the failed one once their visibility timeout elapses.
+		failedGroups := make(map[string]bool)
 		for _, m := range messages {
-			el.processMessage(ctx, m, dispatch, func() {
+			group := aws.StringValue(m.Attributes[sqslib.MessageSystemAttributeNameMessageGroupId])
+			if fifo && failedGroups[group] {
+				log.Infow("skipping the message following a failed one in its group", zap.String("messageGroupId", group), zap.String("messageId", aws.StringValue(m.MessageId)))
+				continue
+			}
+			acked := el.processMessage(ctx, m, dispatch, func() {
 				_, err = sqsClient.DeleteMessage(&sqslib.DeleteMessageInput{
 					QueueUrl:      queueURL.QueueUrl,
 					ReceiptHandle: m.ReceiptHandle,
@@ -125,11 +139,15 @@ func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byt
 					log.Errorw("Failed to delete message", zap.Error(err))
 				}
 			}, log)
+			if fifo && !acked {
+				failedGroups[group] = true
+			}
 		}
 	}
 }
 
-func (el *EventListener) processMessage(ctx context.Context, message *sqslib.Message, dispatch func([]byte, ...eventsourcecommon.Options) error, ack func(), log *zap.SugaredLogger) {
+// processMessage dispatches the message and acknowledges it, it returns whether the message has been acknowledged.
+func (el *EventListener) processMessage(ctx context.Context, message *sqslib.Message, dispatch func([]byte, ...eventsourcecommon.Options) error, ack func(), log *zap.SugaredLogger) bool {
 	defer func(start time.Time) {
 		el.Metrics.EventProcessingDuration(el.GetEventSourceName(), el.GetEventName(), float64(time.Since(start)/time.Millisecond))
 	}(time.Now())
@@ -139,6 +157,11 @@ func (el *EventListener) processMessage(ctx context.Context, message *sqslib.Mes
 		MessageAttributes: message.MessageAttributes,
 		Metadata:          el.SQSEventSource.Metadata,
 	}
+	if isFIFOQueue(el.SQSEventSource.Queue) {
+		data.MessageGroupId = aws.StringValue(message.Attributes[sqslib.MessageSystemAttributeNameMessageGroupId])
+		data.MessageDeduplicationId = aws.StringValue(message.Attributes[sqslib.MessageSystemAttributeNameMessageDeduplicationId])
+		data.SequenceNumber = aws.StringValue(message.Attributes[sqslib.MessageSystemAttributeNameSequenceNumber])
+	}
 	if el.SQSEventSource.JSONBody {
 		body := []byte(*message.Body)
 		data.Body = (*json.RawMessage)(&body)
@@ -149,18 +172,26 @@ func (el *EventListener) processMessage(ctx context.Context, message *sqslib.Mes
 	if err != nil {
 		log.Errorw("failed to marshal event data, will process next message...", zap.Error(err))
 		el.Metrics.EventProcessingFailed(el.GetEventSourceName(), el.GetEventName())
-		// Don't ack if a DLQ is configured to allow to forward the message to the DLQ
-		if !el.SQSEventSource.DLQ {
-			ack()
+		// Don't ack if a DLQ is configured to allow to forward the message to the DLQ,
+		// nor a FIFO message so that the following messages of its group are not dispatched before it
+		if el.SQSEventSource.DLQ || isFIFOQueue(el.SQSEventSource.Queue) {
+			return false
 		}
-		return
+		ack()
+		return true
 	}
 	if err = dispatch(eventBytes); err != nil {
 		log.Errorw("failed to dispatch SQS event", zap.Error(err))
 		el.Metrics.EventProcessingFailed(el.GetEventSourceName(), el.GetEventName())
-	} else {
-		ack()
+		return false
 	}
+	ack()
+	return true
+}
+
+// isFIFOQueue tells whether the queue is a FIFO queue, whose name ends with .fifo
+func isFIFOQueue(queue string) bool {
+	return strings.HasSuffix(queue, ".fifo")
 }
 
 func fetchMessages(ctx context.Context, q *sqslib.SQS, url string, maxSize, waitSeconds int64) ([]*sqslib.Message, error) {
@@ -171,6 +202,9 @@ func fetchMessages(ctx context.Context, q *sqslib.SQS, url string, maxSize, wait
 	result, err := q.ReceiveMessageWithContext(ctx, &sqslib.ReceiveMessageInput{
 		AttributeNames: []*string{
 			aws.String(sqslib.MessageSystemAttributeNameSentTimestamp),
+			aws.String(sqslib.MessageSystemAttributeNameMessageGroupId),
+			aws.String(sqslib.MessageSystemAttributeNameMessageDeduplicationId),
+			aws.String(sqslib.MessageSystemAttributeNameSequenceNumber),
 		},
 		MessageAttributeNames: []*string{
 			aws.String(sqslib.QueueAttributeNameAll),
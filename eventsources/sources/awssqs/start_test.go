@@ -0,0 +1,76 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awssqs
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	sqslib "github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-events/common/logging"
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	metrics "github.com/argoproj/argo-events/metrics"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestIsFIFOQueue(t *testing.T) {
+	assert.True(t, isFIFOQueue("orders.fifo"))
+	assert.False(t, isFIFOQueue("orders"))
+	assert.False(t, isFIFOQueue("fifo-orders"))
+}
+
+func TestProcessFIFOMessage(t *testing.T) {
+	el := &EventListener{
+		EventSourceName: "test-source",
+		EventName:       "test",
+		SQSEventSource:  v1alpha1.SQSEventSource{Queue: "orders.fifo"},
+		Metrics:         metrics.NewMetrics("test-ns"),
+	}
+	message := &sqslib.Message{
+		MessageId: aws.String("1"),
+		Body:      aws.String("hello"),
+		Attributes: map[string]*string{
+			sqslib.MessageSystemAttributeNameMessageGroupId:         aws.String("customer-1"),
+			sqslib.MessageSystemAttributeNameMessageDeduplicationId: aws.String("order-1"),
+			sqslib.MessageSystemAttributeNameSequenceNumber:         aws.String("18849496460467696128"),
+		},
+	}
+	log := logging.NewArgoEventsLogger()
+
+	var data events.SQSEventData
+	acks := 0
+	acked := el.processMessage(context.Background(), message, func(b []byte, _ ...eventsourcecommon.Options) error {
+		return json.Unmarshal(b, &data)
+	}, func() { acks++ }, log)
+	assert.True(t, acked)
+	assert.Equal(t, 1, acks)
+	assert.Equal(t, "customer-1", data.MessageGroupId)
+	assert.Equal(t, "order-1", data.MessageDeduplicationId)
+	assert.Equal(t, "18849496460467696128", data.SequenceNumber)
+
+	acked = el.processMessage(context.Background(), message, func([]byte, ...eventsourcecommon.Options) error {
+		return errors.New("eventbus unavailable")
+	}, func() { acks++ }, log)
+	assert.False(t, acked)
+	assert.Equal(t, 1, acks)
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azureservicebus
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/go-amqp"
+	"go.uber.org/zap"
+
+	metrics "github.com/argoproj/argo-events/metrics"
+)
+
+const (
+	// defaultMaxDeliveryCount is how many times a message is delivered before it's dead-lettered by default, as by the
+	// entities of Service Bus
+	defaultMaxDeliveryCount = 10
+	// settleTimeout is how long the settlement of a message may take
+	settleTimeout = 30 * time.Second
+	// deadLetterCondition is the condition of the rejections which dead-letter the messages
+	deadLetterCondition amqp.ErrorCondition = "com.microsoft:dead-letter"
+	// deadLetterReasonKey and deadLetterDescriptionKey are the properties of a dead-lettered message which tell why
+	deadLetterReasonKey      = "DeadLetterReason"
+	deadLetterDescriptionKey = "DeadLetterErrorDescription"
+)
+
+// settle completes the message once its event is dispatched. A message whose event fails to be dispatched is
+// abandoned, so that it's delivered again, until it has been delivered MaxDeliveryCount times, then it's
+// dead-lettered along with the messages which fail to be converted into an event.
+func (el *EventListener) settle(receiver messageReceiver, msg *amqp.Message, err error, log *zap.SugaredLogger) {
+	// the message is settled even when the event source is stopped, its lock would be held until it expires otherwise
+	ctx, cancel := context.WithTimeout(context.Background(), settleTimeout)
+	defer cancel()
+
+	id := messageID(msg)
+	if err == nil {
+		if err := receiver.AcceptMessage(ctx, msg); err != nil {
+			log.Errorw("failed to complete the message", zap.String("messageId", id), zap.Error(err))
+			el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonAck)
+		}
+		return
+	}
+	count := deliveryCount(msg)
+	if metrics.ReasonOf(err) == metrics.FailureReasonDispatch && count < el.maxDeliveryCount() {
+		log.Infow("abandoning the message", zap.String("messageId", id), zap.Uint32("deliveryCount", count))
+		if err := receiver.ModifyMessage(ctx, msg, true, false, nil); err != nil {
+			log.Errorw("failed to abandon the message", zap.String("messageId", id), zap.Error(err))
+			el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonAck)
+		}
+		return
+	}
+	log.Warnw("dead-lettering the message", zap.String("messageId", id), zap.Uint32("deliveryCount", count))
+	if err := receiver.RejectMessage(ctx, msg, &amqp.Error{
+		Condition: deadLetterCondition,
+		Info: map[string]interface{}{
+			deadLetterReasonKey:      string(metrics.ReasonOf(err)),
+			deadLetterDescriptionKey: err.Error(),
+		},
+	}); err != nil {
+		log.Errorw("failed to dead-letter the message", zap.String("messageId", id), zap.Error(err))
+		el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonAck)
+	}
+}
+
+// maxDeliveryCount returns how many times a message is delivered before it's dead-lettered
+func (el *EventListener) maxDeliveryCount() uint32 {
+	if el.AzureServiceBusEventSource.MaxDeliveryCount > 0 {
+		return uint32(el.AzureServiceBusEventSource.MaxDeliveryCount)
+	}
+	return defaultMaxDeliveryCount
+}
+
+// deliveryCount returns the number of deliveries of the message, this one included. The header of the message counts
+// the previous deliveries only.
+func deliveryCount(msg *amqp.Message) uint32 {
+	if msg.Header == nil {
+		return 1
+	}
+	return msg.Header.DeliveryCount + 1
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azureservicebus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/go-amqp"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	metrics "github.com/argoproj/argo-events/metrics"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// settlement is the outcome of a message recorded by fakeReceiver
+type settlement struct {
+	id         string
+	completed  bool
+	abandoned  bool
+	deadLetter *amqp.Error
+}
+
+// fakeReceiver serves the queued messages, and records their settlements instead of sending them to Service Bus
+type fakeReceiver struct {
+	messages    []*amqp.Message
+	settlements []settlement
+}
+
+func (r *fakeReceiver) Receive(ctx context.Context) (*amqp.Message, error) {
+	if len(r.messages) == 0 {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	msg := r.messages[0]
+	r.messages = r.messages[1:]
+	return msg, nil
+}
+
+func (r *fakeReceiver) AcceptMessage(ctx context.Context, msg *amqp.Message) error {
+	r.settlements = append(r.settlements, settlement{id: messageID(msg), completed: true})
+	return nil
+}
+
+func (r *fakeReceiver) ModifyMessage(ctx context.Context, msg *amqp.Message, deliveryFailed, undeliverableHere bool, messageAnnotations amqp.Annotations) error {
+	r.settlements = append(r.settlements, settlement{id: messageID(msg), abandoned: deliveryFailed && !undeliverableHere})
+	return nil
+}
+
+func (r *fakeReceiver) RejectMessage(ctx context.Context, msg *amqp.Message, e *amqp.Error) error {
+	r.settlements = append(r.settlements, settlement{id: messageID(msg), deadLetter: e})
+	return nil
+}
+
+func newMessage(id string, previousDeliveries uint32, body string) *amqp.Message {
+	return &amqp.Message{
+		Header:     &amqp.MessageHeader{DeliveryCount: previousDeliveries},
+		Properties: &amqp.MessageProperties{MessageID: id},
+		Data:       [][]byte{[]byte(body)},
+	}
+}
+
+func TestSettle(t *testing.T) {
+	el := &EventListener{
+		EventSourceName: "azure-service-bus",
+		EventName:       "example",
+		AzureServiceBusEventSource: v1alpha1.AzureServiceBusEventSource{
+			EntityType:       entityTypeQueue,
+			QueueName:        "orders",
+			MaxDeliveryCount: 3,
+			JSONBody:         true,
+		},
+		Metrics: metrics.NewMetrics("ns"),
+	}
+	log := zap.NewNop().Sugar()
+	failing := func([]byte, ...eventsourcecommon.Options) error { return errors.New("eventbus unavailable") }
+	succeeding := func([]byte, ...eventsourcecommon.Options) error { return nil }
+
+	receiver := &fakeReceiver{}
+	settle := func(msg *amqp.Message, dispatch func([]byte, ...eventsourcecommon.Options) error) {
+		el.settle(receiver, msg, el.handleOne(msg, dispatch, log), log)
+	}
+	// abandoned until the third delivery, then dead-lettered
+	settle(newMessage("m1", 0, `{}`), failing)
+	settle(newMessage("m1", 1, `{}`), failing)
+	settle(newMessage("m1", 2, `{}`), failing)
+	settle(newMessage("m2", 1, `{}`), succeeding)
+	// a message which can't be converted into an event is dead-lettered on its first delivery
+	settle(newMessage("m3", 0, `not json`), succeeding)
+
+	assert.Len(t, receiver.settlements, 5)
+	assert.Equal(t, settlement{id: "m1", abandoned: true}, receiver.settlements[0])
+	assert.Equal(t, settlement{id: "m1", abandoned: true}, receiver.settlements[1])
+	assert.Equal(t, "m1", receiver.settlements[2].id)
+	assert.Equal(t, deadLetterCondition, receiver.settlements[2].deadLetter.Condition)
+	assert.Equal(t, string(metrics.FailureReasonDispatch), receiver.settlements[2].deadLetter.Info[deadLetterReasonKey])
+	assert.Equal(t, settlement{id: "m2", completed: true}, receiver.settlements[3])
+	assert.Equal(t, "m3", receiver.settlements[4].id)
+	assert.Equal(t, string(metrics.FailureReasonValidation), receiver.settlements[4].deadLetter.Info[deadLetterReasonKey])
+}
+
+func TestMaxDeliveryCount(t *testing.T) {
+	el := &EventListener{}
+	assert.Equal(t, uint32(defaultMaxDeliveryCount), el.maxDeliveryCount())
+	el.AzureServiceBusEventSource.MaxDeliveryCount = 1
+	assert.Equal(t, uint32(1), el.maxDeliveryCount())
+
+	assert.Equal(t, uint32(1), deliveryCount(&amqp.Message{}))
+	assert.Equal(t, uint32(3), deliveryCount(&amqp.Message{Header: &amqp.MessageHeader{DeliveryCount: 2}}))
+}
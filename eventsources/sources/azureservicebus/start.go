@@ -0,0 +1,367 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azureservicebus
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-amqp-common-go/v3/auth"
+	"github.com/Azure/azure-amqp-common-go/v3/cbs"
+	"github.com/Azure/azure-amqp-common-go/v3/conn"
+	"github.com/Azure/azure-amqp-common-go/v3/sas"
+	"github.com/Azure/go-amqp"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/argoproj/argo-events/common"
+	"github.com/argoproj/argo-events/common/logging"
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/eventsources/sources"
+	metrics "github.com/argoproj/argo-events/metrics"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+const (
+	// entityTypeQueue is the entity type of the queues
+	entityTypeQueue = "queue"
+	// entityTypeTopic is the entity type of the topic subscriptions
+	entityTypeTopic = "topic"
+	// claimRefreshInterval is how often the claim of the connection is renewed, the SAS tokens are valid for 2 hours
+	claimRefreshInterval = 15 * time.Minute
+	// connectTimeout is how long the connection, the claim negotiation and the link attach may take
+	connectTimeout = 30 * time.Second
+	// enqueuedTimeAnnotation is the message annotation holding when the message was enqueued
+	enqueuedTimeAnnotation = "x-opt-enqueued-time"
+	// sequenceNumberAnnotation is the message annotation holding the sequence number of the message
+	sequenceNumberAnnotation = "x-opt-sequence-number"
+)
+
+// EventListener implements Eventing for the Azure Service Bus event source
+type EventListener struct {
+	EventSourceName            string
+	EventName                  string
+	AzureServiceBusEventSource v1alpha1.AzureServiceBusEventSource
+	Metrics                    *metrics.Metrics
+}
+
+// GetEventSourceName returns name of event source
+func (el *EventListener) GetEventSourceName() string {
+	return el.EventSourceName
+}
+
+// GetEventName returns name of event
+func (el *EventListener) GetEventName() string {
+	return el.EventName
+}
+
+// GetEventSourceType return type of event server
+func (el *EventListener) GetEventSourceType() apicommon.EventSourceType {
+	return apicommon.AzureServiceBusEvent
+}
+
+// messageReceiver receives and settles the messages of an entity, it's implemented by *amqp.Receiver
+type messageReceiver interface {
+	Receive(ctx context.Context) (*amqp.Message, error)
+	AcceptMessage(ctx context.Context, msg *amqp.Message) error
+	ModifyMessage(ctx context.Context, msg *amqp.Message, deliveryFailed, undeliverableHere bool, messageAnnotations amqp.Annotations) error
+	RejectMessage(ctx context.Context, msg *amqp.Message, e *amqp.Error) error
+}
+
+// connection is an AMQP connection to the namespace with a peek-lock receiver on the entity
+type connection struct {
+	client   *amqp.Client
+	receiver *amqp.Receiver
+	audience string
+	provider auth.TokenProvider
+}
+
+// StartListening starts listening events
+func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byte, ...eventsourcecommon.Options) error) error {
+	log := logging.FromContext(ctx).
+		With(logging.LabelEventSourceType, el.GetEventSourceType(), logging.LabelEventName, el.GetEventName())
+	log.Info("started processing the Azure Service Bus event source...")
+	defer sources.Recover(el.GetEventName())
+
+	serviceBusEventSource := &el.AzureServiceBusEventSource
+	if err := validate(serviceBusEventSource); err != nil {
+		return errors.Wrap(err, "invalid azure service bus event source")
+	}
+
+	log.Info("retrieving the connection string...")
+	connectionString, err := common.GetSecretFromVolume(serviceBusEventSource.ConnectionString)
+	if err != nil {
+		return errors.Wrapf(err, "failed to retrieve the connection string from secret %s", serviceBusEventSource.ConnectionString.Name)
+	}
+	parsed, err := conn.ParsedConnectionFromStr(strings.TrimSuffix(strings.TrimSpace(connectionString), ";"))
+	if err != nil {
+		return errors.Wrap(err, "failed to parse the connection string")
+	}
+	path := entityPath(serviceBusEventSource)
+	if parsed.HubName != "" && !strings.EqualFold(parsed.HubName, strings.SplitN(path, "/", 2)[0]) {
+		return errors.Errorf("the connection string is scoped to the entity %s", parsed.HubName)
+	}
+	provider, err := sas.NewTokenProvider(sas.TokenProviderWithKey(parsed.KeyName, parsed.Key))
+	if err != nil {
+		return errors.Wrap(err, "failed to create the token provider")
+	}
+	var tlsConfig *tls.Config
+	if serviceBusEventSource.TLS != nil {
+		tlsConfig, err = common.GetTLSConfig(serviceBusEventSource.TLS, log)
+		if err != nil {
+			return errors.Wrap(err, "failed to get the tls configuration")
+		}
+	}
+
+	for {
+		var c *connection
+		log.Infow("connecting to the entity...", zap.String("namespace", parsed.Namespace), zap.String("entityPath", path))
+		if err := common.ConnectWithContext(ctx, serviceBusEventSource.ConnectionBackoff, func() error {
+			cc, err := connect(ctx, parsed.Host, path, provider, tlsConfig)
+			if err != nil {
+				log.Errorw("failed to connect to the entity", zap.Error(err))
+				return err
+			}
+			c = cc
+			return nil
+		}); err != nil {
+			el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonConnection)
+			return errors.Wrapf(err, "failed to connect to the entity %s for event source %s", path, el.GetEventName())
+		}
+		log.Info("receiving the messages of the entity")
+		el.Metrics.EventSourceActive(el.GetEventSourceName(), el.GetEventName(), true)
+		err := el.consume(ctx, c, dispatch, log)
+		el.Metrics.EventSourceActive(el.GetEventSourceName(), el.GetEventName(), false)
+		c.close()
+		if ctx.Err() != nil {
+			log.Info("event source is stopped, closing the connection...")
+			return nil
+		}
+		log.Errorw("lost the connection to the entity, reconnecting...", zap.Error(err))
+		el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonConnection)
+	}
+}
+
+// entityPath returns the path of the queue, or of the topic subscription, the messages are received from
+func entityPath(eventSource *v1alpha1.AzureServiceBusEventSource) string {
+	if eventSource.EntityType == entityTypeTopic {
+		return eventSource.TopicName + "/Subscriptions/" + eventSource.SubscriptionName
+	}
+	return eventSource.QueueName
+}
+
+// connect opens the connection, negotiates the claim of the entity with the SAS token and attaches a peek-lock
+// receiver to the entity.
+func connect(ctx context.Context, host, path string, provider auth.TokenProvider, tlsConfig *tls.Config) (*connection, error) {
+	ctx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+
+	opts := []amqp.ConnOption{amqp.ConnSASLAnonymous(), amqp.ConnConnectTimeout(connectTimeout)}
+	if tlsConfig != nil {
+		opts = append(opts, amqp.ConnTLSConfig(tlsConfig))
+	}
+	client, err := amqp.Dial(host, opts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to %s", host)
+	}
+	c := &connection{client: client, audience: host + "/" + path, provider: provider}
+	if err := c.negotiateClaim(ctx); err != nil {
+		c.close()
+		return nil, err
+	}
+	session, err := client.NewSession()
+	if err != nil {
+		c.close()
+		return nil, errors.Wrap(err, "failed to create the session")
+	}
+	// a credit of one lets a single message be locked at once, the locks of the prefetched messages would expire
+	// while the previous ones are dispatched
+	c.receiver, err = session.NewReceiver(
+		amqp.LinkSourceAddress(path),
+		amqp.LinkReceiverSettle(amqp.ModeSecond),
+		amqp.LinkSenderSettle(amqp.ModeUnsettled),
+		amqp.LinkCredit(1),
+	)
+	if err != nil {
+		c.close()
+		return nil, errors.Wrapf(err, "failed to create the receiver of the entity %s", path)
+	}
+	return c, nil
+}
+
+// negotiateClaim puts the SAS token of the entity to the claims based security node of the namespace
+func (c *connection) negotiateClaim(ctx context.Context) error {
+	if err := cbs.NegotiateClaim(ctx, c.audience, c.client, c.provider); err != nil {
+		return metrics.WithReason(errors.Wrapf(err, "failed to negotiate the claim of %s", c.audience), metrics.FailureReasonAuth)
+	}
+	return nil
+}
+
+func (c *connection) close() {
+	_ = c.client.Close()
+}
+
+// consume dispatches the messages until the connection is lost or the event source is stopped. The claim of the
+// connection is renewed on every claim refresh interval, before the SAS token expires.
+func (el *EventListener) consume(ctx context.Context, c *connection, dispatch func([]byte, ...eventsourcecommon.Options) error, log *zap.SugaredLogger) error {
+	refreshAt := time.Now().Add(claimRefreshInterval)
+	for {
+		if !time.Now().Before(refreshAt) {
+			refreshCtx, cancel := context.WithTimeout(ctx, connectTimeout)
+			err := c.negotiateClaim(refreshCtx)
+			cancel()
+			if err != nil {
+				return err
+			}
+			refreshAt = time.Now().Add(claimRefreshInterval)
+		}
+		if err := el.receiveOne(ctx, c.receiver, refreshAt, dispatch, log); err != nil {
+			return err
+		}
+	}
+}
+
+// receiveOne waits until a message is received or the deadline, and dispatches and settles the message. The errors
+// returned are the failures to receive.
+func (el *EventListener) receiveOne(ctx context.Context, receiver messageReceiver, deadline time.Time, dispatch func([]byte, ...eventsourcecommon.Options) error, log *zap.SugaredLogger) error {
+	receiveCtx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+	msg, err := receiver.Receive(receiveCtx)
+	if err != nil {
+		if receiveCtx.Err() != nil {
+			return ctx.Err()
+		}
+		return errors.Wrap(err, "failed to receive a message")
+	}
+	err = el.handleOne(msg, dispatch, log)
+	if err != nil {
+		log.Errorw("failed to process the message", zap.String("messageId", messageID(msg)), zap.Error(err))
+		el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.ReasonOf(err))
+	}
+	el.settle(receiver, msg, err, log)
+	return nil
+}
+
+func (el *EventListener) handleOne(msg *amqp.Message, dispatch func([]byte, ...eventsourcecommon.Options) error, log *zap.SugaredLogger) error {
+	defer func(start time.Time) {
+		el.Metrics.EventProcessingDuration(el.GetEventSourceName(), el.GetEventName(), float64(time.Since(start)/time.Millisecond))
+	}(time.Now())
+
+	serviceBusEventSource := &el.AzureServiceBusEventSource
+	eventData := &events.AzureServiceBusEventData{
+		MessageID:             messageID(msg),
+		ApplicationProperties: applicationProperties(msg.ApplicationProperties),
+		DeliveryCount:         deliveryCount(msg),
+		EntityPath:            entityPath(serviceBusEventSource),
+		Metadata:              serviceBusEventSource.Metadata,
+	}
+	if msg.Properties != nil {
+		eventData.CorrelationID = idString(msg.Properties.CorrelationID)
+		if msg.Properties.GroupID != nil {
+			eventData.SessionID = *msg.Properties.GroupID
+		}
+	}
+	if t, ok := msg.Annotations[enqueuedTimeAnnotation].(time.Time); ok {
+		eventData.EnqueuedTime = &t
+	}
+	if n, ok := msg.Annotations[sequenceNumberAnnotation].(int64); ok {
+		eventData.SequenceNumber = n
+	}
+	body := messageBody(msg)
+	if serviceBusEventSource.JSONBody {
+		if !json.Valid(body) {
+			return metrics.WithReason(errors.New("the message body is not valid JSON"), metrics.FailureReasonValidation)
+		}
+		eventData.Body = (*json.RawMessage)(&body)
+	} else {
+		eventData.Body = body
+	}
+
+	eventBytes, err := json.Marshal(eventData)
+	if err != nil {
+		return metrics.WithReason(errors.Wrap(err, "failed to marshal the event data"), metrics.FailureReasonMarshal)
+	}
+	log.Infow("dispatching the message...", zap.String("messageId", eventData.MessageID), zap.Uint32("deliveryCount", eventData.DeliveryCount))
+	if err = dispatch(eventBytes); err != nil {
+		return metrics.WithReason(errors.Wrap(err, "failed to dispatch the event"), metrics.FailureReasonDispatch)
+	}
+	return nil
+}
+
+// messageBody returns the data sections of the message, or its value if it's a string or binary value
+func messageBody(msg *amqp.Message) []byte {
+	if len(msg.Data) > 0 {
+		return bytes.Join(msg.Data, nil)
+	}
+	switch v := msg.Value.(type) {
+	case string:
+		return []byte(v)
+	case []byte:
+		return v
+	default:
+		return nil
+	}
+}
+
+// messageID returns the ID of the message
+func messageID(msg *amqp.Message) string {
+	if msg.Properties == nil {
+		return ""
+	}
+	return idString(msg.Properties.MessageID)
+}
+
+// idString formats a message or correlation ID, which can be a string, a UUID, a binary or an unsigned integer
+func idString(id interface{}) string {
+	switch v := id.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case amqp.UUID:
+		return v.String()
+	case []byte:
+		return string(v)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// applicationProperties returns the application properties of the message, with the UUIDs formatted as strings
+func applicationProperties(properties map[string]interface{}) map[string]interface{} {
+	if len(properties) == 0 {
+		return nil
+	}
+	result := make(map[string]interface{}, len(properties))
+	for k, v := range properties {
+		if id, ok := v.(amqp.UUID); ok {
+			v = id.String()
+		}
+		result[k] = v
+	}
+	return result
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azureservicebus
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-amqp"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	metrics "github.com/argoproj/argo-events/metrics"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestEntityPath(t *testing.T) {
+	assert.Equal(t, "orders", entityPath(&v1alpha1.AzureServiceBusEventSource{EntityType: entityTypeQueue, QueueName: "orders"}))
+	assert.Equal(t, "orders/Subscriptions/billing", entityPath(&v1alpha1.AzureServiceBusEventSource{EntityType: entityTypeTopic, TopicName: "orders", SubscriptionName: "billing"}))
+}
+
+func TestIDString(t *testing.T) {
+	assert.Equal(t, "", idString(nil))
+	assert.Equal(t, "abc", idString("abc"))
+	assert.Equal(t, "42", idString(uint64(42)))
+	assert.Equal(t, "raw", idString([]byte("raw")))
+	assert.Equal(t, "00010203-0405-0607-0809-0a0b0c0d0e0f", idString(amqp.UUID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}))
+}
+
+func TestReceiveOne(t *testing.T) {
+	el := &EventListener{
+		EventSourceName: "azure-service-bus",
+		EventName:       "example",
+		AzureServiceBusEventSource: v1alpha1.AzureServiceBusEventSource{
+			EntityType:       entityTypeTopic,
+			TopicName:        "orders",
+			SubscriptionName: "billing",
+			JSONBody:         true,
+			Metadata:         map[string]string{"team": "billing"},
+		},
+		Metrics: metrics.NewMetrics("ns"),
+	}
+	enqueued := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	sessionID := "customer-1"
+	msg := newMessage("m1", 1, `{"amount":10}`)
+	msg.Properties.CorrelationID = "c1"
+	msg.Properties.GroupID = &sessionID
+	msg.ApplicationProperties = map[string]interface{}{"priority": int32(2), "origin": "web"}
+	msg.Annotations = amqp.Annotations{enqueuedTimeAnnotation: enqueued, sequenceNumberAnnotation: int64(7)}
+	receiver := &fakeReceiver{messages: []*amqp.Message{msg}}
+
+	var dispatched []byte
+	dispatch := func(data []byte, _ ...eventsourcecommon.Options) error {
+		dispatched = data
+		return nil
+	}
+	log := zap.NewNop().Sugar()
+	err := el.receiveOne(context.Background(), receiver, time.Now().Add(time.Minute), dispatch, log)
+	assert.NoError(t, err)
+	assert.Equal(t, []settlement{{id: "m1", completed: true}}, receiver.settlements)
+
+	var eventData events.AzureServiceBusEventData
+	assert.NoError(t, json.Unmarshal(dispatched, &eventData))
+	assert.Equal(t, "m1", eventData.MessageID)
+	assert.Equal(t, "customer-1", eventData.SessionID)
+	assert.Equal(t, "c1", eventData.CorrelationID)
+	assert.Equal(t, map[string]interface{}{"priority": float64(2), "origin": "web"}, eventData.ApplicationProperties)
+	assert.Equal(t, map[string]interface{}{"amount": float64(10)}, eventData.Body)
+	assert.Equal(t, uint32(2), eventData.DeliveryCount)
+	assert.Equal(t, int64(7), eventData.SequenceNumber)
+	assert.True(t, enqueued.Equal(*eventData.EnqueuedTime))
+	assert.Equal(t, "orders/Subscriptions/billing", eventData.EntityPath)
+	assert.Equal(t, map[string]string{"team": "billing"}, eventData.Metadata)
+
+	// no message is received until the deadline, e.g. the claim is to be renewed
+	err = el.receiveOne(context.Background(), receiver, time.Now().Add(10*time.Millisecond), dispatch, log)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = el.receiveOne(ctx, receiver, time.Now().Add(time.Minute), dispatch, log)
+	assert.Equal(t, context.Canceled, err)
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azureservicebus
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/argoproj/argo-events/common"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// ValidateEventSource validates the Azure Service Bus event source
+func (el *EventListener) ValidateEventSource(ctx context.Context) error {
+	return validate(&el.AzureServiceBusEventSource)
+}
+
+func validate(eventSource *v1alpha1.AzureServiceBusEventSource) error {
+	if eventSource == nil {
+		return common.ErrNilEventSource
+	}
+	if eventSource.ConnectionString == nil {
+		return errors.New("connectionString must be specified")
+	}
+	switch eventSource.EntityType {
+	case entityTypeQueue:
+		if eventSource.QueueName == "" {
+			return errors.New("queueName must be specified when the entityType is queue")
+		}
+		if eventSource.TopicName != "" || eventSource.SubscriptionName != "" {
+			return errors.New("topicName and subscriptionName can't be specified when the entityType is queue")
+		}
+	case entityTypeTopic:
+		if eventSource.TopicName == "" || eventSource.SubscriptionName == "" {
+			return errors.New("topicName and subscriptionName must be specified when the entityType is topic")
+		}
+		if eventSource.QueueName != "" {
+			return errors.New("queueName can't be specified when the entityType is topic")
+		}
+		if strings.Contains(eventSource.SubscriptionName, "/") {
+			return errors.New("subscriptionName can't contain a /")
+		}
+	case "":
+		return errors.New("entityType must be specified")
+	default:
+		return errors.Errorf("unsupported entityType %s, it must be either queue or topic", eventSource.EntityType)
+	}
+	if eventSource.MaxDeliveryCount < 0 {
+		return errors.New("maxDeliveryCount can't be negative")
+	}
+	if eventSource.TLS != nil {
+		return apicommon.ValidateTLSConfig(eventSource.TLS)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azureservicebus
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj/argo-events/eventsources/sources"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestValidateEventSource(t *testing.T) {
+	listener := &EventListener{}
+
+	err := listener.ValidateEventSource(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, "connectionString must be specified", err.Error())
+
+	content, err := ioutil.ReadFile(fmt.Sprintf("%s/%s", sources.EventSourceDir, "azure-service-bus.yaml"))
+	assert.Nil(t, err)
+
+	var eventSource *v1alpha1.EventSource
+	err = yaml.Unmarshal(content, &eventSource)
+	assert.Nil(t, err)
+	assert.NotNil(t, eventSource.Spec.AzureServiceBus)
+
+	for _, value := range eventSource.Spec.AzureServiceBus {
+		l := &EventListener{
+			AzureServiceBusEventSource: value,
+		}
+		err := l.ValidateEventSource(context.Background())
+		assert.NoError(t, err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	connectionString := &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "service-bus"}, Key: "connection-string"}
+	tests := []struct {
+		name        string
+		eventSource v1alpha1.AzureServiceBusEventSource
+		err         string
+	}{
+		{"queue", v1alpha1.AzureServiceBusEventSource{ConnectionString: connectionString, EntityType: "queue", QueueName: "orders"}, ""},
+		{"topic", v1alpha1.AzureServiceBusEventSource{ConnectionString: connectionString, EntityType: "topic", TopicName: "orders", SubscriptionName: "billing"}, ""},
+		{"no entity type", v1alpha1.AzureServiceBusEventSource{ConnectionString: connectionString, QueueName: "orders"}, "entityType must be specified"},
+		{"unknown entity type", v1alpha1.AzureServiceBusEventSource{ConnectionString: connectionString, EntityType: "subscription"}, "unsupported entityType subscription, it must be either queue or topic"},
+		{"no queue name", v1alpha1.AzureServiceBusEventSource{ConnectionString: connectionString, EntityType: "queue"}, "queueName must be specified when the entityType is queue"},
+		{"queue with topic", v1alpha1.AzureServiceBusEventSource{ConnectionString: connectionString, EntityType: "queue", QueueName: "orders", TopicName: "orders"}, "topicName and subscriptionName can't be specified when the entityType is queue"},
+		{"no subscription name", v1alpha1.AzureServiceBusEventSource{ConnectionString: connectionString, EntityType: "topic", TopicName: "orders"}, "topicName and subscriptionName must be specified when the entityType is topic"},
+		{"topic with queue", v1alpha1.AzureServiceBusEventSource{ConnectionString: connectionString, EntityType: "topic", TopicName: "orders", SubscriptionName: "billing", QueueName: "orders"}, "queueName can't be specified when the entityType is topic"},
+		{"negative max delivery count", v1alpha1.AzureServiceBusEventSource{ConnectionString: connectionString, EntityType: "queue", QueueName: "orders", MaxDeliveryCount: -1}, "maxDeliveryCount can't be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate(&tt.eventSource)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
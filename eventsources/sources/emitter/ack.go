@@ -0,0 +1,115 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	metrics "github.com/argoproj/argo-events/metrics"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// ack is the acknowledgement published for a message whose event is dispatched.
+type ack struct {
+	// MessageID is the ID of the acknowledged message
+	MessageID int `json:"messageId"`
+	// EventID is the ID the event of the message was dispatched with
+	EventID string `json:"eventId"`
+	// Channel the message was received on
+	Channel string `json:"channel"`
+	// Topic of the message
+	Topic string `json:"topic"`
+	// Time the event was dispatched
+	Time time.Time `json:"time"`
+}
+
+// acknowledge publishes the acknowledgement of the message whose event is dispatched. A failure to publish it
+// is only reported, the event must not be dispatched again once accepted by the eventbus.
+func (s *session) acknowledge(event *events.EmitterEventData, id string) {
+	ackResponse := s.eventSource.AckResponse
+	if ackResponse == nil || event.Type != eventTypeMessage {
+		return
+	}
+	log := s.log
+	channel, ok := ackChannel(ackResponse, event)
+	if !ok {
+		log.Warnw("no channel to publish the acknowledgement to, skip it", zap.String("topic", event.Topic), zap.String("id", id))
+		return
+	}
+	message, err := json.Marshal(&ack{
+		MessageID: event.MessageID,
+		EventID:   id,
+		Channel:   event.Channel,
+		Topic:     event.Topic,
+		Time:      s.clock.Now().UTC(),
+	})
+	if err != nil {
+		log.Errorw("failed to marshal the acknowledgement", zap.String("ackChannel", channel.Name), zap.Error(err))
+		s.el.failed(event.Topic, metrics.FailureReasonAck)
+		return
+	}
+	publish := func() {
+		if err := s.client.Publish(channel.Key, channel.Name, message); err != nil {
+			log.Errorw("failed to publish the acknowledgement", zap.String("ackChannel", channel.Name), zap.String("id", id), zap.Error(err))
+			s.el.failed(event.Topic, metrics.FailureReasonAck)
+			return
+		}
+		log.Debugw("published the acknowledgement", zap.String("ackChannel", channel.Name), zap.String("id", id))
+	}
+	// the dispatch of the event is still registered once the drain has started, publish it as part of it
+	if !s.dispatching.start() {
+		publish()
+		return
+	}
+	// publishing waits for the broker acknowledgement, it must not hold the dispatch of the next events.
+	go func() {
+		defer s.dispatching.done()
+		publish()
+	}()
+}
+
+// ackChannel returns the channel the acknowledgement of the message is published to, the one of the ChannelField
+// of the body if found, else the AckChannel. It returns false if the message has no channel to be acknowledged on.
+func ackChannel(ackResponse *v1alpha1.EmitterAckResponse, event *events.EmitterEventData) (v1alpha1.EmitterChannel, bool) {
+	var channel v1alpha1.EmitterChannel
+	if ackResponse.AckChannel != nil {
+		channel = *ackResponse.AckChannel
+	}
+	if ackResponse.ChannelField != "" {
+		if name := gjson.GetBytes(messageBody(event), ackResponse.ChannelField); name.Type == gjson.String && name.Str != "" {
+			channel.Name = name.Str
+		}
+	}
+	return channel, channel.Name != ""
+}
+
+// messageBody returns the decompressed body of the message the event carries.
+func messageBody(event *events.EmitterEventData) []byte {
+	switch body := event.Body.(type) {
+	case []byte:
+		return body
+	case *json.RawMessage:
+		return *body
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestAckChannel(t *testing.T) {
+	ackResponse := &v1alpha1.EmitterAckResponse{
+		ChannelField: "reply.to",
+		AckChannel:   &v1alpha1.EmitterChannel{Name: "acks", Key: "acks_key"},
+	}
+	body := json.RawMessage(`{"reply": {"to": "orders/123/ack"}}`)
+
+	channel, ok := ackChannel(ackResponse, &events.EmitterEventData{Body: &body})
+	assert.True(t, ok)
+	assert.Equal(t, v1alpha1.EmitterChannel{Name: "orders/123/ack", Key: "acks_key"}, channel)
+
+	// the body isn't necessarily parsed as JSON
+	channel, ok = ackChannel(ackResponse, &events.EmitterEventData{Body: []byte(body)})
+	assert.True(t, ok)
+	assert.Equal(t, "orders/123/ack", channel.Name)
+
+	// the messages without the field, or with a field which isn't a string, are acknowledged on the ack channel
+	for _, body := range []string{`{"reply": {}}`, `{"reply": {"to": 5}}`, `not json`} {
+		channel, ok = ackChannel(ackResponse, &events.EmitterEventData{Body: []byte(body)})
+		assert.True(t, ok)
+		assert.Equal(t, v1alpha1.EmitterChannel{Name: "acks", Key: "acks_key"}, channel)
+	}
+
+	_, ok = ackChannel(&v1alpha1.EmitterAckResponse{ChannelField: "replyTo"}, &events.EmitterEventData{Body: []byte(`{}`)})
+	assert.False(t, ok)
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/argoproj/argo-events/common"
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// Possible backpressure policies of the dispatching
+const (
+	backpressurePolicyDrop   = "drop"
+	backpressurePolicyBlock  = "block"
+	backpressurePolicyBuffer = "buffer"
+)
+
+const (
+	// defaultBackpressureBufferSize is the number of events buffered with the buffer backpressure policy by default
+	defaultBackpressureBufferSize = 1000
+	// redispatchDelay is the delay before dispatching again an event the eventbus failed to accept, doubled on
+	// every failure up to maxRedispatchDelay
+	redispatchDelay    = 100 * time.Millisecond
+	maxRedispatchDelay = 5 * time.Second
+	// defaultDispatchTimeout is how long a dispatch to the eventbus may take by default
+	defaultDispatchTimeout = 30 * time.Second
+)
+
+// defaultDispatchRetryBackoff is the backoff between the retries of a failed dispatch if the DispatchRetry doesn't set
+// it, doubled on every retry up to a minute
+var defaultDispatchRetryBackoff = wait.Backoff{Duration: time.Second, Factor: 2, Cap: time.Minute}
+
+// redispatch runs dispatch until it succeeds or the context is done, with a backoff between the attempts.
+// onFailure is called with the error of every failed attempt. It returns the error of the last attempt if the
+// context is done before the dispatch succeeds, or if the attempt timed out while still running, which may still
+// dispatch the event. An event sampled out is returned as is, it isn't a failure.
+func redispatch(ctx context.Context, dispatch func() error, onFailure func(error)) error {
+	delay := redispatchDelay
+	for {
+		err := dispatch()
+		if err == nil || errors.Is(err, eventsourcecommon.ErrSampledOut) {
+			return err
+		}
+		onFailure(err)
+		if errors.Is(err, eventsourcecommon.ErrDispatchPending) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		delay *= 2
+		if delay > maxRedispatchDelay {
+			delay = maxRedispatchDelay
+		}
+	}
+}
+
+// dispatchWithBackpressure sends the event of the ID per the backpressure policy and acknowledges its message once
+// dispatched. With the block policy, the caller is held back until the eventbus accepts the event, with the buffer
+// policy the event is dispatched by the buffer, or dropped if the buffer is full. Otherwise the failed dispatches
+// are retried per the dispatch retry, if any.
+func (s *session) dispatchWithBackpressure(event *events.EmitterEventData, id string, size int, send func() error) {
+	el, log := s.el, s.log
+	onFailure := func(err error) {
+		log.Errorw("failed to dispatch event", zap.String("type", event.Type), zap.Error(err))
+		el.SetError(err)
+		el.failed(event.Topic, eventsourcecommon.DispatchFailureReason(err))
+	}
+	// a message sampled out by the middlewares of the dispatch is neither retried, reported nor acknowledged
+	sampledOut := func(err error) bool {
+		if !errors.Is(err, eventsourcecommon.ErrSampledOut) {
+			return false
+		}
+		log.Debugw("the message is sampled out, skip the event", zap.String("topic", event.Topic), zap.String("id", id))
+		return true
+	}
+	switch s.eventSource.BackpressurePolicy {
+	case backpressurePolicyBlock:
+		// blocking the callback holds the next messages back in the broker until the eventbus catches up
+		if err := redispatch(s.redispatchCtx, send, onFailure); err != nil {
+			if !sampledOut(err) {
+				log.Errorw("gave up on dispatching the event", zap.String("type", event.Type), zap.String("id", id), zap.Error(err))
+			}
+			return
+		}
+	case backpressurePolicyBuffer:
+		if !s.dispatching.start() {
+			return
+		}
+		if !s.buffer.push(func() {
+			defer s.dispatching.done()
+			if err := redispatch(s.redispatchCtx, send, onFailure); err != nil {
+				if !sampledOut(err) {
+					log.Errorw("gave up on dispatching the event", zap.String("type", event.Type), zap.String("id", id), zap.Error(err))
+				}
+				return
+			}
+			log.Debugw("dispatched the event", zap.String("type", event.Type), zap.String("id", id), zap.Int("bytes", size))
+			s.acknowledge(event, id)
+		}) {
+			s.dispatching.done()
+			log.Warnw("the event buffer is full, dropping the event", zap.String("type", event.Type), zap.String("id", id))
+			el.Metrics.EventsDropped(el.GetEventSourceName(), el.GetEventName())
+		}
+		return
+	default:
+		onRetry := func(err error) {
+			log.Warnw("failed to dispatch event, retrying", zap.String("type", event.Type), zap.String("id", id), zap.Error(err))
+			el.Metrics.DispatchRetried(el.GetEventSourceName(), el.GetEventName())
+		}
+		if err := retryDispatch(s.redispatchCtx, s.dispatchRetryBackoff, s.maxDispatchRetries, send, onRetry); err != nil {
+			if !sampledOut(err) {
+				onFailure(err)
+			}
+			return
+		}
+	}
+	log.Debugw("dispatched the event", zap.String("type", event.Type), zap.String("id", id), zap.Int("bytes", size))
+	s.acknowledge(event, id)
+}
+
+// newDispatchRetryBackoff returns the backoff between the retries of a failed dispatch
+func newDispatchRetryBackoff(retry *v1alpha1.EmitterDispatchRetry) (wait.Backoff, error) {
+	if retry == nil || retry.Backoff == nil {
+		return defaultDispatchRetryBackoff, nil
+	}
+	backoff, err := common.Convert2WaitBackoff(retry.Backoff)
+	if err != nil {
+		return wait.Backoff{}, err
+	}
+	return *backoff, nil
+}
+
+// retryDispatch runs dispatch until it succeeds or it has been retried maxRetries times, with the backoff between
+// the attempts. onRetry is called with the error of every failed attempt before it is retried. It returns the error
+// of the last attempt if the dispatch doesn't succeed, including when the context is done before the last retry.
+// An attempt which timed out while still running isn't retried, it may still dispatch the event, nor is an event
+// sampled out.
+func retryDispatch(ctx context.Context, backoff wait.Backoff, maxRetries int, dispatch func() error, onRetry func(error)) error {
+	// the steps bound how many times the backoff grows, the retries are bounded by maxRetries instead
+	backoff.Steps = maxRetries + 1
+	for retries := 0; ; retries++ {
+		err := dispatch()
+		if err == nil || retries >= maxRetries || errors.Is(err, eventsourcecommon.ErrDispatchPending) || errors.Is(err, eventsourcecommon.ErrSampledOut) {
+			return err
+		}
+		onRetry(err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff.Step()):
+		}
+	}
+}
+
+// eventBuffer is a bounded buffer of the event dispatches, decoupling the message callbacks from the eventbus.
+// The dispatches are run one at a time in the order they were pushed. The highest number of events buffered is
+// reported to onHighWaterMark whenever it grows.
+type eventBuffer struct {
+	dispatches      chan func()
+	onHighWaterMark func(int)
+
+	lock          sync.Mutex
+	highWaterMark int
+}
+
+func newEventBuffer(size int, onHighWaterMark func(int)) *eventBuffer {
+	if size <= 0 {
+		size = defaultBackpressureBufferSize
+	}
+	return &eventBuffer{dispatches: make(chan func(), size), onHighWaterMark: onHighWaterMark}
+}
+
+// push buffers the dispatch of an event without blocking, returning false if the buffer is full.
+func (b *eventBuffer) push(dispatch func()) bool {
+	select {
+	case b.dispatches <- dispatch:
+	default:
+		return false
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if buffered := len(b.dispatches); buffered > b.highWaterMark {
+		b.highWaterMark = buffered
+		b.onHighWaterMark(buffered)
+	}
+	return true
+}
+
+// run dispatches the buffered events until the context is done, the events still buffered are abandoned.
+func (b *eventBuffer) run(ctx context.Context) {
+	for {
+		select {
+		case dispatch := <-b.dispatches:
+			dispatch()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
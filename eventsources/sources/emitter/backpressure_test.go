@@ -0,0 +1,158 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestRedispatch(t *testing.T) {
+	t.Run("test retries until dispatched", func(t *testing.T) {
+		attempts, failures := 0, 0
+		err := redispatch(context.Background(), func() error {
+			attempts++
+			if attempts < 3 {
+				return errors.New("eventbus is slow")
+			}
+			return nil
+		}, func(error) { failures++ })
+		assert.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+		assert.Equal(t, 2, failures)
+	})
+
+	t.Run("test gives up once the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		err := redispatch(ctx, func() error {
+			return errors.New("eventbus is down")
+		}, func(error) {})
+		assert.EqualError(t, err, "eventbus is down")
+	})
+
+	t.Run("test gives up once a dispatch timing out is still running", func(t *testing.T) {
+		attempts, failures := 0, 0
+		err := redispatch(context.Background(), func() error {
+			attempts++
+			return errors.Wrap(eventsourcecommon.ErrDispatchPending, "the event was not dispatched within 1s")
+		}, func(error) { failures++ })
+		assert.True(t, errors.Is(err, eventsourcecommon.ErrDispatchPending))
+		assert.Equal(t, 1, attempts)
+		assert.Equal(t, 1, failures)
+	})
+}
+
+func TestRetryDispatch(t *testing.T) {
+	backoff := wait.Backoff{Duration: time.Millisecond, Factor: 2}
+
+	t.Run("test dispatches at most once without retries", func(t *testing.T) {
+		attempts, retries := 0, 0
+		err := retryDispatch(context.Background(), backoff, 0, func() error {
+			attempts++
+			return errors.New("eventbus is down")
+		}, func(error) { retries++ })
+		assert.EqualError(t, err, "eventbus is down")
+		assert.Equal(t, 1, attempts)
+		assert.Equal(t, 0, retries)
+	})
+
+	t.Run("test retries until dispatched", func(t *testing.T) {
+		attempts, retries := 0, 0
+		err := retryDispatch(context.Background(), backoff, 3, func() error {
+			attempts++
+			if attempts < 3 {
+				return errors.New("eventbus is slow")
+			}
+			return nil
+		}, func(error) { retries++ })
+		assert.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+		assert.Equal(t, 2, retries)
+	})
+
+	t.Run("test gives up after the max retries", func(t *testing.T) {
+		attempts, retries := 0, 0
+		err := retryDispatch(context.Background(), backoff, 2, func() error {
+			attempts++
+			return errors.New("eventbus is down")
+		}, func(error) { retries++ })
+		assert.EqualError(t, err, "eventbus is down")
+		assert.Equal(t, 3, attempts)
+		assert.Equal(t, 2, retries)
+	})
+
+	t.Run("test gives up once the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		attempts := 0
+		err := retryDispatch(ctx, wait.Backoff{Duration: time.Hour}, 5, func() error {
+			attempts++
+			return errors.New("eventbus is down")
+		}, func(error) {})
+		assert.EqualError(t, err, "eventbus is down")
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("test doesn't retry a dispatch timing out still running", func(t *testing.T) {
+		attempts, retries := 0, 0
+		err := retryDispatch(context.Background(), backoff, 3, func() error {
+			attempts++
+			return errors.Wrap(eventsourcecommon.ErrDispatchPending, "the event was not dispatched within 1s")
+		}, func(error) { retries++ })
+		assert.True(t, errors.Is(err, eventsourcecommon.ErrDispatchPending))
+		assert.Equal(t, 1, attempts)
+		assert.Equal(t, 0, retries)
+	})
+}
+
+func TestEventBuffer(t *testing.T) {
+	var highWaterMarks []int
+	buffer := newEventBuffer(2, func(buffered int) {
+		highWaterMarks = append(highWaterMarks, buffered)
+	})
+
+	var dispatched []int
+	for i := 1; i <= 3; i++ {
+		i := i
+		pushed := buffer.push(func() { dispatched = append(dispatched, i) })
+		// the buffer is full once it holds 2 events
+		assert.Equal(t, i <= 2, pushed)
+	}
+	assert.Equal(t, []int{1, 2}, highWaterMarks)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		buffer.run(ctx)
+		close(done)
+	}()
+	assert.Eventually(t, func() bool { return len(buffer.dispatches) == 0 }, time.Second, 10*time.Millisecond)
+	cancel()
+	<-done
+	assert.Equal(t, []int{1, 2}, dispatched)
+
+	// the high water mark only grows
+	assert.True(t, buffer.push(func() {}))
+	assert.Equal(t, []int{1, 2}, highWaterMarks)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+)
+
+// Compressions of the message payloads
+const (
+	compressionNone = "none"
+	compressionGzip = "gzip"
+)
+
+// defaultMaxDecompressedBytes is the maximum size of a decompressed payload if the maximum size of the events isn't set,
+// so that a small gzip bomb can't run the event source out of memory
+const defaultMaxDecompressedBytes = 32 << 20
+
+// decompress decompresses the payload of a message according to the compression, it is returned as is if none.
+// The decompressed payload is rejected once larger than the maximum size, the default one if not positive.
+func decompress(compression string, payload []byte, maxBytes int64) ([]byte, error) {
+	if compression != compressionGzip {
+		return payload, nil
+	}
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxDecompressedBytes
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(payload))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the gzip header")
+	}
+	defer reader.Close()
+	// a byte more than the maximum is read to tell a payload of the maximum size from a larger one
+	result, err := ioutil.ReadAll(io.LimitReader(reader, maxBytes+1))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decompress the gzip payload")
+	}
+	if int64(len(result)) > maxBytes {
+		return nil, errors.Errorf("the decompressed payload exceeds the maximum size of %d bytes", maxBytes)
+	}
+	return result, nil
+}
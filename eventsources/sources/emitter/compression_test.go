@@ -0,0 +1,70 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecompress(t *testing.T) {
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	_, err := writer.Write([]byte(`{"hello":"world"}`))
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	payload, err := decompress(compressionGzip, buffer.Bytes(), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"hello":"world"}`, string(payload))
+
+	payload, err = decompress(compressionNone, []byte("plain"), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "plain", string(payload))
+
+	payload, err = decompress("", []byte("plain"), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "plain", string(payload))
+
+	_, err = decompress(compressionGzip, []byte("plain"), 0)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read the gzip header")
+
+	_, err = decompress(compressionGzip, buffer.Bytes()[:buffer.Len()-4], 0)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to decompress the gzip payload")
+
+	// the payload is rejected once decompressed beyond the maximum size
+	payload, err = decompress(compressionGzip, buffer.Bytes(), 17)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"hello":"world"}`, string(payload))
+	_, err = decompress(compressionGzip, buffer.Bytes(), 16)
+	assert.Error(t, err)
+	assert.Equal(t, "the decompressed payload exceeds the maximum size of 16 bytes", err.Error())
+
+	var bomb bytes.Buffer
+	writer = gzip.NewWriter(&bomb)
+	_, err = writer.Write(make([]byte, defaultMaxDecompressedBytes+1))
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+	_, err = decompress(compressionGzip, bomb.Bytes(), 0)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeds the maximum size")
+}
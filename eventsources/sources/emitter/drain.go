@@ -0,0 +1,96 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// defaultDrainTimeout is how long to wait on shutdown for the events being dispatched to complete
+const defaultDrainTimeout = 5 * time.Second
+
+// inflight tracks the events being dispatched so that the shutdown can wait for them to complete.
+type inflight struct {
+	lock    sync.Mutex
+	wg      sync.WaitGroup
+	count   int
+	drained bool
+}
+
+// start registers an event being dispatched, it returns false once the drain has started.
+func (f *inflight) start() bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.drained {
+		return false
+	}
+	f.count++
+	f.wg.Add(1)
+	return true
+}
+
+// done unregisters an event registered by start.
+func (f *inflight) done() {
+	f.lock.Lock()
+	f.count--
+	f.lock.Unlock()
+	f.wg.Done()
+}
+
+// drain stops accepting new events and waits up to the timeout for the ones being dispatched to complete.
+// It returns the number of events still being dispatched when the timeout elapses.
+func (f *inflight) drain(timeout time.Duration) int {
+	f.lock.Lock()
+	f.drained = true
+	f.lock.Unlock()
+
+	completed := make(chan struct{})
+	go func() {
+		f.wg.Wait()
+		close(completed)
+	}()
+	select {
+	case <-completed:
+		return 0
+	case <-time.After(timeout):
+		f.lock.Lock()
+		defer f.lock.Unlock()
+		return f.count
+	}
+}
+
+// drain waits up to the timeout for the messages being processed by the workers to get their events dispatched,
+// then for the events being dispatched to complete.
+func (s *session) drain(timeout time.Duration) {
+	if s.workers != nil {
+		s.log.Infow("waiting for the messages being processed to complete", zap.Duration("drainTimeout", timeout))
+		started := time.Now()
+		if abandoned := s.workers.drain(timeout); abandoned > 0 {
+			s.log.Errorw("drain timeout elapsed, abandoned the messages being processed", zap.Int("abandoned", abandoned))
+		}
+		if timeout -= time.Since(started); timeout < 0 {
+			timeout = 0
+		}
+	}
+	s.log.Infow("waiting for the events being dispatched to complete", zap.Duration("drainTimeout", timeout))
+	if abandoned := s.dispatching.drain(timeout); abandoned > 0 {
+		s.log.Errorw("drain timeout elapsed, abandoned the events being dispatched", zap.Int("abandoned", abandoned))
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInflight(t *testing.T) {
+	t.Run("test drain waits for the events being dispatched", func(t *testing.T) {
+		f := &inflight{}
+		assert.True(t, f.start())
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			f.done()
+		}()
+		start := time.Now()
+		assert.Equal(t, 0, f.drain(time.Minute))
+		assert.True(t, time.Since(start) >= 50*time.Millisecond)
+		assert.False(t, f.start())
+	})
+
+	t.Run("test drain timeout", func(t *testing.T) {
+		f := &inflight{}
+		assert.True(t, f.start())
+		assert.True(t, f.start())
+		f.done()
+		assert.Equal(t, 1, f.drain(10*time.Millisecond))
+		f.done()
+	})
+
+	t.Run("test drain without events", func(t *testing.T) {
+		f := &inflight{}
+		assert.Equal(t, 0, f.drain(time.Minute))
+	})
+}
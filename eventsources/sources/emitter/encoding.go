@@ -0,0 +1,49 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+)
+
+// Encodings of the message payloads
+const (
+	payloadEncodingJSON          = "json"
+	payloadEncodingConfluentAvro = "confluent-avro"
+)
+
+const (
+	// confluentMagicByte is the first byte of the payloads in the wire format of the Confluent schema registry
+	confluentMagicByte = 0
+	// confluentPrefixSize is the size of the magic byte and of the schema ID prefixing the payloads
+	confluentPrefixSize = 5
+)
+
+// decodeSchemaID strips the prefix of a payload in the wire format of the Confluent schema registry, the magic byte
+// followed by the schema ID as a 4-byte big-endian integer, and returns the schema ID along with the rest of the
+// payload, left undecoded.
+func decodeSchemaID(payload []byte) (int32, []byte, error) {
+	if len(payload) < confluentPrefixSize {
+		return 0, nil, errors.Errorf("the payload of %d bytes is too short to be prefixed with a schema ID", len(payload))
+	}
+	if payload[0] != confluentMagicByte {
+		return 0, nil, errors.Errorf("unknown magic byte %d, the payload isn't in the Confluent wire format", payload[0])
+	}
+	return int32(binary.BigEndian.Uint32(payload[1:confluentPrefixSize])), payload[confluentPrefixSize:], nil
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeSchemaID(t *testing.T) {
+	schemaID, body, err := decodeSchemaID([]byte{0, 0, 0, 1, 2, 'a', 'v', 'r', 'o'})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(258), schemaID)
+	assert.Equal(t, []byte("avro"), body)
+
+	schemaID, body, err = decodeSchemaID([]byte{0, 0, 0, 0, 7})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(7), schemaID)
+	assert.Empty(t, body)
+
+	_, _, err = decodeSchemaID([]byte{0, 0, 1})
+	assert.EqualError(t, err, "the payload of 3 bytes is too short to be prefixed with a schema ID")
+
+	_, _, err = decodeSchemaID([]byte(`{"json": true}`))
+	assert.EqualError(t, err, "unknown magic byte 123, the payload isn't in the Confluent wire format")
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"sync"
+	"time"
+
+	"github.com/eclipse/paho.mqtt.golang/packets"
+	emitter "github.com/emitter-io/go/v2"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj/argo-events/common"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// disconnectWait is how long the in-flight messages are waited for when disconnecting
+const disconnectWait = 250 * time.Millisecond
+
+// failover connects to the brokers in turn. The emitter client connects to a fixed list of brokers, always
+// starting with the first one, and doesn't tell which one it is connected to, so each connection is made by a
+// new client to a single broker: the next one in turn, a broker that can't be reached is skipped for the next
+// connection. The calls are made on the client of the latest connection.
+type failover struct {
+	brokers []string
+	// options are the options the clients are created with, along with their broker
+	options []func(*emitter.Client)
+	// setup registers the handlers on a new client before it connects
+	setup func(*emitter.Client)
+
+	lock   sync.RWMutex
+	next   int
+	broker string
+	client *emitter.Client
+}
+
+func newFailover(brokers []string, options []func(*emitter.Client)) *failover {
+	return &failover{brokers: brokers, options: options}
+}
+
+// connect connects a new client to the next broker in turn. The client is the current one from then on, so
+// that the connect handler, which runs as soon as the connection is made, calls the new client.
+func (f *failover) connect() error {
+	f.lock.Lock()
+	broker := f.brokers[f.next]
+	f.next = (f.next + 1) % len(f.brokers)
+	options := append([]func(*emitter.Client){emitter.WithBrokers(broker)}, f.options...)
+	client := emitter.NewClient(options...)
+	if f.setup != nil {
+		f.setup(client)
+	}
+	f.client, f.broker = client, broker
+	f.lock.Unlock()
+	if err := client.Connect(); err != nil {
+		return classifyConnectError(errors.Wrapf(err, "failed to connect to %s", broker))
+	}
+	return nil
+}
+
+// classifyConnectError tells the connections the broker refused for the credentials, which retrying doesn't fix,
+// apart from the brokers which can't be reached.
+func classifyConnectError(err error) error {
+	if errors.Is(err, packets.ErrorRefusedBadUsernameOrPassword) || errors.Is(err, packets.ErrorRefusedNotAuthorised) {
+		return common.NewAuthError(err)
+	}
+	return common.NewConnectionError(err)
+}
+
+// invalidateCredentials drops the cached secrets of the credentials the broker refused, so that the event source
+// reads the rotated secrets once restarted instead of the stale ones until the secret cache TTL elapses.
+func invalidateCredentials(resolver common.SecretResolver, eventSource *v1alpha1.EmitterEventSource, err error) {
+	if !errors.Is(err, common.ErrAuthFailed) {
+		return
+	}
+	if errors.Is(err, errKeyGenDenied) {
+		if eventSource.KeyGen != nil {
+			resolver.Invalidate(eventSource.KeyGen.MasterKey)
+		}
+		return
+	}
+	for _, ref := range []*v1.SecretKeySelector{eventSource.ConnectionStringSecret, eventSource.Username, eventSource.Password} {
+		if ref != nil {
+			resolver.Invalidate(ref)
+		}
+	}
+}
+
+// current returns the client of the latest connection and its broker
+func (f *failover) current() (*emitter.Client, string) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return f.client, f.broker
+}
+
+// Broker returns the broker of the latest connection
+func (f *failover) Broker() string {
+	_, broker := f.current()
+	return broker
+}
+
+func (f *failover) IsConnected() bool {
+	client, _ := f.current()
+	return client != nil && client.IsConnected()
+}
+
+func (f *failover) Subscribe(key string, channel string, optionalHandler emitter.MessageHandler, options ...emitter.Option) error {
+	client, _ := f.current()
+	return client.Subscribe(key, channel, optionalHandler, options...)
+}
+
+func (f *failover) Unsubscribe(key string, channel string) error {
+	client, _ := f.current()
+	return client.Unsubscribe(key, channel)
+}
+
+func (f *failover) Presence(key, channel string, status, changes bool) error {
+	client, _ := f.current()
+	return client.Presence(key, channel, status, changes)
+}
+
+func (f *failover) Publish(key string, channel string, payload interface{}, options ...emitter.Option) error {
+	client, _ := f.current()
+	return client.Publish(key, channel, payload, options...)
+}
+
+func (f *failover) GenerateKey(key, channel, permissions string, ttl int) (string, error) {
+	client, _ := f.current()
+	return client.GenerateKey(key, channel, permissions, ttl)
+}
+
+// disconnect disconnects the client of the latest connection, if any
+func (f *failover) disconnect() {
+	client, _ := f.current()
+	if client != nil {
+		client.Disconnect(disconnectWait)
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/eclipse/paho.mqtt.golang/packets"
+	emitter "github.com/emitter-io/go/v2"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj/argo-events/common"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// closedBroker returns the address of a port nothing listens on, the connections are refused
+func closedBroker(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	addr := listener.Addr().String()
+	_ = listener.Close()
+	return addr
+}
+
+// acceptingBroker accepts the MQTT connections and then reads the packets without answering them
+func acceptingBroker(t *testing.T) string {
+	return answeringBroker(t, packets.Accepted)
+}
+
+// answeringBroker answers the MQTT connections with the return code, and then reads the packets without
+// answering them
+func answeringBroker(t *testing.T, returnCode byte) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				if _, err := packets.ReadPacket(conn); err != nil {
+					return
+				}
+				connack := packets.NewControlPacket(packets.Connack).(*packets.ConnackPacket)
+				connack.ReturnCode = returnCode
+				if err := connack.Write(conn); err != nil {
+					return
+				}
+				for {
+					if _, err := packets.ReadPacket(conn); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func TestFailoverConnect(t *testing.T) {
+	dead, alive := fmt.Sprintf("tcp://%s", closedBroker(t)), fmt.Sprintf("tcp://%s", acceptingBroker(t))
+	f := newFailover([]string{dead, alive}, []func(*emitter.Client){emitter.WithConnectTimeout(time.Second)})
+	setups := 0
+	f.setup = func(*emitter.Client) { setups++ }
+	assert.False(t, f.IsConnected())
+
+	err := f.connect()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to connect to "+dead)
+	assert.Equal(t, dead, f.Broker())
+
+	// the dead broker is skipped for the next one
+	assert.NoError(t, f.connect())
+	assert.Equal(t, alive, f.Broker())
+	assert.True(t, f.IsConnected())
+	assert.Equal(t, 2, setups)
+	client, _ := f.current()
+	client.Disconnect(0)
+
+	// the brokers are connected to in turn
+	assert.Error(t, f.connect())
+	assert.Equal(t, dead, f.Broker())
+}
+
+func TestFailoverConnectClassifiesErrors(t *testing.T) {
+	options := []func(*emitter.Client){emitter.WithConnectTimeout(time.Second)}
+
+	err := newFailover([]string{fmt.Sprintf("tcp://%s", closedBroker(t))}, options).connect()
+	assert.ErrorIs(t, err, common.ErrConnectionFailed)
+	assert.True(t, common.IsRetryable(err))
+
+	for _, returnCode := range []byte{packets.ErrRefusedBadUsernameOrPassword, packets.ErrRefusedNotAuthorised} {
+		err = newFailover([]string{fmt.Sprintf("tcp://%s", answeringBroker(t, returnCode))}, options).connect()
+		assert.ErrorIs(t, err, common.ErrAuthFailed)
+		assert.False(t, common.IsRetryable(err))
+	}
+}
+
+// invalidatingResolver records the names of the secrets invalidated
+type invalidatingResolver struct {
+	invalidated []string
+}
+
+func (r *invalidatingResolver) Resolve(*corev1.SecretKeySelector) (string, error) {
+	return "", nil
+}
+
+func (r *invalidatingResolver) Invalidate(ref *corev1.SecretKeySelector) {
+	r.invalidated = append(r.invalidated, ref.Name)
+}
+
+func TestInvalidateCredentials(t *testing.T) {
+	secret := func(name string) *corev1.SecretKeySelector {
+		return &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Key: "key"}
+	}
+	eventSource := &v1alpha1.EmitterEventSource{
+		Username: secret("username"),
+		Password: secret("password"),
+		KeyGen:   &v1alpha1.EmitterKeyGen{MasterKey: secret("master-key")},
+	}
+
+	// the failures to reach the brokers keep the cached secrets
+	resolver := &invalidatingResolver{}
+	invalidateCredentials(resolver, eventSource, common.NewConnectionError(errors.New("connection refused")))
+	assert.Empty(t, resolver.invalidated)
+
+	resolver = &invalidatingResolver{}
+	invalidateCredentials(resolver, eventSource, errors.Wrap(common.NewAuthError(errors.New("bad user name or password")), "failed to connect"))
+	assert.Equal(t, []string{"username", "password"}, resolver.invalidated)
+
+	resolver = &invalidatingResolver{}
+	invalidateCredentials(resolver, eventSource, errors.Wrap(errKeyGenDenied, "failed to generate the key"))
+	assert.Equal(t, []string{"master-key"}, resolver.invalidated)
+}
@@ -0,0 +1,36 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// validateJSON checks the body is valid JSON. The error tells the offset of the first syntax error, which is
+// returned as well for the logs, -1 if the error has no offset.
+func validateJSON(body []byte) (int64, error) {
+	if json.Valid(body) {
+		return 0, nil
+	}
+	var syntaxErr *json.SyntaxError
+	if err := json.Unmarshal(body, new(json.RawMessage)); errors.As(err, &syntaxErr) {
+		return syntaxErr.Offset, errors.Wrapf(err, "body is not valid JSON at offset %d", syntaxErr.Offset)
+	}
+	return -1, errors.New("body is not valid JSON")
+}
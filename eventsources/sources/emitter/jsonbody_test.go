@@ -0,0 +1,43 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateJSON(t *testing.T) {
+	for _, body := range []string{`{"hello": "world"}`, `[1, 2]`, `"text"`, `42`} {
+		_, err := validateJSON([]byte(body))
+		assert.NoError(t, err, body)
+	}
+
+	offset, err := validateJSON([]byte(`{"hello": world}`))
+	assert.Error(t, err)
+	assert.Equal(t, int64(11), offset)
+	assert.Equal(t, "body is not valid JSON at offset 11: invalid character 'w' looking for beginning of value", err.Error())
+
+	offset, err = validateJSON([]byte(`{"hello": "world"`))
+	assert.Error(t, err)
+	assert.Equal(t, int64(17), offset)
+
+	offset, err = validateJSON(nil)
+	assert.Error(t, err)
+	assert.Equal(t, int64(0), offset)
+}
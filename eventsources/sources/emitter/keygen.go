@@ -0,0 +1,139 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	emitter "github.com/emitter-io/go/v2"
+	"github.com/pkg/errors"
+
+	"github.com/argoproj/argo-events/common"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+const (
+	// defaultKeyTTL is the validity of the generated channel keys by default
+	defaultKeyTTL = time.Hour
+	// minKeyTTL is the least validity of the generated channel keys, they are regenerated ahead of their expiry
+	minKeyTTL = time.Minute
+	// keyPermissions are the permissions which can be granted to a generated channel key:
+	// read, write, store, load, presence, extend and execute.
+	keyPermissions = "rwslpex"
+)
+
+// errKeyGenDenied tells that the broker denied the generation of a channel key, it's an authentication failure
+var errKeyGenDenied = common.NewAuthError(errors.New("the broker denied the key generation, check the master key and the permissions"))
+
+// generateKeyFunc generates a key of the channel with the permissions, valid for ttl seconds
+type generateKeyFunc func(masterKey, channel, permissions string, ttl int) (string, error)
+
+// cachedKey is a generated channel key and its expiry
+type cachedKey struct {
+	key       string
+	expiresAt time.Time
+}
+
+// keyCache generates the channel keys from the master key and caches them until they are near expiry,
+// i.e. once 80% of their validity has elapsed, so that they are regenerated before the broker rejects them.
+type keyCache struct {
+	generate    generateKeyFunc
+	masterKey   string
+	permissions string
+	ttl         time.Duration
+	now         func() time.Time
+
+	lock sync.Mutex
+	keys map[string]cachedKey
+}
+
+func newKeyCache(generate generateKeyFunc, masterKey, permissions string, ttl time.Duration) *keyCache {
+	return &keyCache{
+		generate:    generate,
+		masterKey:   masterKey,
+		permissions: permissions,
+		ttl:         ttl,
+		now:         time.Now,
+		keys:        make(map[string]cachedKey),
+	}
+}
+
+// get returns the key of the channel, generating it if it isn't cached or is near expiry.
+// The error wraps errKeyGenDenied if the broker denied the generation.
+func (c *keyCache) get(channel string) (string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := c.now()
+	if cached, ok := c.keys[channel]; ok && now.Before(c.refreshTime(cached)) {
+		return cached.key, nil
+	}
+	key, err := c.generate(c.masterKey, keyGenChannel(channel), c.permissions, int(c.ttl/time.Second))
+	if err != nil {
+		var emitterErr *emitter.Error
+		if errors.As(err, &emitterErr) && (emitterErr.Status == http.StatusUnauthorized || emitterErr.Status == http.StatusForbidden) {
+			return "", errors.Wrapf(errKeyGenDenied, "failed to generate the key of the channel %s with permissions %s, %s", channel, c.permissions, emitterErr.Message)
+		}
+		return "", errors.Wrapf(err, "failed to generate the key of the channel %s", channel)
+	}
+	c.keys[channel] = cachedKey{key: key, expiresAt: now.Add(c.ttl)}
+	return key, nil
+}
+
+// nextRefresh returns when the first of the cached keys is near expiry, the zero time if none is cached
+func (c *keyCache) nextRefresh() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	var next time.Time
+	for _, cached := range c.keys {
+		if t := c.refreshTime(cached); next.IsZero() || t.Before(next) {
+			next = t
+		}
+	}
+	return next
+}
+
+func (c *keyCache) refreshTime(cached cachedKey) time.Time {
+	return cached.expiresAt.Add(-c.ttl / 5)
+}
+
+// keyGenChannel returns the channel a key is generated for, without the options and with the trailing slash
+// the broker expects, e.g. sensor/+/ for sensor/+?last=5
+func keyGenChannel(channel string) string {
+	if i := strings.Index(channel, "?"); i >= 0 {
+		channel = channel[:i]
+	}
+	return strings.TrimSuffix(channel, "/") + "/"
+}
+
+// keyGenPermissions returns the permissions of the generated keys, read by default along with load and
+// presence when the history replay and the presence notifications are enabled.
+func keyGenPermissions(eventSource *v1alpha1.EmitterEventSource) string {
+	if eventSource.KeyGen.Permissions != "" {
+		return eventSource.KeyGen.Permissions
+	}
+	permissions := "r"
+	if opts := eventSource.SubscriptionOptions; opts != nil && opts.WithHistory {
+		permissions += "l"
+	}
+	if eventSource.Presence {
+		permissions += "p"
+	}
+	return permissions
+}
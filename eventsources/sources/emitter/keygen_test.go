@@ -0,0 +1,91 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	emitter "github.com/emitter-io/go/v2"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestKeyCache(t *testing.T) {
+	now := time.Now()
+	generated := 0
+	keys := newKeyCache(func(masterKey, channel, permissions string, ttl int) (string, error) {
+		assert.Equal(t, "master", masterKey)
+		assert.Equal(t, "sensor/+/", channel)
+		assert.Equal(t, "rl", permissions)
+		assert.Equal(t, 3600, ttl)
+		generated++
+		return "key" + string(rune('0'+generated)), nil
+	}, "master", "rl", time.Hour)
+	keys.now = func() time.Time { return now }
+
+	assert.True(t, keys.nextRefresh().IsZero())
+	key, err := keys.get("sensor/+?last=5")
+	assert.NoError(t, err)
+	assert.Equal(t, "key1", key)
+	assert.Equal(t, now.Add(48*time.Minute), keys.nextRefresh())
+
+	// cached until it is near expiry
+	now = now.Add(47 * time.Minute)
+	key, err = keys.get("sensor/+?last=5")
+	assert.NoError(t, err)
+	assert.Equal(t, "key1", key)
+
+	now = now.Add(time.Minute)
+	key, err = keys.get("sensor/+?last=5")
+	assert.NoError(t, err)
+	assert.Equal(t, "key2", key)
+	assert.Equal(t, 2, generated)
+}
+
+func TestKeyCacheDenied(t *testing.T) {
+	keys := newKeyCache(func(masterKey, channel, permissions string, ttl int) (string, error) {
+		return "", &emitter.Error{Status: http.StatusUnauthorized, Message: "the security key provided is not authorized"}
+	}, "master", "r", time.Hour)
+	_, err := keys.get("hello")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, errKeyGenDenied))
+	assert.Equal(t, "failed to generate the key of the channel hello with permissions r, the security key provided is not authorized: the broker denied the key generation, check the master key and the permissions", err.Error())
+
+	keys = newKeyCache(func(masterKey, channel, permissions string, ttl int) (string, error) {
+		return "", emitter.ErrTimeout
+	}, "master", "r", time.Hour)
+	_, err = keys.get("hello")
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, errKeyGenDenied))
+	assert.True(t, keys.nextRefresh().IsZero())
+}
+
+func TestKeyGenPermissions(t *testing.T) {
+	eventSource := &v1alpha1.EmitterEventSource{KeyGen: &v1alpha1.EmitterKeyGen{}}
+	assert.Equal(t, "r", keyGenPermissions(eventSource))
+
+	eventSource.SubscriptionOptions = &v1alpha1.EmitterSubscriptionOptions{WithHistory: true, Last: 5}
+	eventSource.Presence = true
+	assert.Equal(t, "rlp", keyGenPermissions(eventSource))
+
+	eventSource.KeyGen.Permissions = "rw"
+	assert.Equal(t, "rw", keyGenPermissions(eventSource))
+}
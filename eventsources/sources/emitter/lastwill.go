@@ -0,0 +1,55 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"encoding/json"
+
+	emitter "github.com/emitter-io/go/v2"
+
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// lastWillChannel returns the last-will topic to subscribe to, with the channel key. The key is generated from the
+// master key instead if KeyGen is set.
+func lastWillChannel(eventSource *v1alpha1.EmitterEventSource) v1alpha1.EmitterChannel {
+	return v1alpha1.EmitterChannel{Name: eventSource.LastWillTopic, Key: eventSource.ChannelKey}
+}
+
+// lastWillEvent returns the event of a last-will message received on the last-will topic. Unlike the messages of the
+// channels, the topics aren't filtered and the payload is neither decompressed nor decoded, it's the one the client
+// registered along with its connection.
+func lastWillEvent(lastWillTopic string, message emitter.Message, jsonBody bool, metadata map[string]string) *events.EmitterEventData {
+	body := message.Payload()
+	event := &events.EmitterEventData{
+		Type:        eventTypeLastWill,
+		Topic:       message.Topic(),
+		Channel:     lastWillTopic,
+		TopicParams: topicParams(lastWillTopic, message.Topic()),
+		Body:        body,
+		Metadata:    metadata,
+	}
+	if msg, ok := message.(mqttMessage); ok {
+		event.Retained = msg.Retained()
+		event.MessageID = int(msg.MessageID())
+	}
+	if jsonBody && json.Valid(body) {
+		event.Body = (*json.RawMessage)(&body)
+	}
+	return event
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestLastWillChannel(t *testing.T) {
+	channel := lastWillChannel(&v1alpha1.EmitterEventSource{ChannelName: "devices/", ChannelKey: "devices_key", LastWillTopic: "devices/lastwill/"})
+	assert.Equal(t, v1alpha1.EmitterChannel{Name: "devices/lastwill/", Key: "devices_key"}, channel)
+}
+
+func TestLastWillEvent(t *testing.T) {
+	metadata := map[string]string{"fleet": "eu"}
+	message := &fakeMessage{topic: "devices/lastwill/sensor-1/", payload: []byte(`{"id":"sensor-1"}`)}
+
+	event := lastWillEvent("devices/lastwill/+/", message, false, metadata)
+	assert.Equal(t, eventTypeLastWill, event.Type)
+	assert.Equal(t, "devices/lastwill/sensor-1/", event.Topic)
+	assert.Equal(t, "devices/lastwill/+/", event.Channel)
+	assert.Equal(t, map[string]string{"2": "sensor-1"}, event.TopicParams)
+	assert.Equal(t, []byte(`{"id":"sensor-1"}`), event.Body)
+	assert.Equal(t, metadata, event.Metadata)
+
+	event = lastWillEvent("devices/lastwill/+/", message, true, metadata)
+	body, err := json.Marshal(event.Body)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id":"sensor-1"}`, string(body))
+
+	// a payload which isn't JSON is kept as is
+	message.payload = []byte("sensor-1")
+	event = lastWillEvent("devices/lastwill/+/", message, true, metadata)
+	assert.Equal(t, []byte("sensor-1"), event.Body)
+}
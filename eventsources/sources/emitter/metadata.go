@@ -0,0 +1,46 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"regexp"
+)
+
+// envPlaceholder matches the ${ENV:VAR} placeholders of the metadata values
+var envPlaceholder = regexp.MustCompile(`\$\{ENV:([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandMetadata returns a copy of the metadata with the ${ENV:VAR} placeholders of the values replaced by the
+// value of the environment variable, looked up with lookupEnv, along with the names of the variables which aren't
+// set. A placeholder of a variable which isn't set is replaced by an empty string.
+func expandMetadata(metadata map[string]string, lookupEnv func(string) (string, bool)) (map[string]string, []string) {
+	if metadata == nil {
+		return nil, nil
+	}
+	var unresolved []string
+	result := make(map[string]string, len(metadata))
+	for key, value := range metadata {
+		result[key] = envPlaceholder.ReplaceAllStringFunc(value, func(placeholder string) string {
+			name := envPlaceholder.FindStringSubmatch(placeholder)[1]
+			v, ok := lookupEnv(name)
+			if !ok {
+				unresolved = append(unresolved, name)
+			}
+			return v
+		})
+	}
+	return result, unresolved
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandMetadata(t *testing.T) {
+	env := map[string]string{"CLUSTER_NAME": "eu-west", "REGION": "eu", "EMPTY": ""}
+	lookupEnv := func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+	metadata := map[string]string{
+		"cluster":  "${ENV:CLUSTER_NAME}",
+		"location": "${ENV:REGION}/${ENV:CLUSTER_NAME}",
+		"empty":    "${ENV:EMPTY}",
+		"missing":  "cluster-${ENV:MISSING}",
+		"literal":  "$CLUSTER_NAME ${CLUSTER_NAME} ${ENV:}",
+	}
+	expanded, unresolved := expandMetadata(metadata, lookupEnv)
+	assert.Equal(t, map[string]string{
+		"cluster":  "eu-west",
+		"location": "eu/eu-west",
+		"empty":    "",
+		"missing":  "cluster-",
+		"literal":  "$CLUSTER_NAME ${CLUSTER_NAME} ${ENV:}",
+	}, expanded)
+	assert.Equal(t, []string{"MISSING"}, unresolved)
+	// the spec is left untouched
+	assert.Equal(t, "${ENV:CLUSTER_NAME}", metadata["cluster"])
+
+	expanded, unresolved = expandMetadata(nil, lookupEnv)
+	assert.Nil(t, expanded)
+	assert.Empty(t, unresolved)
+}
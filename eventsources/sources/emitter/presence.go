@@ -0,0 +1,49 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	emitter "github.com/emitter-io/go/v2"
+
+	"github.com/argoproj/argo-events/pkg/apis/events"
+)
+
+// presenceEvent builds the event carrying a presence notification of the channel. The number of subscribers is
+// only set for the status notifications, the others list the subscribers which joined or left.
+func presenceEvent(presence emitter.PresenceEvent, metadata map[string]string) *events.EmitterEventData {
+	data := &events.EmitterPresenceData{
+		Event: presence.Event,
+		Time:  presence.Time,
+		Who:   make([]events.EmitterPresenceInfo, 0, len(presence.Who)),
+	}
+	for _, who := range presence.Who {
+		data.Who = append(data.Who, events.EmitterPresenceInfo{
+			ID:       who.ID,
+			Username: who.Username,
+		})
+	}
+	if presence.Event == "status" {
+		data.Count = len(presence.Who)
+	}
+	return &events.EmitterEventData{
+		Type:     eventTypePresence,
+		Topic:    presence.Channel,
+		Channel:  presence.Channel,
+		Presence: data,
+		Metadata: metadata,
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"encoding/json"
+	"testing"
+
+	emitter "github.com/emitter-io/go/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-events/pkg/apis/events"
+)
+
+func TestPresenceEvent(t *testing.T) {
+	metadata := map[string]string{"fleet": "eu"}
+	var presence emitter.PresenceEvent
+	presence.Event = "status"
+	presence.Channel = "devices/"
+	presence.Time = 1600000000
+	presence.Who = []emitter.PresenceInfo{{ID: "a1", Username: "sensor-1"}, {ID: "b2", Username: "sensor-2"}}
+
+	event := presenceEvent(presence, metadata)
+	assert.Equal(t, eventTypePresence, event.Type)
+	assert.Equal(t, "devices/", event.Topic)
+	assert.Equal(t, "devices/", event.Channel)
+	assert.Equal(t, metadata, event.Metadata)
+	assert.Equal(t, &events.EmitterPresenceData{
+		Event: "status",
+		Time:  1600000000,
+		Count: 2,
+		Who:   []events.EmitterPresenceInfo{{ID: "a1", Username: "sensor-1"}, {ID: "b2", Username: "sensor-2"}},
+	}, event.Presence)
+
+	// the subscribers aren't counted for the join and leave notifications
+	presence.Event = "subscribe"
+	presence.Who = presence.Who[:1]
+	event = presenceEvent(presence, metadata)
+	assert.Equal(t, 0, event.Presence.Count)
+	assert.Equal(t, []events.EmitterPresenceInfo{{ID: "a1", Username: "sensor-1"}}, event.Presence.Who)
+
+	// an empty notification still serializes the list of subscribers
+	presence.Event = "unsubscribe"
+	presence.Who = nil
+	event = presenceEvent(presence, metadata)
+	assert.NotNil(t, event.Presence.Who)
+	assert.Empty(t, event.Presence.Who)
+
+	// the presence events don't carry the fields of the messages
+	body, err := json.Marshal(event)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(body), "messageId")
+	assert.NotContains(t, string(body), "retained")
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import "go.uber.org/zap"
+
+// Possible overflow policies of the rate limiting
+const (
+	overflowPolicyDrop  = "drop"
+	overflowPolicyBlock = "block"
+)
+
+// admit tells whether the message received on the channel can be dispatched under the rate limit. Blocking is
+// bounded by the event source context, so that the client callback is released on shutdown.
+func (s *session) admit(channelName string) bool {
+	if s.limiter == nil {
+		return true
+	}
+	el := s.el
+	if s.eventSource.OverflowPolicy == overflowPolicyBlock {
+		if err := s.limiter.Wait(s.ctx); err != nil {
+			s.log.Infow("event source is shutting down, drop the message", zap.String("channelName", channelName))
+			el.Metrics.EventsDropped(el.GetEventSourceName(), el.GetEventName())
+			return false
+		}
+		return true
+	}
+	if !s.limiter.Allow() {
+		s.log.Debugw("rate limit exceeded, drop the message", zap.String("channelName", channelName))
+		el.Metrics.EventsDropped(el.GetEventSourceName(), el.GetEventName())
+		return false
+	}
+	return true
+}
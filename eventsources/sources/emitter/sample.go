@@ -0,0 +1,66 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// DispatchMiddlewares returns the middleware sampling the events of the messages at the sample rate, if any. The
+// invalid sample rate is reported by StartListening.
+func (el *EventListener) DispatchMiddlewares() []eventsourcecommon.DispatchMiddleware {
+	rate, err := sampleRate(&el.EmitterEventSource)
+	if err != nil || rate >= 1 {
+		return nil
+	}
+	return []eventsourcecommon.DispatchMiddleware{
+		eventsourcecommon.SampleDispatch(rate, messageEvent(el.EmitterEventSource.EnvelopeVersion), func() {
+			el.Metrics.EventsFiltered(el.GetEventSourceName(), el.GetEventName())
+		}),
+	}
+}
+
+// sampleRate returns the share of the events dispatched, all of them if not set
+func sampleRate(eventSource *v1alpha1.EmitterEventSource) (float64, error) {
+	if eventSource.SampleRate == nil {
+		return 1, nil
+	}
+	rate, err := eventSource.SampleRate.Float64()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to parse the sample rate")
+	}
+	if rate < 0 || rate > 1 {
+		return 0, errors.New("sampleRate must be between 0 and 1")
+	}
+	return rate, nil
+}
+
+// messageEvent returns whether the marshaled event, wrapped in the envelope of the version if set, is the event of a
+// message. Only the messages are sampled, the heartbeat, lifecycle and error events are always dispatched.
+func messageEvent(envelopeVersion string) func([]byte) bool {
+	path := "type"
+	if envelopeVersion != "" {
+		path = "data.type"
+	}
+	return func(data []byte) bool {
+		return gjson.GetBytes(data, path).Str == eventTypeMessage
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	metrics "github.com/argoproj/argo-events/metrics"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestSampleRate(t *testing.T) {
+	rate, err := sampleRate(&v1alpha1.EmitterEventSource{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, rate)
+
+	amount := apicommon.NewAmount("0.25")
+	rate, err = sampleRate(&v1alpha1.EmitterEventSource{SampleRate: &amount})
+	assert.NoError(t, err)
+	assert.Equal(t, 0.25, rate)
+
+	amount = apicommon.NewAmount("1.5")
+	_, err = sampleRate(&v1alpha1.EmitterEventSource{SampleRate: &amount})
+	assert.EqualError(t, err, "sampleRate must be between 0 and 1")
+
+	amount = apicommon.NewAmount("often")
+	_, err = sampleRate(&v1alpha1.EmitterEventSource{SampleRate: &amount})
+	assert.Error(t, err)
+}
+
+func TestDispatchMiddlewares(t *testing.T) {
+	el := &EventListener{EventSourceName: "emitter", EventName: "example", Metrics: metrics.NewMetrics("test-ns")}
+	// all the events are dispatched without sample rate
+	assert.Empty(t, el.DispatchMiddlewares())
+	amount := apicommon.NewAmount("1")
+	el.EmitterEventSource.SampleRate = &amount
+	assert.Empty(t, el.DispatchMiddlewares())
+
+	dispatched := 0
+	dispatch := func([]byte, ...eventsourcecommon.Options) error {
+		dispatched++
+		return nil
+	}
+	amount = apicommon.NewAmount("0")
+	for _, envelopeVersion := range []string{"", events.EventEnvelopeSpecVersion} {
+		el.EmitterEventSource.EnvelopeVersion = envelopeVersion
+		sampled := eventsourcecommon.ChainDispatch(dispatch, el.DispatchMiddlewares()...)
+		dispatched = 0
+		message, _, err := marshalEvent(&events.EmitterEventData{Type: eventTypeMessage}, envelopeVersion, "emitter", "example", time.Now())
+		assert.NoError(t, err)
+		assert.Equal(t, eventsourcecommon.ErrSampledOut, sampled(message))
+		// only the messages are sampled out
+		for _, eventType := range []string{eventTypeHeartbeat, eventTypeConnection, eventTypeLastWill, eventTypeError, eventTypePresence} {
+			event, _, err := marshalEvent(&events.EmitterEventData{Type: eventType}, envelopeVersion, "emitter", "example", time.Now())
+			assert.NoError(t, err)
+			assert.NoError(t, sampled(event), eventType)
+		}
+		assert.Equal(t, 5, dispatched)
+	}
+}
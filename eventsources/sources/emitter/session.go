@@ -0,0 +1,618 @@
+/*
+Copyright 2020 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"time"
+
+	emitter "github.com/emitter-io/go/v2"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	metrics "github.com/argoproj/argo-events/metrics"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// sessionClient is the client of a session, implemented by the lease of the shared client
+type sessionClient interface {
+	subscriber
+	Unsubscribe(key string, channel string) error
+	Publish(key string, channel string, payload interface{}, options ...emitter.Option) error
+	Broker() string
+	IsConnected() bool
+}
+
+// deadLetter is the message republished to the dead letter channel for a message that failed to be converted into an event.
+type deadLetter struct {
+	// Channel the message was received on
+	Channel string `json:"channel"`
+	// Topic of the message
+	Topic string `json:"topic"`
+	// Error is the reason of the failure
+	Error string `json:"error"`
+	// Payload is the raw payload of the message
+	Payload []byte `json:"payload"`
+}
+
+// session is a run of the event listener, from the connection to the brokers to the drain on shutdown. It holds
+// what the message callbacks, the connection handlers and the background loops of the run share.
+type session struct {
+	el          *EventListener
+	eventSource *v1alpha1.EmitterEventSource
+	// ctx is the context of the event source, done once it is stopped
+	ctx      context.Context
+	log      *zap.SugaredLogger
+	clock    eventsourcecommon.Clock
+	client   sessionClient
+	clientID string
+	metadata map[string]string
+	// dispatch dispatches the events to the eventbus, bounded by the dispatch timeout if set
+	dispatch func([]byte, ...eventsourcecommon.Options) error
+
+	// dispatching tracks the events being dispatched and the acknowledgements being published
+	dispatching *inflight
+	// workers processes the messages concurrently, nil if they are processed by the message callback
+	workers *workerPool
+	// buffer holds the events to dispatch with the buffer backpressure policy, nil with the other policies
+	buffer *eventBuffer
+	// the events the eventbus fails to accept are retried until the drain completes, rather than until the event
+	// source is stopped, so that they get a chance to be dispatched on shutdown.
+	redispatchCtx     context.Context
+	stopRedispatching context.CancelFunc
+	// the events are dispatched at most once unless the failed dispatches are retried
+	maxDispatchRetries   int
+	dispatchRetryBackoff wait.Backoff
+	drainTimeout         time.Duration
+	heartbeatInterval    time.Duration
+
+	topics  *topicFilter
+	limiter *eventsourcecommon.TokenBucket
+
+	subs *subscriptions
+	keys *keyCache
+	// subscribed is set once the channels are subscribed, connects counts the connections to the broker.
+	subscribed, connects int32
+	// reconnectFailed and keyGenDenied receive the failures which stop the event source
+	reconnectFailed chan error
+	keyGenDenied    chan error
+}
+
+// newSession returns the session of the event listener with the client, the settings of the dispatch being parsed
+// from the event source. The dispatch of the buffered events is started if the backpressure policy is buffer, it
+// runs until stopRedispatching is called.
+func newSession(ctx context.Context, el *EventListener, client sessionClient, clientID string, metadata map[string]string,
+	dispatch func([]byte, ...eventsourcecommon.Options) error, log *zap.SugaredLogger) (*session, error) {
+	eventSource := &el.EmitterEventSource
+	s := &session{
+		el:              el,
+		eventSource:     eventSource,
+		ctx:             ctx,
+		log:             log,
+		clock:           el.clock(),
+		client:          client,
+		clientID:        clientID,
+		metadata:        metadata,
+		dispatching:     &inflight{},
+		drainTimeout:    defaultDrainTimeout,
+		subs:            newSubscriptions(client, eventSource.Presence),
+		reconnectFailed: make(chan error, 1),
+		keyGenDenied:    make(chan error, 1),
+	}
+	if eventSource.DrainTimeout != "" {
+		d, err := time.ParseDuration(eventSource.DrainTimeout)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the drain timeout %s", eventSource.DrainTimeout)
+		}
+		s.drainTimeout = d
+	}
+	heartbeatInterval, err := eventsourcecommon.HeartbeatInterval(eventSource.Heartbeat)
+	if err != nil {
+		return nil, err
+	}
+	s.heartbeatInterval = heartbeatInterval
+	dispatchTimeout, err := eventsourcecommon.DispatchTimeout(eventSource.DispatchTimeout, defaultDispatchTimeout)
+	if err != nil {
+		return nil, err
+	}
+	// a hanging eventbus fails the dispatch once the timeout elapses rather than holding the message callback back
+	s.dispatch = eventsourcecommon.DispatchWithTimeout(dispatchTimeout, dispatch)
+
+	if eventSource.DispatchRetry != nil {
+		s.maxDispatchRetries = int(eventSource.DispatchRetry.MaxRetries)
+	}
+	if s.dispatchRetryBackoff, err = newDispatchRetryBackoff(eventSource.DispatchRetry); err != nil {
+		return nil, errors.Wrap(err, "failed to parse the dispatch retry backoff")
+	}
+
+	if s.topics, err = newTopicFilter(eventSource.TopicAllow, eventSource.TopicDeny); err != nil {
+		return nil, err
+	}
+	if s.topics != nil {
+		log.Infow("filtering the topics", zap.Strings("topicAllow", eventSource.TopicAllow), zap.Strings("topicDeny", eventSource.TopicDeny))
+	}
+
+	// the messages are sampled by the middleware of the dispatch, see DispatchMiddlewares
+	rate, err := sampleRate(eventSource)
+	if err != nil {
+		return nil, err
+	}
+	if rate < 1 {
+		log.Infow("sampling the messages", zap.Float64("sampleRate", rate))
+	}
+	if eventSource.MaxEventsPerSecond > 0 {
+		log.Infow("rate limiting the messages", zap.Int32("maxEventsPerSecond", eventSource.MaxEventsPerSecond), zap.String("overflowPolicy", eventSource.OverflowPolicy))
+		s.limiter = eventsourcecommon.NewTokenBucket(float64(eventSource.MaxEventsPerSecond), int(eventSource.MaxEventsPerSecond))
+	}
+
+	if s.workers = newWorkerPool(eventSource.Concurrency); s.workers != nil {
+		log.Infow("processing the messages concurrently", zap.Int32("concurrency", eventSource.Concurrency))
+	}
+	s.redispatchCtx, s.stopRedispatching = context.WithCancel(context.Background())
+	if eventSource.BackpressurePolicy == backpressurePolicyBuffer {
+		log.Infow("buffering the events", zap.Int32("bufferSize", eventSource.BufferSize))
+		s.buffer = newEventBuffer(int(eventSource.BufferSize), func(buffered int) {
+			el.Metrics.BufferHighWaterMark(el.GetEventSourceName(), el.GetEventName(), buffered)
+		})
+		go s.buffer.run(s.redispatchCtx)
+	}
+	return s, nil
+}
+
+// handlers returns the handlers of the connection events of the client
+func (s *session) handlers() clientHandlers {
+	handlers := clientHandlers{
+		connected:          s.onConnect,
+		disconnected:       s.onDisconnect,
+		reconnectAttempted: s.onReconnectAttempted,
+		reconnectFailed:    s.onReconnectFailed,
+	}
+	if s.eventSource.Presence {
+		handlers.presence = s.onPresence
+	}
+	return handlers
+}
+
+func (s *session) onConnect() {
+	el := s.el
+	s.log.Infow("connected to the broker", zap.String("broker", s.client.Broker()))
+	el.SetConnected(true)
+	if atomic.AddInt32(&s.connects, 1) > 1 {
+		// the broker forgot the subscriptions along with the lost connection
+		s.log.Debugw("subscribing again after reconnecting", zap.Int("channels", len(s.subs.list())))
+		resubscribed, errs := s.subs.resubscribeAll()
+		for _, err := range errs {
+			s.log.Errorw("failed to subscribe again after reconnecting", zap.Error(err))
+			el.SetError(err)
+			el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonSubscribe)
+			s.dispatchError(errorStageSubscribe, "", err, false)
+		}
+		if resubscribed > 0 {
+			s.log.Infow("subscribed again after reconnecting", zap.Int("channels", resubscribed))
+			el.Metrics.Resubscribed(el.GetEventSourceName(), el.GetEventName())
+		}
+	}
+	if atomic.LoadInt32(&s.subscribed) == 1 {
+		el.Metrics.EventSourceActive(el.GetEventSourceName(), el.GetEventName(), true)
+	}
+	if s.eventSource.EmitLifecycleEvents {
+		s.dispatchEvent(s.lifecycleEvent(connectionStateConnected, nil), nil)
+	}
+}
+
+func (s *session) onDisconnect(err error) {
+	el := s.el
+	s.log.Errorw("lost the connection to the broker", zap.String("broker", s.client.Broker()), zap.Error(err))
+	el.SetConnected(false)
+	if err != nil {
+		el.SetError(err)
+	}
+	el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonConnection)
+	el.Metrics.EventSourceActive(el.GetEventSourceName(), el.GetEventName(), false)
+	if s.eventSource.EmitLifecycleEvents {
+		s.dispatchEvent(s.lifecycleEvent(connectionStateDisconnected, err), nil)
+	}
+}
+
+// onReconnectAttempted and onReconnectFailed are notified of the reconnections of the shared client, which fails
+// over to the next brokers until one of them is connected. The event source stops once the reconnect attempts are
+// exhausted.
+func (s *session) onReconnectAttempted(err error) {
+	s.el.Metrics.ReconnectAttempted(s.el.GetEventSourceName(), s.el.GetEventName())
+	s.el.Metrics.ConnectionAttempt(s.el.GetEventSourceName(), s.el.GetEventName(), err == nil)
+}
+
+func (s *session) onReconnectFailed(err error) {
+	s.el.SetError(err)
+	s.el.Metrics.EventProcessingFailedWithReason(s.el.GetEventSourceName(), s.el.GetEventName(), metrics.FailureReasonConnection)
+	select {
+	case s.reconnectFailed <- err:
+	default:
+	}
+}
+
+func (s *session) onPresence(presence emitter.PresenceEvent) {
+	s.el.EventReceived()
+	defer s.el.processed("", s.clock.Now())
+
+	s.dispatchEvent(presenceEvent(presence, s.metadata), nil)
+}
+
+// lifecycleEvent returns the event of a transition of the connection to the state
+func (s *session) lifecycleEvent(state string, err error) *events.EmitterEventData {
+	data := &events.EmitterConnectionData{
+		State:  state,
+		Broker: s.client.Broker(),
+		Time:   s.clock.Now().UTC(),
+	}
+	if err != nil {
+		data.Error = err.Error()
+	}
+	return &events.EmitterEventData{
+		Type:       eventTypeConnection,
+		Topic:      s.eventSource.ChannelName,
+		Connection: data,
+		Metadata:   s.metadata,
+	}
+}
+
+// dispatchError dispatches an event of type "error", if enabled. The fatal failures are dispatched along with
+// the events being dispatched, by the drain before the event source returns. The failures on shutdown are not
+// dispatched.
+func (s *session) dispatchError(stage, channelName string, reason error, fatal bool) {
+	if !s.eventSource.EmitErrorEvents || s.ctx.Err() != nil {
+		return
+	}
+	topic := channelName
+	if topic == "" {
+		topic = s.eventSource.ChannelName
+	}
+	s.dispatchEvent(&events.EmitterEventData{
+		Type:    eventTypeError,
+		Topic:   topic,
+		Channel: channelName,
+		Error: &events.EmitterErrorData{
+			Stage:  stage,
+			Broker: s.client.Broker(),
+			Error:  reason.Error(),
+			Fatal:  fatal,
+			Time:   s.clock.Now().UTC(),
+		},
+		Metadata: s.metadata,
+	}, nil)
+}
+
+// dispatchEvent dispatches the event, payload is the raw message the event originates from, if any.
+func (s *session) dispatchEvent(event *events.EmitterEventData, payload []byte) {
+	el, eventSource, log := s.el, s.eventSource, s.log
+	if !s.dispatching.start() {
+		log.Infow("event source is shutting down, skip the event", zap.String("type", event.Type))
+		return
+	}
+	defer s.dispatching.done()
+	if eventSource.IncludeOrigin {
+		event.Broker = s.client.Broker()
+		event.ClientID = s.clientID
+	}
+	eventBytes, eventData, err := marshalEvent(event, eventSource.EnvelopeVersion, el.GetEventSourceName(), el.GetEventName(), s.clock.Now())
+	if err != nil {
+		log.Errorw("failed to marshal the event data", zap.String("type", event.Type), zap.Error(err))
+		el.SetError(err)
+		el.failed(event.Topic, metrics.FailureReasonMarshal)
+		s.publishDeadLetter(event, payload, err)
+		return
+	}
+	// an event too large is rejected rather than retried, it would never fit
+	if err := eventsourcecommon.CheckEventSize(len(eventBytes), eventSource.MaxEventSizeBytes); err != nil {
+		log.Errorw("the event is too large, skip the event", zap.String("type", event.Type), zap.Error(err))
+		el.SetError(err)
+		el.failed(event.Topic, metrics.FailureReasonTooLarge)
+		return
+	}
+	el.Metrics.EventPayloadSize(el.GetEventSourceName(), el.GetEventName(), float64(len(eventBytes)))
+	idPayload := payload
+	if idPayload == nil {
+		idPayload = eventData
+	}
+	id := eventsourcecommon.EventID(eventSource.IDStrategy, el.GetEventSourceName(), el.GetEventName(), event.Topic, idPayload)
+	// the options are made once, so that the retries of the event are part of the same trace
+	options := dispatchOptions(id, event, eventSource.TraceHeader)
+	send := func() error {
+		log.Infow("dispatching event on data channel...", zap.String("type", event.Type), zap.String("id", id))
+		return s.dispatch(eventBytes, options...)
+	}
+	s.dispatchWithBackpressure(event, id, len(eventBytes), send)
+}
+
+// publishDeadLetter republishes the payload of the message which failed to be converted into the event to the dead
+// letter channel, if any.
+func (s *session) publishDeadLetter(event *events.EmitterEventData, payload []byte, reason error) {
+	dl := s.eventSource.DeadLetterChannel
+	if dl == nil {
+		return
+	}
+	message, err := json.Marshal(&deadLetter{
+		Channel: event.Channel,
+		Topic:   event.Topic,
+		Error:   reason.Error(),
+		Payload: payload,
+	})
+	if err != nil {
+		s.log.Errorw("failed to marshal the dead letter", zap.String("deadLetterChannel", dl.Name), zap.Error(err))
+		return
+	}
+	// publishing waits for the broker acknowledgement, it must not block the subscription callback.
+	go func() {
+		if err := s.client.Publish(dl.Key, dl.Name, message); err != nil {
+			s.log.Errorw("failed to publish to the dead letter channel", zap.String("deadLetterChannel", dl.Name), zap.Error(err))
+		}
+	}()
+}
+
+// messageHandler returns the callback of the messages of the channel. The messages of the topics filtered out and
+// the ones over the rate limit are dropped, the others are processed by the callback or by a worker.
+func (s *session) messageHandler(channelName string) emitter.MessageHandler {
+	return func(_ *emitter.Client, message emitter.Message) {
+		s.el.EventReceived()
+		if s.topics != nil && !s.topics.accepts(message.Topic()) {
+			s.log.Debugw("the topic is filtered out, drop the message", zap.String("channelName", channelName), zap.String("topic", message.Topic()))
+			s.el.Metrics.EventsFiltered(s.el.GetEventSourceName(), s.el.GetEventName())
+			return
+		}
+		if !s.admit(channelName) {
+			return
+		}
+		if s.workers == nil {
+			s.processMessage(channelName, message)
+			return
+		}
+		// the message is processed by a worker, the callback only blocks while all the workers are busy
+		if !s.workers.submit(func() { s.processMessage(channelName, message) }) {
+			s.log.Infow("event source is shutting down, skip the message", zap.String("channelName", channelName))
+		}
+	}
+}
+
+// processMessage converts the message received on the channel into an event and dispatches it. The messages
+// which can't be converted are republished to the dead letter channel, if any.
+func (s *session) processMessage(channelName string, message emitter.Message) {
+	el, eventSource, log := s.el, s.eventSource, s.log
+	defer el.processed(message.Topic(), s.clock.Now())
+
+	payload := message.Payload()
+	log.Debugw("received a message", zap.String("channelName", channelName), zap.String("topic", message.Topic()), zap.Int("bytes", len(payload)))
+	// the payload is checked before the event is built, so that an oversized message isn't copied around
+	if err := eventsourcecommon.CheckEventSize(len(payload), eventSource.MaxEventSizeBytes); err != nil {
+		log.Errorw("the message is too large, skip the message", zap.String("topic", message.Topic()), zap.Error(err))
+		el.failed(message.Topic(), metrics.FailureReasonTooLarge)
+		el.SetError(err)
+		return
+	}
+	event := &events.EmitterEventData{
+		Type:        eventTypeMessage,
+		Topic:       message.Topic(),
+		Channel:     channelName,
+		TopicParams: topicParams(channelName, message.Topic()),
+		Metadata:    s.metadata,
+	}
+	body, err := decompress(eventSource.Compression, payload, eventSource.MaxEventSizeBytes)
+	if err != nil {
+		log.Errorw("failed to decompress the message", zap.String("topic", message.Topic()), zap.Error(err))
+		el.failed(message.Topic(), metrics.FailureReasonDecompress)
+		el.SetError(err)
+		s.publishDeadLetter(event, payload, err)
+		return
+	}
+	if eventSource.PayloadEncoding == payloadEncodingConfluentAvro {
+		schemaID, rest, err := decodeSchemaID(body)
+		if err != nil {
+			log.Errorw("failed to decode the schema ID of the message", zap.String("topic", message.Topic()), zap.Error(err))
+			el.failed(message.Topic(), metrics.FailureReasonValidation)
+			el.SetError(err)
+			s.publishDeadLetter(event, payload, err)
+			return
+		}
+		event.SchemaID = schemaID
+		body = rest
+	}
+	event.Body = body
+	if msg, ok := message.(mqttMessage); ok {
+		event.Retained = msg.Retained()
+		event.MessageID = int(msg.MessageID())
+	}
+	if eventSource.JSONBody {
+		if eventSource.ValidateJSON {
+			if offset, err := validateJSON(body); err != nil {
+				log.Errorw("the message body is not valid JSON, skip the message", zap.String("topic", message.Topic()),
+					zap.Int64("offset", offset), zap.Error(err))
+				el.failed(message.Topic(), metrics.FailureReasonValidation)
+				el.SetError(err)
+				s.publishDeadLetter(event, payload, err)
+				return
+			}
+		}
+		event.Body = (*json.RawMessage)(&body)
+	}
+	s.dispatchEvent(event, payload)
+}
+
+// subscribeChannels subscribes to the channels, with the keys generated from the master key if any. A channel
+// failing to subscribe is logged and skipped so that it doesn't prevent the others from working. It fails if the
+// broker denies the key generation, which is dispatched as a fatal error.
+func (s *session) subscribeChannels(options []emitter.Option) error {
+	el, log := s.el, s.log
+	for _, channel := range channels(s.eventSource) {
+		channelName := channel.Name
+		if s.keys != nil {
+			key, err := s.keys.get(channelName)
+			if err != nil {
+				el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonAuth)
+				el.SetError(err)
+				if errors.Is(err, errKeyGenDenied) {
+					s.dispatchError(errorStageSubscribe, channelName, err, true)
+					return err
+				}
+				log.Errorw("failed to generate the channel key", zap.String("channelName", channelName), zap.Error(err))
+				s.dispatchError(errorStageSubscribe, channelName, err, false)
+				continue
+			}
+			channel.Key = key
+		}
+		log.Infow("subscribing to the channel", zap.String("channelName", channelName))
+		log.Debugw("subscription options", zap.String("channelName", channelName), zap.Bool("withHistory", len(options) > 0),
+			zap.Bool("presence", s.eventSource.Presence), zap.Bool("generatedKey", s.keys != nil))
+		if err := s.subs.subscribe(channel, s.messageHandler(channelName), options...); err != nil {
+			log.Errorw("failed to subscribe to the channel", zap.String("channelName", channelName), zap.Error(err))
+			el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonSubscribe)
+			s.dispatchError(errorStageSubscribe, channelName, err, false)
+			continue
+		}
+
+		if s.eventSource.Presence {
+			log.Infow("subscribing to the presence notifications", zap.String("channelName", channelName))
+			if err := s.client.Presence(channel.Key, channelName, true, true); err != nil {
+				log.Errorw("failed to subscribe to the presence notifications", zap.String("channelName", channelName), zap.Error(err))
+			}
+		}
+	}
+	return nil
+}
+
+// subscribeLastWill subscribes to the last-will topic. The topic is recorded along with the channels, so that it is
+// subscribed again on reconnect, with a regenerated key, and unsubscribed on shutdown.
+func (s *session) subscribeLastWill() {
+	el, log := s.el, s.log
+	lastWill := lastWillChannel(s.eventSource)
+	if s.keys != nil {
+		key, err := s.keys.get(lastWill.Name)
+		if err != nil {
+			log.Errorw("failed to generate the key of the last-will topic", zap.String("lastWillTopic", lastWill.Name), zap.Error(err))
+			el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonAuth)
+			el.SetError(err)
+			return
+		}
+		lastWill.Key = key
+	}
+	log.Infow("subscribing to the last-will topic", zap.String("lastWillTopic", lastWill.Name))
+	if err := s.subs.subscribeWithoutPresence(lastWill, func(_ *emitter.Client, message emitter.Message) {
+		el.EventReceived()
+		defer el.processed(message.Topic(), s.clock.Now())
+		log.Infow("received a last-will message", zap.String("topic", message.Topic()))
+		s.dispatchEvent(lastWillEvent(lastWill.Name, message, s.eventSource.JSONBody, s.metadata), message.Payload())
+	}); err != nil {
+		log.Errorw("failed to subscribe to the last-will topic", zap.String("lastWillTopic", lastWill.Name), zap.Error(err))
+		el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonSubscribe)
+	}
+}
+
+// unsubscribe unsubscribes from the channels and their presence notifications
+func (s *session) unsubscribe() {
+	for _, channel := range s.subs.list() {
+		if s.subs.hasPresence(channel.Name) {
+			s.log.Infow("event source stopped, unsubscribe the presence notifications", zap.String("channelName", channel.Name))
+			if err := s.client.Presence(channel.Key, channel.Name, false, false); err != nil {
+				s.log.Errorw("failed to unsubscribe the presence notifications", zap.String("channelName", channel.Name), zap.Error(err))
+			}
+		}
+
+		s.log.Infow("event source stopped, unsubscribe the channel", zap.String("channelName", channel.Name))
+		if err := s.client.Unsubscribe(channel.Key, channel.Name); err != nil {
+			s.log.Errorw("failed to unsubscribe", zap.String("channelName", channel.Name), zap.Error(err))
+		}
+	}
+}
+
+// pingHealth records the connection still up after a while as a successful ping, the client pings the broker on
+// its own to keep the connection alive. It runs until the event source is stopped.
+func (s *session) pingHealth() {
+	ticker := time.NewTicker(healthPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if s.client.IsConnected() {
+				s.el.Pinged()
+			}
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// refreshKeys regenerates the generated keys before they expire and subscribes to the channels again with them,
+// so that the subscriptions issued again on reconnect are still authorized. It runs until the event source is
+// stopped, or until the broker denies the key generation, which is sent to keyGenDenied.
+func (s *session) refreshKeys() {
+	el, log := s.el, s.log
+	for {
+		wait := time.Until(s.keys.nextRefresh())
+		if wait < keyGenRetryInterval {
+			wait = keyGenRetryInterval
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-s.ctx.Done():
+			timer.Stop()
+			return
+		}
+		for _, channel := range s.subs.list() {
+			key, err := s.keys.get(channel.Name)
+			if err != nil {
+				el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonAuth)
+				el.SetError(err)
+				if errors.Is(err, errKeyGenDenied) {
+					s.keyGenDenied <- err
+					return
+				}
+				log.Errorw("failed to regenerate the channel key", zap.String("channelName", channel.Name), zap.Error(err))
+				continue
+			}
+			if key == channel.Key {
+				continue
+			}
+			log.Infow("subscribing to the channel with the regenerated key", zap.String("channelName", channel.Name))
+			channel.Key = key
+			if err := s.subs.resubscribe(channel); err != nil {
+				log.Errorw("failed to subscribe to the channel with the regenerated key", zap.String("channelName", channel.Name), zap.Error(err))
+				el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonSubscribe)
+				s.dispatchError(errorStageSubscribe, channel.Name, err, false)
+			}
+		}
+	}
+}
+
+// startHeartbeat dispatches the heartbeat events, if enabled, until the returned function is called
+func (s *session) startHeartbeat() func() {
+	if s.heartbeatInterval <= 0 {
+		return func() {}
+	}
+	s.log.Infow("dispatching the heartbeat events", zap.Duration("interval", s.heartbeatInterval))
+	return eventsourcecommon.StartHeartbeat(s.ctx, s.heartbeatInterval, func(heartbeat events.HeartbeatData) {
+		s.dispatchEvent(&events.EmitterEventData{
+			Type:      eventTypeHeartbeat,
+			Topic:     s.eventSource.ChannelName,
+			Heartbeat: &heartbeat,
+			Metadata:  s.metadata,
+		}, nil)
+	})
+}
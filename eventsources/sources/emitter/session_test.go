@@ -0,0 +1,188 @@
+/*
+Copyright 2020 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	emitter "github.com/emitter-io/go/v2"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	metrics "github.com/argoproj/argo-events/metrics"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// fakeSessionClient is the client of a session connected to the fake broker, it records the published messages
+type fakeSessionClient struct {
+	*fakeBroker
+
+	lock      sync.Mutex
+	published map[string][]string
+}
+
+func newFakeSessionClient() *fakeSessionClient {
+	return &fakeSessionClient{fakeBroker: newFakeBroker(), published: make(map[string][]string)}
+}
+
+func (c *fakeSessionClient) Unsubscribe(key string, channel string) error {
+	c.fakeBroker.lock.Lock()
+	defer c.fakeBroker.lock.Unlock()
+	delete(c.subscribed, channel)
+	return nil
+}
+
+func (c *fakeSessionClient) Publish(key string, channel string, payload interface{}, options ...emitter.Option) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.published[channel] = append(c.published[channel], string(payload.([]byte)))
+	return nil
+}
+
+func (c *fakeSessionClient) messages(channel string) []string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return append([]string(nil), c.published[channel]...)
+}
+
+func (c *fakeSessionClient) Broker() string    { return "tcp://broker:4000" }
+func (c *fakeSessionClient) IsConnected() bool { return true }
+
+// newTestSession returns the session of the event source with the fake client, the events being dispatched to dispatch
+func newTestSession(t *testing.T, eventSource v1alpha1.EmitterEventSource, dispatch func([]byte, ...eventsourcecommon.Options) error) (*session, *fakeSessionClient) {
+	el := &EventListener{
+		EventSourceName:    "emitter",
+		EventName:          "example",
+		EmitterEventSource: eventSource,
+		Metrics:            metrics.NewMetrics("test-ns"),
+	}
+	client := newFakeSessionClient()
+	s, err := newSession(context.Background(), el, client, "client-id", nil, dispatch, zap.NewNop().Sugar())
+	assert.NoError(t, err)
+	t.Cleanup(s.stopRedispatching)
+	return s, client
+}
+
+func TestSessionSubscribeChannels(t *testing.T) {
+	var lock sync.Mutex
+	var dispatched []events.EmitterEventData
+	dispatch := func(data []byte, _ ...eventsourcecommon.Options) error {
+		var event events.EmitterEventData
+		assert.NoError(t, json.Unmarshal(data, &event))
+		lock.Lock()
+		defer lock.Unlock()
+		dispatched = append(dispatched, event)
+		return nil
+	}
+	s, client := newTestSession(t, v1alpha1.EmitterEventSource{
+		ChannelName:       "hello",
+		ChannelKey:        "hello_key",
+		Channels:          []v1alpha1.EmitterChannel{{Name: "world", Key: "world_key"}},
+		JSONBody:          true,
+		ValidateJSON:      true,
+		AckResponse:       &v1alpha1.EmitterAckResponse{AckChannel: &v1alpha1.EmitterChannel{Name: "acks", Key: "acks_key"}},
+		DeadLetterChannel: &v1alpha1.EmitterChannel{Name: "dead", Key: "dead_key"},
+	}, dispatch)
+	assert.NoError(t, s.subscribeChannels(nil))
+	assert.Len(t, s.subs.list(), 2)
+
+	assert.True(t, client.publish("hello", `{"temp":21}`))
+	assert.True(t, client.publish("world", `not json`))
+	s.drain(time.Second)
+
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Len(t, dispatched, 1)
+	assert.Equal(t, eventTypeMessage, dispatched[0].Type)
+	assert.Equal(t, "hello", dispatched[0].Channel)
+	assert.Equal(t, map[string]interface{}{"temp": float64(21)}, dispatched[0].Body)
+	// the dispatched message is acknowledged, the invalid one is republished to the dead letter channel
+	assert.Len(t, client.messages("acks"), 1)
+	assert.Eventually(t, func() bool { return len(client.messages("dead")) == 1 }, time.Second, 10*time.Millisecond)
+}
+
+func TestSessionAdmit(t *testing.T) {
+	dispatch := func([]byte, ...eventsourcecommon.Options) error { return nil }
+	s, _ := newTestSession(t, v1alpha1.EmitterEventSource{ChannelName: "hello"}, dispatch)
+	// no rate limit
+	for i := 0; i < 10; i++ {
+		assert.True(t, s.admit("hello"))
+	}
+
+	s, _ = newTestSession(t, v1alpha1.EmitterEventSource{ChannelName: "hello", MaxEventsPerSecond: 1}, dispatch)
+	assert.True(t, s.admit("hello"))
+	// the messages over the rate limit are dropped
+	assert.False(t, s.admit("hello"))
+}
+
+func TestSessionDispatchBufferFull(t *testing.T) {
+	release := make(chan struct{})
+	var dispatched int32
+	var lock sync.Mutex
+	dispatch := func([]byte, ...eventsourcecommon.Options) error {
+		<-release
+		lock.Lock()
+		defer lock.Unlock()
+		dispatched++
+		return nil
+	}
+	s, _ := newTestSession(t, v1alpha1.EmitterEventSource{ChannelName: "hello", BackpressurePolicy: backpressurePolicyBuffer, BufferSize: 1}, dispatch)
+
+	// the first event is being dispatched, the second one is buffered and the third one is dropped
+	s.dispatchEvent(&events.EmitterEventData{Type: eventTypeMessage, Topic: "hello"}, []byte("1"))
+	assert.Eventually(t, func() bool { return len(s.buffer.dispatches) == 0 }, time.Second, 10*time.Millisecond)
+	s.dispatchEvent(&events.EmitterEventData{Type: eventTypeMessage, Topic: "hello"}, []byte("2"))
+	s.dispatchEvent(&events.EmitterEventData{Type: eventTypeMessage, Topic: "hello"}, []byte("3"))
+	close(release)
+	s.drain(time.Second)
+
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, int32(2), dispatched)
+}
+
+func TestSessionDispatchSampledOut(t *testing.T) {
+	eventSource := v1alpha1.EmitterEventSource{
+		ChannelName: "hello",
+		AckResponse: &v1alpha1.EmitterAckResponse{AckChannel: &v1alpha1.EmitterChannel{Name: "acks", Key: "acks_key"}},
+	}
+	amount := apicommon.NewAmount("0")
+	eventSource.SampleRate = &amount
+	el := &EventListener{EventSourceName: "emitter", EventName: "example", EmitterEventSource: eventSource, Metrics: metrics.NewMetrics("test-ns")}
+	dispatched := 0
+	dispatch := eventsourcecommon.ChainDispatch(func([]byte, ...eventsourcecommon.Options) error {
+		dispatched++
+		return nil
+	}, el.DispatchMiddlewares()...)
+	for _, policy := range []string{"", backpressurePolicyBlock, backpressurePolicyBuffer} {
+		eventSource.BackpressurePolicy = policy
+		s, client := newTestSession(t, eventSource, dispatch)
+		s.dispatchEvent(&events.EmitterEventData{Type: eventTypeMessage, Topic: "hello"}, []byte("1"))
+		s.dispatchEvent(&events.EmitterEventData{Type: eventTypeHeartbeat, Topic: "hello"}, nil)
+		s.drain(time.Second)
+		// the message sampled out is neither retried nor acknowledged, the heartbeat is dispatched
+		assert.Empty(t, client.messages("acks"), policy)
+	}
+	assert.Equal(t, 3, dispatched)
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"time"
+
+	emitter "github.com/emitter-io/go/v2"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/argoproj/argo-events/common"
+	metrics "github.com/argoproj/argo-events/metrics"
+)
+
+// clientSettings are the settings the emitter client is created with, resolved from the event source and its secrets
+type clientSettings struct {
+	brokers []string
+	options []func(client *emitter.Client)
+	// username and password are the credentials of the connection, if any
+	username, password string
+	// masterKey is the key the channel keys are generated from, with the validity keyTTL, if KeyGen is set
+	masterKey string
+	keyTTL    time.Duration
+}
+
+// resolveClientSettings resolves the settings of the client, reading the credentials and the master key with the resolver.
+func (el *EventListener) resolveClientSettings(resolver common.SecretResolver, log *zap.SugaredLogger) (*clientSettings, error) {
+	eventSource := &el.EmitterEventSource
+	var options []func(client *emitter.Client)
+	if eventSource.TLS != nil {
+		tlsConfig, err := common.GetTLSConfig(eventSource.TLS, log)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get the tls configuration")
+		}
+		options = append(options, emitter.WithTLSConfig(tlsConfig))
+	}
+
+	broker := eventSource.Broker
+	var username, password string
+	if eventSource.ConnectionStringSecret != nil {
+		connectionString, err := resolver.Resolve(eventSource.ConnectionStringSecret)
+		if err != nil {
+			el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonAuth)
+			return nil, errors.Wrapf(common.RedactError(err), "failed to retrieve the connection string from %s", eventSource.ConnectionStringSecret.Name)
+		}
+		conn, err := parseConnectionString(connectionString)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the connection string from %s", eventSource.ConnectionStringSecret.Name)
+		}
+		// the connection string overrides the broker and the credentials
+		log.Infow("connecting to the broker of the connection string", zap.String("broker", conn.broker))
+		broker = conn.broker
+		username, password = conn.username, conn.password
+		options = append(options, emitter.WithUsername(username), emitter.WithPassword(password))
+	}
+	var brokers []string
+	if broker != "" {
+		brokers = append(brokers, broker)
+	}
+	brokers = append(brokers, eventSource.Brokers...)
+	// the client doesn't reconnect on its own, so that the reconnections are retried per the connection backoff and
+	// given up on after the max reconnect attempts, each one connecting a new client to the next broker.
+	options = append(options, emitter.WithClientID(el.ClientID()), emitter.WithAutoReconnect(false))
+	if eventSource.ConnectTimeout != "" {
+		connectTimeout, err := time.ParseDuration(eventSource.ConnectTimeout)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the connect timeout %s", eventSource.ConnectTimeout)
+		}
+		options = append(options, emitter.WithConnectTimeout(connectTimeout))
+	}
+	if eventSource.KeepAlive != "" {
+		keepAlive, err := time.ParseDuration(eventSource.KeepAlive)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the keep alive %s", eventSource.KeepAlive)
+		}
+		options = append(options, emitter.WithKeepAlive(keepAlive))
+	}
+
+	if eventSource.Username != nil && eventSource.ConnectionStringSecret == nil {
+		resolved, err := resolver.Resolve(eventSource.Username)
+		if err != nil {
+			el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonAuth)
+			return nil, errors.Wrapf(common.RedactError(err), "failed to retrieve the username from %s", eventSource.Username.Name)
+		}
+		username = resolved
+		options = append(options, emitter.WithUsername(username))
+	}
+
+	if eventSource.Password != nil && eventSource.ConnectionStringSecret == nil {
+		resolved, err := resolver.Resolve(eventSource.Password)
+		if err != nil {
+			el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonAuth)
+			return nil, errors.Wrapf(common.RedactError(err), "failed to retrieve the password from %s", eventSource.Password.Name)
+		}
+		password = resolved
+		options = append(options, emitter.WithPassword(password))
+	}
+
+	var masterKey string
+	keyTTL := defaultKeyTTL
+	if keyGen := eventSource.KeyGen; keyGen != nil {
+		key, err := resolver.Resolve(keyGen.MasterKey)
+		if err != nil {
+			el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonAuth)
+			return nil, errors.Wrapf(common.RedactError(err), "failed to retrieve the master key from %s", keyGen.MasterKey.Name)
+		}
+		masterKey = key
+		if keyGen.TTL != "" {
+			d, err := time.ParseDuration(keyGen.TTL)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse the key ttl %s", keyGen.TTL)
+			}
+			keyTTL = d
+		}
+	}
+
+	return &clientSettings{
+		brokers:   brokers,
+		options:   options,
+		username:  username,
+		password:  password,
+		masterKey: masterKey,
+		keyTTL:    keyTTL,
+	}, nil
+}
@@ -0,0 +1,432 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	emitter "github.com/emitter-io/go/v2"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/argoproj/argo-events/common"
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+)
+
+// clients are the clients shared by the listeners connecting to the same brokers with the same settings
+var clients = eventsourcecommon.NewPool()
+
+// acquireClient returns the client shared by the listeners connecting to the same brokers with the same settings,
+// the first one creates it. The returned function releases it, the last listener releasing it closes it.
+func (el *EventListener) acquireClient(settings *clientSettings, log *zap.SugaredLogger) (*sharedClient, func(), error) {
+	eventSource := &el.EmitterEventSource
+	tlsSettings, err := json.Marshal(eventSource.TLS)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to marshal the tls configuration")
+	}
+	backoffSettings, err := json.Marshal(eventSource.ConnectionBackoff)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to marshal the connection backoff")
+	}
+	key := append([]string{settings.username, settings.password, string(tlsSettings), eventSource.ConnectTimeout, eventSource.KeepAlive,
+		strconv.Itoa(int(eventSource.MaxReconnectAttempts)), string(backoffSettings)}, settings.brokers...)
+	pooled, release, err := clients.Acquire(eventsourcecommon.PoolKey(key...), func() (io.Closer, error) {
+		log.Infow("creating a client", zap.Strings("brokers", settings.brokers))
+		conn := newFailover(settings.brokers, settings.options)
+		shared := newSharedClient(conn, el.ClientID(), eventSource.ConnectionBackoff, eventSource.MaxReconnectAttempts, log.With("clientId", el.ClientID()))
+		conn.setup = shared.setup
+		return shared, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return pooled.(*sharedClient), func() {
+		if err := release(); err != nil {
+			log.Errorw("failed to release the client", zap.Error(err))
+		}
+	}, nil
+}
+
+// brokerConnection is the connection to the brokers, it is implemented by failover
+type brokerConnection interface {
+	subscriber
+	Unsubscribe(key string, channel string) error
+	Publish(key string, channel string, payload interface{}, options ...emitter.Option) error
+	GenerateKey(key, channel, permissions string, ttl int) (string, error)
+	IsConnected() bool
+	Broker() string
+	connect() error
+	disconnect()
+}
+
+// clientHandlers are the handlers of the connection events of a listener sharing a client
+type clientHandlers struct {
+	connected    func()
+	disconnected func(err error)
+	presence     func(presence emitter.PresenceEvent)
+	// reconnectAttempted is called after each attempt to reconnect, with its error if it failed
+	reconnectAttempted func(err error)
+	reconnectFailed    func(err error)
+}
+
+// sharedClient is a connection shared by several listeners. The client calls a single handler per event and per
+// channel, so the shared client registers its own ones, which fan the events out: the connection events to all
+// the listeners, the messages and the presence notifications of a channel to the listeners subscribed to it.
+// The failover to another broker, and the reconnections, are made once by the shared client rather than by each
+// listener.
+type sharedClient struct {
+	conn     brokerConnection
+	clientID string
+	backoff  *apicommon.Backoff
+	// maxReconnectAttempts is how many times in a row the client attempts to reconnect, unlimited if not positive
+	maxReconnectAttempts int32
+	log                  *zap.SugaredLogger
+	ctx                  context.Context
+	cancel               context.CancelFunc
+
+	// connectLock serializes the connections, so that the listeners connecting at once make a single connection
+	connectLock sync.Mutex
+
+	lock   sync.Mutex
+	nextID int
+	leases map[int]*clientLease
+	// channels are the message handlers of the listeners by channel
+	channels map[string]map[int]emitter.MessageHandler
+}
+
+func newSharedClient(conn brokerConnection, clientID string, backoff *apicommon.Backoff, maxReconnectAttempts int32, log *zap.SugaredLogger) *sharedClient {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &sharedClient{
+		conn:                 conn,
+		clientID:             clientID,
+		backoff:              backoff,
+		maxReconnectAttempts: maxReconnectAttempts,
+		log:                  log,
+		ctx:                  ctx,
+		cancel:               cancel,
+		leases:               make(map[int]*clientLease),
+		channels:             make(map[string]map[int]emitter.MessageHandler),
+	}
+}
+
+// setup registers the handlers of the shared client on a new emitter client
+func (s *sharedClient) setup(c *emitter.Client) {
+	c.OnConnect(s.onConnect)
+	c.OnDisconnect(s.onDisconnect)
+	c.OnPresence(s.onPresence)
+}
+
+// join returns the lease of a new listener of the client
+func (s *sharedClient) join() *clientLease {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.nextID++
+	lease := &clientLease{shared: s, id: s.nextID}
+	s.leases[lease.id] = lease
+	return lease
+}
+
+// connect connects to the next broker unless connected already
+func (s *sharedClient) connect() error {
+	s.connectLock.Lock()
+	defer s.connectLock.Unlock()
+	if s.conn.IsConnected() {
+		return nil
+	}
+	if err := s.conn.connect(); err != nil {
+		s.log.Errorw("failed to connect to the broker", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// reconnect fails over to the next brokers until one of them is connected, or until the reconnect attempts are
+// exhausted. The listeners are notified of each attempt, and of the failure to reconnect which stops them.
+func (s *sharedClient) reconnect() {
+	attempts := 0
+	attempt := func() error {
+		attempts++
+		s.log.Infow("reconnecting to the brokers", zap.Int("attempt", attempts), zap.Int32("maxAttempts", s.maxReconnectAttempts))
+		err := s.connect()
+		for _, lease := range s.started() {
+			lease.handlers.reconnectAttempted(err)
+		}
+		return err
+	}
+	backoff := reconnectBackoff(s.backoff, s.maxReconnectAttempts)
+	if err := common.ConnectWithMaxDelay(s.ctx, backoff, maxReconnectDelay, attempt); err != nil && s.ctx.Err() == nil {
+		s.log.Errorw("failed to reconnect to any of the brokers, giving up", zap.Int("attempts", attempts), zap.Error(err))
+		for _, lease := range s.started() {
+			lease.handlers.reconnectFailed(errors.Wrapf(err, "failed to reconnect to the brokers after %d attempts", attempts))
+		}
+	}
+}
+
+// maxReconnectDelay caps the delay between the reconnect attempts, which the factor of the connection backoff would
+// grow without bound with the unlimited attempts
+const maxReconnectDelay = time.Minute
+
+// reconnectBackoff returns the backoff of the reconnections: the connection backoff whose steps are the maximum
+// reconnect attempts, unlimited if not positive. The delay between the attempts is capped to maxReconnectDelay.
+func reconnectBackoff(backoff *apicommon.Backoff, maxAttempts int32) *apicommon.Backoff {
+	result := common.DefaultBackoff
+	if backoff != nil {
+		result = *backoff
+	}
+	result.Steps = maxAttempts
+	if result.Steps <= 0 {
+		result.Steps = math.MaxInt32
+	}
+	return &result
+}
+
+// transition records the connection state of the listeners, it returns those whose state changed
+func (s *sharedClient) transition(connected bool, leases ...*clientLease) []*clientLease {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if len(leases) == 0 {
+		for _, lease := range s.leases {
+			leases = append(leases, lease)
+		}
+	}
+	var changed []*clientLease
+	for _, lease := range leases {
+		if lease.started && lease.connected != connected {
+			lease.connected = connected
+			changed = append(changed, lease)
+		}
+	}
+	return changed
+}
+
+// started returns the listeners which connected
+func (s *sharedClient) started() []*clientLease {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var leases []*clientLease
+	for _, lease := range s.leases {
+		if lease.started {
+			leases = append(leases, lease)
+		}
+	}
+	return leases
+}
+
+func (s *sharedClient) onConnect(_ *emitter.Client) {
+	for _, lease := range s.transition(true) {
+		lease.handlers.connected()
+	}
+}
+
+func (s *sharedClient) onDisconnect(_ *emitter.Client, err error) {
+	for _, lease := range s.transition(false) {
+		lease.handlers.disconnected(err)
+	}
+	if s.ctx.Err() == nil {
+		go s.reconnect()
+	}
+}
+
+// onPresence notifies the listeners subscribed to the channel of the notification
+func (s *sharedClient) onPresence(_ *emitter.Client, presence emitter.PresenceEvent) {
+	channel := strings.TrimSuffix(presence.Channel, "/")
+	s.lock.Lock()
+	var leases []*clientLease
+	for name, handlers := range s.channels {
+		if strings.TrimSuffix(name, "/") != channel {
+			continue
+		}
+		for id := range handlers {
+			if lease := s.leases[id]; lease != nil && lease.handlers.presence != nil {
+				leases = append(leases, lease)
+			}
+		}
+	}
+	s.lock.Unlock()
+	for _, lease := range leases {
+		lease.handlers.presence(presence)
+	}
+}
+
+// dispatcher returns the message handler of the channel, which calls the handlers of the listeners subscribed to it
+func (s *sharedClient) dispatcher(channel string) emitter.MessageHandler {
+	return func(c *emitter.Client, message emitter.Message) {
+		s.lock.Lock()
+		handlers := make([]emitter.MessageHandler, 0, len(s.channels[channel]))
+		for _, handler := range s.channels[channel] {
+			handlers = append(handlers, handler)
+		}
+		s.lock.Unlock()
+		for _, handler := range handlers {
+			handler(c, message)
+		}
+	}
+}
+
+func (s *sharedClient) subscribe(id int, key string, channel string, handler emitter.MessageHandler, options ...emitter.Option) error {
+	s.lock.Lock()
+	handlers, ok := s.channels[channel]
+	if !ok {
+		handlers = make(map[int]emitter.MessageHandler)
+		s.channels[channel] = handlers
+	}
+	_, subscribed := handlers[id]
+	if handler != nil {
+		handlers[id] = handler
+	}
+	s.lock.Unlock()
+	if err := s.conn.Subscribe(key, channel, s.dispatcher(channel), options...); err != nil {
+		if !subscribed {
+			s.forget(id, channel)
+		}
+		return err
+	}
+	return nil
+}
+
+// forget removes the handler of the listener for the channel, it returns whether other listeners still subscribe to it
+func (s *sharedClient) forget(id int, channel string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.channels[channel], id)
+	if len(s.channels[channel]) > 0 {
+		return true
+	}
+	delete(s.channels, channel)
+	return false
+}
+
+// sharedWithOthers tells whether other listeners subscribe to the channel
+func (s *sharedClient) sharedWithOthers(id int, channel string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for other := range s.channels[channel] {
+		if other != id {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *sharedClient) leave(id int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.leases, id)
+	for channel, handlers := range s.channels {
+		delete(handlers, id)
+		if len(handlers) == 0 {
+			delete(s.channels, channel)
+		}
+	}
+}
+
+// Close stops reconnecting and disconnects, it is called once released by the last listener
+func (s *sharedClient) Close() error {
+	s.cancel()
+	s.conn.disconnect()
+	return nil
+}
+
+// clientLease is the share of a listener in a shared client
+type clientLease struct {
+	shared   *sharedClient
+	id       int
+	handlers clientHandlers
+	// started is set once the listener connected, connected is the connection state it was notified of
+	started   bool
+	connected bool
+}
+
+// handle sets the handlers of the connection events of the listener, before it connects
+func (l *clientLease) handle(handlers clientHandlers) {
+	l.shared.lock.Lock()
+	defer l.shared.lock.Unlock()
+	l.handlers = handlers
+}
+
+// connect connects the shared client unless connected already, the listener is notified of the connection
+// either way.
+func (l *clientLease) connect() error {
+	l.shared.lock.Lock()
+	l.started = true
+	l.shared.lock.Unlock()
+	if err := l.shared.connect(); err != nil {
+		return err
+	}
+	// the client may be connected already by another listener, which was notified then
+	for _, lease := range l.shared.transition(true, l) {
+		lease.handlers.connected()
+	}
+	return nil
+}
+
+// leave removes the listener from the shared client, its handlers aren't called anymore
+func (l *clientLease) leave() {
+	l.shared.leave(l.id)
+}
+
+// ClientID returns the ID of the shared client, the one of the listener which created it
+func (l *clientLease) ClientID() string {
+	return l.shared.clientID
+}
+
+func (l *clientLease) Broker() string {
+	return l.shared.conn.Broker()
+}
+
+func (l *clientLease) IsConnected() bool {
+	return l.shared.conn.IsConnected()
+}
+
+// Subscribe subscribes to the channel, the messages of the channel are passed to the handler of each listener
+// subscribed to it.
+func (l *clientLease) Subscribe(key string, channel string, optionalHandler emitter.MessageHandler, options ...emitter.Option) error {
+	return l.shared.subscribe(l.id, key, channel, optionalHandler, options...)
+}
+
+// Unsubscribe unsubscribes from the channel once no other listener subscribes to it
+func (l *clientLease) Unsubscribe(key string, channel string) error {
+	if l.shared.forget(l.id, channel) {
+		return nil
+	}
+	return l.shared.conn.Unsubscribe(key, channel)
+}
+
+// Presence subscribes to the presence notifications of the channel, they are unsubscribed from once no other
+// listener subscribes to the channel.
+func (l *clientLease) Presence(key, channel string, status, changes bool) error {
+	if !status && !changes && l.shared.sharedWithOthers(l.id, channel) {
+		return nil
+	}
+	return l.shared.conn.Presence(key, channel, status, changes)
+}
+
+func (l *clientLease) Publish(key string, channel string, payload interface{}, options ...emitter.Option) error {
+	return l.shared.conn.Publish(key, channel, payload, options...)
+}
+
+func (l *clientLease) GenerateKey(key, channel, permissions string, ttl int) (string, error) {
+	return l.shared.conn.GenerateKey(key, channel, permissions, ttl)
+}
@@ -0,0 +1,287 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"io"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	emitter "github.com/emitter-io/go/v2"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/argoproj/argo-events/common"
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// fakeConnection is a connection to the fake broker, it notifies the shared client of the connection events
+type fakeConnection struct {
+	*fakeBroker
+	shared *sharedClient
+
+	lock         sync.Mutex
+	connected    bool
+	connects     int
+	disconnected bool
+	// unreachable fails the connections
+	unreachable bool
+}
+
+func (c *fakeConnection) connect() error {
+	c.lock.Lock()
+	c.connects++
+	if c.unreachable {
+		c.lock.Unlock()
+		return errors.New("connection refused")
+	}
+	c.connected = true
+	c.lock.Unlock()
+	c.shared.onConnect(nil)
+	return nil
+}
+
+func (c *fakeConnection) setUnreachable(unreachable bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.unreachable = unreachable
+}
+
+func (c *fakeConnection) drop() {
+	c.lock.Lock()
+	c.connected = false
+	c.lock.Unlock()
+	c.fakeBroker.disconnect()
+	c.shared.onDisconnect(nil, io.EOF)
+}
+
+func (c *fakeConnection) disconnect() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.connected, c.disconnected = false, true
+}
+
+func (c *fakeConnection) IsConnected() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.connected
+}
+
+func (c *fakeConnection) Unsubscribe(key string, channel string) error {
+	c.fakeBroker.lock.Lock()
+	defer c.fakeBroker.lock.Unlock()
+	delete(c.subscribed, channel)
+	delete(c.handlers, channel)
+	return nil
+}
+
+func (c *fakeConnection) Publish(key string, channel string, payload interface{}, options ...emitter.Option) error {
+	return nil
+}
+
+func (c *fakeConnection) GenerateKey(key, channel, permissions string, ttl int) (string, error) {
+	return channel + "_key", nil
+}
+
+func (c *fakeConnection) Broker() string { return "tcp://broker:4000" }
+
+// fakeListener records the messages and the connection events of a listener sharing the client
+type fakeListener struct {
+	lock      sync.Mutex
+	received  []string
+	connected int
+	lost      int
+	attempts  int
+	failed    error
+}
+
+func (l *fakeListener) handlers() clientHandlers {
+	return clientHandlers{
+		connected: func() {
+			l.lock.Lock()
+			defer l.lock.Unlock()
+			l.connected++
+		},
+		disconnected: func(error) {
+			l.lock.Lock()
+			defer l.lock.Unlock()
+			l.lost++
+		},
+		reconnectAttempted: func(error) {
+			l.lock.Lock()
+			defer l.lock.Unlock()
+			l.attempts++
+		},
+		reconnectFailed: func(err error) {
+			l.lock.Lock()
+			defer l.lock.Unlock()
+			l.failed = err
+		},
+	}
+}
+
+func (l *fakeListener) handler(_ *emitter.Client, message emitter.Message) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.received = append(l.received, message.Topic()+":"+string(message.Payload()))
+}
+
+func TestSharedClient(t *testing.T) {
+	pool := eventsourcecommon.NewPool()
+	conn := &fakeConnection{fakeBroker: newFakeBroker()}
+	created := 0
+	acquire := func() (*clientLease, func() error) {
+		pooled, release, err := pool.Acquire(eventsourcecommon.PoolKey("tcp://broker:4000", "user", "pass"), func() (io.Closer, error) {
+			created++
+			conn.shared = newSharedClient(conn, "first", nil, 0, zap.NewNop().Sugar())
+			return conn.shared, nil
+		})
+		assert.NoError(t, err)
+		return pooled.(*sharedClient).join(), release
+	}
+	first, second := &fakeListener{}, &fakeListener{}
+	firstLease, releaseFirst := acquire()
+	secondLease, releaseSecond := acquire()
+	assert.Equal(t, 1, created)
+	assert.Equal(t, "first", secondLease.ClientID())
+	firstLease.handle(first.handlers())
+	secondLease.handle(second.handlers())
+
+	// a single connection is made, both listeners are notified of it
+	assert.NoError(t, firstLease.connect())
+	assert.NoError(t, secondLease.connect())
+	assert.Equal(t, 1, conn.connects)
+	assert.Equal(t, 1, first.connected)
+	assert.Equal(t, 1, second.connected)
+
+	// each listener receives the messages of its channels, both receive those of a channel they share
+	firstSubs, secondSubs := newSubscriptions(firstLease, false), newSubscriptions(secondLease, false)
+	assert.NoError(t, firstSubs.subscribe(v1alpha1.EmitterChannel{Name: "hello", Key: "hello_key"}, first.handler))
+	assert.NoError(t, firstSubs.subscribe(v1alpha1.EmitterChannel{Name: "both", Key: "both_key"}, first.handler))
+	assert.NoError(t, secondSubs.subscribe(v1alpha1.EmitterChannel{Name: "world", Key: "world_key"}, second.handler))
+	assert.NoError(t, secondSubs.subscribe(v1alpha1.EmitterChannel{Name: "both", Key: "both_key"}, second.handler))
+	assert.True(t, conn.publish("hello", "1"))
+	assert.True(t, conn.publish("world", "2"))
+	assert.True(t, conn.publish("both", "3"))
+	assert.Equal(t, []string{"hello:1", "both:3"}, first.received)
+	assert.Equal(t, []string{"world:2", "both:3"}, second.received)
+
+	// both listeners subscribe again to their channels once the client reconnected
+	conn.setUnreachable(true)
+	conn.drop()
+	assert.Equal(t, 1, first.lost)
+	assert.Equal(t, 1, second.lost)
+	assert.False(t, conn.publish("hello", "lost"))
+	conn.setUnreachable(false)
+	assert.Eventually(t, func() bool {
+		first.lock.Lock()
+		defer first.lock.Unlock()
+		return first.connected == 2
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 2, second.connected)
+	assert.GreaterOrEqual(t, first.attempts, 1)
+	assert.NoError(t, first.failed)
+	_, errs := firstSubs.resubscribeAll()
+	assert.Empty(t, errs)
+	_, errs = secondSubs.resubscribeAll()
+	assert.Empty(t, errs)
+	assert.True(t, conn.publish("hello", "4"))
+	assert.True(t, conn.publish("world", "5"))
+	assert.Equal(t, []string{"hello:1", "both:3", "hello:4"}, first.received)
+	assert.Equal(t, []string{"world:2", "both:3", "world:5"}, second.received)
+
+	// a channel is unsubscribed from once no listener subscribes to it
+	assert.NoError(t, firstLease.Unsubscribe("both_key", "both"))
+	assert.True(t, conn.publish("both", "6"))
+	assert.Equal(t, []string{"hello:1", "both:3", "hello:4"}, first.received)
+	assert.Equal(t, []string{"world:2", "both:3", "world:5", "both:6"}, second.received)
+	assert.NoError(t, secondLease.Unsubscribe("both_key", "both"))
+	assert.False(t, conn.publish("both", "7"))
+
+	// the client is disconnected once released by the last listener
+	firstLease.leave()
+	assert.NoError(t, releaseFirst())
+	assert.False(t, conn.disconnected)
+	assert.True(t, conn.publish("world", "8"))
+	assert.Len(t, first.received, 3)
+	secondLease.leave()
+	assert.NoError(t, releaseSecond())
+	assert.True(t, conn.disconnected)
+	assert.Equal(t, 0, pool.Len())
+}
+
+func TestSharedClientPresence(t *testing.T) {
+	conn := &fakeConnection{fakeBroker: newFakeBroker()}
+	conn.shared = newSharedClient(conn, "first", nil, 0, zap.NewNop().Sugar())
+	var firstPresence, secondPresence []string
+	firstLease, secondLease := conn.shared.join(), conn.shared.join()
+	firstLease.handle(clientHandlers{presence: func(presence emitter.PresenceEvent) {
+		firstPresence = append(firstPresence, presence.Channel)
+	}})
+	secondLease.handle(clientHandlers{presence: func(presence emitter.PresenceEvent) {
+		secondPresence = append(secondPresence, presence.Channel)
+	}})
+	handler := func(*emitter.Client, emitter.Message) {}
+	assert.NoError(t, firstLease.Subscribe("hello_key", "hello/", handler))
+	assert.NoError(t, firstLease.Presence("hello_key", "hello/", true, true))
+	assert.NoError(t, secondLease.Subscribe("world_key", "world/", handler))
+	assert.NoError(t, secondLease.Presence("world_key", "world/", true, true))
+
+	var hello, world emitter.PresenceEvent
+	hello.Channel, world.Channel = "hello", "world/"
+	conn.shared.onPresence(nil, hello)
+	conn.shared.onPresence(nil, world)
+	assert.Equal(t, []string{"hello"}, firstPresence)
+	assert.Equal(t, []string{"world/"}, secondPresence)
+}
+
+func TestSharedClientReconnectGivesUp(t *testing.T) {
+	conn := &fakeConnection{fakeBroker: newFakeBroker()}
+	duration := apicommon.FromString("1ms")
+	conn.shared = newSharedClient(conn, "first", &apicommon.Backoff{Duration: &duration}, 3, zap.NewNop().Sugar())
+	defer conn.shared.Close()
+	listener := &fakeListener{}
+	lease := conn.shared.join()
+	lease.handle(listener.handlers())
+	assert.NoError(t, lease.connect())
+
+	// the listeners are notified once the attempts are exhausted
+	conn.setUnreachable(true)
+	conn.drop()
+	assert.Eventually(t, func() bool {
+		listener.lock.Lock()
+		defer listener.lock.Unlock()
+		return listener.failed != nil
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 3, listener.attempts)
+	assert.Contains(t, listener.failed.Error(), "failed to reconnect to the brokers after 3 attempts")
+	assert.Equal(t, 4, conn.connects)
+}
+
+func TestReconnectBackoff(t *testing.T) {
+	assert.Equal(t, int32(math.MaxInt32), reconnectBackoff(nil, 0).Steps)
+	assert.Equal(t, common.DefaultBackoff.Duration, reconnectBackoff(nil, 0).Duration)
+	duration := apicommon.FromString("5s")
+	backoff := &apicommon.Backoff{Duration: &duration, Steps: 2}
+	assert.Equal(t, &apicommon.Backoff{Duration: &duration, Steps: 10}, reconnectBackoff(backoff, 10))
+	assert.Equal(t, int32(2), backoff.Steps)
+}
@@ -19,9 +19,13 @@ package emitter
 import (
 	"context"
 	"encoding/json"
+	"os"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	emitter "github.com/emitter-io/go/v2"
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
@@ -35,12 +39,74 @@ import (
 	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
 )
 
+const (
+	// eventTypeMessage is the type of the events carrying a message published on the channel
+	eventTypeMessage = "message"
+	// eventTypePresence is the type of the events carrying a presence notification of the channel
+	eventTypePresence = "presence"
+	// eventTypeConnection is the type of the events carrying a connection state transition of the client
+	eventTypeConnection = "connection"
+	// eventTypeLastWill is the type of the events carrying a last-will message, published by the broker on behalf
+	// of a client which disconnected ungracefully
+	eventTypeLastWill = "lastwill"
+	// eventTypeHeartbeat is the type of the heartbeat events, dispatched periodically whatever the traffic
+	eventTypeHeartbeat = eventsourcecommon.HeartbeatEventType
+	// eventTypeError is the type of the events carrying a failure to connect to the brokers or to subscribe
+	eventTypeError = "error"
+	// healthPingInterval is how often the connection to the broker is checked for the health probes
+	healthPingInterval = 10 * time.Second
+	// keyGenRetryInterval is the least delay between two regenerations of the channel keys, so that a failing
+	// regeneration is retried without flooding the broker
+	keyGenRetryInterval = 5 * time.Second
+
+	connectionStateConnected    = "connected"
+	connectionStateDisconnected = "disconnected"
+
+	errorStageConnect   = "connect"
+	errorStageSubscribe = "subscribe"
+)
+
+// mqttMessage exposes the MQTT message properties of the messages received by the emitter client.
+type mqttMessage interface {
+	Retained() bool
+	MessageID() uint16
+}
+
 // EventListener implements Eventing for Emitter event source
 type EventListener struct {
 	EventSourceName    string
 	EventName          string
 	EmitterEventSource v1alpha1.EmitterEventSource
 	Metrics            *metrics.Metrics
+	// SecretResolver resolves the username and password, they are read from the mounted secrets if not set
+	SecretResolver common.SecretResolver
+	// HealthTracker records the health of the connection to the broker, reported by the /healthz endpoint
+	eventsourcecommon.HealthTracker
+	// Clock stamps the events and measures their processing, the system time if not set
+	Clock eventsourcecommon.Clock
+
+	clientIDOnce sync.Once
+	clientID     string
+
+	topicLabelsOnce sync.Once
+	topicLabels     *metrics.TopicLabels
+}
+
+// ClientID returns the ID of the emitter client, generated once so that it's the same across the reconnections
+// and the restarts of the listener.
+func (el *EventListener) ClientID() string {
+	el.clientIDOnce.Do(func() {
+		el.clientID = uuid.New().String()
+	})
+	return el.clientID
+}
+
+// clock returns the Clock of the listener, the system time by default.
+func (el *EventListener) clock() eventsourcecommon.Clock {
+	if el.Clock == nil {
+		return eventsourcecommon.RealClock{}
+	}
+	return el.Clock
 }
 
 // GetEventSourceName returns name of event source
@@ -60,92 +126,146 @@ func (el *EventListener) GetEventSourceType() apicommon.EventSourceType {
 
 // StartListening starts listening events
 func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byte, ...eventsourcecommon.Options) error) error {
-	log := logging.FromContext(ctx).
+	// the logger scrubs the credentials the errors of the broker and of the secrets may hold
+	log := logging.WithRedaction(logging.FromContext(ctx)).
 		With(logging.LabelEventSourceType, el.GetEventSourceType(), logging.LabelEventName, el.GetEventName())
 	log.Info("started processing the Emitter event source...")
 	defer sources.Recover(el.GetEventName())
 
 	emitterEventSource := &el.EmitterEventSource
-
-	var options []func(client *emitter.Client)
-	if emitterEventSource.TLS != nil {
-		tlsConfig, err := common.GetTLSConfig(emitterEventSource.TLS)
-		if err != nil {
-			return errors.Wrap(err, "failed to get the tls configuration")
-		}
-		options = append(options, emitter.WithTLSConfig(tlsConfig))
+	// a misconfigured spec fails with all its errors rather than with the first failure of the client
+	if err := validate(emitterEventSource); err != nil {
+		return errors.Wrap(err, "invalid emitter event source")
 	}
-	options = append(options, emitter.WithBrokers(emitterEventSource.Broker), emitter.WithAutoReconnect(true))
+	el.Started()
+	defer el.SetConnected(false)
 
-	if emitterEventSource.Username != nil {
-		username, err := common.GetSecretFromVolume(emitterEventSource.Username)
-		if err != nil {
-			return errors.Wrapf(err, "failed to retrieve the username from %s", emitterEventSource.Username.Name)
-		}
-		options = append(options, emitter.WithUsername(username))
+	secretResolver := el.SecretResolver
+	if secretResolver == nil {
+		secretResolver = common.VolumeSecretResolver{}
 	}
-
-	if emitterEventSource.Password != nil {
-		password, err := common.GetSecretFromVolume(emitterEventSource.Password)
-		if err != nil {
-			return errors.Wrapf(err, "failed to retrieve the password from %s", emitterEventSource.Password.Name)
-		}
-		options = append(options, emitter.WithPassword(password))
+	settings, err := el.resolveClientSettings(secretResolver, log)
+	if err != nil {
+		return err
 	}
 
 	if emitterEventSource.JSONBody {
 		log.Info("assuming all events have a json body...")
 	}
 
-	log.Infow("creating a client", zap.Any("channelName", emitterEventSource.ChannelName))
-	client := emitter.NewClient(options...)
+	// the same spec may be deployed across clusters, the values of the metadata may refer to the environment
+	metadata, unresolved := expandMetadata(emitterEventSource.Metadata, os.LookupEnv)
+	for _, name := range unresolved {
+		log.Warnw("the environment variable of the metadata is not set, it is replaced by an empty string", zap.String("variable", name))
+	}
 
-	if err := common.Connect(emitterEventSource.ConnectionBackoff, func() error {
-		if err := client.Connect(); err != nil {
-			return err
-		}
-		return nil
-	}); err != nil {
-		return errors.Wrapf(err, "failed to connect to %s", emitterEventSource.Broker)
+	shared, release, err := el.acquireClient(settings, log)
+	if err != nil {
+		return err
 	}
+	defer release()
+	client := shared.join()
+	defer client.leave()
+	clientID := client.ClientID()
+	log = log.With("clientId", clientID)
 
-	if err := client.Subscribe(emitterEventSource.ChannelKey, emitterEventSource.ChannelName, func(_ *emitter.Client, message emitter.Message) {
-		defer func(start time.Time) {
-			el.Metrics.EventProcessingDuration(el.GetEventSourceName(), el.GetEventName(), float64(time.Since(start)/time.Millisecond))
-		}(time.Now())
+	s, err := newSession(ctx, el, client, clientID, metadata, dispatch, log)
+	if err != nil {
+		return err
+	}
+	defer s.stopRedispatching()
+	if emitterEventSource.KeyGen != nil {
+		permissions := keyGenPermissions(emitterEventSource)
+		log.Infow("generating the channel keys from the master key", zap.String("permissions", permissions), zap.Duration("ttl", settings.keyTTL))
+		s.keys = newKeyCache(client.GenerateKey, settings.masterKey, permissions, settings.keyTTL)
+	}
+	client.handle(s.handlers())
 
-		body := message.Payload()
-		event := &events.EmitterEventData{
-			Topic:    message.Topic(),
-			Body:     body,
-			Metadata: emitterEventSource.Metadata,
+	if err := common.ConnectWithContext(ctx, emitterEventSource.ConnectionBackoff, client.connect); err != nil {
+		reason := metrics.FailureReasonConnection
+		if errors.Is(err, common.ErrAuthFailed) {
+			log.Errorw("the broker refused the credentials, not retrying", zap.Error(err))
+			reason = metrics.FailureReasonAuth
+			invalidateCredentials(secretResolver, emitterEventSource, err)
 		}
-		if emitterEventSource.JSONBody {
-			event.Body = (*json.RawMessage)(&body)
-		}
-		eventBytes, err := json.Marshal(event)
+		el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), reason)
+		el.SetError(err)
+		s.dispatchError(errorStageConnect, "", err, true)
+		s.drain(s.drainTimeout)
+		return errors.Wrap(err, "failed to connect to the brokers")
+	}
 
-		if err != nil {
-			log.Errorw("failed to marshal the event data", zap.Error(err))
-			el.Metrics.EventProcessingFailed(el.GetEventSourceName(), el.GetEventName())
-			return
-		}
-		log.Info("dispatching event on data channel...")
-		if err = dispatch(eventBytes); err != nil {
-			log.Errorw("failed to dispatch event", zap.Error(err))
-			el.Metrics.EventProcessingFailed(el.GetEventSourceName(), el.GetEventName())
-		}
-	}); err != nil {
-		return errors.Wrapf(err, "failed to subscribe to channel %s", emitterEventSource.ChannelName)
+	var subscribeOptions []emitter.Option
+	if opts := emitterEventSource.SubscriptionOptions; opts != nil && opts.WithHistory {
+		// the broker replays the stored messages right after the subscription, capped to what the channel holds.
+		log.Infow("replaying the last messages of the channels", zap.Int32("last", opts.Last))
+		subscribeOptions = append(subscribeOptions, emitter.WithLast(int(opts.Last)))
+	}
+	if err := s.subscribeChannels(subscribeOptions); err != nil {
+		invalidateCredentials(secretResolver, emitterEventSource, err)
+		s.drain(s.drainTimeout)
+		return err
+	}
+	if len(s.subs.list()) == 0 {
+		err := errors.New("failed to subscribe to any of the channels")
+		el.SetError(err)
+		s.dispatchError(errorStageSubscribe, "", err, true)
+		s.drain(s.drainTimeout)
+		return err
 	}
+	if emitterEventSource.LastWillTopic != "" {
+		s.subscribeLastWill()
+	}
+	atomic.StoreInt32(&s.subscribed, 1)
+	el.Metrics.EventSourceActive(el.GetEventSourceName(), el.GetEventName(), true)
+	defer el.Metrics.EventSourceActive(el.GetEventSourceName(), el.GetEventName(), false)
+
+	go s.pingHealth()
+	if s.keys != nil {
+		go s.refreshKeys()
+	}
+	stopHeartbeat := s.startHeartbeat()
+
+	select {
+	case <-ctx.Done():
+	case err = <-s.keyGenDenied:
+		log.Errorw("stopping the event source", zap.Error(err))
+		invalidateCredentials(secretResolver, emitterEventSource, err)
+		s.dispatchError(errorStageSubscribe, "", err, true)
+	case err = <-s.reconnectFailed:
+		log.Errorw("stopping the event source", zap.Error(err))
+		invalidateCredentials(secretResolver, emitterEventSource, err)
+		s.dispatchError(errorStageConnect, "", err, true)
+	}
+	stopHeartbeat()
 
-	<-ctx.Done()
+	// the unsubscribe and the drain happen within the termination grace period of the coordinated shutdown
+	drainTimeout := eventsourcecommon.ShutdownTimeout(ctx, s.drainTimeout)
+	s.unsubscribe()
 
-	log.Infow("event source stopped, unsubscribe the channel", zap.Any("channelName", emitterEventSource.ChannelName))
+	// the messages being processed by the workers get their events dispatched, along with the fatal error if any
+	s.drain(drainTimeout)
 
-	if err := client.Unsubscribe(emitterEventSource.ChannelKey, emitterEventSource.ChannelName); err != nil {
-		log.Errorw("failed to unsubscribe", zap.Any("channelName", emitterEventSource.ChannelName), zap.Error(err))
+	return err
+}
+
+// channels returns the channel set by ChannelName and ChannelKey, if any, followed by the Channels.
+func channels(eventSource *v1alpha1.EmitterEventSource) []v1alpha1.EmitterChannel {
+	var result []v1alpha1.EmitterChannel
+	if eventSource.ChannelName != "" {
+		result = append(result, v1alpha1.EmitterChannel{Name: eventSource.ChannelName, Key: eventSource.ChannelKey})
 	}
+	return append(result, eventSource.Channels...)
+}
 
-	return nil
+// marshalEvent marshals the event, wrapped in the envelope of the version if set. The event marshaled alone is
+// returned as well, the IDs of the events without payload are derived from it so that the time of the envelope
+// doesn't make the IDs of the same event differ.
+func marshalEvent(event *events.EmitterEventData, envelopeVersion, eventSourceName, eventName string, t time.Time) (eventBytes, eventData []byte, err error) {
+	eventData, err = json.Marshal(event)
+	if err != nil || envelopeVersion == "" {
+		return eventData, eventData, err
+	}
+	eventBytes, err = json.Marshal(eventsourcecommon.NewEventEnvelope(envelopeVersion, eventSourceName, eventName, t, json.RawMessage(eventData)))
+	return eventBytes, eventData, err
 }
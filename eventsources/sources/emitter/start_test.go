@@ -0,0 +1,260 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	metrics "github.com/argoproj/argo-events/metrics"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// silentBroker accepts the connections and never answers, like a broker behind a dropping network
+func silentBroker(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	var lock sync.Mutex
+	var conns []net.Conn
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			lock.Lock()
+			conns = append(conns, conn)
+			lock.Unlock()
+		}
+	}()
+	t.Cleanup(func() {
+		_ = listener.Close()
+		lock.Lock()
+		defer lock.Unlock()
+		for _, conn := range conns {
+			_ = conn.Close()
+		}
+	})
+	return listener.Addr().String()
+}
+
+func TestClientID(t *testing.T) {
+	el := &EventListener{}
+	id := el.ClientID()
+	assert.NotEmpty(t, id)
+	assert.Equal(t, id, el.ClientID())
+	assert.NotEqual(t, id, (&EventListener{}).ClientID())
+}
+
+func TestProcessed(t *testing.T) {
+	clock := eventsourcecommon.NewFakeClock(time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC))
+	el := &EventListener{
+		EventSourceName: "emitter",
+		EventName:       "example",
+		Metrics:         metrics.NewMetrics("ns"),
+		Clock:           clock,
+	}
+	start := clock.Now()
+	clock.Advance(250 * time.Millisecond)
+	el.processed("devices/1", start)
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(el.Metrics)
+	families, err := registry.Gather()
+	assert.NoError(t, err)
+	var found bool
+	for _, family := range families {
+		if family.GetName() != "argo_events_event_processing_duration_milliseconds" {
+			continue
+		}
+		found = true
+		// the topics aren't labeled unless topicMetricsLabel is set
+		for _, label := range family.GetMetric()[0].GetLabel() {
+			if label.GetName() == "topic" {
+				assert.Empty(t, label.GetValue())
+			}
+		}
+		summary := family.GetMetric()[0].GetSummary()
+		assert.Equal(t, uint64(1), summary.GetSampleCount())
+		assert.Equal(t, float64(250), summary.GetSampleSum())
+	}
+	assert.True(t, found)
+}
+
+func TestStartListeningInvalid(t *testing.T) {
+	el := &EventListener{
+		EventSourceName:    "emitter",
+		EventName:          "example",
+		EmitterEventSource: v1alpha1.EmitterEventSource{Broker: "http://broker:4000", KeepAlive: "10ms"},
+		Metrics:            metrics.NewMetrics("ns"),
+	}
+	err := el.StartListening(context.Background(), func(data []byte, opts ...eventsourcecommon.Options) error {
+		return nil
+	})
+	assert.Error(t, err)
+	assert.Equal(t, `invalid emitter event source: [broker url scheme "http" is not supported, channel name must be specified, keep alive must be at least 1s]`, err.Error())
+	assert.False(t, el.HealthStatus().Started)
+}
+
+// leakyResolver fails to resolve the secrets with an error holding their value, like a backend echoing its response
+type leakyResolver struct {
+	values map[string]string
+}
+
+func (r leakyResolver) Resolve(ref *corev1.SecretKeySelector) (string, error) {
+	return "", errors.Errorf(`unexpected response {"%s": "%s"}`, ref.Key, r.values[ref.Key])
+}
+
+func (r leakyResolver) Invalidate(*corev1.SecretKeySelector) {}
+
+func TestStartListeningRedactsSecrets(t *testing.T) {
+	el := &EventListener{
+		EventSourceName: "emitter",
+		EventName:       "example",
+		EmitterEventSource: v1alpha1.EmitterEventSource{
+			Broker:      "tcp://broker:4000",
+			ChannelName: "hello",
+			ChannelKey:  "hello_key",
+			Password:    &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "emitter"}, Key: "password"},
+		},
+		SecretResolver: leakyResolver{values: map[string]string{"password": "hunter2"}},
+		Metrics:        metrics.NewMetrics("ns"),
+	}
+	err := el.StartListening(context.Background(), func([]byte, ...eventsourcecommon.Options) error { return nil })
+	assert.Error(t, err)
+	assert.Equal(t, `failed to retrieve the password from emitter: unexpected response {"password": "***"}`, err.Error())
+	assert.NotContains(t, err.Error(), "hunter2")
+}
+
+func TestStartListeningConnectTimeout(t *testing.T) {
+	steps := 3
+	duration := apicommon.FromString("50ms")
+	el := &EventListener{
+		EventSourceName: "emitter",
+		EventName:       "example",
+		EmitterEventSource: v1alpha1.EmitterEventSource{
+			Broker:         fmt.Sprintf("ssl://%s", silentBroker(t)),
+			ChannelName:    "hello",
+			ChannelKey:     "hello_key",
+			TLS:            &apicommon.TLSConfig{InsecureSkipVerify: true},
+			ConnectTimeout: "200ms",
+			ConnectionBackoff: &apicommon.Backoff{
+				Duration: &duration,
+				Steps:    int32(steps),
+			},
+		},
+		Metrics: metrics.NewMetrics("test-ns"),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	start := time.Now()
+	err := el.StartListening(ctx, func([]byte, ...eventsourcecommon.Options) error { return nil })
+	elapsed := time.Since(start)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to connect to ssl://")
+	// each attempt gives up after the connect timeout, then the backoff retries it
+	assert.Less(t, int64(elapsed), int64(time.Duration(steps)*200*time.Millisecond+time.Duration(steps)*50*time.Millisecond+time.Second))
+	assert.GreaterOrEqual(t, int64(elapsed), int64(time.Duration(steps)*200*time.Millisecond))
+}
+
+func TestStartListeningDispatchesConnectError(t *testing.T) {
+	duration := apicommon.FromString("50ms")
+	el := &EventListener{
+		EventSourceName: "emitter",
+		EventName:       "example",
+		EmitterEventSource: v1alpha1.EmitterEventSource{
+			Broker:            fmt.Sprintf("ssl://%s", silentBroker(t)),
+			ChannelName:       "hello",
+			ChannelKey:        "hello_key",
+			TLS:               &apicommon.TLSConfig{InsecureSkipVerify: true},
+			ConnectTimeout:    "100ms",
+			ConnectionBackoff: &apicommon.Backoff{Duration: &duration, Steps: 1},
+			Metadata:          map[string]string{"team": "iot"},
+		},
+		Metrics: metrics.NewMetrics("test-ns"),
+	}
+	var lock sync.Mutex
+	var dispatched []events.EmitterEventData
+	dispatch := func(data []byte, _ ...eventsourcecommon.Options) error {
+		var event events.EmitterEventData
+		assert.NoError(t, json.Unmarshal(data, &event))
+		lock.Lock()
+		defer lock.Unlock()
+		dispatched = append(dispatched, event)
+		return nil
+	}
+
+	// the errors are not dispatched unless enabled
+	assert.Error(t, el.StartListening(context.Background(), dispatch))
+	assert.Empty(t, dispatched)
+
+	el.EmitterEventSource.EmitErrorEvents = true
+	err := el.StartListening(context.Background(), dispatch)
+	assert.Error(t, err)
+	lock.Lock()
+	defer lock.Unlock()
+	assert.NotEmpty(t, dispatched)
+	// the failure the event source stops on is dispatched before it returns
+	event := dispatched[len(dispatched)-1]
+	assert.Equal(t, "error", event.Type)
+	assert.Equal(t, "hello", event.Topic)
+	assert.Equal(t, map[string]string{"team": "iot"}, event.Metadata)
+	assert.Equal(t, "connect", event.Error.Stage)
+	assert.True(t, event.Error.Fatal)
+	assert.Contains(t, event.Error.Error, "failed to connect to ssl://")
+	assert.Contains(t, event.Error.Broker, "ssl://")
+}
+
+func TestMarshalEvent(t *testing.T) {
+	event := &events.EmitterEventData{Type: eventTypeHeartbeat, Topic: "hello"}
+	start := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	eventBytes, eventData, err := marshalEvent(event, "", "emitter", "example", start)
+	assert.NoError(t, err)
+	assert.Equal(t, eventData, eventBytes)
+
+	eventBytes, eventData, err = marshalEvent(event, events.EventEnvelopeSpecVersion, "emitter", "example", start)
+	assert.NoError(t, err)
+	var envelope events.EventEnvelope
+	assert.NoError(t, json.Unmarshal(eventBytes, &envelope))
+	assert.Equal(t, start, envelope.Time)
+	assert.Equal(t, "example", envelope.EventName)
+	var wrapped events.EmitterEventData
+	data, err := json.Marshal(envelope.Data)
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(data, &wrapped))
+	assert.Equal(t, *event, wrapped)
+
+	// the time of the envelope is left out of the event the ID is derived from
+	_, later, err := marshalEvent(event, events.EventEnvelopeSpecVersion, "emitter", "example", start.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, eventData, later)
+}
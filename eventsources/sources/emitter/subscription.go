@@ -0,0 +1,134 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"sync"
+
+	emitter "github.com/emitter-io/go/v2"
+	"github.com/pkg/errors"
+
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// subscriber issues the subscriptions, it is implemented by the emitter client
+type subscriber interface {
+	Subscribe(key string, channel string, optionalHandler emitter.MessageHandler, options ...emitter.Option) error
+	Presence(key, channel string, status, changes bool) error
+}
+
+// subscriptions records the channels subscribed to, the keys they are subscribed with and their message
+// handlers, so that they are subscribed again when the client reconnects: the client connects with a clean
+// session, the broker forgets the subscriptions along with the lost connection and the library doesn't restore
+// them. After a failover to another broker the client is a new one, which doesn't know the handlers either.
+type subscriptions struct {
+	client   subscriber
+	presence bool
+
+	lock       sync.Mutex
+	channels   []v1alpha1.EmitterChannel
+	handlers   map[string]emitter.MessageHandler
+	noPresence map[string]bool
+}
+
+func newSubscriptions(client subscriber, presence bool) *subscriptions {
+	return &subscriptions{
+		client:     client,
+		presence:   presence,
+		handlers:   make(map[string]emitter.MessageHandler),
+		noPresence: make(map[string]bool),
+	}
+}
+
+// subscribe subscribes to the channel and records it. The options, e.g. the history replay, only apply to
+// this first subscription, not to the ones issued again on reconnect.
+func (s *subscriptions) subscribe(channel v1alpha1.EmitterChannel, handler emitter.MessageHandler, options ...emitter.Option) error {
+	if err := s.client.Subscribe(channel.Key, channel.Name, handler, options...); err != nil {
+		return err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.channels = append(s.channels, channel)
+	s.handlers[channel.Name] = handler
+	return nil
+}
+
+// subscribeWithoutPresence subscribes to the channel and records it like subscribe, but the presence
+// notifications of the channel are never subscribed to, e.g. for the last-will topic.
+func (s *subscriptions) subscribeWithoutPresence(channel v1alpha1.EmitterChannel, handler emitter.MessageHandler) error {
+	if err := s.subscribe(channel, handler); err != nil {
+		return err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.noPresence[channel.Name] = true
+	return nil
+}
+
+// hasPresence tells whether the presence notifications of a recorded channel are subscribed to
+func (s *subscriptions) hasPresence(channelName string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.presence && !s.noPresence[channelName]
+}
+
+// resubscribe subscribes again to a recorded channel, along with its presence notifications if enabled, with
+// the key of the channel, which is recorded once subscribed, and its message handler.
+func (s *subscriptions) resubscribe(channel v1alpha1.EmitterChannel) error {
+	s.lock.Lock()
+	handler := s.handlers[channel.Name]
+	s.lock.Unlock()
+	if err := s.client.Subscribe(channel.Key, channel.Name, handler); err != nil {
+		return errors.Wrapf(err, "failed to subscribe again to the channel %s", channel.Name)
+	}
+	s.lock.Lock()
+	for i := range s.channels {
+		if s.channels[i].Name == channel.Name {
+			s.channels[i].Key = channel.Key
+		}
+	}
+	s.lock.Unlock()
+	if s.hasPresence(channel.Name) {
+		// only the changes are notified, the occupancy was notified on the first subscription
+		if err := s.client.Presence(channel.Key, channel.Name, false, true); err != nil {
+			return errors.Wrapf(err, "failed to subscribe again to the presence notifications of the channel %s", channel.Name)
+		}
+	}
+	return nil
+}
+
+// resubscribeAll subscribes again to all the recorded channels, it returns the number of channels subscribed
+// again and the errors of the others, a channel failing doesn't prevent the others from being subscribed.
+func (s *subscriptions) resubscribeAll() (int, []error) {
+	var errs []error
+	resubscribed := 0
+	for _, channel := range s.list() {
+		if err := s.resubscribe(channel); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		resubscribed++
+	}
+	return resubscribed, errs
+}
+
+// list returns the recorded channels along with their latest keys
+func (s *subscriptions) list() []v1alpha1.EmitterChannel {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]v1alpha1.EmitterChannel(nil), s.channels...)
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"sync"
+	"testing"
+
+	emitter "github.com/emitter-io/go/v2"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// fakeMessage is a message delivered by the fake broker
+type fakeMessage struct {
+	topic   string
+	payload []byte
+}
+
+func (m *fakeMessage) Topic() string   { return m.topic }
+func (m *fakeMessage) Payload() []byte { return m.payload }
+
+// fakeBroker simulates the subscriptions of a clean session connection, which are forgotten on disconnect,
+// and the message handlers of the client, which are kept.
+type fakeBroker struct {
+	lock       sync.Mutex
+	subscribed map[string]string
+	presence   map[string]bool
+	handlers   map[string]emitter.MessageHandler
+	options    map[string][]emitter.Option
+	fail       map[string]error
+}
+
+func newFakeBroker() *fakeBroker {
+	return &fakeBroker{
+		subscribed: make(map[string]string),
+		presence:   make(map[string]bool),
+		handlers:   make(map[string]emitter.MessageHandler),
+		options:    make(map[string][]emitter.Option),
+		fail:       make(map[string]error),
+	}
+}
+
+func (b *fakeBroker) Subscribe(key string, channel string, handler emitter.MessageHandler, options ...emitter.Option) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if err := b.fail[channel]; err != nil {
+		return err
+	}
+	if handler != nil {
+		b.handlers[channel] = handler
+	}
+	b.subscribed[channel] = key
+	b.options[channel] = options
+	return nil
+}
+
+func (b *fakeBroker) Presence(key, channel string, status, changes bool) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.presence[channel] = changes
+	return nil
+}
+
+func (b *fakeBroker) disconnect() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.subscribed = make(map[string]string)
+	b.presence = make(map[string]bool)
+}
+
+// publish delivers the message if the channel is subscribed
+func (b *fakeBroker) publish(channel string, payload string) bool {
+	b.lock.Lock()
+	_, ok := b.subscribed[channel]
+	handler := b.handlers[channel]
+	b.lock.Unlock()
+	if !ok || handler == nil {
+		return false
+	}
+	handler(nil, &fakeMessage{topic: channel, payload: []byte(payload)})
+	return true
+}
+
+func TestSubscriptionsReconnect(t *testing.T) {
+	broker := newFakeBroker()
+	subs := newSubscriptions(broker, true)
+
+	var received []string
+	handler := func(_ *emitter.Client, message emitter.Message) {
+		received = append(received, string(message.Payload()))
+	}
+	assert.NoError(t, subs.subscribe(v1alpha1.EmitterChannel{Name: "hello", Key: "hello_key"}, handler, emitter.WithLast(5)))
+	assert.NoError(t, subs.subscribe(v1alpha1.EmitterChannel{Name: "world", Key: "world_key"}, handler, emitter.WithLast(5)))
+	assert.True(t, broker.publish("hello", "before"))
+
+	broker.disconnect()
+	assert.False(t, broker.publish("hello", "lost"))
+
+	resubscribed, errs := subs.resubscribeAll()
+	assert.Empty(t, errs)
+	assert.Equal(t, 2, resubscribed)
+	assert.True(t, broker.publish("hello", "after"))
+	assert.True(t, broker.publish("world", "after"))
+	assert.Equal(t, []string{"before", "after", "after"}, received)
+	assert.Equal(t, "hello_key", broker.subscribed["hello"])
+	assert.True(t, broker.presence["hello"])
+	// the history isn't replayed again
+	assert.Empty(t, broker.options["hello"])
+}
+
+func TestSubscriptionsResubscribeFailure(t *testing.T) {
+	broker := newFakeBroker()
+	subs := newSubscriptions(broker, false)
+	handler := func(_ *emitter.Client, message emitter.Message) {}
+	assert.NoError(t, subs.subscribe(v1alpha1.EmitterChannel{Name: "hello", Key: "hello_key"}, handler))
+	assert.NoError(t, subs.subscribe(v1alpha1.EmitterChannel{Name: "world", Key: "world_key"}, handler))
+
+	broker.disconnect()
+	broker.fail["hello"] = errors.New("timeout")
+	resubscribed, errs := subs.resubscribeAll()
+	assert.Equal(t, 1, resubscribed)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "failed to subscribe again to the channel hello: timeout", errs[0].Error())
+	assert.False(t, broker.publish("hello", "lost"))
+	assert.True(t, broker.publish("world", "after"))
+	assert.Empty(t, broker.presence)
+}
+
+func TestSubscriptionsRekey(t *testing.T) {
+	broker := newFakeBroker()
+	subs := newSubscriptions(broker, false)
+	assert.NoError(t, subs.subscribe(v1alpha1.EmitterChannel{Name: "hello", Key: "key1"}, func(_ *emitter.Client, message emitter.Message) {}))
+
+	assert.NoError(t, subs.resubscribe(v1alpha1.EmitterChannel{Name: "hello", Key: "key2"}))
+	assert.Equal(t, []v1alpha1.EmitterChannel{{Name: "hello", Key: "key2"}}, subs.list())
+
+	// the latest key is used on reconnect
+	broker.disconnect()
+	_, errs := subs.resubscribeAll()
+	assert.Empty(t, errs)
+	assert.Equal(t, "key2", broker.subscribed["hello"])
+}
+
+func TestSubscriptionsFailover(t *testing.T) {
+	broker := newFakeBroker()
+	subs := newSubscriptions(broker, false)
+	var received []string
+	assert.NoError(t, subs.subscribe(v1alpha1.EmitterChannel{Name: "hello", Key: "hello_key"}, func(_ *emitter.Client, message emitter.Message) {
+		received = append(received, string(message.Payload()))
+	}))
+
+	// the client connected to the next broker knows neither the subscriptions nor the handlers
+	next := newFakeBroker()
+	subs.client = next
+	resubscribed, errs := subs.resubscribeAll()
+	assert.Empty(t, errs)
+	assert.Equal(t, 1, resubscribed)
+	assert.True(t, next.publish("hello", "after"))
+	assert.Equal(t, []string{"after"}, received)
+}
+
+func TestSubscriptionsWithoutPresence(t *testing.T) {
+	broker := newFakeBroker()
+	subs := newSubscriptions(broker, true)
+	handler := func(_ *emitter.Client, message emitter.Message) {}
+	assert.NoError(t, subs.subscribe(v1alpha1.EmitterChannel{Name: "hello", Key: "hello_key"}, handler))
+	assert.NoError(t, subs.subscribeWithoutPresence(v1alpha1.EmitterChannel{Name: "lastwill", Key: "hello_key"}, handler))
+	assert.True(t, subs.hasPresence("hello"))
+	assert.False(t, subs.hasPresence("lastwill"))
+
+	broker.disconnect()
+	resubscribed, errs := subs.resubscribeAll()
+	assert.Empty(t, errs)
+	assert.Equal(t, 2, resubscribed)
+	assert.True(t, broker.publish("lastwill", "gone"))
+	assert.True(t, broker.presence["hello"])
+	_, ok := broker.presence["lastwill"]
+	assert.False(t, ok)
+}
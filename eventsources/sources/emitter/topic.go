@@ -0,0 +1,122 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gobwas/glob"
+	"github.com/pkg/errors"
+)
+
+const (
+	// singleLevelWildcard matches exactly one segment of the topic
+	singleLevelWildcard = "+"
+	// multiLevelWildcard matches the remaining segments of the topic, it must be the last segment of the channel
+	multiLevelWildcard = "#"
+)
+
+// topicParams returns the segments of the topic matched by the wildcards of the channel pattern, keyed by
+// the zero-based position of the wildcard in the pattern, e.g. {"1": "kitchen"} for the topic sensor/kitchen/temp/
+// and the pattern sensor/+/temp/. The map is empty, never nil, if the pattern has no wildcard or doesn't match.
+func topicParams(pattern, topic string) map[string]string {
+	params := make(map[string]string)
+	// the channel may carry options, e.g. sensor/+/temp/?last=5
+	if i := strings.Index(pattern, "?"); i >= 0 {
+		pattern = pattern[:i]
+	}
+	patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+	topicSegments := strings.Split(strings.Trim(topic, "/"), "/")
+	for i, segment := range patternSegments {
+		if segment == multiLevelWildcard {
+			if i < len(topicSegments) {
+				params[strconv.Itoa(i)] = strings.Join(topicSegments[i:], "/")
+			}
+			return params
+		}
+		if i >= len(topicSegments) {
+			return make(map[string]string)
+		}
+		switch segment {
+		case singleLevelWildcard:
+			params[strconv.Itoa(i)] = topicSegments[i]
+		case topicSegments[i]:
+		default:
+			return make(map[string]string)
+		}
+	}
+	return params
+}
+
+// topicSeparator separates the segments of a topic, a * of a topic glob pattern doesn't match it
+const topicSeparator = '/'
+
+// topicFilter tells whether the messages of a topic are dispatched, according to the allow and deny lists of
+// glob patterns. The deny list takes precedence over the allow list, an empty allow list allows all the topics.
+type topicFilter struct {
+	allow []glob.Glob
+	deny  []glob.Glob
+}
+
+// newTopicFilter compiles the glob patterns of the topics, it returns nil if both lists are empty.
+func newTopicFilter(allow, deny []string) (*topicFilter, error) {
+	if len(allow) == 0 && len(deny) == 0 {
+		return nil, nil
+	}
+	f := &topicFilter{}
+	var err error
+	if f.allow, err = compileTopicPatterns(allow); err != nil {
+		return nil, errors.Wrap(err, "invalid topicAllow")
+	}
+	if f.deny, err = compileTopicPatterns(deny); err != nil {
+		return nil, errors.Wrap(err, "invalid topicDeny")
+	}
+	return f, nil
+}
+
+func compileTopicPatterns(patterns []string) ([]glob.Glob, error) {
+	globs := make([]glob.Glob, 0, len(patterns))
+	for _, pattern := range patterns {
+		g, err := glob.Compile(strings.Trim(pattern, "/"), topicSeparator)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compile the pattern %q", pattern)
+		}
+		globs = append(globs, g)
+	}
+	return globs, nil
+}
+
+// accepts tells whether the messages of the topic are dispatched. The leading and trailing slashes of the topic
+// are ignored.
+func (f *topicFilter) accepts(topic string) bool {
+	topic = strings.Trim(topic, "/")
+	for _, g := range f.deny {
+		if g.Match(topic) {
+			return false
+		}
+	}
+	if len(f.allow) == 0 {
+		return true
+	}
+	for _, g := range f.allow {
+		if g.Match(topic) {
+			return true
+		}
+	}
+	return false
+}
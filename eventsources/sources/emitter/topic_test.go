@@ -0,0 +1,86 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-events/pkg/apis/events"
+)
+
+func TestTopicParams(t *testing.T) {
+	tests := []struct {
+		pattern string
+		topic   string
+		params  map[string]string
+	}{
+		{"sensor/+/temp/", "sensor/kitchen/temp/", map[string]string{"1": "kitchen"}},
+		{"sensor/+/+/", "sensor/kitchen/temp/", map[string]string{"1": "kitchen", "2": "temp"}},
+		{"sensor/+/temp/?last=5", "sensor/kitchen/temp/", map[string]string{"1": "kitchen"}},
+		{"sensor/#/", "sensor/kitchen/temp/", map[string]string{"1": "kitchen/temp"}},
+		{"sensor/temp/", "sensor/temp/", map[string]string{}},
+		{"sensor/+/temp/", "sensor/kitchen/humidity/", map[string]string{}},
+		{"sensor/+/temp/", "sensor/", map[string]string{}},
+	}
+	for _, tt := range tests {
+		params := topicParams(tt.pattern, tt.topic)
+		assert.NotNil(t, params, tt.pattern)
+		assert.Equal(t, tt.params, params, tt.pattern)
+	}
+
+	// the events of the channels without wildcards don't carry the params
+	body, err := json.Marshal(&events.EmitterEventData{Topic: "sensor/temp/", TopicParams: topicParams("sensor/temp/", "sensor/temp/")})
+	assert.NoError(t, err)
+	assert.NotContains(t, string(body), "topicParams")
+}
+
+func TestTopicFilter(t *testing.T) {
+	f, err := newTopicFilter(nil, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, f)
+
+	tests := []struct {
+		name    string
+		allow   []string
+		deny    []string
+		allowed []string
+		denied  []string
+	}{
+		{"allow", []string{"sensor/*/temp"}, nil, []string{"sensor/kitchen/temp", "sensor/kitchen/temp/"}, []string{"sensor/kitchen/humidity/", "sensor/a/b/temp/"}},
+		{"allow across segments", []string{"sensor/**"}, nil, []string{"sensor/kitchen/temp/"}, []string{"alarm/kitchen/"}},
+		{"deny", nil, []string{"sensor/debug/**"}, []string{"sensor/kitchen/temp/", "alarm/"}, []string{"sensor/debug/temp/"}},
+		{"deny takes precedence", []string{"sensor/**"}, []string{"sensor/debug/**"}, []string{"sensor/kitchen/temp/"}, []string{"sensor/debug/temp/", "alarm/"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := newTopicFilter(tt.allow, tt.deny)
+			assert.NoError(t, err)
+			for _, topic := range tt.allowed {
+				assert.True(t, f.accepts(topic), topic)
+			}
+			for _, topic := range tt.denied {
+				assert.False(t, f.accepts(topic), topic)
+			}
+		})
+	}
+
+	_, err = newTopicFilter([]string{"sensor/[temp"}, nil)
+	assert.Error(t, err)
+}
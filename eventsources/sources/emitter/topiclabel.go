@@ -0,0 +1,52 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"time"
+
+	metrics "github.com/argoproj/argo-events/metrics"
+)
+
+// defaultMaxTopicLabels is how many distinct topics are labeled by default
+const defaultMaxTopicLabels = 100
+
+// topicLabel returns the topic label of the metrics of a message, empty unless TopicMetricsLabel is set. The labeled
+// topics are kept across the restarts of the listener, as the series they create are.
+func (el *EventListener) topicLabel(topic string) string {
+	el.topicLabelsOnce.Do(func() {
+		if !el.EmitterEventSource.TopicMetricsLabel {
+			return
+		}
+		max := defaultMaxTopicLabels
+		if el.EmitterEventSource.MaxTopicLabels > 0 {
+			max = int(el.EmitterEventSource.MaxTopicLabels)
+		}
+		el.topicLabels = metrics.NewTopicLabels(max)
+	})
+	return el.topicLabels.Label(topic)
+}
+
+// processed records the processing duration of an event received at start, from a message of the topic.
+func (el *EventListener) processed(topic string, start time.Time) {
+	el.Metrics.EventProcessingDurationWithTopic(el.GetEventSourceName(), el.GetEventName(), el.topicLabel(topic), float64(el.clock().Since(start)/time.Millisecond))
+}
+
+// failed counts a processing failure of a message of the topic under its reason.
+func (el *EventListener) failed(topic string, reason metrics.FailureReason) {
+	el.Metrics.EventProcessingFailedWithTopic(el.GetEventSourceName(), el.GetEventName(), el.topicLabel(topic), reason)
+}
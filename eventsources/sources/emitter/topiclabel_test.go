@@ -0,0 +1,78 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	metrics "github.com/argoproj/argo-events/metrics"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestTopicLabel(t *testing.T) {
+	el := &EventListener{}
+	assert.Equal(t, "", el.topicLabel("devices/1"))
+
+	el = &EventListener{EmitterEventSource: v1alpha1.EmitterEventSource{TopicMetricsLabel: true, MaxTopicLabels: 2}}
+	assert.Equal(t, "devices/1", el.topicLabel("devices/1"))
+	assert.Equal(t, "devices/2", el.topicLabel("devices/2"))
+	assert.Equal(t, metrics.OtherTopic, el.topicLabel("devices/3"))
+	assert.Equal(t, "devices/1", el.topicLabel("devices/1"))
+
+	el = &EventListener{EmitterEventSource: v1alpha1.EmitterEventSource{TopicMetricsLabel: true}}
+	for i := 0; i < defaultMaxTopicLabels; i++ {
+		assert.NotEqual(t, metrics.OtherTopic, el.topicLabel(fmt.Sprintf("devices/%d", i)))
+	}
+	assert.Equal(t, metrics.OtherTopic, el.topicLabel("sensors/1"))
+}
+
+func TestTopicMetrics(t *testing.T) {
+	clock := eventsourcecommon.NewFakeClock(time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC))
+	el := &EventListener{
+		EventSourceName:    "emitter",
+		EventName:          "example",
+		EmitterEventSource: v1alpha1.EmitterEventSource{TopicMetricsLabel: true, MaxTopicLabels: 1},
+		Metrics:            metrics.NewMetrics("ns"),
+		Clock:              clock,
+	}
+	el.processed("devices/1", clock.Now())
+	el.processed("devices/2", clock.Now())
+	el.failed("devices/2", metrics.FailureReasonDispatch)
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(el.Metrics)
+	families, err := registry.Gather()
+	assert.NoError(t, err)
+	topics := map[string][]string{}
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "topic" {
+					topics[family.GetName()] = append(topics[family.GetName()], label.GetValue())
+				}
+			}
+		}
+	}
+	assert.ElementsMatch(t, []string{"devices/1", metrics.OtherTopic}, topics["argo_events_event_processing_duration_milliseconds"])
+	assert.Equal(t, []string{metrics.OtherTopic}, topics["argo_events_events_processing_failed_total"])
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"encoding/json"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+)
+
+// traceContext returns the trace context of the event, read from the traceHeader field of the JSON body of the
+// messages. The events of the messages without a valid one, and the other events, start a new trace.
+func traceContext(traceHeader string, event *events.EmitterEventData) eventsourcecommon.TraceContext {
+	var body []byte
+	switch b := event.Body.(type) {
+	case []byte:
+		body = b
+	case *json.RawMessage:
+		if b != nil {
+			body = *b
+		}
+	}
+	var fields map[string]json.RawMessage
+	if len(body) > 0 && json.Unmarshal(body, &fields) == nil {
+		var traceParent string
+		if json.Unmarshal(fields[traceHeader], &traceParent) == nil {
+			if tc, err := eventsourcecommon.ParseTraceParent(traceParent); err == nil {
+				return tc
+			}
+		}
+	}
+	return eventsourcecommon.NewRootTraceContext()
+}
+
+// dispatchOptions returns the options the event is dispatched with. The events carry the topic as their partition
+// key, and the trace context if a trace header is set.
+func dispatchOptions(id string, event *events.EmitterEventData, traceHeader string) []eventsourcecommon.Options {
+	options := []eventsourcecommon.Options{eventsourcecommon.WithID(id), eventsourcecommon.WithPartitionKey(event.Topic)}
+	if traceHeader != "" {
+		options = append(options, eventsourcecommon.WithTraceContext(traceContext(traceHeader, event)))
+	}
+	return options
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/stretchr/testify/assert"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+)
+
+const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestTraceContext(t *testing.T) {
+	body := json.RawMessage(`{"traceparent":"` + traceParent + `","temp":21}`)
+	tc := traceContext("traceparent", &events.EmitterEventData{Type: eventTypeMessage, Body: &body})
+	assert.Equal(t, traceParent, tc.String())
+
+	// the body is read even if it isn't dispatched as JSON
+	tc = traceContext("traceparent", &events.EmitterEventData{Type: eventTypeMessage, Body: []byte(body)})
+	assert.Equal(t, traceParent, tc.String())
+
+	for _, e := range []*events.EmitterEventData{
+		{Type: eventTypeMessage, Body: []byte(`{"temp":21}`)},
+		{Type: eventTypeMessage, Body: []byte(`{"traceparent":"invalid"}`)},
+		{Type: eventTypeMessage, Body: []byte(`{"traceparent":42}`)},
+		{Type: eventTypeMessage, Body: []byte(`not json`)},
+		{Type: eventTypeConnection},
+	} {
+		tc := traceContext("traceparent", e)
+		assert.NotEqual(t, "4bf92f3577b34da6a3ce929d0e0e4736", tc.TraceID)
+		_, err := eventsourcecommon.ParseTraceParent(tc.String())
+		assert.NoError(t, err)
+	}
+	assert.NotEqual(t, traceParent, traceContext("x-trace", &events.EmitterEventData{Type: eventTypeMessage, Body: &body}).String())
+}
+
+func TestDispatchOptionsTraceContext(t *testing.T) {
+	var received []eventsourcecommon.Options
+	dispatch := eventsourcecommon.DispatchWithTimeout(time.Second, func(data []byte, opts ...eventsourcecommon.Options) error {
+		received = opts
+		return nil
+	})
+	apply := func() event.Event {
+		e := event.New()
+		for _, opt := range received {
+			assert.NoError(t, opt(&e))
+		}
+		return e
+	}
+	msg := &events.EmitterEventData{Type: eventTypeMessage, Topic: "sensor/kitchen/", Body: []byte(`{"traceparent":"` + traceParent + `"}`)}
+
+	assert.NoError(t, dispatch([]byte(`{}`), dispatchOptions("id", msg, "traceparent")...))
+	e := apply()
+	assert.Equal(t, "id", e.ID())
+	assert.Equal(t, "sensor/kitchen/", eventsourcecommon.PartitionKey(e))
+	tc, ok := eventsourcecommon.TraceContextOf(e)
+	assert.True(t, ok)
+	assert.Equal(t, traceParent, tc.String())
+
+	assert.NoError(t, dispatch([]byte(`{}`), dispatchOptions("id", &events.EmitterEventData{Type: eventTypeConnection}, "traceparent")...))
+	tc, ok = eventsourcecommon.TraceContextOf(apply())
+	assert.True(t, ok)
+	assert.NotEqual(t, "4bf92f3577b34da6a3ce929d0e0e4736", tc.TraceID)
+
+	// the trace contexts aren't propagated without a trace header
+	assert.NoError(t, dispatch([]byte(`{}`), dispatchOptions("id", msg, "")...))
+	_, ok = eventsourcecommon.TraceContextOf(apply())
+	assert.False(t, ok)
+}
@@ -18,11 +18,14 @@ package emitter
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	"github.com/argoproj/argo-events/common"
-	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
 	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
 )
 
@@ -35,17 +38,137 @@ func validate(eventSource *v1alpha1.EmitterEventSource) error {
 	if eventSource == nil {
 		return common.ErrNilEventSource
 	}
-	if eventSource.Broker == "" {
-		return errors.New("broker url must be specified")
+	var errs []error
+	if err := eventSource.Validate(); err != nil {
+		errs = append(errs, err)
 	}
-	if eventSource.ChannelName == "" {
-		return errors.New("channel name must be specified")
+	switch eventSource.OverflowPolicy {
+	case "", overflowPolicyDrop, overflowPolicyBlock:
+	default:
+		errs = append(errs, errors.Errorf("overflowPolicy must be either %s or %s", overflowPolicyDrop, overflowPolicyBlock))
 	}
-	if eventSource.ChannelKey == "" {
-		return errors.New("channel key secret selector must be specified")
+	switch eventSource.BackpressurePolicy {
+	case "", backpressurePolicyDrop, backpressurePolicyBlock, backpressurePolicyBuffer:
+	default:
+		errs = append(errs, errors.Errorf("backpressurePolicy must be one of %s, %s or %s", backpressurePolicyDrop, backpressurePolicyBlock, backpressurePolicyBuffer))
 	}
-	if eventSource.TLS != nil {
-		return apicommon.ValidateTLSConfig(eventSource.TLS)
+	if err := validateDispatchRetry(eventSource); err != nil {
+		errs = append(errs, err)
+	}
+	if eventSource.BufferSize < 0 {
+		errs = append(errs, errors.New("bufferSize must not be negative"))
+	}
+	switch eventSource.Compression {
+	case "", compressionNone, compressionGzip:
+	default:
+		errs = append(errs, errors.Errorf("compression must be either %s or %s", compressionNone, compressionGzip))
+	}
+	switch eventSource.PayloadEncoding {
+	case "", payloadEncodingJSON:
+	case payloadEncodingConfluentAvro:
+		if eventSource.JSONBody {
+			errs = append(errs, errors.Errorf("payloadEncoding %s can not be set along with jsonBody", payloadEncodingConfluentAvro))
+		}
+	default:
+		errs = append(errs, errors.Errorf("payloadEncoding must be either %s or %s", payloadEncodingJSON, payloadEncodingConfluentAvro))
+	}
+	if err := eventsourcecommon.ValidateIDStrategy(eventSource.IDStrategy); err != nil {
+		errs = append(errs, err)
+	}
+	if err := eventsourcecommon.ValidateEnvelopeVersion(eventSource.EnvelopeVersion); err != nil {
+		errs = append(errs, err)
+	}
+	if ackResponse := eventSource.AckResponse; ackResponse != nil && ackResponse.ChannelField == "" && (ackResponse.AckChannel == nil || ackResponse.AckChannel.Name == "") {
+		errs = append(errs, errors.New("ackResponse requires either channelField or ackChannel name"))
+	}
+	if _, err := eventsourcecommon.DispatchTimeout(eventSource.DispatchTimeout, defaultDispatchTimeout); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateLastWillTopic(eventSource); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateKeyGen(eventSource.KeyGen); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := newTopicFilter(eventSource.TopicAllow, eventSource.TopicDeny); err != nil {
+		errs = append(errs, err)
+	}
+	if eventSource.MaxTopicLabels < 0 {
+		errs = append(errs, errors.New("maxTopicLabels must not be negative"))
+	}
+	if eventSource.Concurrency < 0 {
+		errs = append(errs, errors.New("concurrency must not be negative"))
+	}
+	if eventSource.MaxEventSizeBytes < 0 {
+		errs = append(errs, errors.New("maxEventSizeBytes must not be negative"))
+	}
+	if eventSource.MaxReconnectAttempts < 0 {
+		errs = append(errs, errors.New("maxReconnectAttempts must not be negative"))
+	}
+	if _, err := sampleRate(eventSource); err != nil {
+		errs = append(errs, err)
+	}
+	return utilerrors.Flatten(utilerrors.NewAggregate(errs))
+}
+
+func validateKeyGen(keyGen *v1alpha1.EmitterKeyGen) error {
+	if keyGen == nil {
+		return nil
+	}
+	if keyGen.MasterKey == nil {
+		return errors.New("master key secret selector must be specified for the key generation")
+	}
+	if keyGen.Permissions != "" {
+		if !strings.Contains(keyGen.Permissions, "r") {
+			return errors.New("key generation permissions must include r to subscribe to the channels")
+		}
+		for _, p := range keyGen.Permissions {
+			if !strings.ContainsRune(keyPermissions, p) {
+				return errors.Errorf("key generation permission %q is not one of %s", p, keyPermissions)
+			}
+		}
+	}
+	if keyGen.TTL != "" {
+		ttl, err := time.ParseDuration(keyGen.TTL)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse key generation ttl")
+		}
+		if ttl < minKeyTTL {
+			return errors.Errorf("key generation ttl must be at least %s", minKeyTTL)
+		}
+	}
+	return nil
+}
+
+func validateLastWillTopic(eventSource *v1alpha1.EmitterEventSource) error {
+	if eventSource.LastWillTopic == "" {
+		return nil
+	}
+	if eventSource.ChannelKey == "" && eventSource.KeyGen == nil {
+		return errors.New("lastWillTopic requires either channelKey or keyGen to subscribe to it")
+	}
+	for _, channel := range channels(eventSource) {
+		if channel.Name == eventSource.LastWillTopic {
+			return errors.Errorf("lastWillTopic %s is already subscribed to as a channel", eventSource.LastWillTopic)
+		}
+	}
+	return nil
+}
+
+func validateDispatchRetry(eventSource *v1alpha1.EmitterEventSource) error {
+	retry := eventSource.DispatchRetry
+	if retry == nil {
+		return nil
+	}
+	if eventSource.BackpressurePolicy != "" && eventSource.BackpressurePolicy != backpressurePolicyDrop {
+		// the other policies retry the failed dispatches until they succeed
+		return errors.Errorf("dispatchRetry only applies to the %s backpressure policy", backpressurePolicyDrop)
+	}
+	if retry.MaxRetries < 0 {
+		return errors.New("dispatchRetry maxRetries must not be negative")
+	}
+	if _, err := newDispatchRetryBackoff(retry); err != nil {
+		return errors.Wrap(err, "invalid dispatchRetry backoff")
 	}
 	return nil
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
 	"github.com/ghodss/yaml"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func TestValidateEventSource(t *testing.T) {
@@ -33,7 +34,7 @@ func TestValidateEventSource(t *testing.T) {
 
 	err := listener.ValidateEventSource(context.Background())
 	assert.Error(t, err)
-	assert.Equal(t, "broker url must be specified", err.Error())
+	assert.Equal(t, "[broker url must be specified, channel name must be specified]", err.Error())
 
 	content, err := ioutil.ReadFile(fmt.Sprintf("%s/%s", sources.EventSourceDir, "emitter.yaml"))
 	assert.Nil(t, err)
@@ -49,5 +50,393 @@ func TestValidateEventSource(t *testing.T) {
 		}
 		err := l.ValidateEventSource(context.Background())
 		assert.NoError(t, err)
+
+		l.EmitterEventSource.SubscriptionOptions = &v1alpha1.EmitterSubscriptionOptions{WithHistory: true}
+		err = l.ValidateEventSource(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, "last must be greater than 0 when history is enabled", err.Error())
+	}
+}
+
+func TestValidateChannels(t *testing.T) {
+	eventSource := &v1alpha1.EmitterEventSource{
+		Broker: "tcp://broker.argo-events.svc:4000",
+		Channels: []v1alpha1.EmitterChannel{
+			{Name: "hello", Key: "hello_key"},
+			{Name: "world", Key: "world_key"},
+		},
+	}
+	assert.NoError(t, validate(eventSource))
+
+	eventSource.ChannelName = "foo"
+	err := validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "channel key secret selector must be specified", err.Error())
+
+	eventSource.ChannelName = "hello"
+	eventSource.ChannelKey = "hello_key"
+	err = validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "channel hello is specified more than once", err.Error())
+
+	eventSource.ChannelName = ""
+	eventSource.ChannelKey = ""
+	eventSource.Channels[1].Key = ""
+	err = validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "channel key must be specified for channels[1]", err.Error())
+
+	eventSource.Channels = nil
+	err = validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "channel name must be specified", err.Error())
+}
+
+func TestValidateDeadLetterChannel(t *testing.T) {
+	eventSource := &v1alpha1.EmitterEventSource{
+		Broker:            "tcp://broker.argo-events.svc:4000",
+		ChannelName:       "hello",
+		ChannelKey:        "hello_key",
+		DeadLetterChannel: &v1alpha1.EmitterChannel{Name: "hello-dlq", Key: "hello_dlq_key"},
+	}
+	assert.NoError(t, validate(eventSource))
+
+	eventSource.DeadLetterChannel.Key = ""
+	err := validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "dead letter channel name and key must be specified", err.Error())
+
+	eventSource.DeadLetterChannel = &v1alpha1.EmitterChannel{Name: "hello", Key: "hello_key"}
+	err = validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "dead letter channel hello must not be subscribed", err.Error())
+}
+
+func TestValidateDrainTimeout(t *testing.T) {
+	eventSource := &v1alpha1.EmitterEventSource{
+		Broker:       "tcp://broker.argo-events.svc:4000",
+		ChannelName:  "hello",
+		ChannelKey:   "hello_key",
+		DrainTimeout: "10s",
+	}
+	assert.NoError(t, validate(eventSource))
+
+	eventSource.DrainTimeout = "10"
+	err := validate(eventSource)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse drain timeout")
+}
+
+func TestValidateRateLimit(t *testing.T) {
+	eventSource := &v1alpha1.EmitterEventSource{
+		Broker:             "tcp://broker.argo-events.svc:4000",
+		ChannelName:        "hello",
+		ChannelKey:         "hello_key",
+		MaxEventsPerSecond: 100,
+		OverflowPolicy:     "block",
+	}
+	assert.NoError(t, validate(eventSource))
+
+	eventSource.OverflowPolicy = "queue"
+	err := validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "overflowPolicy must be either drop or block", err.Error())
+
+	eventSource.OverflowPolicy = ""
+	eventSource.MaxEventsPerSecond = -1
+	err = validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "maxEventsPerSecond must not be negative", err.Error())
+
+	eventSource.MaxEventsPerSecond = 0
+	eventSource.BackpressurePolicy = "queue"
+	err = validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "backpressurePolicy must be one of drop, block or buffer", err.Error())
+
+	eventSource.BackpressurePolicy = "buffer"
+	eventSource.BufferSize = -1
+	err = validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "bufferSize must not be negative", err.Error())
+
+	eventSource.BufferSize = 0
+	eventSource.DispatchRetry = &v1alpha1.EmitterDispatchRetry{MaxRetries: 3}
+	err = validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "dispatchRetry only applies to the drop backpressure policy", err.Error())
+
+	eventSource.BackpressurePolicy = ""
+	assert.NoError(t, validate(eventSource))
+
+	eventSource.DispatchRetry.MaxRetries = -1
+	err = validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "dispatchRetry maxRetries must not be negative", err.Error())
+}
+
+func TestValidateIDStrategy(t *testing.T) {
+	eventSource := &v1alpha1.EmitterEventSource{
+		Broker:      "tcp://broker.argo-events.svc:4000",
+		ChannelName: "hello",
+		ChannelKey:  "hello_key",
+		IDStrategy:  "deterministic",
+	}
+	assert.NoError(t, validate(eventSource))
+
+	eventSource.IDStrategy = "sequential"
+	err := validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "idStrategy must be either random or deterministic", err.Error())
+}
+
+func TestValidateEnvelopeVersion(t *testing.T) {
+	eventSource := &v1alpha1.EmitterEventSource{
+		Broker:          "tcp://broker.argo-events.svc:4000",
+		ChannelName:     "hello",
+		ChannelKey:      "hello_key",
+		EnvelopeVersion: "1.0",
+	}
+	assert.NoError(t, validate(eventSource))
+
+	eventSource.EnvelopeVersion = "v2"
+	err := validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "envelopeVersion must be 1.0", err.Error())
+}
+
+func TestValidateAckResponse(t *testing.T) {
+	eventSource := &v1alpha1.EmitterEventSource{
+		Broker:      "tcp://broker.argo-events.svc:4000",
+		ChannelName: "hello",
+		ChannelKey:  "hello_key",
+		AckResponse: &v1alpha1.EmitterAckResponse{},
+	}
+	err := validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "ackResponse requires either channelField or ackChannel name", err.Error())
+
+	eventSource.AckResponse.AckChannel = &v1alpha1.EmitterChannel{Key: "acks_key"}
+	assert.Error(t, validate(eventSource))
+
+	eventSource.AckResponse.ChannelField = "replyTo"
+	assert.NoError(t, validate(eventSource))
+
+	eventSource.AckResponse = &v1alpha1.EmitterAckResponse{AckChannel: &v1alpha1.EmitterChannel{Name: "acks", Key: "acks_key"}}
+	assert.NoError(t, validate(eventSource))
+}
+
+func TestValidateLastWillTopic(t *testing.T) {
+	eventSource := &v1alpha1.EmitterEventSource{
+		Broker:        "tcp://broker.argo-events.svc:4000",
+		Channels:      []v1alpha1.EmitterChannel{{Name: "hello", Key: "hello_key"}},
+		LastWillTopic: "devices/lastwill/",
+	}
+	err := validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "lastWillTopic requires either channelKey or keyGen to subscribe to it", err.Error())
+
+	eventSource.KeyGen = &v1alpha1.EmitterKeyGen{MasterKey: &corev1.SecretKeySelector{Key: "master"}}
+	assert.NoError(t, validate(eventSource))
+
+	eventSource.LastWillTopic = "hello"
+	err = validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "lastWillTopic hello is already subscribed to as a channel", err.Error())
+}
+
+func TestValidateDispatchTimeout(t *testing.T) {
+	eventSource := &v1alpha1.EmitterEventSource{
+		Broker:          "tcp://broker.argo-events.svc:4000",
+		ChannelName:     "hello",
+		ChannelKey:      "hello_key",
+		DispatchTimeout: "5s",
+	}
+	assert.NoError(t, validate(eventSource))
+
+	eventSource.DispatchTimeout = "-5s"
+	err := validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "dispatchTimeout must be positive", err.Error())
+}
+
+func TestValidatePayloadEncoding(t *testing.T) {
+	eventSource := &v1alpha1.EmitterEventSource{
+		Broker:          "tcp://broker.argo-events.svc:4000",
+		ChannelName:     "hello",
+		ChannelKey:      "hello_key",
+		PayloadEncoding: "confluent-avro",
+	}
+	assert.NoError(t, validate(eventSource))
+
+	eventSource.JSONBody = true
+	err := validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "payloadEncoding confluent-avro can not be set along with jsonBody", err.Error())
+
+	eventSource.PayloadEncoding = "json"
+	assert.NoError(t, validate(eventSource))
+
+	eventSource.PayloadEncoding = "protobuf"
+	err = validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "payloadEncoding must be either json or confluent-avro", err.Error())
+}
+
+func TestValidateConnectionString(t *testing.T) {
+	eventSource := &v1alpha1.EmitterEventSource{
+		ChannelName:            "hello",
+		ChannelKey:             "hello_key",
+		ConnectionStringSecret: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "emitter"}, Key: "uri"},
+	}
+	assert.NoError(t, validate(eventSource))
+}
+
+func TestValidateCompression(t *testing.T) {
+	eventSource := &v1alpha1.EmitterEventSource{
+		Broker:      "tcp://broker.argo-events.svc:4000",
+		ChannelName: "hello",
+		ChannelKey:  "hello_key",
+		Compression: "gzip",
+	}
+	assert.NoError(t, validate(eventSource))
+
+	eventSource.Compression = "zstd"
+	err := validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "compression must be either none or gzip", err.Error())
+}
+
+func TestValidateTopicFilter(t *testing.T) {
+	eventSource := &v1alpha1.EmitterEventSource{
+		Broker:      "tcp://broker.argo-events.svc:4000",
+		ChannelName: "hello",
+		ChannelKey:  "hello_key",
+		TopicAllow:  []string{"sensor/**"},
+		TopicDeny:   []string{"sensor/debug/**"},
+	}
+	assert.NoError(t, validate(eventSource))
+
+	eventSource.TopicDeny = []string{"sensor/[debug"}
+	err := validate(eventSource)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid topicDeny: failed to compile the pattern "sensor/[debug"`)
+}
+
+func TestValidateMaxTopicLabels(t *testing.T) {
+	eventSource := &v1alpha1.EmitterEventSource{
+		Broker:            "tcp://broker.argo-events.svc:4000",
+		ChannelName:       "sensor/#/",
+		ChannelKey:        "sensor_key",
+		TopicMetricsLabel: true,
+		MaxTopicLabels:    20,
+	}
+	assert.NoError(t, validate(eventSource))
+
+	eventSource.MaxTopicLabels = -1
+	err := validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "maxTopicLabels must not be negative", err.Error())
+}
+
+func TestValidateConcurrency(t *testing.T) {
+	eventSource := &v1alpha1.EmitterEventSource{
+		Broker:      "tcp://broker.argo-events.svc:4000",
+		ChannelName: "sensor/#/",
+		ChannelKey:  "sensor_key",
+		Concurrency: 8,
 	}
+	assert.NoError(t, validate(eventSource))
+
+	eventSource.Concurrency = -1
+	err := validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "concurrency must not be negative", err.Error())
+}
+
+func TestValidateMaxEventSize(t *testing.T) {
+	eventSource := &v1alpha1.EmitterEventSource{
+		Broker:            "tcp://broker.argo-events.svc:4000",
+		ChannelName:       "sensor/#/",
+		ChannelKey:        "sensor_key",
+		MaxEventSizeBytes: 1 << 20,
+	}
+	assert.NoError(t, validate(eventSource))
+
+	eventSource.MaxEventSizeBytes = -1
+	err := validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "maxEventSizeBytes must not be negative", err.Error())
+}
+
+func TestValidateMaxReconnectAttempts(t *testing.T) {
+	eventSource := &v1alpha1.EmitterEventSource{
+		Broker:               "tcp://broker.argo-events.svc:4000",
+		ChannelName:          "sensor/#/",
+		ChannelKey:           "sensor_key",
+		MaxReconnectAttempts: 10,
+	}
+	assert.NoError(t, validate(eventSource))
+
+	eventSource.MaxReconnectAttempts = -1
+	err := validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "maxReconnectAttempts must not be negative", err.Error())
+}
+
+func TestValidateKeyGen(t *testing.T) {
+	eventSource := &v1alpha1.EmitterEventSource{
+		Broker:      "tcp://broker.argo-events.svc:4000",
+		ChannelName: "hello",
+		KeyGen:      &v1alpha1.EmitterKeyGen{},
+	}
+	err := validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "master key secret selector must be specified for the key generation", err.Error())
+
+	eventSource.KeyGen.MasterKey = &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "emitter"}, Key: "master-key"}
+	assert.NoError(t, validate(eventSource))
+
+	eventSource.KeyGen.Permissions = "w"
+	err = validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "key generation permissions must include r to subscribe to the channels", err.Error())
+
+	eventSource.KeyGen.Permissions = "rz"
+	err = validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, `key generation permission 'z' is not one of rwslpex`, err.Error())
+
+	eventSource.KeyGen.Permissions = "rp"
+	eventSource.KeyGen.TTL = "30s"
+	err = validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "key generation ttl must be at least 1m0s", err.Error())
+}
+
+func TestValidateTimeouts(t *testing.T) {
+	eventSource := &v1alpha1.EmitterEventSource{
+		Broker:         "tcp://broker.argo-events.svc:4000",
+		ChannelName:    "hello",
+		ChannelKey:     "hello_key",
+		ConnectTimeout: "5s",
+		KeepAlive:      "15s",
+	}
+	assert.NoError(t, validate(eventSource))
+
+	eventSource.ConnectTimeout = "0s"
+	err := validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "connect timeout must be positive", err.Error())
+
+	eventSource.ConnectTimeout = "5"
+	err = validate(eventSource)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse connect timeout")
+
+	eventSource.ConnectTimeout = ""
+	eventSource.KeepAlive = "500ms"
+	err = validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "keep alive must be at least 1s", err.Error())
 }
@@ -0,0 +1,58 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import "time"
+
+// workerPool processes the messages concurrently, on up to as many goroutines as it has workers. Submitting a
+// message blocks while all the workers are busy, so that the message callback holds the next messages back rather
+// than piling goroutines up.
+type workerPool struct {
+	slots   chan struct{}
+	running inflight
+}
+
+// newWorkerPool returns the pool processing the messages with the concurrency, or nil if they are processed one at
+// a time by the message callback.
+func newWorkerPool(concurrency int32) *workerPool {
+	if concurrency <= 1 {
+		return nil
+	}
+	return &workerPool{slots: make(chan struct{}, concurrency)}
+}
+
+// submit processes the message on a worker once one is free. It returns false once the pool is drained.
+func (p *workerPool) submit(process func()) bool {
+	if !p.running.start() {
+		return false
+	}
+	p.slots <- struct{}{}
+	go func() {
+		defer p.running.done()
+		defer func() {
+			<-p.slots
+		}()
+		process()
+	}()
+	return true
+}
+
+// drain stops accepting messages and waits up to the timeout for the ones being processed to complete.
+// It returns the number of messages still being processed when the timeout elapses.
+func (p *workerPool) drain(timeout time.Duration) int {
+	return p.running.drain(timeout)
+}
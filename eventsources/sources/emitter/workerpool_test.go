@@ -0,0 +1,86 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emitter
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWorkerPool(t *testing.T) {
+	assert.Nil(t, newWorkerPool(0))
+	assert.Nil(t, newWorkerPool(1))
+	assert.NotNil(t, newWorkerPool(2))
+}
+
+func TestWorkerPool(t *testing.T) {
+	t.Run("test the concurrency is bounded", func(t *testing.T) {
+		p := newWorkerPool(3)
+		var running, maxRunning int32
+		var wg sync.WaitGroup
+		release := make(chan struct{})
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.True(t, p.submit(func() {
+					n := atomic.AddInt32(&running, 1)
+					for {
+						m := atomic.LoadInt32(&maxRunning)
+						if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+							break
+						}
+					}
+					<-release
+					atomic.AddInt32(&running, -1)
+				}))
+			}()
+		}
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&running))
+		close(release)
+		wg.Wait()
+		assert.Equal(t, 0, p.drain(time.Minute))
+		assert.Equal(t, int32(3), maxRunning)
+	})
+
+	t.Run("test drain waits for the messages being processed", func(t *testing.T) {
+		p := newWorkerPool(2)
+		var processed int32
+		assert.True(t, p.submit(func() {
+			time.Sleep(50 * time.Millisecond)
+			atomic.AddInt32(&processed, 1)
+		}))
+		assert.Equal(t, 0, p.drain(time.Minute))
+		assert.Equal(t, int32(1), processed)
+		assert.False(t, p.submit(func() {}))
+	})
+
+	t.Run("test drain timeout", func(t *testing.T) {
+		p := newWorkerPool(2)
+		release := make(chan struct{})
+		defer close(release)
+		assert.True(t, p.submit(func() {
+			<-release
+		}))
+		assert.Equal(t, 1, p.drain(10*time.Millisecond))
+	})
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/eventsources/common/fsevent"
+	metrics "github.com/argoproj/argo-events/metrics"
+)
+
+// batchEventType is the type of the batch events, in the CloudEvents type of the batches
+const batchEventType = "batch"
+
+// Default bounds of a batch
+const (
+	defaultBatchMaxEvents = 100
+	defaultBatchMaxWait   = time.Second
+)
+
+// batcher collects the file events into batches, a batch being flushed once it holds maxEvents events or maxWait
+// elapsed since its first event.
+type batcher struct {
+	maxEvents int
+	maxWait   time.Duration
+	flush     func([]fsevent.Event)
+
+	lock    sync.Mutex
+	events  []fsevent.Event
+	timer   *time.Timer
+	stopped bool
+}
+
+func newBatcher(maxEvents int, maxWait time.Duration, flush func([]fsevent.Event)) *batcher {
+	return &batcher{maxEvents: maxEvents, maxWait: maxWait, flush: flush}
+}
+
+// add appends the event to the pending batch, flushing the batch if it is full. Once the batcher is stopped, the
+// events are flushed right away so that none is lost.
+func (b *batcher) add(event fsevent.Event) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.events = append(b.events, event)
+	if len(b.events) >= b.maxEvents || b.stopped {
+		b.flushLocked()
+		return
+	}
+	if b.timer == nil {
+		var timer *time.Timer
+		timer = time.AfterFunc(b.maxWait, func() {
+			b.expire(timer)
+		})
+		b.timer = timer
+	}
+}
+
+// expire flushes the batch the timer was started for, unless it has been flushed already.
+func (b *batcher) expire(timer *time.Timer) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.timer == timer {
+		b.flushLocked()
+	}
+}
+
+// flushLocked flushes the pending batch, if any. The lock is held while flushing so that the batches are
+// dispatched in order.
+func (b *batcher) flushLocked() {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	if len(b.events) == 0 {
+		return
+	}
+	events := b.events
+	b.events = nil
+	b.flush(events)
+}
+
+// stop flushes the pending batch, so that the trailing events are not lost when the event source stops.
+func (b *batcher) stop() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.stopped = true
+	b.flushLocked()
+}
+
+// newBatcher returns the batcher of the file events, which dispatches the batches, or nil if the events are not
+// batched.
+func (el *EventListener) newBatcher(dispatch func([]byte, ...eventsourcecommon.Options) error, log *zap.SugaredLogger) (*batcher, error) {
+	config := el.FileEventSource.Batch
+	if config == nil {
+		return nil, nil
+	}
+	maxEvents := int(config.MaxEvents)
+	if maxEvents <= 0 {
+		maxEvents = defaultBatchMaxEvents
+	}
+	maxWait := defaultBatchMaxWait
+	if config.MaxWait != "" {
+		d, err := time.ParseDuration(config.MaxWait)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the batch maxWait %s", config.MaxWait)
+		}
+		maxWait = d
+	}
+	log.Infow("batching the file events...", zap.Int("maxEvents", maxEvents), zap.Duration("maxWait", maxWait))
+	return newBatcher(maxEvents, maxWait, func(batch []fsevent.Event) {
+		if err := el.dispatchBatch(batch, dispatch); err != nil {
+			log.Errorw("failed to dispatch a batch of file events", zap.Int("count", len(batch)), zap.Error(err))
+			el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.ReasonOf(err))
+		}
+	}), nil
+}
+
+// dispatchBatch dispatches the batch as a single event, whose payload is the JSON array of the file events, in the
+// output format of the event source. The event carries the number of file events of the batch in its batchcount
+// extension.
+func (el *EventListener) dispatchBatch(batch []fsevent.Event, dispatch func([]byte, ...eventsourcecommon.Options) error) error {
+	payload, err := json.Marshal(batch)
+	if err != nil {
+		return metrics.WithReason(errors.Wrap(err, "failed to marshal the batch of file events"), metrics.FailureReasonMarshal)
+	}
+	id := eventsourcecommon.EventID(el.FileEventSource.IDStrategy, el.GetEventSourceName(), el.GetEventName(), batchEventType, payload)
+	if el.FileEventSource.OutputFormat == outputFormatCloudEvents {
+		if payload, err = encodeCloudEvent(el.GetEventSourceName(), id, fsevent.Event{Type: batchEventType}, payload); err != nil {
+			return metrics.WithReason(errors.Wrap(err, "failed to encode the batch of file events as a cloud event"), metrics.FailureReasonMarshal)
+		}
+	}
+	if err = dispatch(payload, eventsourcecommon.WithID(id), eventsourcecommon.WithBatchCount(len(batch))); err != nil {
+		return metrics.WithReason(errors.Wrap(err, "failed to dispatch the batch of file events"), eventsourcecommon.DispatchFailureReason(err))
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/eventsources/common/fsevent"
+	"github.com/argoproj/argo-events/metrics"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestBatcher(t *testing.T) {
+	var lock sync.Mutex
+	var batches [][]string
+	b := newBatcher(3, 50*time.Millisecond, func(events []fsevent.Event) {
+		lock.Lock()
+		defer lock.Unlock()
+		var names []string
+		for _, e := range events {
+			names = append(names, e.Name)
+		}
+		batches = append(batches, names)
+	})
+	get := func() [][]string {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([][]string(nil), batches...)
+	}
+
+	// flushed once full
+	for _, name := range []string{"a", "b", "c"} {
+		b.add(fsevent.Event{Name: name})
+	}
+	assert.Equal(t, [][]string{{"a", "b", "c"}}, get())
+
+	// flushed once the wait elapsed
+	b.add(fsevent.Event{Name: "d"})
+	assert.Len(t, get(), 1)
+	assert.Eventually(t, func() bool {
+		return len(get()) == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"d"}, get()[1])
+
+	// flushed when stopped, then right away
+	b.add(fsevent.Event{Name: "e"})
+	b.stop()
+	assert.Equal(t, []string{"e"}, get()[2])
+	b.add(fsevent.Event{Name: "f"})
+	assert.Equal(t, []string{"f"}, get()[3])
+	time.Sleep(100 * time.Millisecond)
+	assert.Len(t, get(), 4)
+}
+
+func TestDispatchBatch(t *testing.T) {
+	el := &EventListener{
+		EventSourceName: "file",
+		EventName:       "example",
+	}
+	batch := []fsevent.Event{{Name: "a.txt", Op: fsevent.Write}, {Name: "b.txt", Op: fsevent.Write}}
+	var payloads [][]byte
+	var events []event.Event
+	dispatch := func(data []byte, opts ...eventsourcecommon.Options) error {
+		payloads = append(payloads, data)
+		e := cloudevents.NewEvent()
+		for _, opt := range opts {
+			assert.NoError(t, opt(&e))
+		}
+		events = append(events, e)
+		return nil
+	}
+	assert.NoError(t, el.dispatchBatch(batch, dispatch))
+	var fileEvents []fsevent.Event
+	assert.NoError(t, json.Unmarshal(payloads[0], &fileEvents))
+	assert.Equal(t, batch, fileEvents)
+	assert.Equal(t, int32(2), events[0].Extensions()[eventsourcecommon.BatchCountExtension])
+
+	el.FileEventSource = v1alpha1.FileEventSource{OutputFormat: outputFormatCloudEvents}
+	assert.NoError(t, el.dispatchBatch(batch, dispatch))
+	e := cloudevents.NewEvent()
+	assert.NoError(t, json.Unmarshal(payloads[1], &e))
+	assert.Equal(t, "io.argoproj.events.file.batch", e.Type())
+	assert.NoError(t, e.DataAs(&fileEvents))
+	assert.Len(t, fileEvents, 2)
+}
+
+func TestDispatchBatchTooLarge(t *testing.T) {
+	el := &EventListener{
+		EventSourceName: "file",
+		EventName:       "example",
+	}
+	batch := []fsevent.Event{{Name: "a.txt", Op: fsevent.Write}, {Name: "b.txt", Op: fsevent.Write}}
+	dispatched := 0
+	dispatch := eventsourcecommon.DispatchWithMaxSize(16, func(data []byte, opts ...eventsourcecommon.Options) error {
+		dispatched++
+		return nil
+	})
+	err := el.dispatchBatch(batch, dispatch)
+	assert.True(t, errors.Is(err, eventsourcecommon.ErrEventTooLarge))
+	assert.Equal(t, metrics.FailureReasonTooLarge, metrics.ReasonOf(err))
+	assert.Equal(t, 0, dispatched)
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/argoproj/argo-events/eventsources/common/fsevent"
+)
+
+// Formats of the dispatched payloads
+const (
+	// outputFormatNative dispatches the file event as is, the default
+	outputFormatNative = "native"
+	// outputFormatCloudEvents dispatches the file event wrapped into a structured CloudEvents 1.0 envelope
+	outputFormatCloudEvents = "cloudevents"
+)
+
+// cloudEventTypePrefix is the prefix of the type of the CloudEvents, followed by the lowercase operation
+const cloudEventTypePrefix = "io.argoproj.events.file."
+
+// encodeCloudEvent wraps the file event payload into a structured CloudEvents 1.0 envelope, whose source is
+// the event source name and whose type derives from the operation, e.g. io.argoproj.events.file.create, or is
+// io.argoproj.events.file.heartbeat for the heartbeat events and io.argoproj.events.file.batch for the batches.
+func encodeCloudEvent(eventSourceName, id string, fileEvent fsevent.Event, payload []byte) ([]byte, error) {
+	event := cloudevents.NewEvent()
+	event.SetID(id)
+	event.SetSource(eventSourceName)
+	eventType := fileEvent.Type
+	if eventType == "" {
+		eventType = strings.ReplaceAll(fileEvent.Op.String(), "|", ".")
+	}
+	event.SetType(cloudEventTypePrefix + strings.ToLower(eventType))
+	event.SetSubject(fileEvent.Name)
+	event.SetTime(time.Now().UTC())
+	if err := event.SetData(cloudevents.ApplicationJSON, json.RawMessage(payload)); err != nil {
+		return nil, err
+	}
+	return json.Marshal(event)
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"encoding/json"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-events/eventsources/common/fsevent"
+)
+
+func TestEncodeCloudEvent(t *testing.T) {
+	fileEvent := fsevent.Event{Name: "/data/x.txt", Op: fsevent.Create, Metadata: map[string]string{"team": "a"}}
+	payload, err := json.Marshal(fileEvent)
+	assert.NoError(t, err)
+
+	b, err := encodeCloudEvent("file-source", "8e1c5c1e-0c0a-4b1e-9c7a-2f5c0b6f1d3a", fileEvent, payload)
+	assert.NoError(t, err)
+
+	var raw map[string]interface{}
+	assert.NoError(t, json.Unmarshal(b, &raw))
+	assert.Equal(t, "1.0", raw["specversion"])
+	assert.Equal(t, "application/json", raw["datacontenttype"])
+
+	event := cloudevents.NewEvent()
+	assert.NoError(t, json.Unmarshal(b, &event))
+	assert.NoError(t, event.Validate())
+	assert.Equal(t, "8e1c5c1e-0c0a-4b1e-9c7a-2f5c0b6f1d3a", event.ID())
+	assert.Equal(t, "file-source", event.Source())
+	assert.Equal(t, "io.argoproj.events.file.create", event.Type())
+	assert.Equal(t, "/data/x.txt", event.Subject())
+	assert.False(t, event.Time().IsZero())
+
+	var data fsevent.Event
+	assert.NoError(t, event.DataAs(&data))
+	assert.Equal(t, fileEvent, data)
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/argoproj/argo-events/eventsources/common/fsevent"
+)
+
+// configMapSettleWindow is how long the update of a projected volume waits after the swap of its data link for the
+// events that follow it, e.g. the links of the keys added to the ConfigMap, before being dispatched
+const configMapSettleWindow = 200 * time.Millisecond
+
+// newVolumeUpdates returns the debouncer collapsing the events of the projected volume into its updates, or nil if
+// the volume updates aren't dispatched
+func (el *EventListener) newVolumeUpdates(log *zap.SugaredLogger) *debouncer {
+	if !el.FileEventSource.ConfigMapMode {
+		return nil
+	}
+	log.Info("dispatching the updates of the projected volume...")
+	return newDebouncer(configMapSettleWindow)
+}
+
+// volumeFiles returns the files of the projected volume mounted at the watched directory which match the watch
+// path configuration and aren't ignored, along with the paths they resolve to.
+func (el *EventListener) volumeFiles(pathRegexp *regexp.Regexp, ignores *ignoreMatcher, log *zap.SugaredLogger) []fsevent.VolumeFile {
+	directory := el.FileEventSource.WatchPathConfig.Directory
+	var files []fsevent.VolumeFile
+	for _, path := range el.watchedFiles(pathRegexp, log) {
+		if isProjectedInternal(directory, path) || (ignores != nil && ignores.ignores(path)) {
+			continue
+		}
+		target, err := filepath.EvalSymlinks(path)
+		if err != nil {
+			log.Debugw("failed to resolve the file of the volume, skip it", zap.String("path", path), zap.Error(err))
+			continue
+		}
+		info, err := os.Stat(target)
+		if err != nil || info.IsDir() || !el.accepts(path, fsevent.Update, log) {
+			continue
+		}
+		files = append(files, fsevent.VolumeFile{Name: path, Target: target, Size: info.Size()})
+	}
+	return files
+}
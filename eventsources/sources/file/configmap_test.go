@@ -0,0 +1,134 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/eventsources/common/fsevent"
+	"github.com/argoproj/argo-events/metrics"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// projectVolume lays out the files like the kubelet updates a projected volume: the files are written to a new
+// timestamped directory, the ..data link is atomically swapped to it, then the links of the added keys are created
+// and the ones of the removed keys deleted.
+func projectVolume(t *testing.T, dir, version string, files map[string]string) {
+	data := filepath.Join(dir, ".."+version)
+	assert.NoError(t, os.Mkdir(data, 0755))
+	for name, content := range files {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(data, name), []byte(content), 0600))
+	}
+	assert.NoError(t, os.Symlink(".."+version, filepath.Join(dir, "..data_tmp")))
+	assert.NoError(t, os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")))
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	for _, entry := range entries {
+		if _, ok := files[entry.Name()]; !ok && !isProjectedInternal(dir, filepath.Join(dir, entry.Name())) {
+			assert.NoError(t, os.Remove(filepath.Join(dir, entry.Name())))
+		}
+	}
+	for name := range files {
+		if _, err := os.Lstat(filepath.Join(dir, name)); os.IsNotExist(err) {
+			assert.NoError(t, os.Symlink(filepath.Join("..data", name), filepath.Join(dir, name)))
+		}
+	}
+}
+
+func TestVolumeFiles(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	assert.NoError(t, err)
+	projectVolume(t, dir, "v1", map[string]string{"app.yaml": "a: 1", "db.yaml": "b: 2", "notes.txt": "hello"})
+
+	el := &EventListener{
+		FileEventSource: v1alpha1.FileEventSource{
+			WatchPathConfig: v1alpha1.WatchPathConfig{Directory: dir, Path: "*"},
+			Extensions:      []string{"yaml"},
+		},
+	}
+	files := el.volumeFiles(nil, newIgnoreMatcher(dir, []string{"db.yaml"}, false), zap.NewNop().Sugar())
+	assert.Equal(t, []fsevent.VolumeFile{
+		{Name: filepath.Join(dir, "app.yaml"), Target: filepath.Join(dir, "..v1", "app.yaml"), Size: 4},
+	}, files)
+}
+
+func TestConfigMapMode(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	assert.NoError(t, err)
+	projectVolume(t, dir, "v1", map[string]string{"app.yaml": "a: 1"})
+
+	el := &EventListener{
+		EventSourceName: "file",
+		EventName:       "example",
+		FileEventSource: v1alpha1.FileEventSource{
+			EventType:       "UPDATE",
+			WatchPathConfig: v1alpha1.WatchPathConfig{Directory: dir, Path: "*"},
+			ConfigMapMode:   true,
+		},
+		Metrics: metrics.NewMetrics("ns"),
+	}
+
+	var lock sync.Mutex
+	var updates []fsevent.Event
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = el.StartListening(ctx, func(data []byte, _ ...eventsourcecommon.Options) error {
+			var event fsevent.Event
+			assert.NoError(t, json.Unmarshal(data, &event))
+			lock.Lock()
+			defer lock.Unlock()
+			updates = append(updates, event)
+			return nil
+		})
+	}()
+	// let the watcher start before updating the volume
+	time.Sleep(200 * time.Millisecond)
+
+	// a key is added along with the update of the other one
+	projectVolume(t, dir, "v2", map[string]string{"app.yaml": "a: 2", "db.yaml": "b: 2"})
+	assert.NoError(t, os.RemoveAll(filepath.Join(dir, "..v1")))
+
+	assert.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(updates) > 0
+	}, 10*time.Second, 50*time.Millisecond)
+	// no other event follows the update
+	time.Sleep(2 * configMapSettleWindow)
+
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Len(t, updates, 1)
+	assert.Equal(t, dir, updates[0].Name)
+	assert.Equal(t, fsevent.Update, updates[0].Op)
+	assert.Equal(t, []fsevent.VolumeFile{
+		{Name: filepath.Join(dir, "app.yaml"), Target: filepath.Join(dir, "..v2", "app.yaml"), Size: 4},
+		{Name: filepath.Join(dir, "db.yaml"), Target: filepath.Join(dir, "..v2", "db.yaml"), Size: 4},
+	}, updates[0].Files)
+}
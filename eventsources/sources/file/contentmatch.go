@@ -0,0 +1,90 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+
+	"github.com/argoproj/argo-events/eventsources/common/fsevent"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+const (
+	// contentMatchDispatch dispatches the events
+	contentMatchDispatch = "dispatch"
+	// contentMatchSuppress drops the events
+	contentMatchSuppress = "suppress"
+)
+
+// contentMatcher tells whether a WRITE event is dispatched according to the content appended to the file
+type contentMatcher struct {
+	regex     *regexp.Regexp
+	onMatch   string
+	onNoMatch string
+	maxBytes  int64
+}
+
+func newContentMatcher(config *v1alpha1.FileContentMatch, maxBytes int64) (*contentMatcher, error) {
+	regex, err := regexp.Compile(config.Regex)
+	if err != nil {
+		return nil, err
+	}
+	m := &contentMatcher{regex: regex, onMatch: config.OnMatch, onNoMatch: config.OnNoMatch, maxBytes: maxBytes}
+	if m.onMatch == "" {
+		m.onMatch = contentMatchDispatch
+	}
+	if m.onNoMatch == "" {
+		m.onNoMatch = contentMatchSuppress
+	}
+	return m, nil
+}
+
+// accepts tells whether the WRITE event, whose change has been attached by the offset tracker, is dispatched.
+// Up to maxBytes of the appended content are read from the file at the path.
+func (m *contentMatcher) accepts(fileEvent *fsevent.Event, path string) (bool, error) {
+	matched, err := m.matches(fileEvent, path)
+	if err != nil {
+		return false, err
+	}
+	if matched {
+		return m.onMatch == contentMatchDispatch, nil
+	}
+	return m.onNoMatch == contentMatchDispatch, nil
+}
+
+func (m *contentMatcher) matches(fileEvent *fsevent.Event, path string) (bool, error) {
+	if fileEvent.Change != fsevent.ChangeAppended || fileEvent.RangeEnd <= fileEvent.RangeStart {
+		return false, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	length := fileEvent.RangeEnd - fileEvent.RangeStart
+	if length > m.maxBytes {
+		length = m.maxBytes
+	}
+	content, err := ioutil.ReadAll(io.NewSectionReader(f, fileEvent.RangeStart, length))
+	if err != nil {
+		return false, err
+	}
+	return m.regex.Match(content), nil
+}
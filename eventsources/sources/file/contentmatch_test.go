@@ -0,0 +1,70 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-events/eventsources/common/fsevent"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestContentMatcher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("INFO started\nERROR failed\nINFO done\n"), 0644))
+	appended := func(start, end int64) *fsevent.Event {
+		return &fsevent.Event{Name: path, Op: fsevent.Write, Change: fsevent.ChangeAppended, RangeStart: start, RangeEnd: end}
+	}
+
+	matcher, err := newContentMatcher(&v1alpha1.FileContentMatch{Regex: "ERROR"}, defaultMaxContentBytes)
+	assert.NoError(t, err)
+	// only the appended range is matched
+	accepted, err := matcher.accepts(appended(13, 26), path)
+	assert.NoError(t, err)
+	assert.True(t, accepted)
+	accepted, err = matcher.accepts(appended(26, 36), path)
+	assert.NoError(t, err)
+	assert.False(t, accepted)
+	// a truncation appends nothing
+	accepted, err = matcher.accepts(&fsevent.Event{Name: path, Op: fsevent.Write, Change: fsevent.ChangeTruncated, RangeStart: 0, RangeEnd: 36}, path)
+	assert.NoError(t, err)
+	assert.False(t, accepted)
+
+	// the appended content is matched up to the max bytes
+	matcher, err = newContentMatcher(&v1alpha1.FileContentMatch{Regex: "ERROR"}, 5)
+	assert.NoError(t, err)
+	accepted, err = matcher.accepts(appended(0, 36), path)
+	assert.NoError(t, err)
+	assert.False(t, accepted)
+
+	// the policies can be inverted
+	matcher, err = newContentMatcher(&v1alpha1.FileContentMatch{Regex: "ERROR", OnMatch: contentMatchSuppress, OnNoMatch: contentMatchDispatch}, defaultMaxContentBytes)
+	assert.NoError(t, err)
+	accepted, err = matcher.accepts(appended(13, 26), path)
+	assert.NoError(t, err)
+	assert.False(t, accepted)
+	accepted, err = matcher.accepts(appended(26, 36), path)
+	assert.NoError(t, err)
+	assert.True(t, accepted)
+
+	_, err = matcher.accepts(appended(0, 36), filepath.Join(t.TempDir(), "missing.log"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"sync"
+	"time"
+)
+
+// debouncer collapses the bursts of events on a same path into the last event of the burst.
+type debouncer struct {
+	window  time.Duration
+	lock    sync.Mutex
+	pending map[string]*pendingEvent
+}
+
+// pendingEvent is the processing of the last event received for a path, waiting for the window to elapse.
+type pendingEvent struct {
+	timer   *time.Timer
+	process func()
+}
+
+func newDebouncer(window time.Duration) *debouncer {
+	return &debouncer{
+		window:  window,
+		pending: make(map[string]*pendingEvent),
+	}
+}
+
+// debounce schedules the processing of an event once no other event has been received for the path
+// within the window, replacing the processing of the previous event of the path if any.
+func (d *debouncer) debounce(path string, process func()) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if p, ok := d.pending[path]; ok {
+		p.timer.Stop()
+	}
+	p := &pendingEvent{process: process}
+	p.timer = time.AfterFunc(d.window, func() {
+		if d.take(path, p) {
+			p.process()
+		}
+	})
+	d.pending[path] = p
+}
+
+// isPending tells whether an event of the path is waiting for the window to elapse.
+func (d *debouncer) isPending(path string) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	_, ok := d.pending[path]
+	return ok
+}
+
+// flush processes the event pending for the path right away, if any.
+func (d *debouncer) flush(path string) {
+	d.lock.Lock()
+	p, ok := d.pending[path]
+	if ok {
+		p.timer.Stop()
+		delete(d.pending, path)
+	}
+	d.lock.Unlock()
+	if ok {
+		p.process()
+	}
+}
+
+// stop processes all the pending events right away, so that the last event of a burst isn't lost on shutdown.
+func (d *debouncer) stop() {
+	d.lock.Lock()
+	pending := d.pending
+	d.pending = make(map[string]*pendingEvent)
+	d.lock.Unlock()
+	for _, p := range pending {
+		// a timer which has already fired doesn't process its event once it has been taken from the pending ones
+		p.timer.Stop()
+		p.process()
+	}
+}
+
+// take removes the pending event of the path, returning false if it has been superseded in the meantime.
+func (d *debouncer) take(path string, p *pendingEvent) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.pending[path] != p {
+		return false
+	}
+	delete(d.pending, path)
+	return true
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebouncer(t *testing.T) {
+	d := newDebouncer(50 * time.Millisecond)
+	defer d.stop()
+
+	var lock sync.Mutex
+	var processed []string
+	record := func(s string) func() {
+		return func() {
+			lock.Lock()
+			defer lock.Unlock()
+			processed = append(processed, s)
+		}
+	}
+	get := func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]string(nil), processed...)
+	}
+
+	d.debounce("a", record("a-1"))
+	d.debounce("a", record("a-2"))
+	d.debounce("b", record("b-1"))
+	d.debounce("a", record("a-3"))
+	assert.Empty(t, get())
+	assert.Eventually(t, func() bool {
+		return len(get()) == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.ElementsMatch(t, []string{"a-3", "b-1"}, get())
+
+	d.debounce("c", record("c-1"))
+	d.flush("c")
+	assert.Contains(t, get(), "c-1")
+	d.flush("c")
+	time.Sleep(100 * time.Millisecond)
+	assert.Len(t, get(), 3)
+
+	// the pending events are processed on stop rather than dropped
+	d.debounce("d", record("d-1"))
+	d.stop()
+	assert.Contains(t, get(), "d-1")
+	time.Sleep(100 * time.Millisecond)
+	assert.Len(t, get(), 4)
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/eventsources/common/fsevent"
+	metrics "github.com/argoproj/argo-events/metrics"
+)
+
+// digestEventType is the type of the digest events, in the CloudEvents type of the digests
+const digestEventType = "digest"
+
+// digestInterval returns the interval between the digest events, 0 if no digest is dispatched
+func digestInterval(interval string) (time.Duration, error) {
+	if interval == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(interval)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to parse the digest interval %s", interval)
+	}
+	if d <= 0 {
+		return 0, errors.New("digestInterval must be positive")
+	}
+	return d, nil
+}
+
+// startDigest dispatches a digest of the watched paths on every interval until the context is done or the returned
+// function is called. The returned function waits for the ticker to be stopped, so that no digest is dispatched
+// once it returns.
+func (el *EventListener) startDigest(ctx context.Context, interval time.Duration, dispatch func([]byte, ...eventsourcecommon.Options) error, log *zap.SugaredLogger) func() {
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := el.dispatchDigest(el.digest(log), dispatch); err != nil {
+					log.Errorw("failed to dispatch a digest event", zap.Error(err))
+					el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.ReasonOf(err))
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+// digest lists the files of the watched paths which aren't ignored and pass the extension and size filters, along
+// with their size and checksum, sorted by name. A file which can't be read, e.g. removed while listed, is skipped.
+func (el *EventListener) digest(log *zap.SugaredLogger) []fsevent.DigestFile {
+	listeners := []*EventListener{el}
+	if len(el.FileEventSource.Paths) > 0 {
+		listeners = el.pathListeners()
+	}
+	files := []fsevent.DigestFile{}
+	for _, listener := range listeners {
+		config := &listener.FileEventSource
+		var pathRegexp *regexp.Regexp
+		if config.WatchPathConfig.PathRegexp != "" {
+			var err error
+			if pathRegexp, err = regexp.Compile(config.WatchPathConfig.PathRegexp); err != nil {
+				log.Errorw("failed to compile the path regex, skip the path in the digest", zap.String("regex", config.WatchPathConfig.PathRegexp), zap.Error(err))
+				continue
+			}
+		}
+		ignores := newIgnoreMatcher(config.WatchPathConfig.Directory, config.IgnorePatterns, config.IgnoreEditorTempFiles)
+		listener.walkExisting(pathRegexp, func(path string) {
+			if ignores != nil && ignores.ignores(path) || !listener.accepts(path, fsevent.Create, log) {
+				return
+			}
+			size, checksum, err := fileChecksum(path)
+			if err != nil {
+				log.Debugw("failed to read the file, skip it in the digest", zap.String("path", path), zap.Error(err))
+				return
+			}
+			files = append(files, fsevent.DigestFile{Name: path, Size: size, Checksum: checksum, WatchPath: listener.watchPath})
+		}, log)
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].Name < files[j].Name
+	})
+	return files
+}
+
+// dispatchDigest dispatches the digest of the watched paths, in the output format of the event source. A digest
+// isn't about a single file, it isn't batched.
+func (el *EventListener) dispatchDigest(files []fsevent.DigestFile, dispatch func([]byte, ...eventsourcecommon.Options) error) error {
+	fileEvent := fsevent.Event{Type: digestEventType, Digest: files, Metadata: el.FileEventSource.Metadata}
+	payload, err := json.Marshal(fileEvent)
+	if err != nil {
+		return metrics.WithReason(errors.Wrap(err, "failed to marshal the digest event"), metrics.FailureReasonMarshal)
+	}
+	id := eventsourcecommon.EventID(el.FileEventSource.IDStrategy, el.GetEventSourceName(), el.GetEventName(), digestEventType, payload)
+	if el.FileEventSource.OutputFormat == outputFormatCloudEvents {
+		if payload, err = encodeCloudEvent(el.GetEventSourceName(), id, fileEvent, payload); err != nil {
+			return metrics.WithReason(errors.Wrap(err, "failed to encode the digest event as a cloud event"), metrics.FailureReasonMarshal)
+		}
+	}
+	if err = dispatch(payload, eventsourcecommon.WithID(id)); err != nil {
+		return metrics.WithReason(errors.Wrap(err, "failed to dispatch the digest event"), eventsourcecommon.DispatchFailureReason(err))
+	}
+	return nil
+}
+
+// fileChecksum returns the size of the file and the hex encoded SHA-256 checksum of its content
+func fileChecksum(path string) (int64, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, "", err
+	}
+	defer f.Close()
+	hash := sha256.New()
+	n, err := io.Copy(hash, f)
+	if err != nil {
+		return 0, "", err
+	}
+	return n, hex.EncodeToString(hash.Sum(nil)), nil
+}
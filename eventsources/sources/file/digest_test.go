@@ -0,0 +1,156 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/eventsources/common/fsevent"
+	"github.com/argoproj/argo-events/metrics"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestDigestInterval(t *testing.T) {
+	interval, err := digestInterval("")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), interval)
+	interval, err = digestInterval("1h")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, interval)
+	_, err = digestInterval("0s")
+	assert.EqualError(t, err, "digestInterval must be positive")
+	_, err = digestInterval("soon")
+	assert.Error(t, err)
+}
+
+func TestDigest(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "app.json"), []byte("hello"), 0600))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "db.json"), []byte("{}"), 0600))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "notes.txt"), []byte("notes"), 0600))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "app.json.tmp"), []byte("tmp"), 0600))
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "nested"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "nested", "deep.json"), []byte("deep"), 0600))
+
+	el := &EventListener{
+		FileEventSource: v1alpha1.FileEventSource{
+			WatchPathConfig: v1alpha1.WatchPathConfig{Directory: dir, PathRegexp: ".*"},
+			Extensions:      []string{"json"},
+			IgnorePatterns:  []string{"db.json"},
+		},
+	}
+	assert.Equal(t, []fsevent.DigestFile{
+		{Name: filepath.Join(dir, "app.json"), Size: 5, Checksum: "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+	}, el.digest(zap.NewNop().Sugar()))
+
+	el.FileEventSource.Recursive = true
+	el.FileEventSource.MinSizeBytes = 5
+	files := el.digest(zap.NewNop().Sugar())
+	assert.Len(t, files, 1)
+	assert.Equal(t, filepath.Join(dir, "app.json"), files[0].Name)
+
+	el.FileEventSource.MinSizeBytes = 0
+	files = el.digest(zap.NewNop().Sugar())
+	assert.Len(t, files, 2)
+	assert.Equal(t, filepath.Join(dir, "nested", "deep.json"), files[1].Name)
+}
+
+func TestDigestPaths(t *testing.T) {
+	configs, data := t.TempDir(), t.TempDir()
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(configs, "app.yaml"), []byte("a: 1"), 0600))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(data, "users.json"), []byte("[]"), 0600))
+
+	el := &EventListener{
+		FileEventSource: v1alpha1.FileEventSource{
+			Paths: []v1alpha1.FileWatchPath{
+				{Name: "configs", EventType: "WRITE", WatchPathConfig: v1alpha1.WatchPathConfig{Directory: configs, Path: "*.yaml"}},
+				{Name: "data", EventType: "CREATE", WatchPathConfig: v1alpha1.WatchPathConfig{Directory: data, Path: "*.json"}},
+			},
+		},
+	}
+	files := el.digest(zap.NewNop().Sugar())
+	assert.Len(t, files, 2)
+	watchPaths := map[string]string{}
+	for _, file := range files {
+		watchPaths[file.Name] = file.WatchPath
+	}
+	assert.Equal(t, map[string]string{filepath.Join(configs, "app.yaml"): "configs", filepath.Join(data, "users.json"): "data"}, watchPaths)
+}
+
+func TestDispatchDigest(t *testing.T) {
+	el := &EventListener{
+		EventSourceName: "file",
+		EventName:       "example",
+		FileEventSource: v1alpha1.FileEventSource{Metadata: map[string]string{"team": "a"}},
+	}
+	files := []fsevent.DigestFile{{Name: "/data/app.json", Size: 5, Checksum: "abc"}}
+	var payloads [][]byte
+	dispatch := func(data []byte, _ ...eventsourcecommon.Options) error {
+		payloads = append(payloads, data)
+		return nil
+	}
+	assert.NoError(t, el.dispatchDigest(files, dispatch))
+	var fileEvent fsevent.Event
+	assert.NoError(t, json.Unmarshal(payloads[0], &fileEvent))
+	assert.Equal(t, fsevent.Event{Type: "digest", Digest: files, Metadata: map[string]string{"team": "a"}}, fileEvent)
+
+	el.FileEventSource.OutputFormat = outputFormatCloudEvents
+	assert.NoError(t, el.dispatchDigest(files, dispatch))
+	event := cloudevents.NewEvent()
+	assert.NoError(t, json.Unmarshal(payloads[1], &event))
+	assert.Equal(t, "io.argoproj.events.file.digest", event.Type())
+}
+
+func TestStartDigest(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "app.json"), []byte("hello"), 0600))
+	el := &EventListener{
+		EventSourceName: "file",
+		EventName:       "example",
+		FileEventSource: v1alpha1.FileEventSource{WatchPathConfig: v1alpha1.WatchPathConfig{Directory: dir, Path: "*.json"}},
+		Metrics:         metrics.NewMetrics("ns"),
+	}
+	digests := make(chan fsevent.Event, 10)
+	stop := el.startDigest(context.Background(), 20*time.Millisecond, func(data []byte, _ ...eventsourcecommon.Options) error {
+		var fileEvent fsevent.Event
+		assert.NoError(t, json.Unmarshal(data, &fileEvent))
+		digests <- fileEvent
+		return nil
+	}, zap.NewNop().Sugar())
+	select {
+	case fileEvent := <-digests:
+		assert.Len(t, fileEvent.Digest, 1)
+		assert.Equal(t, filepath.Join(dir, "app.json"), fileEvent.Digest[0].Name)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no digest event was dispatched")
+	}
+	stop()
+	count := len(digests)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, count, len(digests))
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultEditorDebounceWindow is the window the events of a file and of its editor temporary files are grouped
+// within, if DebounceMillis isn't set.
+const defaultEditorDebounceWindow = 200 * time.Millisecond
+
+// editorTempFile is the naming of the temporary, backup or swap files an editor saves a file through, i.e. the name
+// of the file between a prefix and a suffix.
+type editorTempFile struct {
+	prefix string
+	suffix string
+}
+
+// editorTempFiles are the names of the temporary files of the common editors recognized by EditorAwareDebounce,
+// checked in order.
+var editorTempFiles = []editorTempFile{
+	// vim swap files
+	{prefix: ".", suffix: ".swp"},
+	{prefix: ".", suffix: ".swx"},
+	{prefix: ".", suffix: ".swo"},
+	{prefix: "", suffix: ".swp"},
+	// vim and emacs backup files
+	{prefix: "", suffix: "~"},
+	// emacs auto-save and lock files
+	{prefix: "#", suffix: "#"},
+	{prefix: ".#", suffix: ""},
+	// JetBrains safe write files
+	{prefix: "", suffix: "___jb_tmp___"},
+	{prefix: "", suffix: "___jb_old___"},
+}
+
+// editorTarget returns the path of the file the editor temporary file at the path belongs to, and true, or the path
+// itself and false if it isn't the temporary file of an editor.
+func editorTarget(path string) (string, bool) {
+	dir, base := filepath.Split(path)
+	for _, f := range editorTempFiles {
+		if len(base) <= len(f.prefix)+len(f.suffix) || !strings.HasPrefix(base, f.prefix) || !strings.HasSuffix(base, f.suffix) {
+			continue
+		}
+		return filepath.Join(dir, base[len(f.prefix):len(base)-len(f.suffix)]), true
+	}
+	return path, false
+}
+
+// editorGrouper collapses the events of a file and of its editor temporary files, e.g. the write of file.swp or
+// file~ renamed onto file, into a single WRITE event of the file once no event of the group has been received
+// within the window.
+type editorGrouper struct {
+	debouncer *debouncer
+	lock      sync.Mutex
+	// touched holds the files of the pending groups, true once the file itself had an event
+	touched map[string]bool
+}
+
+func newEditorGrouper(window time.Duration) *editorGrouper {
+	return &editorGrouper{
+		debouncer: newDebouncer(window),
+		touched:   make(map[string]bool),
+	}
+}
+
+// add groups the event of the path with the events of the file it belongs to. Once the window elapses, write is
+// called with the path of the file if the file itself had an event and still exists. The groups of the temporary
+// files only, e.g. the swap file of a file opened without being saved, are dropped.
+func (g *editorGrouper) add(path string, write func(path string)) {
+	target, temp := editorTarget(path)
+	g.lock.Lock()
+	g.touched[target] = g.touched[target] || !temp
+	g.lock.Unlock()
+	g.debouncer.debounce(target, func() {
+		g.lock.Lock()
+		touched := g.touched[target]
+		delete(g.touched, target)
+		g.lock.Unlock()
+		if !touched {
+			return
+		}
+		if _, err := os.Stat(target); err != nil {
+			return
+		}
+		write(target)
+	})
+}
+
+// stop writes the pending groups right away.
+func (g *editorGrouper) stop() {
+	g.debouncer.stop()
+}
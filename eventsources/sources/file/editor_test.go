@@ -0,0 +1,156 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/eventsources/common/fsevent"
+	"github.com/argoproj/argo-events/metrics"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestEditorTarget(t *testing.T) {
+	tests := []struct {
+		path   string
+		target string
+		temp   bool
+	}{
+		{"/config/app.yaml", "/config/app.yaml", false},
+		{"/config/.app.yaml.swp", "/config/app.yaml", true},
+		{"/config/.app.yaml.swx", "/config/app.yaml", true},
+		{"/config/.app.yaml.swo", "/config/app.yaml", true},
+		{"/config/app.yaml.swp", "/config/app.yaml", true},
+		{"/config/app.yaml~", "/config/app.yaml", true},
+		{"/config/.bashrc~", "/config/.bashrc", true},
+		{"/config/#app.yaml#", "/config/app.yaml", true},
+		{"/config/.#app.yaml", "/config/app.yaml", true},
+		{"/config/app.yaml___jb_tmp___", "/config/app.yaml", true},
+		{"/config/app.yaml___jb_old___", "/config/app.yaml", true},
+		{"/config/~", "/config/~", false},
+		{"/config/##", "/config/##", false},
+	}
+	for _, tt := range tests {
+		target, temp := editorTarget(tt.path)
+		assert.Equal(t, tt.target, target, tt.path)
+		assert.Equal(t, tt.temp, temp, tt.path)
+	}
+}
+
+func TestEditorGrouper(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "app.yaml")
+	assert.NoError(t, ioutil.WriteFile(file, []byte("a: 1"), 0o600))
+
+	g := newEditorGrouper(50 * time.Millisecond)
+	defer g.stop()
+	var lock sync.Mutex
+	var writes []string
+	write := func(path string) {
+		lock.Lock()
+		defer lock.Unlock()
+		writes = append(writes, path)
+	}
+	written := func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]string{}, writes...)
+	}
+
+	// a save through the backup and the swap files collapses into a single write
+	for _, name := range []string{".app.yaml.swp", "app.yaml", "app.yaml~", "app.yaml", "app.yaml~"} {
+		g.add(filepath.Join(dir, name), write)
+	}
+	assert.Eventually(t, func() bool { return len(written()) > 0 }, time.Second, 10*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, []string{file}, written())
+
+	// the swap file of a file opened without being saved
+	g.add(filepath.Join(dir, ".app.yaml.swp"), write)
+	// a file removed during the window
+	g.add(filepath.Join(dir, "gone.yaml"), write)
+	time.Sleep(150 * time.Millisecond)
+	assert.Equal(t, []string{file}, written())
+}
+
+func TestEditorAwareDebounce(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	assert.NoError(t, err)
+	file := filepath.Join(dir, "app.yaml")
+	assert.NoError(t, ioutil.WriteFile(file, []byte("a: 1"), 0o600))
+
+	el := &EventListener{
+		EventSourceName: "file",
+		EventName:       "example",
+		FileEventSource: v1alpha1.FileEventSource{
+			EventType:           "WRITE",
+			WatchPathConfig:     v1alpha1.WatchPathConfig{Directory: dir, Path: "app.yaml"},
+			EditorAwareDebounce: true,
+			DebounceMillis:      100,
+		},
+		Metrics: metrics.NewMetrics("ns"),
+	}
+
+	var lock sync.Mutex
+	var dispatched []fsevent.Event
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = el.StartListening(ctx, func(data []byte, _ ...eventsourcecommon.Options) error {
+			var event fsevent.Event
+			assert.NoError(t, json.Unmarshal(data, &event))
+			lock.Lock()
+			defer lock.Unlock()
+			dispatched = append(dispatched, event)
+			return nil
+		})
+	}()
+	// let the watcher start before saving the file
+	time.Sleep(200 * time.Millisecond)
+
+	// vim writes its swap file, renames the file to its backup, writes the file again and removes the backup
+	swap := filepath.Join(dir, ".app.yaml.swp")
+	assert.NoError(t, ioutil.WriteFile(swap, []byte("swap"), 0o600))
+	assert.NoError(t, os.Rename(file, file+"~"))
+	assert.NoError(t, ioutil.WriteFile(file, []byte("a: 2"), 0o600))
+	assert.NoError(t, os.Remove(file+"~"))
+	assert.NoError(t, os.Remove(swap))
+
+	assert.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(dispatched) > 0
+	}, 5*time.Second, 20*time.Millisecond)
+	// no other event follows the save
+	time.Sleep(300 * time.Millisecond)
+
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Len(t, dispatched, 1)
+	assert.Equal(t, file, dispatched[0].Name)
+	assert.Equal(t, fsevent.Write, dispatched[0].Op)
+}
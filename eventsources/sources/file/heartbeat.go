@@ -0,0 +1,48 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/eventsources/common/fsevent"
+	metrics "github.com/argoproj/argo-events/metrics"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+)
+
+// dispatchHeartbeat dispatches a heartbeat event, in the output format of the event source. A heartbeat isn't
+// about a file, it isn't filtered by the watch paths and the file settings.
+func (el *EventListener) dispatchHeartbeat(heartbeat events.HeartbeatData, dispatch func([]byte, ...eventsourcecommon.Options) error) error {
+	fileEvent := fsevent.Event{Type: eventsourcecommon.HeartbeatEventType, Heartbeat: &heartbeat, Metadata: el.FileEventSource.Metadata}
+	payload, err := json.Marshal(fileEvent)
+	if err != nil {
+		return metrics.WithReason(errors.Wrap(err, "failed to marshal the heartbeat event"), metrics.FailureReasonMarshal)
+	}
+	id := eventsourcecommon.EventID(el.FileEventSource.IDStrategy, el.GetEventSourceName(), el.GetEventName(), eventsourcecommon.HeartbeatEventType, payload)
+	if el.FileEventSource.OutputFormat == outputFormatCloudEvents {
+		if payload, err = encodeCloudEvent(el.GetEventSourceName(), id, fileEvent, payload); err != nil {
+			return metrics.WithReason(errors.Wrap(err, "failed to encode the heartbeat event as a cloud event"), metrics.FailureReasonMarshal)
+		}
+	}
+	if err = dispatch(payload, eventsourcecommon.WithID(id)); err != nil {
+		return metrics.WithReason(errors.Wrap(err, "failed to dispatch the heartbeat event"), eventsourcecommon.DispatchFailureReason(err))
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/eventsources/common/fsevent"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestDispatchHeartbeat(t *testing.T) {
+	el := &EventListener{
+		EventSourceName: "file",
+		EventName:       "example",
+		FileEventSource: v1alpha1.FileEventSource{Metadata: map[string]string{"team": "a"}},
+	}
+	heartbeat := events.HeartbeatData{Time: time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC), Sequence: 3}
+	var payloads [][]byte
+	dispatch := func(data []byte, _ ...eventsourcecommon.Options) error {
+		payloads = append(payloads, data)
+		return nil
+	}
+	assert.NoError(t, el.dispatchHeartbeat(heartbeat, dispatch))
+	var fileEvent fsevent.Event
+	assert.NoError(t, json.Unmarshal(payloads[0], &fileEvent))
+	assert.Equal(t, fsevent.Event{Type: "heartbeat", Heartbeat: &heartbeat, Metadata: map[string]string{"team": "a"}}, fileEvent)
+
+	el.FileEventSource.OutputFormat = outputFormatCloudEvents
+	assert.NoError(t, el.dispatchHeartbeat(heartbeat, dispatch))
+	event := cloudevents.NewEvent()
+	assert.NoError(t, json.Unmarshal(payloads[1], &event))
+	assert.Equal(t, "io.argoproj.events.file.heartbeat", event.Type())
+	assert.NoError(t, event.DataAs(&fileEvent))
+	assert.Equal(t, uint64(3), fileEvent.Heartbeat.Sequence)
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+)
+
+// editorTempFilePatterns are the patterns of the temporary, backup and lock files of the common editors,
+// ignored with IgnoreEditorTempFiles.
+var editorTempFilePatterns = []string{"*~", ".*.swp", ".*.swx", ".#*", "\\#*#"}
+
+// ignoreMatcher tells whether the events of a file are ignored according to gitignore-style patterns, matched
+// against the path of the file relative to the watched directory.
+type ignoreMatcher struct {
+	root    string
+	matcher gitignore.Matcher
+}
+
+// newIgnoreMatcher returns the matcher of the patterns, or nil if there is no pattern. As in a .gitignore file, the
+// empty patterns and the ones starting with a # are skipped, and a later pattern overrides the earlier ones, so that
+// a pattern negated with a ! includes the files an earlier pattern ignores.
+func newIgnoreMatcher(root string, patterns []string, editorTempFiles bool) *ignoreMatcher {
+	if editorTempFiles {
+		patterns = append(append([]string{}, editorTempFilePatterns...), patterns...)
+	}
+	var ps []gitignore.Pattern
+	for _, p := range patterns {
+		if strings.TrimSpace(p) == "" || strings.HasPrefix(p, "#") {
+			continue
+		}
+		ps = append(ps, gitignore.ParsePattern(p, nil))
+	}
+	if len(ps) == 0 {
+		return nil
+	}
+	return &ignoreMatcher{root: root, matcher: gitignore.NewMatcher(ps)}
+}
+
+// ignores tells whether the events of the file at the path are ignored. A path out of the watched directory is
+// never ignored.
+func (m *ignoreMatcher) ignores(path string) bool {
+	relPath, err := filepath.Rel(m.root, path)
+	if err != nil || relPath == "." || strings.HasPrefix(relPath, "..") {
+		return false
+	}
+	info, err := os.Stat(path)
+	isDir := err == nil && info.IsDir()
+	return m.matcher.Match(strings.Split(filepath.ToSlash(relPath), "/"), isDir)
+}
+
+// validateIgnorePatterns checks that the segments of the patterns are valid globs
+func validateIgnorePatterns(patterns []string) error {
+	for _, p := range patterns {
+		for _, segment := range strings.Split(strings.TrimPrefix(p, "!"), "/") {
+			if _, err := filepath.Match(segment, ""); err != nil {
+				return fmt.Errorf("ignorePatterns %q must be a valid pattern, %w", p, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIgnoreMatcher(t *testing.T) {
+	assert.Nil(t, newIgnoreMatcher("/data", nil, false))
+	assert.Nil(t, newIgnoreMatcher("/data", []string{"", "# comment"}, false))
+
+	root := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "cache"), 0755))
+	m := newIgnoreMatcher(root, []string{"*.tmp", "/build/*.json", "cache/", "!cache/keep.lock", "logs/**/*.gz"}, false)
+	for _, path := range []string{"a.tmp", "nested/a.tmp", "build/x.json", "cache", "cache/x.lock", "logs/2021/06/app.gz"} {
+		assert.True(t, m.ignores(filepath.Join(root, path)), path)
+	}
+	for _, path := range []string{"a.txt", "nested/build/x.json", "build/nested/x.json", "cache/keep.lock", "logs/app.log", "a.swp"} {
+		assert.False(t, m.ignores(filepath.Join(root, path)), path)
+	}
+	// the patterns are matched against the path relative to the watched directory, not the absolute path
+	m = newIgnoreMatcher(root, []string{filepath.Base(root) + "/"}, false)
+	assert.False(t, m.ignores(filepath.Join(root, "a.txt")))
+	assert.False(t, m.ignores(filepath.Join(filepath.Dir(root), "other", "a.tmp")))
+}
+
+func TestIgnoreMatcherEditorTempFiles(t *testing.T) {
+	m := newIgnoreMatcher("/data", nil, true)
+	for _, path := range []string{"a.txt~", ".a.txt.swp", "nested/.a.txt.swx", ".#a.txt", "#a.txt#"} {
+		assert.True(t, m.ignores(filepath.Join("/data", path)), path)
+	}
+	assert.False(t, m.ignores("/data/a.txt"))
+
+	// the user patterns override the editor ones
+	m = newIgnoreMatcher("/data", []string{"!keep~"}, true)
+	assert.True(t, m.ignores("/data/a.txt~"))
+	assert.False(t, m.ignores("/data/keep~"))
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package file
+
+import (
+	"sync"
+	"time"
+)
+
+// defaultInodeWindow is the window within which the events of a same inode collapse into a single event
+const defaultInodeWindow = 100 * time.Millisecond
+
+// inodeKey identifies a file whatever its path
+type inodeKey struct {
+	dev uint64
+	ino uint64
+}
+
+// inodeDeduper collapses the events of a same inode received within the window, e.g. the CREATE, CHMOD and RENAME of
+// a temporary file followed by the CREATE of the target path of an atomic save, into the last event of the burst.
+// It remembers the inodes of the paths, so that the events of a path which has been renamed or removed can be matched.
+type inodeDeduper struct {
+	window time.Duration
+	// inodeOf returns the inode of the file, false if it isn't available, e.g. on the platforms without inodes
+	inodeOf func(path string) (inodeKey, bool)
+
+	lock    sync.Mutex
+	inodes  map[string]inodeKey
+	pending map[inodeKey]*pendingEvent
+}
+
+func newInodeDeduper(window time.Duration) *inodeDeduper {
+	return &inodeDeduper{
+		window:  window,
+		inodeOf: fileInode,
+		inodes:  make(map[string]inodeKey),
+		pending: make(map[inodeKey]*pendingEvent),
+	}
+}
+
+// observe remembers the inode of the file at the path, if available.
+func (d *inodeDeduper) observe(path string) {
+	key, ok := d.inodeOf(path)
+	if !ok {
+		return
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.inodes[path] = key
+}
+
+// forget drops the inode of a path which has been renamed or removed.
+func (d *inodeDeduper) forget(path string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	delete(d.inodes, path)
+}
+
+// dedupe schedules the processing of an event once no other event of the same inode has been received within the
+// window, replacing the processing of the previous event of the inode if any. It returns false, leaving the event
+// to be processed right away, if the inode of the path is unknown.
+func (d *inodeDeduper) dedupe(path string, process func()) bool {
+	key, ok := d.inodeOf(path)
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if ok {
+		d.inodes[path] = key
+	} else if key, ok = d.inodes[path]; ok {
+		// the path is gone, e.g. renamed or removed
+		delete(d.inodes, path)
+	} else {
+		return false
+	}
+	if p, ok := d.pending[key]; ok {
+		p.timer.Stop()
+	}
+	p := &pendingEvent{process: process}
+	p.timer = time.AfterFunc(d.window, func() {
+		if d.take(key, p) {
+			p.process()
+		}
+	})
+	d.pending[key] = p
+	return true
+}
+
+// stop processes all the pending events right away, so that the last event of a burst isn't lost on shutdown.
+func (d *inodeDeduper) stop() {
+	d.lock.Lock()
+	pending := d.pending
+	d.pending = make(map[inodeKey]*pendingEvent)
+	d.lock.Unlock()
+	for _, p := range pending {
+		// a timer which has already fired doesn't process its event once it has been taken from the pending ones
+		p.timer.Stop()
+		p.process()
+	}
+}
+
+// take removes the pending event of the inode, returning false if it has been superseded in the meantime.
+func (d *inodeDeduper) take(key inodeKey, p *pendingEvent) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.pending[key] != p {
+		return false
+	}
+	delete(d.pending, key)
+	return true
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInodeDeduper(t *testing.T) {
+	d := newInodeDeduper(50 * time.Millisecond)
+	defer d.stop()
+	files := map[string]inodeKey{
+		"tmp":    {dev: 1, ino: 10},
+		"target": {dev: 1, ino: 10},
+		"other":  {dev: 1, ino: 11},
+	}
+	var lock sync.Mutex
+	d.inodeOf = func(path string) (inodeKey, bool) {
+		lock.Lock()
+		defer lock.Unlock()
+		key, ok := files[path]
+		return key, ok
+	}
+
+	var processed []string
+	record := func(s string) func() {
+		return func() {
+			lock.Lock()
+			defer lock.Unlock()
+			processed = append(processed, s)
+		}
+	}
+	get := func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]string(nil), processed...)
+	}
+
+	// an atomic save: the temporary file is created, then renamed over the target
+	assert.True(t, d.dedupe("tmp", record("tmp-create")))
+	assert.True(t, d.dedupe("tmp", record("tmp-chmod")))
+	assert.True(t, d.dedupe("other", record("other-write")))
+	lock.Lock()
+	delete(files, "tmp")
+	lock.Unlock()
+	assert.True(t, d.dedupe("tmp", record("tmp-rename")))
+	assert.True(t, d.dedupe("target", record("target-create")))
+	assert.Empty(t, get())
+	assert.Eventually(t, func() bool {
+		return len(get()) == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.ElementsMatch(t, []string{"target-create", "other-write"}, get())
+
+	// the inode of the renamed path has been forgotten
+	assert.False(t, d.dedupe("tmp", record("tmp-remove")))
+	assert.False(t, d.dedupe("unknown", record("unknown-remove")))
+
+	d.observe("other")
+	lock.Lock()
+	delete(files, "other")
+	lock.Unlock()
+	// the pending events are processed on stop rather than dropped
+	assert.True(t, d.dedupe("other", record("other-remove")))
+	d.stop()
+	assert.Contains(t, get(), "other-remove")
+	time.Sleep(100 * time.Millisecond)
+	assert.Len(t, get(), 3)
+}
+
+func TestFileInode(t *testing.T) {
+	dir := t.TempDir()
+	tmp := filepath.Join(dir, "x.txt.tmp")
+	target := filepath.Join(dir, "x.txt")
+	require.NoError(t, os.WriteFile(tmp, []byte("x"), 0600))
+	before, ok := fileInode(tmp)
+	if !ok {
+		t.Skip("the inodes are not available on this platform")
+	}
+	require.NoError(t, os.Rename(tmp, target))
+	after, ok := fileInode(target)
+	assert.True(t, ok)
+	assert.Equal(t, before, after)
+	_, ok = fileInode(tmp)
+	assert.False(t, ok)
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	"github.com/argoproj/argo-events/eventsources/common/fsevent"
+)
+
+// attachMetadataPaths evaluates the metadata paths against the JSON content of the file on CREATE and WRITE events,
+// and adds the values to the metadata of the event, over the user defined metadata of the same keys.
+func (el *EventListener) attachMetadataPaths(fileEvent *fsevent.Event, path string, log *zap.SugaredLogger) {
+	paths := el.FileEventSource.MetadataPaths
+	if len(paths) == 0 || fileEvent.Op&(fsevent.Create|fsevent.Write) == 0 {
+		return
+	}
+	var content []byte
+	if fileEvent.ContentEncoding == fsevent.ContentEncodingText && !fileEvent.Truncated {
+		// the content already attached to the event is the whole file
+		content = []byte(fileEvent.Content)
+	} else {
+		maxBytes := el.FileEventSource.MaxContentBytes
+		if maxBytes <= 0 {
+			maxBytes = defaultMaxContentBytes
+		}
+		var err error
+		if content, err = readFile(path, maxBytes); err != nil {
+			log.Debugw("failed to read the file for the metadata paths", zap.String("path", path), zap.Error(err))
+		}
+	}
+	fileEvent.Metadata = extractMetadata(fileEvent.Metadata, paths, content)
+}
+
+// extractMetadata returns a copy of the metadata along with the values of the paths in the JSON content, keyed by the
+// metadata keys of the paths. A path yields an empty value if the content isn't JSON or the path doesn't match.
+func extractMetadata(metadata map[string]string, paths map[string]string, content []byte) map[string]string {
+	result := make(map[string]string, len(metadata)+len(paths))
+	for k, v := range metadata {
+		result[k] = v
+	}
+	valid := gjson.ValidBytes(content)
+	for key, path := range paths {
+		result[key] = ""
+		if valid {
+			result[key] = gjson.GetBytes(content, path).String()
+		}
+	}
+	return result
+}
+
+// readFile reads up to maxBytes of the file
+func readFile(path string, maxBytes int64) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ioutil.ReadAll(io.LimitReader(f, maxBytes))
+}
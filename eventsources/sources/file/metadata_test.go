@@ -0,0 +1,68 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/argoproj/argo-events/eventsources/common/fsevent"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestExtractMetadata(t *testing.T) {
+	paths := map[string]string{"owner": "spec.owner", "replicas": "spec.replicas", "first": "items.0.name", "missing": "spec.missing"}
+	content := []byte(`{"spec":{"owner":"team-a","replicas":3},"items":[{"name":"x"}]}`)
+
+	metadata := extractMetadata(map[string]string{"env": "prod", "owner": "static"}, paths, content)
+	assert.Equal(t, map[string]string{"env": "prod", "owner": "team-a", "replicas": "3", "first": "x", "missing": ""}, metadata)
+
+	metadata = extractMetadata(nil, paths, []byte("owner: team-a"))
+	assert.Equal(t, map[string]string{"owner": "", "replicas": "", "first": "", "missing": ""}, metadata)
+}
+
+func TestAttachMetadataPaths(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "x.json")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`{"kind":"order"}`), 0644))
+	log := zap.NewNop().Sugar()
+	staticMetadata := map[string]string{"env": "prod"}
+	el := &EventListener{FileEventSource: v1alpha1.FileEventSource{MetadataPaths: map[string]string{"kind": "kind"}}}
+
+	event := &fsevent.Event{Op: fsevent.Write, Metadata: staticMetadata}
+	el.attachMetadataPaths(event, path, log)
+	assert.Equal(t, map[string]string{"env": "prod", "kind": "order"}, event.Metadata)
+	// the user defined metadata shared by the events is left untouched
+	assert.Equal(t, map[string]string{"env": "prod"}, staticMetadata)
+
+	// the attached content is used when it holds the whole file
+	event = &fsevent.Event{Op: fsevent.Create, Content: `{"kind":"invoice"}`, ContentEncoding: fsevent.ContentEncodingText}
+	el.attachMetadataPaths(event, path, log)
+	assert.Equal(t, "invoice", event.Metadata["kind"])
+
+	event = &fsevent.Event{Op: fsevent.Write}
+	el.attachMetadataPaths(event, filepath.Join(dir, "removed.json"), log)
+	assert.Equal(t, map[string]string{"kind": ""}, event.Metadata)
+
+	event = &fsevent.Event{Op: fsevent.Remove}
+	el.attachMetadataPaths(event, path, log)
+	assert.Nil(t, event.Metadata)
+}
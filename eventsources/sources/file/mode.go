@@ -0,0 +1,88 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/argoproj/argo-events/eventsources/common/fsevent"
+)
+
+// modeTracker remembers the mode of the watched files, so that a CHMOD event can report the mode the file had before.
+type modeTracker struct {
+	lock  sync.Mutex
+	modes map[string]os.FileMode
+}
+
+func newModeTracker() *modeTracker {
+	return &modeTracker{modes: make(map[string]os.FileMode)}
+}
+
+// record remembers the current mode of the file, if it can be read.
+func (t *modeTracker) record(path string) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.modes[path] = info.Mode()
+}
+
+// forget drops the mode of a file which has been removed or renamed.
+func (t *modeTracker) forget(path string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.modes, path)
+}
+
+// attach sets the mode the file had before the CHMOD event, if known, and its new mode to the event.
+// The new mode is zero and the event flagged if the file can't be read anymore.
+func (t *modeTracker) attach(fileEvent *fsevent.Event, path string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if old, ok := t.modes[path]; ok {
+		fileEvent.OldMode = formatMode(old)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		delete(t.modes, path)
+		fileEvent.NewMode = formatMode(0)
+		fileEvent.StatFailed = true
+		fileEvent.Error = err.Error()
+		return
+	}
+	t.modes[path] = info.Mode()
+	fileEvent.NewMode = formatMode(info.Mode())
+}
+
+// formatMode formats the permission bits of the mode in octal, including the setuid, setgid and sticky bits, e.g. 4755.
+func formatMode(mode os.FileMode) string {
+	bits := uint32(mode.Perm())
+	if mode&os.ModeSetuid != 0 {
+		bits |= 04000
+	}
+	if mode&os.ModeSetgid != 0 {
+		bits |= 02000
+	}
+	if mode&os.ModeSticky != 0 {
+		bits |= 01000
+	}
+	return fmt.Sprintf("%04o", bits)
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-events/eventsources/common/fsevent"
+)
+
+func TestModeTracker(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "x.txt")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("x"), 0644))
+	assert.NoError(t, os.Chmod(path, 0644))
+
+	modes := newModeTracker()
+	modes.record(path)
+	assert.NoError(t, os.Chmod(path, 0600))
+	event := fsevent.Event{Name: path, Op: fsevent.Chmod}
+	modes.attach(&event, path)
+	assert.Equal(t, "0644", event.OldMode)
+	assert.Equal(t, "0600", event.NewMode)
+	assert.False(t, event.StatFailed)
+
+	// the mode attached last is remembered
+	assert.NoError(t, os.Chmod(path, 0640))
+	event = fsevent.Event{Name: path, Op: fsevent.Chmod}
+	modes.attach(&event, path)
+	assert.Equal(t, "0600", event.OldMode)
+	assert.Equal(t, "0640", event.NewMode)
+
+	// the mode of a file unknown to the tracker is not reported
+	modes.forget(path)
+	event = fsevent.Event{Name: path, Op: fsevent.Chmod}
+	modes.attach(&event, path)
+	assert.Empty(t, event.OldMode)
+	assert.Equal(t, "0640", event.NewMode)
+
+	assert.NoError(t, os.Remove(path))
+	event = fsevent.Event{Name: path, Op: fsevent.Chmod}
+	modes.attach(&event, path)
+	assert.Equal(t, "0640", event.OldMode)
+	assert.Equal(t, "0000", event.NewMode)
+	assert.True(t, event.StatFailed)
+	assert.NotEmpty(t, event.Error)
+}
+
+func TestFormatMode(t *testing.T) {
+	assert.Equal(t, "0000", formatMode(0))
+	assert.Equal(t, "0755", formatMode(os.ModeDir|0755))
+	assert.Equal(t, "4755", formatMode(os.ModeSetuid|0755))
+	assert.Equal(t, "3775", formatMode(os.ModeSetgid|os.ModeSticky|0775))
+}
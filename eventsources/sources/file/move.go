@@ -0,0 +1,72 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// defaultMoveWindow is the window within which a RENAME followed by a CREATE is considered a move.
+const defaultMoveWindow = 100 * time.Millisecond
+
+// moveDetector correlates a RENAME of the old path with the CREATE of the new path that fsnotify
+// reports for a move. It is not goroutine-safe, it is meant to be driven by the watcher loop.
+type moveDetector struct {
+	window  time.Duration
+	pending *fsnotify.Event
+	timer   *time.Timer
+}
+
+func newMoveDetector(window time.Duration) *moveDetector {
+	return &moveDetector{window: window}
+}
+
+// rename holds the RENAME event until the window elapses or a CREATE event is received.
+// It returns the event previously held, if any, which must be processed on its own.
+func (m *moveDetector) rename(event fsnotify.Event) *fsnotify.Event {
+	previous := m.take()
+	m.pending = &event
+	m.timer = time.NewTimer(m.window)
+	return previous
+}
+
+// create returns the RENAME event held, if any, which forms a move with the CREATE event.
+func (m *moveDetector) create() *fsnotify.Event {
+	return m.take()
+}
+
+// expired fires when the window of the RENAME event held elapses, the event must then be taken
+// and processed on its own. It never fires if no event is held, or if the detector is nil.
+func (m *moveDetector) expired() <-chan time.Time {
+	if m == nil || m.timer == nil {
+		return nil
+	}
+	return m.timer.C
+}
+
+// take removes the RENAME event held, if any.
+func (m *moveDetector) take() *fsnotify.Event {
+	if m.timer != nil {
+		m.timer.Stop()
+		m.timer = nil
+	}
+	event := m.pending
+	m.pending = nil
+	return event
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/stretchr/testify/assert"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/eventsources/common/fsevent"
+	metrics "github.com/argoproj/argo-events/metrics"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestMoveDetector(t *testing.T) {
+	t.Run("rename followed by create", func(t *testing.T) {
+		m := newMoveDetector(time.Second)
+		previous := m.rename(fsnotify.Event{Name: "/tmp/old.txt", Op: fsnotify.Rename})
+		assert.Nil(t, previous)
+		renamed := m.create()
+		assert.NotNil(t, renamed)
+		assert.Equal(t, "/tmp/old.txt", renamed.Name)
+		assert.Nil(t, m.expired())
+		assert.Nil(t, m.create())
+	})
+
+	t.Run("rename not followed by create", func(t *testing.T) {
+		m := newMoveDetector(10 * time.Millisecond)
+		m.rename(fsnotify.Event{Name: "/tmp/old.txt", Op: fsnotify.Rename})
+		select {
+		case <-m.expired():
+		case <-time.After(time.Second):
+			assert.Fail(t, "the window should have elapsed")
+		}
+		renamed := m.take()
+		assert.NotNil(t, renamed)
+		assert.Equal(t, "/tmp/old.txt", renamed.Name)
+		assert.Nil(t, m.expired())
+	})
+
+	t.Run("rename followed by rename", func(t *testing.T) {
+		m := newMoveDetector(time.Second)
+		m.rename(fsnotify.Event{Name: "/tmp/first.txt", Op: fsnotify.Rename})
+		previous := m.rename(fsnotify.Event{Name: "/tmp/second.txt", Op: fsnotify.Rename})
+		assert.NotNil(t, previous)
+		assert.Equal(t, "/tmp/first.txt", previous.Name)
+		renamed := m.create()
+		assert.NotNil(t, renamed)
+		assert.Equal(t, "/tmp/second.txt", renamed.Name)
+	})
+
+	t.Run("create without rename", func(t *testing.T) {
+		m := newMoveDetector(time.Second)
+		assert.Nil(t, m.create())
+	})
+
+	t.Run("nil detector", func(t *testing.T) {
+		var m *moveDetector
+		assert.Nil(t, m.expired())
+	})
+}
+
+func TestListenMovesNotWatched(t *testing.T) {
+	dir := t.TempDir()
+	el := &EventListener{
+		EventSourceName: "file",
+		EventName:       "example",
+		FileEventSource: v1alpha1.FileEventSource{
+			EventType:       "CREATE",
+			WatchPathConfig: v1alpha1.WatchPathConfig{Directory: dir, Path: "*.txt"},
+			DetectMoves:     true,
+		},
+		Metrics: metrics.NewMetrics("ns"),
+	}
+
+	var lock sync.Mutex
+	var received []fsevent.Event
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = el.StartListening(ctx, func(data []byte, _ ...eventsourcecommon.Options) error {
+			var event fsevent.Event
+			assert.NoError(t, json.Unmarshal(data, &event))
+			lock.Lock()
+			defer lock.Unlock()
+			received = append(received, event)
+			return nil
+		})
+	}()
+
+	// the CREATE of a move is still dispatched when MOVE isn't watched
+	i := 0
+	assert.Eventually(t, func() bool {
+		i++
+		oldPath := filepath.Join(dir, fmt.Sprintf("old-%d.tmp", i))
+		assert.NoError(t, ioutil.WriteFile(oldPath, []byte("hello"), 0600))
+		assert.NoError(t, os.Rename(oldPath, filepath.Join(dir, fmt.Sprintf("new-%d.txt", i))))
+		lock.Lock()
+		defer lock.Unlock()
+		for _, event := range received {
+			if event.Op == fsevent.Create && filepath.Ext(event.Name) == ".txt" {
+				return true
+			}
+		}
+		return false
+	}, 10*time.Second, 200*time.Millisecond)
+}
@@ -0,0 +1,51 @@
+//go:build linux
+// +build linux
+
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// the types of the network file systems reported by statfs(2)
+const (
+	nfsSuperMagic  = 0x6969
+	smbSuperMagic  = 0x517b
+	smb2SuperMagic = 0xfe534d42
+	cifsMagic      = 0xff534d42
+	cephSuperMagic = 0x00c36400
+	afsSuperMagic  = 0x5346414f
+	v9fsMagic      = 0x01021997
+)
+
+// isNetworkFS tells whether the directory is on a network file system, e.g. NFS or SMB, per its type reported by
+// statfs.
+func isNetworkFS(directory string) (bool, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(directory, &stat); err != nil {
+		return false, errors.Wrapf(err, "failed to stat the file system of %s", directory)
+	}
+	switch uint32(stat.Type) {
+	case nfsSuperMagic, smbSuperMagic, smb2SuperMagic, cifsMagic, cephSuperMagic, afsSuperMagic, v9fsMagic:
+		return true, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"os"
+	"sync"
+
+	"github.com/argoproj/argo-events/eventsources/common/fsevent"
+)
+
+// fileOffset is the last seen size of a file along with its inode, if available
+type fileOffset struct {
+	size     int64
+	inode    inodeKey
+	hasInode bool
+	// rotated is set once the file is removed or renamed, until it's written again
+	rotated bool
+}
+
+// offsetTracker remembers the size of the watched files, so that a WRITE event can tell whether data was appended
+// to the file or the file was truncated. A file recreated with a new inode, e.g. by a log rotation, is tracked from
+// the start again.
+type offsetTracker struct {
+	// inodeOf returns the inode of the file, false if it isn't available, e.g. on the platforms without inodes
+	inodeOf func(path string) (inodeKey, bool)
+
+	lock    sync.Mutex
+	offsets map[string]*fileOffset
+}
+
+func newOffsetTracker() *offsetTracker {
+	return &offsetTracker{inodeOf: fileInode, offsets: make(map[string]*fileOffset)}
+}
+
+// record remembers the current size of the file, if it can be read.
+func (t *offsetTracker) record(path string) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return
+	}
+	inode, hasInode := t.inodeOf(path)
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.offsets[path] = &fileOffset{size: info.Size(), inode: inode, hasInode: hasInode}
+}
+
+// rotate flags a file which has been removed or renamed, the next file written at the path is tracked from the start.
+func (t *offsetTracker) rotate(path string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if _, ok := t.offsets[path]; ok {
+		t.offsets[path] = &fileOffset{rotated: true}
+	}
+}
+
+// attach sets the change of the WRITE event to the event: Appended along with the range of the appended data if the
+// file grew or kept its size, Truncated along with the range of the data cut otherwise. A file unknown so far, or
+// recreated with a new inode, is tracked from the start and flagged as rotated if it replaced a tracked file.
+func (t *offsetTracker) attach(fileEvent *fsevent.Event, path string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	info, err := os.Stat(path)
+	if err != nil {
+		delete(t.offsets, path)
+		fileEvent.Error = err.Error()
+		return
+	}
+	inode, hasInode := t.inodeOf(path)
+	previous, ok := t.offsets[path]
+	if !ok {
+		previous = &fileOffset{}
+	} else if previous.hasInode && hasInode && previous.inode != inode {
+		previous = &fileOffset{rotated: true}
+	}
+	size := info.Size()
+	t.offsets[path] = &fileOffset{size: size, inode: inode, hasInode: hasInode}
+	fileEvent.Rotated = previous.rotated
+	if size < previous.size {
+		fileEvent.Change = fsevent.ChangeTruncated
+		fileEvent.RangeStart, fileEvent.RangeEnd = size, previous.size
+		return
+	}
+	fileEvent.Change = fsevent.ChangeAppended
+	fileEvent.RangeStart, fileEvent.RangeEnd = previous.size, size
+}
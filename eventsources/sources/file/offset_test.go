@@ -0,0 +1,98 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-events/eventsources/common/fsevent"
+)
+
+func TestOffsetTracker(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("first\n"), 0644))
+
+	inode := uint64(1)
+	offsets := newOffsetTracker()
+	offsets.inodeOf = func(string) (inodeKey, bool) {
+		return inodeKey{ino: inode}, true
+	}
+	offsets.record(path)
+	attach := func() fsevent.Event {
+		event := fsevent.Event{Name: path, Op: fsevent.Write}
+		offsets.attach(&event, path)
+		return event
+	}
+	appendLine := func(line string) {
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+		assert.NoError(t, err)
+		_, err = f.WriteString(line)
+		assert.NoError(t, err)
+		assert.NoError(t, f.Close())
+	}
+
+	appendLine("second\n")
+	event := attach()
+	assert.Equal(t, fsevent.ChangeAppended, event.Change)
+	assert.Equal(t, int64(6), event.RangeStart)
+	assert.Equal(t, int64(13), event.RangeEnd)
+	assert.False(t, event.Rotated)
+
+	// a truncated file reports the range of the data cut
+	assert.NoError(t, os.Truncate(path, 2))
+	event = attach()
+	assert.Equal(t, fsevent.ChangeTruncated, event.Change)
+	assert.Equal(t, int64(2), event.RangeStart)
+	assert.Equal(t, int64(13), event.RangeEnd)
+
+	// a file recreated with a new inode is tracked from its start
+	assert.NoError(t, ioutil.WriteFile(path, []byte("rotated\n"), 0644))
+	inode = 2
+	event = attach()
+	assert.Equal(t, fsevent.ChangeAppended, event.Change)
+	assert.Equal(t, int64(0), event.RangeStart)
+	assert.Equal(t, int64(8), event.RangeEnd)
+	assert.True(t, event.Rotated)
+
+	// so is a file written again after being removed, even if it got the same inode
+	offsets.rotate(path)
+	assert.NoError(t, ioutil.WriteFile(path, []byte("new\n"), 0644))
+	event = attach()
+	assert.Equal(t, fsevent.ChangeAppended, event.Change)
+	assert.Equal(t, int64(0), event.RangeStart)
+	assert.Equal(t, int64(4), event.RangeEnd)
+	assert.True(t, event.Rotated)
+
+	// a file unknown to the tracker is new since the event source started
+	other := filepath.Join(filepath.Dir(path), "other.log")
+	assert.NoError(t, ioutil.WriteFile(other, []byte("other\n"), 0644))
+	event = fsevent.Event{Name: other, Op: fsevent.Write}
+	offsets.attach(&event, other)
+	assert.Equal(t, fsevent.ChangeAppended, event.Change)
+	assert.Equal(t, int64(6), event.RangeEnd)
+	assert.False(t, event.Rotated)
+
+	assert.NoError(t, os.Remove(path))
+	event = attach()
+	assert.Empty(t, event.Change)
+	assert.NotEmpty(t, event.Error)
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"fmt"
+	"strings"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/argoproj/argo-events/eventsources/common/fsevent"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// fileOps are the names of the file operations, in the order they are listed in the errors
+var fileOps = []fsevent.Op{fsevent.Create, fsevent.Write, fsevent.Remove, fsevent.Rename, fsevent.Chmod, fsevent.Move, fsevent.Update}
+
+// watchedOps returns the file operations of the type along with the ones of the more types
+func watchedOps(eventType string, eventTypes []string) fsevent.Op {
+	ops := fsevent.NewOp(eventType)
+	for _, t := range eventTypes {
+		ops |= fsevent.NewOp(t)
+	}
+	return ops
+}
+
+// watches tells whether the file operations are all watched by the listener
+func (el *EventListener) watches(op fsevent.Op) bool {
+	return op != 0 && op&^watchedOps(el.FileEventSource.EventType, el.FileEventSource.EventTypes) == 0
+}
+
+// opName returns the name of the file operations per the op name mapping, the operations which aren't mapped keeping
+// their name, or an empty name if no mapping is specified.
+func (el *EventListener) opName(op fsevent.Op) string {
+	mapping := el.FileEventSource.OpNameMapping
+	if len(mapping) == 0 {
+		return ""
+	}
+	names := strings.Split(op.String(), "|")
+	for i, name := range names {
+		if mapped, ok := mapping[name]; ok {
+			names[i] = mapped
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+// validateOps checks the more types of the watched paths and the keys of the op name mapping are file operations,
+// and that the names they map to are specified.
+func validateOps(fileEventSource *v1alpha1.FileEventSource) error {
+	var errs []error
+	if !areFileOps(fileEventSource.EventTypes) {
+		errs = append(errs, fmt.Errorf("eventTypes must be file operations, i.e. %s", fileOpNames()))
+	}
+	for i, path := range fileEventSource.Paths {
+		if !areFileOps(path.EventTypes) {
+			errs = append(errs, fmt.Errorf("paths[%d]: eventTypes must be file operations, i.e. %s", i, fileOpNames()))
+		}
+	}
+	for op, name := range fileEventSource.OpNameMapping {
+		if !isFileOp(op) {
+			errs = append(errs, fmt.Errorf("opNameMapping keys must be file operations, i.e. %s", fileOpNames()))
+			break
+		}
+		if name == "" {
+			errs = append(errs, fmt.Errorf("opNameMapping names must not be empty"))
+			break
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func areFileOps(names []string) bool {
+	for _, name := range names {
+		if name != "" && !isFileOp(name) {
+			return false
+		}
+	}
+	return true
+}
+
+// isFileOp tells whether the name is the one of a single file operation
+func isFileOp(name string) bool {
+	for _, op := range fileOps {
+		if op.String() == name {
+			return true
+		}
+	}
+	return false
+}
+
+func fileOpNames() string {
+	names := make([]string, len(fileOps))
+	for i, op := range fileOps {
+		names[i] = op.String()
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/eventsources/common/fsevent"
+	metrics "github.com/argoproj/argo-events/metrics"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestWatches(t *testing.T) {
+	el := &EventListener{FileEventSource: v1alpha1.FileEventSource{EventType: "CREATE"}}
+	assert.True(t, el.watches(fsevent.Create))
+	assert.False(t, el.watches(fsevent.Write))
+	assert.False(t, el.watches(0))
+
+	el.FileEventSource.EventTypes = []string{"WRITE", "REMOVE"}
+	assert.True(t, el.watches(fsevent.Create))
+	assert.True(t, el.watches(fsevent.Write))
+	assert.True(t, el.watches(fsevent.Remove))
+	assert.True(t, el.watches(fsevent.Create|fsevent.Write))
+	assert.False(t, el.watches(fsevent.Chmod))
+	assert.False(t, el.watches(fsevent.Write|fsevent.Chmod))
+
+	// the type can be omitted along with the more types
+	el.FileEventSource.EventType = ""
+	assert.False(t, el.watches(fsevent.Create))
+	assert.True(t, el.watches(fsevent.Write))
+}
+
+func TestOpName(t *testing.T) {
+	el := &EventListener{}
+	assert.Equal(t, "", el.opName(fsevent.Create))
+
+	el.FileEventSource.OpNameMapping = map[string]string{"CREATE": "created", "WRITE": "modified", "REMOVE": "deleted"}
+	assert.Equal(t, "created", el.opName(fsevent.Create))
+	assert.Equal(t, "modified", el.opName(fsevent.Write))
+	assert.Equal(t, "deleted", el.opName(fsevent.Remove))
+	// the operations which aren't mapped keep their name
+	assert.Equal(t, "CHMOD", el.opName(fsevent.Chmod))
+	assert.Equal(t, "created|CHMOD", el.opName(fsevent.Create|fsevent.Chmod))
+}
+
+func TestValidateOps(t *testing.T) {
+	fileEventSource := &v1alpha1.FileEventSource{
+		EventTypes:    []string{"CREATE", "WRITE"},
+		OpNameMapping: map[string]string{"CREATE": "created", "WRITE": "modified"},
+	}
+	assert.NoError(t, validateOps(fileEventSource))
+
+	fileEventSource.EventTypes = []string{"CREATE|WRITE"}
+	fileEventSource.Paths = []v1alpha1.FileWatchPath{{EventTypes: []string{"REMOVE"}}, {EventTypes: []string{"remove"}}}
+	err := validateOps(fileEventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "[eventTypes must be file operations, i.e. CREATE, WRITE, REMOVE, RENAME, CHMOD, MOVE, UPDATE, "+
+		"paths[1]: eventTypes must be file operations, i.e. CREATE, WRITE, REMOVE, RENAME, CHMOD, MOVE, UPDATE]", err.Error())
+
+	fileEventSource = &v1alpha1.FileEventSource{OpNameMapping: map[string]string{"created": "CREATE"}}
+	err = validateOps(fileEventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "opNameMapping keys must be file operations, i.e. CREATE, WRITE, REMOVE, RENAME, CHMOD, MOVE, UPDATE", err.Error())
+
+	fileEventSource = &v1alpha1.FileEventSource{OpNameMapping: map[string]string{"CREATE": ""}}
+	err = validateOps(fileEventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "opNameMapping names must not be empty", err.Error())
+}
+
+func TestListenSeveralOps(t *testing.T) {
+	dir := t.TempDir()
+	el := &EventListener{
+		EventSourceName: "file",
+		EventName:       "example",
+		FileEventSource: v1alpha1.FileEventSource{
+			EventTypes:      []string{"CREATE", "WRITE"},
+			WatchPathConfig: v1alpha1.WatchPathConfig{Directory: dir, Path: "*.txt"},
+			OpNameMapping:   map[string]string{"CREATE": "created", "WRITE": "modified"},
+		},
+		Metrics: metrics.NewMetrics("ns"),
+	}
+
+	var lock sync.Mutex
+	var received []fsevent.Event
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = el.StartListening(ctx, func(data []byte, _ ...eventsourcecommon.Options) error {
+			var event fsevent.Event
+			assert.NoError(t, json.Unmarshal(data, &event))
+			lock.Lock()
+			defer lock.Unlock()
+			received = append(received, event)
+			return nil
+		})
+	}()
+
+	path := filepath.Join(dir, "x.txt")
+	assert.Eventually(t, func() bool {
+		assert.NoError(t, ioutil.WriteFile(path, []byte("hello"), 0600))
+		lock.Lock()
+		defer lock.Unlock()
+		ops := map[fsevent.Op]string{}
+		for _, event := range received {
+			ops[event.Op] = event.OpName
+		}
+		return ops[fsevent.Create] == "created" && ops[fsevent.Write] == "modified"
+	}, 10*time.Second, 100*time.Millisecond)
+}
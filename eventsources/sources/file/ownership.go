@@ -0,0 +1,58 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"os/user"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/argoproj/argo-events/eventsources/common/fsevent"
+)
+
+// attachOwnership adds the owner of the file to CREATE and WRITE events if enabled. The event is left without owner if
+// the file can't be stat, e.g. it was removed in between, or the platform has no Unix ownership, and without names if
+// they can't be resolved.
+func (el *EventListener) attachOwnership(fileEvent *fsevent.Event, path string, log *zap.SugaredLogger) {
+	if !el.FileEventSource.IncludeOwnership || fileEvent.Op&(fsevent.Create|fsevent.Write) == 0 {
+		return
+	}
+	uid, gid, ok := fileOwner(path)
+	if !ok {
+		log.Debugw("the ownership of the file is not available", zap.String("path", path))
+		return
+	}
+	fileEvent.Ownership = resolveOwnership(uid, gid, log)
+}
+
+// resolveOwnership returns the ownership of the IDs along with the names of the user and of the group, which are
+// left empty if the lookup fails, e.g. the IDs are unknown to the container.
+func resolveOwnership(uid, gid uint32, log *zap.SugaredLogger) *fsevent.Ownership {
+	ownership := &fsevent.Ownership{UID: uid, GID: gid}
+	if u, err := user.LookupId(strconv.FormatUint(uint64(uid), 10)); err == nil {
+		ownership.User = u.Username
+	} else {
+		log.Debugw("failed to resolve the user of the file", zap.Uint32("uid", uid), zap.Error(err))
+	}
+	if g, err := user.LookupGroupId(strconv.FormatUint(uint64(gid), 10)); err == nil {
+		ownership.Group = g.Name
+	} else {
+		log.Debugw("failed to resolve the group of the file", zap.Uint32("gid", gid), zap.Error(err))
+	}
+	return ownership
+}
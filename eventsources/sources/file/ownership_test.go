@@ -0,0 +1,73 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/argoproj/argo-events/eventsources/common/fsevent"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestAttachOwnership(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "x.txt")
+	require.NoError(t, os.WriteFile(path, []byte("x"), 0600))
+	log := zap.NewNop().Sugar()
+	uid, gid, ok := fileOwner(path)
+	if !ok {
+		t.Skip("the ownership is not available on this platform")
+	}
+
+	el := &EventListener{}
+	event := &fsevent.Event{Op: fsevent.Create}
+	el.attachOwnership(event, path, log)
+	assert.Nil(t, event.Ownership)
+
+	el.FileEventSource = v1alpha1.FileEventSource{IncludeOwnership: true}
+	el.attachOwnership(event, path, log)
+	require.NotNil(t, event.Ownership)
+	assert.Equal(t, uint32(os.Getuid()), event.Ownership.UID)
+	assert.Equal(t, uid, event.Ownership.UID)
+	assert.Equal(t, gid, event.Ownership.GID)
+	if u, err := user.LookupId(strconv.Itoa(os.Getuid())); err == nil {
+		assert.Equal(t, u.Username, event.Ownership.User)
+	}
+
+	event = &fsevent.Event{Op: fsevent.Remove}
+	el.attachOwnership(event, path, log)
+	assert.Nil(t, event.Ownership)
+
+	// a file removed in between is left without owner
+	event = &fsevent.Event{Op: fsevent.Write}
+	el.attachOwnership(event, filepath.Join(dir, "removed.txt"), log)
+	assert.Nil(t, event.Ownership)
+}
+
+func TestResolveOwnership(t *testing.T) {
+	// the IDs unknown to the system are kept without names
+	ownership := resolveOwnership(4000000000, 4000000000, zap.NewNop().Sugar())
+	assert.Equal(t, &fsevent.Ownership{UID: 4000000000, GID: 4000000000}, ownership)
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// listenPaths watches the paths of the event source independently, a path failing to be watched doesn't prevent
+// the others from being watched. It returns once all the paths are no longer watched, with the errors of the ones
+// which failed.
+func (el *EventListener) listenPaths(ctx context.Context, dispatch func([]byte, ...eventsourcecommon.Options) error, log *zap.SugaredLogger) error {
+	listeners := el.pathListeners()
+	errs := make([]error, len(listeners))
+	var wg sync.WaitGroup
+	for i, listener := range listeners {
+		wg.Add(1)
+		go func(i int, listener *EventListener) {
+			defer wg.Done()
+			log := log.With("watchPath", listener.watchPath)
+			log.Info("watching the path...")
+			if err := listener.listen(ctx, dispatch, log); err != nil {
+				log.Errorw("failed to watch the path, the other paths are still watched", zap.Error(err))
+				errs[i] = errors.Wrapf(err, "failed to watch %s", listener.watchPath)
+			}
+		}(i, listener)
+	}
+	wg.Wait()
+	return utilerrors.NewAggregate(errs)
+}
+
+// pathListeners returns a listener per watch path of the event source: the one of WatchPathConfig, if specified,
+// and the ones of Paths. They share the other settings of the event source.
+func (el *EventListener) pathListeners() []*EventListener {
+	source := el.FileEventSource
+	source.Paths = nil
+	var listeners []*EventListener
+	if source.EventType != "" || len(source.EventTypes) > 0 || source.WatchPathConfig != (v1alpha1.WatchPathConfig{}) {
+		listeners = append(listeners, el.pathListener(source, ""))
+	}
+	for _, path := range el.FileEventSource.Paths {
+		pathSource := source
+		pathSource.EventType = path.EventType
+		pathSource.EventTypes = path.EventTypes
+		pathSource.WatchPathConfig = path.WatchPathConfig
+		listeners = append(listeners, el.pathListener(pathSource, path.Name))
+	}
+	return listeners
+}
+
+func (el *EventListener) pathListener(source v1alpha1.FileEventSource, name string) *EventListener {
+	if name == "" {
+		name = watchPathName(&source.WatchPathConfig)
+	}
+	return &EventListener{
+		EventSourceName: el.EventSourceName,
+		EventName:       el.EventName,
+		FileEventSource: source,
+		Metrics:         el.Metrics,
+		watchPath:       name,
+	}
+}
+
+// watchPathName returns the default name of a watch path: its directory joined with its path or path regexp
+func watchPathName(config *v1alpha1.WatchPathConfig) string {
+	pattern := config.Path
+	if pattern == "" {
+		pattern = config.PathRegexp
+	}
+	return strings.TrimSuffix(config.Directory, "/") + "/" + pattern
+}
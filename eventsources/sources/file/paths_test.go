@@ -0,0 +1,129 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/eventsources/common/fsevent"
+	metrics "github.com/argoproj/argo-events/metrics"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestPathListeners(t *testing.T) {
+	el := &EventListener{
+		EventSourceName: "file",
+		EventName:       "example",
+		FileEventSource: v1alpha1.FileEventSource{
+			EventType:       "CREATE",
+			WatchPathConfig: v1alpha1.WatchPathConfig{Directory: "/data/", Path: "*.json"},
+			ReadContent:     true,
+			Paths: []v1alpha1.FileWatchPath{
+				{EventType: "WRITE", WatchPathConfig: v1alpha1.WatchPathConfig{Directory: "/logs", PathRegexp: `\.log$`}},
+				{Name: "configs", EventType: "REMOVE", WatchPathConfig: v1alpha1.WatchPathConfig{Directory: "/etc/app/", Path: "*.yaml"}},
+			},
+		},
+	}
+	listeners := el.pathListeners()
+	assert.Len(t, listeners, 3)
+	for i, expected := range []struct {
+		name      string
+		eventType string
+		directory string
+	}{
+		{"/data/*.json", "CREATE", "/data/"},
+		{`/logs/\.log$`, "WRITE", "/logs"},
+		{"configs", "REMOVE", "/etc/app/"},
+	} {
+		listener := listeners[i]
+		assert.Equal(t, expected.name, listener.watchPath)
+		assert.Equal(t, expected.eventType, listener.FileEventSource.EventType)
+		assert.Equal(t, expected.directory, listener.FileEventSource.WatchPathConfig.Directory)
+		assert.True(t, listener.FileEventSource.ReadContent)
+		assert.Empty(t, listener.FileEventSource.Paths)
+	}
+
+	// the watch path config is optional along with the paths
+	el.FileEventSource.EventType = ""
+	el.FileEventSource.WatchPathConfig = v1alpha1.WatchPathConfig{}
+	assert.Len(t, el.pathListeners(), 2)
+}
+
+func TestListenPaths(t *testing.T) {
+	created, written := t.TempDir(), t.TempDir()
+	el := &EventListener{
+		EventSourceName: "file",
+		EventName:       "example",
+		FileEventSource: v1alpha1.FileEventSource{
+			Paths: []v1alpha1.FileWatchPath{
+				{Name: "created", EventType: "CREATE", WatchPathConfig: v1alpha1.WatchPathConfig{Directory: created, Path: "*.txt"}},
+				{Name: "missing", EventType: "CREATE", WatchPathConfig: v1alpha1.WatchPathConfig{Directory: filepath.Join(created, "missing"), Path: "*.txt"}},
+				{Name: "written", EventType: "WRITE", WatchPathConfig: v1alpha1.WatchPathConfig{Directory: written, Path: "*.txt"}},
+			},
+		},
+		Metrics: metrics.NewMetrics("ns"),
+	}
+
+	var lock sync.Mutex
+	watchPaths := map[string]string{}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- el.StartListening(ctx, func(data []byte, _ ...eventsourcecommon.Options) error {
+			var event fsevent.Event
+			assert.NoError(t, json.Unmarshal(data, &event))
+			lock.Lock()
+			defer lock.Unlock()
+			watchPaths[filepath.Dir(event.Name)] = event.WatchPath
+			return nil
+		})
+	}()
+
+	// the missing directory doesn't prevent the other paths from being watched
+	i := 0
+	assert.Eventually(t, func() bool {
+		i++
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(created, fmt.Sprintf("%d.txt", i)), []byte("hello"), 0600))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(written, "x.txt"), []byte(fmt.Sprint(i)), 0600))
+		lock.Lock()
+		defer lock.Unlock()
+		return len(watchPaths) == 2
+	}, 10*time.Second, 100*time.Millisecond)
+	lock.Lock()
+	assert.Equal(t, map[string]string{created: "created", written: "written"}, watchPaths)
+	lock.Unlock()
+
+	// all the paths stop being watched on shutdown
+	cancel()
+	select {
+	case err := <-done:
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to watch missing")
+	case <-time.After(10 * time.Second):
+		t.Fatal("the paths are still watched after shutdown")
+	}
+}
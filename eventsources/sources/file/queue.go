@@ -0,0 +1,99 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"sync/atomic"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+)
+
+// defaultBufferSize is the number of events buffered between the watcher and the dispatching by default.
+const defaultBufferSize = 100
+
+// dispatchQueue is a bounded buffer of the event processings, decoupling the watcher from the dispatching.
+// The processings are run one at a time in the order they were pushed, which preserves the ordering of the
+// events of a same path, and throttled by the limiter if any.
+type dispatchQueue struct {
+	events  chan func()
+	limiter *eventsourcecommon.TokenBucket
+	dropped uint64
+}
+
+func newDispatchQueue(size int, limiter *eventsourcecommon.TokenBucket) *dispatchQueue {
+	if size <= 0 {
+		size = defaultBufferSize
+	}
+	return &dispatchQueue{events: make(chan func(), size), limiter: limiter}
+}
+
+// push buffers the processing of an event without blocking, returning false if the buffer is full
+// and the event has been dropped.
+func (q *dispatchQueue) push(process func()) bool {
+	select {
+	case q.events <- process:
+		return true
+	default:
+		atomic.AddUint64(&q.dropped, 1)
+		return false
+	}
+}
+
+// droppedCount returns the number of events dropped so far because the buffer was full.
+func (q *dispatchQueue) droppedCount() uint64 {
+	return atomic.LoadUint64(&q.dropped)
+}
+
+// start runs the queue in the background until the returned function is called, which waits for the events still
+// buffered to be processed.
+func (q *dispatchQueue) start() (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		q.run(ctx)
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// run processes the buffered events until the context is done, then processes the events still buffered, without
+// throttling them, so that they aren't lost on shutdown.
+func (q *dispatchQueue) run(ctx context.Context) {
+	for {
+		select {
+		case process := <-q.events:
+			if q.limiter != nil {
+				// the event is processed all the same once the context is done
+				_ = q.limiter.Wait(ctx)
+			}
+			process()
+		case <-ctx.Done():
+			for {
+				select {
+				case process := <-q.events:
+					process()
+				default:
+					return
+				}
+			}
+		}
+	}
+}
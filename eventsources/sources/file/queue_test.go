@@ -0,0 +1,88 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+)
+
+func TestDispatchQueue(t *testing.T) {
+	q := newDispatchQueue(3, nil)
+	var processed []string
+	for _, name := range []string{"a-1", "b-1", "a-2"} {
+		name := name
+		assert.True(t, q.push(func() { processed = append(processed, name) }))
+	}
+	assert.False(t, q.push(func() { processed = append(processed, "b-2") }))
+	assert.Equal(t, uint64(1), q.droppedCount())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		q.run(ctx)
+		close(done)
+	}()
+	assert.Eventually(t, func() bool { return len(q.events) == 0 }, time.Second, 10*time.Millisecond)
+	cancel()
+	<-done
+	assert.Equal(t, []string{"a-1", "b-1", "a-2"}, processed)
+}
+
+func TestDispatchQueueDefaultSize(t *testing.T) {
+	assert.Equal(t, defaultBufferSize, cap(newDispatchQueue(0, nil).events))
+}
+
+func TestDispatchQueueThrottled(t *testing.T) {
+	// a burst of 2 events, then an event every 50ms
+	q := newDispatchQueue(10, eventsourcecommon.NewTokenBucket(20, 2))
+	var processed int32
+	for i := 0; i < 5; i++ {
+		assert.True(t, q.push(func() { atomic.AddInt32(&processed, 1) }))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	start := time.Now()
+	go q.run(ctx)
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&processed) == 2 }, time.Second, time.Millisecond)
+	assert.Less(t, atomic.LoadInt32(&processed), int32(5))
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&processed) == 5 }, time.Second, 10*time.Millisecond)
+	assert.GreaterOrEqual(t, time.Since(start), 140*time.Millisecond)
+}
+
+func TestDispatchQueueDrainsOnStop(t *testing.T) {
+	// the limiter would hold the buffered events back for a second each
+	q := newDispatchQueue(10, eventsourcecommon.NewTokenBucket(1, 1))
+	stop := q.start()
+	var processed int32
+	for i := 0; i < 5; i++ {
+		assert.True(t, q.push(func() { atomic.AddInt32(&processed, 1) }))
+	}
+	start := time.Now()
+	stop()
+	assert.Equal(t, int32(5), atomic.LoadInt32(&processed))
+	assert.Less(t, time.Since(start), time.Second)
+	// stopping again is a no-op
+	stop()
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// defaultRewatchInterval is how often a directory which is no longer watched is attempted to be watched again
+// by default
+const defaultRewatchInterval = 10 * time.Second
+
+// rewatcher watches the directory again once it is no longer watched, e.g. removed and recreated, retrying until it
+// succeeds. It's only used by the watcher loop and isn't goroutine-safe.
+type rewatcher struct {
+	directory string
+	add       func(string) error
+	// lost is set once the directory is no longer watched
+	lost bool
+}
+
+func newRewatcher(directory string, add func(string) error) *rewatcher {
+	return &rewatcher{directory: filepath.Clean(directory), add: add}
+}
+
+// isDirectory tells whether the path is the watched directory
+func (r *rewatcher) isDirectory(path string) bool {
+	return filepath.Clean(path) == r.directory
+}
+
+// lose marks the directory as no longer watched.
+func (r *rewatcher) lose() {
+	r.lost = true
+}
+
+// retry attempts to watch the directory again if it is no longer watched. It returns true once the directory is
+// watched again, and the error of the attempt if it failed.
+func (r *rewatcher) retry() (bool, error) {
+	if !r.lost {
+		return false, nil
+	}
+	if err := r.add(r.directory); err != nil {
+		return false, errors.Wrapf(err, "failed to watch the directory %s again", r.directory)
+	}
+	r.lost = false
+	return true, nil
+}
+
+// rewatchInterval returns how often the directory is attempted to be watched again once it is no longer watched
+func rewatchInterval(interval string) (time.Duration, error) {
+	if interval == "" {
+		return defaultRewatchInterval, nil
+	}
+	d, err := time.ParseDuration(interval)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to parse the rewatch interval %s", interval)
+	}
+	if d <= 0 {
+		return 0, errors.New("rewatchInterval must be positive")
+	}
+	return d, nil
+}
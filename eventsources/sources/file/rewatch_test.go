@@ -0,0 +1,151 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/eventsources/common/fsevent"
+	metrics "github.com/argoproj/argo-events/metrics"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestRewatcher(t *testing.T) {
+	var added []string
+	fail := true
+	rewatch := newRewatcher("/data/", func(path string) error {
+		added = append(added, path)
+		if fail {
+			return errors.New("no such file or directory")
+		}
+		return nil
+	})
+	assert.True(t, rewatch.isDirectory("/data"))
+	assert.False(t, rewatch.isDirectory("/data/x.txt"))
+
+	// nothing to do while the directory is watched
+	rewatched, err := rewatch.retry()
+	assert.False(t, rewatched)
+	assert.NoError(t, err)
+	assert.Empty(t, added)
+
+	rewatch.lose()
+	rewatched, err = rewatch.retry()
+	assert.False(t, rewatched)
+	assert.EqualError(t, err, "failed to watch the directory /data again: no such file or directory")
+
+	fail = false
+	rewatched, err = rewatch.retry()
+	assert.True(t, rewatched)
+	assert.NoError(t, err)
+	rewatched, _ = rewatch.retry()
+	assert.False(t, rewatched)
+	assert.Equal(t, []string{"/data", "/data"}, added)
+}
+
+func TestRewatchInterval(t *testing.T) {
+	interval, err := rewatchInterval("")
+	assert.NoError(t, err)
+	assert.Equal(t, defaultRewatchInterval, interval)
+	interval, err = rewatchInterval("1m")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, interval)
+	_, err = rewatchInterval("0s")
+	assert.EqualError(t, err, "rewatchInterval must be positive")
+	_, err = rewatchInterval("soon")
+	assert.Error(t, err)
+}
+
+func TestListenRemovedDirectory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "watched")
+	assert.NoError(t, os.Mkdir(dir, 0755))
+	el := &EventListener{
+		EventSourceName: "file",
+		EventName:       "example",
+		FileEventSource: v1alpha1.FileEventSource{
+			EventType:       "CREATE",
+			WatchPathConfig: v1alpha1.WatchPathConfig{Directory: dir, Path: "*.txt"},
+			RewatchInterval: "50ms",
+		},
+		Metrics: metrics.NewMetrics("ns"),
+	}
+
+	var lock sync.Mutex
+	var names []string
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- el.StartListening(ctx, func(data []byte, _ ...eventsourcecommon.Options) error {
+			var event fsevent.Event
+			assert.NoError(t, json.Unmarshal(data, &event))
+			lock.Lock()
+			defer lock.Unlock()
+			names = append(names, filepath.Base(event.Name))
+			return nil
+		})
+	}()
+	created := func(name string) func() bool {
+		return func() bool {
+			lock.Lock()
+			defer lock.Unlock()
+			for _, n := range names {
+				if n == name {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	i := 0
+	assert.Eventually(t, func() bool {
+		i++
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, fmt.Sprintf("before-%d.txt", i)), []byte("hello"), 0600))
+		return created(fmt.Sprintf("before-%d.txt", i))()
+	}, 10*time.Second, 100*time.Millisecond)
+
+	// the directory is watched again once recreated
+	assert.NoError(t, os.RemoveAll(dir))
+	time.Sleep(100 * time.Millisecond)
+	assert.NoError(t, os.Mkdir(dir, 0755))
+	i = 0
+	assert.Eventually(t, func() bool {
+		i++
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, fmt.Sprintf("after-%d.txt", i)), []byte("hello"), 0600))
+		return created(fmt.Sprintf("after-%d.txt", i))()
+	}, 10*time.Second, 100*time.Millisecond)
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("the directory is still watched after shutdown")
+	}
+}
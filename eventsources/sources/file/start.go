@@ -17,11 +17,20 @@ limitations under the License.
 package file
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/pkg/errors"
@@ -34,15 +43,26 @@ import (
 	"github.com/argoproj/argo-events/eventsources/sources"
 	metrics "github.com/argoproj/argo-events/metrics"
 	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/events"
 	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
 )
 
+// defaultMaxContentBytes is the default maximum size of the file content attached to the events
+const defaultMaxContentBytes = 1 << 20
+
+// defaultDispatchTimeout is how long a dispatch to the eventbus may take by default
+const defaultDispatchTimeout = 10 * time.Second
+
 // EventListener implements Eventing for file event source
 type EventListener struct {
 	EventSourceName string
 	EventName       string
 	FileEventSource v1alpha1.FileEventSource
 	Metrics         *metrics.Metrics
+
+	// watchPath is the name of the watch path of the listener, set in the events, when the event source
+	// watches several paths.
+	watchPath string
 }
 
 // GetEventSourceName returns name of event source
@@ -67,20 +87,61 @@ func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byt
 	defer sources.Recover(el.GetEventName())
 
 	fileEventSource := &el.FileEventSource
-	if fileEventSource.Polling {
-		if err := el.listenEventsPolling(ctx, dispatch, log); err != nil {
-			log.Error("failed to listen to events", zap.Error(err))
-			return err
-		}
-	} else {
-		if err := el.listenEvents(ctx, dispatch, log); err != nil {
-			log.Error("failed to listen to events", zap.Error(err))
-			return err
-		}
+	// a misconfigured spec fails with all its errors rather than with the first failure of the watcher
+	if err := validate(fileEventSource); err != nil {
+		return errors.Wrap(err, "invalid file event source")
+	}
+	heartbeatInterval, err := eventsourcecommon.HeartbeatInterval(fileEventSource.Heartbeat)
+	if err != nil {
+		return err
+	}
+	digestInterval, err := digestInterval(fileEventSource.DigestInterval)
+	if err != nil {
+		return err
+	}
+	dispatchTimeout, err := eventsourcecommon.DispatchTimeout(fileEventSource.DispatchTimeout, defaultDispatchTimeout)
+	if err != nil {
+		return err
+	}
+	// a hanging eventbus fails the dispatch once the timeout elapses, the event is logged and the next ones processed
+	dispatch = eventsourcecommon.DispatchWithTimeout(dispatchTimeout, dispatch)
+	// an event too large fails before it reaches the eventbus, it is logged and counted as the other failed events
+	dispatch = eventsourcecommon.DispatchWithMaxSize(fileEventSource.MaxEventSizeBytes, dispatch)
+	if heartbeatInterval > 0 {
+		log.Infow("dispatching the heartbeat events", zap.Duration("interval", heartbeatInterval))
+		stopHeartbeat := eventsourcecommon.StartHeartbeat(ctx, heartbeatInterval, func(heartbeat events.HeartbeatData) {
+			if err := el.dispatchHeartbeat(heartbeat, dispatch); err != nil {
+				log.Errorw("failed to dispatch a heartbeat event", zap.Error(err))
+				el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.ReasonOf(err))
+			}
+		})
+		defer stopHeartbeat()
+	}
+	if digestInterval > 0 {
+		log.Infow("dispatching the digest events", zap.Duration("interval", digestInterval))
+		stopDigest := el.startDigest(ctx, digestInterval, dispatch, log)
+		defer stopDigest()
+	}
+
+	listen := el.listen
+	if len(fileEventSource.Paths) > 0 {
+		listen = el.listenPaths
+	}
+	if err := listen(ctx, dispatch, log); err != nil {
+		log.Errorw("failed to listen to events", zap.Error(err))
+		return err
 	}
 	return nil
 }
 
+// listen watches the path of the event source, either by polling or with the notifications of the file system
+func (el *EventListener) listen(ctx context.Context, dispatch func([]byte, ...eventsourcecommon.Options) error, log *zap.SugaredLogger) error {
+	if el.polls(log) {
+		return el.listenEventsPolling(ctx, dispatch, log)
+	}
+	return el.listenEvents(ctx, dispatch, log)
+}
+
 // listenEvents listen to file related events.
 func (el *EventListener) listenEvents(ctx context.Context, dispatch func([]byte, ...eventsourcecommon.Options) error, log *zap.SugaredLogger) error {
 	fileEventSource := &el.FileEventSource
@@ -99,6 +160,13 @@ func (el *EventListener) listenEvents(ctx context.Context, dispatch func([]byte,
 	if err != nil {
 		return errors.Wrapf(err, "failed to add directory %s to the watcher for %s", fileEventSource.WatchPathConfig.Directory, el.GetEventName())
 	}
+	if err = el.watchGlobDirectories(watcher.Add, log); err != nil {
+		return err
+	}
+	if fileEventSource.Recursive {
+		log.Info("adding the subdirectories to monitor for the watcher...")
+		watchSubdirectories(watcher.Add, fileEventSource.WatchPathConfig.Directory, log)
+	}
 
 	var pathRegexp *regexp.Regexp
 	if fileEventSource.WatchPathConfig.PathRegexp != "" {
@@ -109,26 +177,209 @@ func (el *EventListener) listenEvents(ctx context.Context, dispatch func([]byte,
 		}
 	}
 
-	processOne := func(event fsnotify.Event) error {
+	debouncer := el.newDebouncer(log)
+	editors := el.newEditorGrouper(log)
+
+	el.Metrics.EventSourceActive(el.GetEventSourceName(), el.GetEventName(), true)
+	defer el.Metrics.EventSourceActive(el.GetEventSourceName(), el.GetEventName(), false)
+
+	modes := el.newModeTracker(pathRegexp, log)
+
+	offsets := el.newOffsetTracker(pathRegexp, log)
+	contents, err := el.newContentMatcher(offsets, log)
+	if err != nil {
+		return err
+	}
+
+	inodes := el.newInodeDeduper(pathRegexp, log)
+
+	batches, err := el.newBatcher(dispatch, log)
+	if err != nil {
+		return err
+	}
+	if batches != nil {
+		// the pending batch is dispatched once the event source stops
+		defer batches.stop()
+	}
+
+	ignores := el.newIgnoreMatcher(log)
+
+	processOne := func(fileEvent fsevent.Event) error {
+		if ignores != nil && ignores.ignores(fileEvent.Name) {
+			log.Debugw("ignoring the file event per the ignore patterns", zap.String("descriptor-name", fileEvent.Name))
+			return nil
+		}
+		// the files of a volume update are checked against the extensions and the size when listed
+		if fileEvent.Op != fsevent.Update && !el.accepts(fileEvent.Name, fileEvent.Op, log) {
+			return nil
+		}
 		defer func(start time.Time) {
 			el.Metrics.EventProcessingDuration(el.GetEventSourceName(), el.GetEventName(), float64(time.Since(start)/time.Millisecond))
 		}(time.Now())
 
-		log.Infow("file event", zap.Any("event-type", event.Op.String()), zap.Any("descriptor-name", event.Name))
+		log.Infow("file event", zap.Any("event-type", fileEvent.Op.String()), zap.Any("descriptor-name", fileEvent.Name))
 
-		// Assume fsnotify event has the same Op spec of our file event
-		fileEvent := fsevent.Event{Name: event.Name, Op: fsevent.NewOp(event.Op.String()), Metadata: el.FileEventSource.Metadata}
+		fileEvent.OpName = el.opName(fileEvent.Op)
+		el.attachContent(&fileEvent, fileEvent.Name, log)
+		el.attachMetadataPaths(&fileEvent, fileEvent.Name, log)
+		el.attachOwnership(&fileEvent, fileEvent.Name, log)
+		if modes != nil && fileEvent.Op&fsevent.Chmod != 0 {
+			modes.attach(&fileEvent, fileEvent.Name)
+		}
+		if offsets != nil && fileEvent.Op&fsevent.Write != 0 {
+			offsets.attach(&fileEvent, fileEvent.Name)
+		}
+		if contents != nil && fileEvent.Op&fsevent.Write != 0 {
+			dispatched, err := contents.accepts(&fileEvent, fileEvent.Name)
+			if err != nil {
+				log.Errorw("failed to match the appended content", zap.String("path", fileEvent.Name), zap.Error(err))
+				fileEvent.Error = err.Error()
+			} else if !dispatched {
+				log.Debugw("suppressing the file event per the content match", zap.String("descriptor-name", fileEvent.Name))
+				return nil
+			}
+		}
+		fileEvent.WatchPath = el.watchPath
+		if batches != nil {
+			batches.add(fileEvent)
+			return nil
+		}
 		payload, err := json.Marshal(fileEvent)
 		if err != nil {
-			return errors.Wrap(err, "failed to marshal the event to the fs event")
+			return metrics.WithReason(errors.Wrap(err, "failed to marshal the event to the fs event"), metrics.FailureReasonMarshal)
+		}
+		id := eventsourcecommon.EventID(fileEventSource.IDStrategy, el.GetEventSourceName(), el.GetEventName(), fileEvent.Name, payload)
+		if fileEventSource.OutputFormat == outputFormatCloudEvents {
+			if payload, err = encodeCloudEvent(el.GetEventSourceName(), id, fileEvent, payload); err != nil {
+				return metrics.WithReason(errors.Wrap(err, "failed to encode the fs event as a cloud event"), metrics.FailureReasonMarshal)
+			}
 		}
-		log.Infow("dispatching file event on data channel...", zap.Any("event-type", event.Op.String()), zap.Any("descriptor-name", event.Name))
-		if err = dispatch(payload); err != nil {
-			return errors.Wrap(err, "failed to dispatch a file event")
+		log.Infow("dispatching file event on data channel...", zap.Any("event-type", fileEvent.Op.String()), zap.Any("descriptor-name", fileEvent.Name), zap.String("id", id))
+		if err = dispatch(payload, eventsourcecommon.WithID(id)); err != nil {
+			return metrics.WithReason(errors.Wrap(err, "failed to dispatch a file event"), eventsourcecommon.DispatchFailureReason(err))
 		}
 		return nil
 	}
 
+	// the events are processed apart from the watcher loop so that a slow dispatching doesn't hold the watcher back
+	log.Infow("buffering the file events...", zap.Int32("bufferSize", fileEventSource.BufferSize))
+	limiter := el.newLimiter(log)
+	queue := newDispatchQueue(int(fileEventSource.BufferSize), limiter)
+	// the events still buffered are processed before the pending batch is dispatched
+	stopQueue := queue.start()
+	defer stopQueue()
+	// the pending events are flushed into the queue before it stops, the editor groups and the inodes first as they
+	// feed the debouncer
+	defer func() {
+		if editors != nil {
+			editors.stop()
+		}
+		if inodes != nil {
+			inodes.stop()
+		}
+		if debouncer != nil {
+			debouncer.stop()
+		}
+	}()
+
+	enqueue := func(fileEvent fsevent.Event) {
+		if !queue.push(func() {
+			if err := processOne(fileEvent); err != nil {
+				log.Errorw("failed to process a file event", zap.Error(err))
+				el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.ReasonOf(err))
+			}
+		}) {
+			log.Warnw("the event buffer is full, dropping the file event", zap.String("descriptor-name", fileEvent.Name),
+				zap.String("event-type", fileEvent.Op.String()), zap.Uint64("dropped", queue.droppedCount()))
+			el.Metrics.EventsDropped(el.GetEventSourceName(), el.GetEventName())
+		}
+	}
+
+	handle := func(event fsnotify.Event) {
+		if !el.matches(event.Name, pathRegexp) || !el.watches(fsevent.NewOp(event.Op.String())) {
+			return
+		}
+		// Assume fsnotify event has the same Op spec of our file event
+		fileEvent := fsevent.Event{Name: event.Name, Op: fsevent.NewOp(event.Op.String()), Metadata: fileEventSource.Metadata}
+		process := func() {
+			enqueue(fileEvent)
+		}
+		debounce := func() {
+			if debouncer != nil && event.Op&fsnotify.Rename == 0 {
+				debouncer.debounce(event.Name, process)
+			} else {
+				process()
+			}
+		}
+		if inodes != nil && inodes.dedupe(event.Name, debounce) {
+			return
+		}
+		debounce()
+	}
+
+	handleMove := func(renamed, created fsnotify.Event) {
+		if !el.watches(fsevent.Move) {
+			// the move isn't watched, the RENAME and the CREATE it pairs are reported on their own
+			handle(renamed)
+			handle(created)
+			return
+		}
+		oldPath, newPath := renamed.Name, created.Name
+		if !el.matches(oldPath, pathRegexp) && !el.matches(newPath, pathRegexp) {
+			return
+		}
+		enqueue(fsevent.Event{Name: newPath, Op: fsevent.Move, OldPath: oldPath, NewPath: newPath, Metadata: fileEventSource.Metadata})
+	}
+
+	volumeUpdates := el.newVolumeUpdates(log)
+	if volumeUpdates != nil {
+		defer volumeUpdates.stop()
+	}
+	updateVolume := func() {
+		directory := fileEventSource.WatchPathConfig.Directory
+		files := el.volumeFiles(pathRegexp, ignores, log)
+		log.Infow("the projected volume has been updated", zap.String("directory", directory), zap.Int("files", len(files)))
+		enqueue(fsevent.Event{Name: directory, Op: fsevent.Update, Files: files, Metadata: fileEventSource.Metadata})
+	}
+
+	var symlinks *symlinkFollower
+	if fileEventSource.FollowSymlinks && !fileEventSource.ConfigMapMode {
+		log.Info("following the symlinks among the watched files...")
+		symlinks = newSymlinkFollower(func() []string {
+			return el.watchedFiles(pathRegexp, log)
+		})
+		followSymlinks(symlinks, watcher.Add, log)
+	}
+
+	var moves *moveDetector
+	if fileEventSource.DetectMoves {
+		moves = newMoveDetector(defaultMoveWindow)
+		defer moves.take()
+	}
+
+	// the directory removed while watched is watched again once recreated, along with its nested directories
+	interval, err := rewatchInterval(fileEventSource.RewatchInterval)
+	if err != nil {
+		return err
+	}
+	rewatch := newRewatcher(fileEventSource.WatchPathConfig.Directory, watcher.Add)
+	rewatchTicker := time.NewTicker(interval)
+	defer rewatchTicker.Stop()
+
+	if fileEventSource.EmitExistingOnStart {
+		log.Info("dispatching the events of the existing files...")
+		el.walkExisting(pathRegexp, func(path string) {
+			if limiter != nil && limiter.Wait(ctx) != nil {
+				return
+			}
+			fileEvent := fsevent.Event{Name: path, Op: fsevent.Create, Synthetic: true, Metadata: fileEventSource.Metadata}
+			if err := processOne(fileEvent); err != nil {
+				log.Errorw("failed to process a file event", zap.Error(err))
+				el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.ReasonOf(err))
+			}
+		}, log)
+	}
+
 	log.Info("listening to file notifications...")
 	for {
 		select {
@@ -138,22 +389,126 @@ func (el *EventListener) listenEvents(ctx context.Context, dispatch func([]byte,
 				// watcher stopped watching file events
 				return errors.Errorf("fs watcher stopped for %s", el.GetEventName())
 			}
-			// fwc.Path == event.Name is required because we don't want to send event when .swp files are created
-			matched := false
-			relPath := strings.TrimPrefix(event.Name, fileEventSource.WatchPathConfig.Directory)
-			if fileEventSource.WatchPathConfig.Path != "" && fileEventSource.WatchPathConfig.Path == relPath {
-				matched = true
-			} else if pathRegexp != nil && pathRegexp.MatchString(relPath) {
-				matched = true
+			if rewatch.isDirectory(event.Name) && event.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
+				log.Errorw("the watched directory has been removed, watching it again once recreated", zap.String("directory", event.Name),
+					zap.Duration("rewatchInterval", interval))
+				el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonWatch)
+				// the watch of a moved directory follows it to its new path
+				_ = watcher.Remove(event.Name)
+				rewatch.lose()
+			}
+			if volumeUpdates != nil {
+				// the swap of the data link and the events following it collapse into a single update of the volume
+				directory := fileEventSource.WatchPathConfig.Directory
+				if event.Op&fsnotify.Create == fsnotify.Create && isProjectedDataSwap(event.Name) || volumeUpdates.isPending(directory) {
+					volumeUpdates.debounce(directory, updateVolume)
+				}
+				continue
+			}
+			if symlinks != nil {
+				if event.Op&fsnotify.Create == fsnotify.Create && isProjectedDataSwap(event.Name) {
+					// the files of a projected volume have been atomically updated, report a single change per file
+					for _, link := range followSymlinks(symlinks, watcher.Add, log) {
+						handle(fsnotify.Event{Name: link, Op: fsnotify.Write})
+					}
+					continue
+				}
+				for _, link := range symlinks.linksOf(event.Name) {
+					handle(fsnotify.Event{Name: link, Op: event.Op})
+				}
+				if event.Op&(fsnotify.Create|fsnotify.Remove|fsnotify.Rename) != 0 {
+					// a link may have been created, removed or repointed
+					followSymlinks(symlinks, watcher.Add, log)
+				}
+				if isProjectedInternal(fileEventSource.WatchPathConfig.Directory, event.Name) {
+					continue
+				}
 			}
-			if matched && fileEventSource.EventType == event.Op.String() {
-				if err = processOne(event); err != nil {
-					log.Errorw("failed to process a file event", zap.Error(err))
-					el.Metrics.EventProcessingFailed(el.GetEventSourceName(), el.GetEventName())
+			if modes != nil && el.matches(event.Name, pathRegexp) {
+				if event.Op&fsnotify.Create == fsnotify.Create {
+					modes.record(event.Name)
+				} else if event.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
+					modes.forget(event.Name)
 				}
 			}
-		case err := <-watcher.Errors:
-			return errors.Wrapf(err, "failed to process %s", el.GetEventName())
+			if offsets != nil && el.matches(event.Name, pathRegexp) && event.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
+				offsets.rotate(event.Name)
+			}
+			if inodes != nil && el.matches(event.Name, pathRegexp) {
+				if event.Op&(fsnotify.Create|fsnotify.Write|fsnotify.Chmod) != 0 {
+					inodes.observe(event.Name)
+				} else if !el.watches(fsevent.NewOp(event.Op.String())) {
+					// the path is gone and its event won't be deduplicated
+					inodes.forget(event.Name)
+				}
+			}
+			if event.Op&fsnotify.Create == fsnotify.Create {
+				el.watchNewGlobDirectory(watcher.Add, event.Name, log)
+				if fileEventSource.Recursive {
+					watchSubdirectories(watcher.Add, event.Name, log)
+				}
+			}
+			if editors != nil {
+				// the saves through the temporary files of an editor collapse into a single WRITE of the file
+				editors.add(event.Name, func(path string) {
+					handle(fsnotify.Event{Name: path, Op: fsnotify.Write})
+				})
+				continue
+			}
+			if debouncer != nil && event.Op&fsnotify.Rename == fsnotify.Rename {
+				debouncer.flush(event.Name)
+			}
+			if moves != nil {
+				if event.Op&fsnotify.Rename == fsnotify.Rename {
+					if previous := moves.rename(event); previous != nil {
+						handle(*previous)
+					}
+					continue
+				}
+				if event.Op&fsnotify.Create == fsnotify.Create {
+					if renamed := moves.create(); renamed != nil {
+						handleMove(*renamed, event)
+						continue
+					}
+				}
+			}
+			handle(event)
+		case <-moves.expired():
+			// no CREATE followed the RENAME, the file has been moved out of the watched directories.
+			if renamed := moves.take(); renamed != nil {
+				handle(*renamed)
+			}
+		case <-rewatchTicker.C:
+			rewatched, err := rewatch.retry()
+			if err != nil {
+				log.Debugw("the directory can not be watched yet", zap.Error(err))
+				continue
+			}
+			if !rewatched {
+				continue
+			}
+			log.Infow("watching the recreated directory again", zap.String("directory", fileEventSource.WatchPathConfig.Directory))
+			if err := el.watchGlobDirectories(watcher.Add, log); err != nil {
+				log.Errorw("failed to watch the nested directories again", zap.Error(err))
+			}
+			if fileEventSource.Recursive {
+				watchSubdirectories(watcher.Add, fileEventSource.WatchPathConfig.Directory, log)
+			}
+			if symlinks != nil {
+				followSymlinks(symlinks, watcher.Add, log)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return errors.Errorf("fs watcher stopped for %s", el.GetEventName())
+			}
+			// the error of a single watch, e.g. an overflow of the event queue, doesn't stop the other watches
+			log.Errorw("the fs watcher failed, keep watching", zap.Error(err))
+			el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonWatch)
+			if _, err := os.Stat(fileEventSource.WatchPathConfig.Directory); err != nil {
+				log.Errorw("the watched directory is not available, watching it again once recreated", zap.Error(err))
+				_ = watcher.Remove(fileEventSource.WatchPathConfig.Directory)
+				rewatch.lose()
+			}
 		case <-ctx.Done():
 			log.Info("event source has been stopped")
 			return nil
@@ -164,18 +519,30 @@ func (el *EventListener) listenEvents(ctx context.Context, dispatch func([]byte,
 // listenEvents listen to file related events using polling.
 func (el *EventListener) listenEventsPolling(ctx context.Context, dispatch func([]byte, ...eventsourcecommon.Options) error, log *zap.SugaredLogger) error {
 	fileEventSource := &el.FileEventSource
+	interval, err := pollInterval(fileEventSource.PollInterval)
+	if err != nil {
+		return err
+	}
 
 	// create new fs watcher
-	log.Info("setting up a new file polling watcher...")
+	log.Infow("setting up a new file polling watcher...", zap.Duration("interval", interval))
 	watcher := watcherpkg.New()
 	defer watcher.Close()
 
 	// file descriptor to watch must be available in file system. You can't watch an fs descriptor that is not present.
 	log.Info("adding directory to monitor for the watcher...")
-	err := watcher.Add(fileEventSource.WatchPathConfig.Directory)
+	add := watcher.Add
+	if fileEventSource.Recursive {
+		// the polling watcher lists the nested files, including the ones of the new subdirectories, on each poll
+		add = watcher.AddRecursive
+	}
+	err = add(fileEventSource.WatchPathConfig.Directory)
 	if err != nil {
 		return errors.Wrapf(err, "failed to add directory %s to the watcher for %s", fileEventSource.WatchPathConfig.Directory, el.GetEventName())
 	}
+	if err = el.watchGlobDirectories(watcher.Add, log); err != nil {
+		return err
+	}
 
 	var pathRegexp *regexp.Regexp
 	if fileEventSource.WatchPathConfig.PathRegexp != "" {
@@ -186,27 +553,117 @@ func (el *EventListener) listenEventsPolling(ctx context.Context, dispatch func(
 		}
 	}
 
-	processOne := func(event watcherpkg.Event) error {
+	debouncer := el.newDebouncer(log)
+
+	modes := el.newModeTracker(pathRegexp, log)
+
+	offsets := el.newOffsetTracker(pathRegexp, log)
+	contents, err := el.newContentMatcher(offsets, log)
+	if err != nil {
+		return err
+	}
+
+	inodes := el.newInodeDeduper(pathRegexp, log)
+
+	batches, err := el.newBatcher(dispatch, log)
+	if err != nil {
+		return err
+	}
+
+	ignores := el.newIgnoreMatcher(log)
+
+	// processFileEvent dispatches the file event of the file at the path
+	processFileEvent := func(fileEvent fsevent.Event, path string) error {
+		if ignores != nil && ignores.ignores(path) {
+			log.Debugw("ignoring the file event per the ignore patterns", zap.String("descriptor-name", path))
+			return nil
+		}
+		if !el.accepts(path, fileEvent.Op, log) {
+			return nil
+		}
 		defer func(start time.Time) {
 			el.Metrics.EventProcessingDuration(el.GetEventSourceName(), el.GetEventName(), float64(time.Since(start)/time.Millisecond))
 		}(time.Now())
 
-		log.Infow("file event", zap.Any("event-type", event.Op.String()), zap.Any("descriptor-name", event.Name))
+		log.Infow("file event", zap.Any("event-type", fileEvent.Op.String()), zap.Any("descriptor-name", fileEvent.Name))
 
-		// Assume fsnotify event has the same Op spec of our file event
-		fileEvent := fsevent.Event{Name: event.Name(), Op: fsevent.NewOp(event.Op.String()), Metadata: el.FileEventSource.Metadata}
+		fileEvent.OpName = el.opName(fileEvent.Op)
+		el.attachContent(&fileEvent, path, log)
+		el.attachMetadataPaths(&fileEvent, path, log)
+		el.attachOwnership(&fileEvent, path, log)
+		if modes != nil && fileEvent.Op&fsevent.Chmod != 0 {
+			modes.attach(&fileEvent, path)
+		}
+		if offsets != nil && fileEvent.Op&fsevent.Write != 0 {
+			offsets.attach(&fileEvent, path)
+		}
+		if contents != nil && fileEvent.Op&fsevent.Write != 0 {
+			dispatched, err := contents.accepts(&fileEvent, path)
+			if err != nil {
+				log.Errorw("failed to match the appended content", zap.String("path", path), zap.Error(err))
+				fileEvent.Error = err.Error()
+			} else if !dispatched {
+				log.Debugw("suppressing the file event per the content match", zap.String("descriptor-name", fileEvent.Name))
+				return nil
+			}
+		}
+		fileEvent.WatchPath = el.watchPath
+		if batches != nil {
+			batches.add(fileEvent)
+			return nil
+		}
 		payload, err := json.Marshal(fileEvent)
 		if err != nil {
-			return errors.Wrap(err, "failed to marshal the event to the fs event")
+			return metrics.WithReason(errors.Wrap(err, "failed to marshal the event to the fs event"), metrics.FailureReasonMarshal)
 		}
-		log.Infow("dispatching file event on data channel...", zap.Any("event-type", event.Op.String()), zap.Any("descriptor-name", event.Name))
-		if err = dispatch(payload); err != nil {
-			return errors.Wrap(err, "failed to dispatch file event")
+		id := eventsourcecommon.EventID(fileEventSource.IDStrategy, el.GetEventSourceName(), el.GetEventName(), fileEvent.Name, payload)
+		if fileEventSource.OutputFormat == outputFormatCloudEvents {
+			if payload, err = encodeCloudEvent(el.GetEventSourceName(), id, fileEvent, payload); err != nil {
+				return metrics.WithReason(errors.Wrap(err, "failed to encode the fs event as a cloud event"), metrics.FailureReasonMarshal)
+			}
+		}
+		log.Infow("dispatching file event on data channel...", zap.Any("event-type", fileEvent.Op.String()), zap.Any("descriptor-name", fileEvent.Name), zap.String("id", id))
+		if err = dispatch(payload, eventsourcecommon.WithID(id)); err != nil {
+			return metrics.WithReason(errors.Wrap(err, "failed to dispatch file event"), eventsourcecommon.DispatchFailureReason(err))
 		}
 		return nil
 	}
 
+	processOne := func(event watcherpkg.Event) error {
+		// Assume fsnotify event has the same Op spec of our file event
+		fileEvent := fsevent.Event{Name: event.Name(), Op: fsevent.NewOp(event.Op.String()), Metadata: el.FileEventSource.Metadata}
+		if event.Op == watcherpkg.Move {
+			fileEvent.OldPath = event.OldPath
+			fileEvent.NewPath = event.Path
+		}
+		return processFileEvent(fileEvent, event.Path)
+	}
+
+	// the polling watcher dispatches the events right away, unless throttled
+	limiter := el.newLimiter(log)
+	var queue *dispatchQueue
+	stopQueue := func() {}
+	if limiter != nil {
+		queue = newDispatchQueue(int(fileEventSource.BufferSize), limiter)
+		stopQueue = queue.start()
+	}
+
+	if fileEventSource.EmitExistingOnStart {
+		log.Info("dispatching the events of the existing files...")
+		el.walkExisting(pathRegexp, func(path string) {
+			if limiter != nil && limiter.Wait(ctx) != nil {
+				return
+			}
+			fileEvent := fsevent.Event{Name: filepath.Base(path), Op: fsevent.Create, Synthetic: true, Metadata: fileEventSource.Metadata}
+			if err := processFileEvent(fileEvent, path); err != nil {
+				log.Errorw("failed to process a file event", zap.Error(err))
+				el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.ReasonOf(err))
+			}
+		}, log)
+	}
+
 	go func() {
+		defer stopQueue()
 		log.Info("listening to file notifications...")
 		for {
 			select {
@@ -217,18 +674,71 @@ func (el *EventListener) listenEventsPolling(ctx context.Context, dispatch func(
 					log.Errorw("fs watcher stopped", zap.Any("eventName", el.GetEventName()))
 					return
 				}
-				// fwc.Path == event.Name is required because we don't want to send event when .swp files are created
-				matched := false
-				relPath := strings.TrimPrefix(event.Name(), fileEventSource.WatchPathConfig.Directory)
-				if fileEventSource.WatchPathConfig.Path != "" && fileEventSource.WatchPathConfig.Path == relPath {
-					matched = true
-				} else if pathRegexp != nil && pathRegexp.MatchString(relPath) {
-					matched = true
-				}
-				if matched && fileEventSource.EventType == event.Op.String() {
-					if err := processOne(event); err != nil {
-						log.Errorw("failed to process a file event", zap.Error(err))
-						el.Metrics.EventProcessingFailed(el.GetEventSourceName(), el.GetEventName())
+				if event.Op == watcherpkg.Create {
+					el.watchNewGlobDirectory(watcher.Add, event.Path, log)
+				}
+				if modes != nil {
+					switch event.Op {
+					case watcherpkg.Create:
+						modes.record(event.Path)
+					case watcherpkg.Remove:
+						modes.forget(event.Path)
+					case watcherpkg.Rename, watcherpkg.Move:
+						modes.forget(event.OldPath)
+						modes.record(event.Path)
+					}
+				}
+				if offsets != nil {
+					switch event.Op {
+					case watcherpkg.Remove:
+						offsets.rotate(event.Path)
+					case watcherpkg.Rename, watcherpkg.Move:
+						offsets.rotate(event.OldPath)
+					}
+				}
+				if inodes != nil {
+					switch event.Op {
+					case watcherpkg.Create, watcherpkg.Write, watcherpkg.Chmod:
+						inodes.observe(event.Path)
+					case watcherpkg.Remove:
+						if !el.watches(fsevent.NewOp(event.Op.String())) {
+							inodes.forget(event.Path)
+						}
+					case watcherpkg.Rename, watcherpkg.Move:
+						inodes.forget(event.OldPath)
+						inodes.observe(event.Path)
+					}
+				}
+				if debouncer != nil && (event.Op == watcherpkg.Rename || event.Op == watcherpkg.Move) {
+					debouncer.flush(event.OldPath)
+				}
+				if el.matches(event.Path, pathRegexp) && el.watches(fsevent.NewOp(event.Op.String())) {
+					event := event
+					process := func() {
+						if err := processOne(event); err != nil {
+							log.Errorw("failed to process a file event", zap.Error(err))
+							el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.ReasonOf(err))
+						}
+					}
+					if queue != nil {
+						dispatchOne := process
+						process = func() {
+							if !queue.push(dispatchOne) {
+								log.Warnw("the event buffer is full, dropping the file event", zap.String("descriptor-name", event.Name()),
+									zap.String("event-type", event.Op.String()), zap.Uint64("dropped", queue.droppedCount()))
+								el.Metrics.EventsDropped(el.GetEventSourceName(), el.GetEventName())
+							}
+						}
+					}
+					debounce := func() {
+						if debouncer != nil && event.Op != watcherpkg.Rename && event.Op != watcherpkg.Move {
+							debouncer.debounce(event.Path, process)
+						} else {
+							process()
+						}
+					}
+					if inodes == nil || !inodes.dedupe(event.Path, debounce) {
+						debounce()
 					}
 				}
 			case err := <-watcher.Error:
@@ -236,13 +746,375 @@ func (el *EventListener) listenEventsPolling(ctx context.Context, dispatch func(
 				return
 			case <-ctx.Done():
 				log.Info("event source has been stopped")
+				// the inodes feed the debouncer, their pending events are flushed first
+				if inodes != nil {
+					inodes.stop()
+				}
+				if debouncer != nil {
+					debouncer.stop()
+				}
+				// the events still buffered are processed before the pending batch is dispatched
+				stopQueue()
+				if batches != nil {
+					// dispatch the pending batch so that the trailing events are not lost
+					batches.stop()
+				}
+				// stop polling, once started
+				watcher.Wait()
+				watcher.Close()
 				return
 			}
 		}
 	}()
 	log.Info("Starting watcher...")
-	if err = watcher.Start(time.Millisecond * 100); err != nil {
+	el.Metrics.EventSourceActive(el.GetEventSourceName(), el.GetEventName(), true)
+	defer el.Metrics.EventSourceActive(el.GetEventSourceName(), el.GetEventName(), false)
+	if err = watcher.Start(interval); err != nil {
 		return errors.Wrapf(err, "Failed to start watcher for %s", el.GetEventName())
 	}
 	return nil
 }
+
+// newModeTracker returns the tracker of the modes of the watched files, seeded with the existing files,
+// or nil if the CHMOD events are not watched.
+func (el *EventListener) newModeTracker(pathRegexp *regexp.Regexp, log *zap.SugaredLogger) *modeTracker {
+	if !el.watches(fsevent.Chmod) {
+		return nil
+	}
+	modes := newModeTracker()
+	el.walkExisting(pathRegexp, modes.record, log)
+	return modes
+}
+
+// newOffsetTracker returns the tracker of the sizes of the watched files, seeded with the existing files,
+// or nil if the offsets are not tracked or the WRITE events are not watched.
+func (el *EventListener) newOffsetTracker(pathRegexp *regexp.Regexp, log *zap.SugaredLogger) *offsetTracker {
+	if !el.FileEventSource.TrackOffset || !el.watches(fsevent.Write) {
+		return nil
+	}
+	log.Info("tracking the offset of the written files...")
+	offsets := newOffsetTracker()
+	el.walkExisting(pathRegexp, offsets.record, log)
+	return offsets
+}
+
+// newContentMatcher returns the matcher of the content appended by the WRITE events, or nil if the events are not
+// restricted by their content or the offsets are not tracked.
+func (el *EventListener) newContentMatcher(offsets *offsetTracker, log *zap.SugaredLogger) (*contentMatcher, error) {
+	if el.FileEventSource.ContentMatch == nil || offsets == nil {
+		return nil, nil
+	}
+	maxBytes := el.FileEventSource.MaxContentBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxContentBytes
+	}
+	log.Infow("matching the content appended to the files...", zap.String("regex", el.FileEventSource.ContentMatch.Regex))
+	matcher, err := newContentMatcher(el.FileEventSource.ContentMatch, maxBytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compile the content match regex %s", el.FileEventSource.ContentMatch.Regex)
+	}
+	return matcher, nil
+}
+
+// newIgnoreMatcher returns the matcher of the files whose events are ignored, or nil if none is.
+func (el *EventListener) newIgnoreMatcher(log *zap.SugaredLogger) *ignoreMatcher {
+	config := &el.FileEventSource
+	ignores := newIgnoreMatcher(config.WatchPathConfig.Directory, config.IgnorePatterns, config.IgnoreEditorTempFiles)
+	if ignores != nil {
+		log.Infow("ignoring the files matching the ignore patterns...", zap.Strings("ignorePatterns", config.IgnorePatterns),
+			zap.Bool("ignoreEditorTempFiles", config.IgnoreEditorTempFiles))
+	}
+	return ignores
+}
+
+// newInodeDeduper returns the deduplicator of the events by inode, seeded with the existing files,
+// or nil if the deduplication is disabled.
+func (el *EventListener) newInodeDeduper(pathRegexp *regexp.Regexp, log *zap.SugaredLogger) *inodeDeduper {
+	if !el.FileEventSource.DedupeByInode {
+		return nil
+	}
+	log.Infow("deduplicating the file events by inode...", zap.Duration("window", defaultInodeWindow))
+	inodes := newInodeDeduper(defaultInodeWindow)
+	el.walkExisting(pathRegexp, inodes.observe, log)
+	return inodes
+}
+
+// newLimiter returns the token bucket throttling the dispatching of the events, or nil if throttling is disabled.
+func (el *EventListener) newLimiter(log *zap.SugaredLogger) *eventsourcecommon.TokenBucket {
+	refillRate := el.FileEventSource.RefillRate
+	if refillRate <= 0 {
+		return nil
+	}
+	maxBurst := el.FileEventSource.MaxBurst
+	if maxBurst <= 0 {
+		maxBurst = refillRate
+	}
+	log.Infow("throttling the file events...", zap.Int32("refillRate", refillRate), zap.Int32("maxBurst", maxBurst))
+	return eventsourcecommon.NewTokenBucket(float64(refillRate), int(maxBurst))
+}
+
+// newDebouncer returns the debouncer of the write bursts, or nil if debouncing is disabled.
+func (el *EventListener) newDebouncer(log *zap.SugaredLogger) *debouncer {
+	// the window applies to the groups of the editor temporary files instead
+	if el.FileEventSource.DebounceMillis <= 0 || el.FileEventSource.EditorAwareDebounce {
+		return nil
+	}
+	log.Infow("debouncing the file events...", zap.Int32("debounceMillis", el.FileEventSource.DebounceMillis))
+	return newDebouncer(time.Duration(el.FileEventSource.DebounceMillis) * time.Millisecond)
+}
+
+// newEditorGrouper returns the grouper of the events of the files and of their editor temporary files, or nil if
+// it isn't enabled.
+func (el *EventListener) newEditorGrouper(log *zap.SugaredLogger) *editorGrouper {
+	if !el.FileEventSource.EditorAwareDebounce || watchMode(&el.FileEventSource) == watchModePoll {
+		return nil
+	}
+	window := defaultEditorDebounceWindow
+	if el.FileEventSource.DebounceMillis > 0 {
+		window = time.Duration(el.FileEventSource.DebounceMillis) * time.Millisecond
+	}
+	log.Infow("grouping the events of the editor temporary files...", zap.Duration("window", window))
+	return newEditorGrouper(window)
+}
+
+// attachContent reads the file and attaches its content and checksum to CREATE and WRITE events if enabled.
+// A failure to read the file, e.g. because it was removed in between, is reported in the event rather than dropping it.
+func (el *EventListener) attachContent(fileEvent *fsevent.Event, path string, log *zap.SugaredLogger) {
+	if !el.FileEventSource.ReadContent || fileEvent.Op&(fsevent.Create|fsevent.Write) == 0 {
+		return
+	}
+	maxBytes := el.FileEventSource.MaxContentBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxContentBytes
+	}
+	if err := readContent(fileEvent, path, maxBytes); err != nil {
+		log.Errorw("failed to read the file content", zap.String("path", path), zap.Error(err))
+		fileEvent.Error = err.Error()
+	}
+}
+
+// readContent reads up to maxBytes of the file into the event, along with the checksum of the whole file.
+func readContent(fileEvent *fsevent.Event, path string, maxBytes int64) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	content, err := ioutil.ReadAll(io.LimitReader(io.TeeReader(f, hash), maxBytes))
+	if err != nil {
+		return err
+	}
+	n, err := io.Copy(hash, f)
+	if err != nil {
+		return err
+	}
+	fileEvent.Truncated = n > 0
+	fileEvent.Checksum = hex.EncodeToString(hash.Sum(nil))
+	if utf8.Valid(content) && !bytes.ContainsRune(content, 0) {
+		fileEvent.Content = string(content)
+		fileEvent.ContentEncoding = fsevent.ContentEncodingText
+	} else {
+		fileEvent.Content = base64.StdEncoding.EncodeToString(content)
+		fileEvent.ContentEncoding = fsevent.ContentEncodingBase64
+	}
+	return nil
+}
+
+// watchSubdirectories walks the directory tree under root and adds every directory, root included, to the watcher.
+// A directory failing to be added, e.g. once the inotify watches are exhausted, is logged and skipped rather than
+// aborting the event source.
+func watchSubdirectories(add func(string) error, root string, log *zap.SugaredLogger) {
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			log.Errorw("failed to walk the directory", zap.String("directory", path), zap.Error(err))
+			return nil
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if err := add(path); err != nil {
+			log.Errorw("failed to add directory to the watcher", zap.String("directory", path), zap.Error(err))
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorw("failed to walk the directory tree", zap.String("directory", root), zap.Error(err))
+	}
+}
+
+// followSymlinks resolves the followed symlinks again and watches the directories of their new targets.
+// It returns the links whose target changed.
+func followSymlinks(symlinks *symlinkFollower, add func(string) error, log *zap.SugaredLogger) []string {
+	changed, dirs := symlinks.resolve()
+	for _, dir := range dirs {
+		if err := add(dir); err != nil {
+			log.Errorw("failed to add the symlink target directory to the watcher", zap.String("directory", dir), zap.Error(err))
+		}
+	}
+	return changed
+}
+
+// watchedFiles returns the files of the directory matching the watch path configuration.
+func (el *EventListener) watchedFiles(pathRegexp *regexp.Regexp, log *zap.SugaredLogger) []string {
+	config := el.FileEventSource.WatchPathConfig
+	if config.Path != "" {
+		paths, err := filepath.Glob(filepath.Join(config.Directory, config.Path))
+		if err != nil {
+			log.Errorw("failed to expand the path glob", zap.String("path", config.Path), zap.Error(err))
+		}
+		return paths
+	}
+	entries, err := os.ReadDir(config.Directory)
+	if err != nil {
+		log.Errorw("failed to list the directory", zap.String("directory", config.Directory), zap.Error(err))
+		return nil
+	}
+	var paths []string
+	for _, entry := range entries {
+		path := filepath.Join(config.Directory, entry.Name())
+		if el.matches(path, pathRegexp) {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// walkExisting calls fn for each existing file matching the watch path configuration. The nested directories are
+// only walked if recursive, or down to the depth of the path glob.
+func (el *EventListener) walkExisting(pathRegexp *regexp.Regexp, fn func(path string), log *zap.SugaredLogger) {
+	config := &el.FileEventSource
+	maxDepth := 0
+	if config.WatchPathConfig.Path != "" {
+		maxDepth = strings.Count(filepath.ToSlash(filepath.Clean(config.WatchPathConfig.Path)), "/")
+	}
+	root := config.WatchPathConfig.Directory
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			log.Errorw("failed to walk the directory", zap.String("path", path), zap.Error(err))
+			return nil
+		}
+		relPath, err := filepath.Rel(root, path)
+		if err != nil || relPath == "." {
+			return nil
+		}
+		depth := strings.Count(filepath.ToSlash(relPath), "/")
+		if info.IsDir() {
+			if !config.Recursive && depth >= maxDepth {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if el.matches(path, pathRegexp) {
+			fn(path)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorw("failed to walk the directory tree", zap.String("directory", root), zap.Error(err))
+	}
+}
+
+// accepts tells whether the file passes the extension and size filters. The size is not checked
+// for the REMOVE and RENAME events since the file no longer exists.
+func (el *EventListener) accepts(path string, op fsevent.Op, log *zap.SugaredLogger) bool {
+	config := &el.FileEventSource
+	if len(config.Extensions) > 0 {
+		ext := filepath.Ext(path)
+		matched := false
+		for _, e := range config.Extensions {
+			if strings.EqualFold(ext, "."+strings.TrimPrefix(e, ".")) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if config.MinSizeBytes > 0 && op&(fsevent.Remove|fsevent.Rename) == 0 {
+		info, err := os.Stat(path)
+		if err != nil {
+			log.Debugw("failed to check the size of the file, skip the event", zap.String("path", path), zap.Error(err))
+			return false
+		}
+		if info.Size() < config.MinSizeBytes {
+			return false
+		}
+	}
+	return true
+}
+
+// matches tells whether the file event path matches the watch path configuration.
+// The configured path is a glob pattern relative to the directory, plain paths match themselves only,
+// so that no event is sent for e.g. the .swp files created by the editors. The path regexp is matched
+// against the event path stripped of the directory, as it always was, so that the existing regexps
+// anchored on the leading slash keep matching when the directory has no trailing slash.
+func (el *EventListener) matches(name string, pathRegexp *regexp.Regexp) bool {
+	config := el.FileEventSource.WatchPathConfig
+	trimmed := strings.TrimPrefix(name, config.Directory)
+	if config.Path != "" {
+		if config.Path == trimmed {
+			return true
+		}
+		relPath, err := filepath.Rel(config.Directory, name)
+		if err != nil {
+			return false
+		}
+		matched, err := filepath.Match(config.Path, relPath)
+		return err == nil && matched
+	}
+	return pathRegexp != nil && pathRegexp.MatchString(trimmed)
+}
+
+// globDirectory returns the glob pattern of the directories holding the files matched by the path,
+// or an empty string if the path refers to files of the watched directory itself.
+func (el *EventListener) globDirectory() string {
+	config := el.FileEventSource.WatchPathConfig
+	if config.Path == "" || filepath.Dir(config.Path) == "." {
+		return ""
+	}
+	return filepath.Join(config.Directory, filepath.Dir(config.Path))
+}
+
+// watchGlobDirectories expands the path glob and adds the nested directories holding the matching files to the watcher.
+func (el *EventListener) watchGlobDirectories(add func(string) error, log *zap.SugaredLogger) error {
+	pattern := el.globDirectory()
+	if pattern == "" {
+		return nil
+	}
+	dirs, err := filepath.Glob(pattern)
+	if err != nil {
+		return errors.Wrapf(err, "failed to expand the path glob %s for %s", el.FileEventSource.WatchPathConfig.Path, el.GetEventName())
+	}
+	for _, dir := range dirs {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			continue
+		}
+		log.Infow("adding directory matching the path glob to the watcher...", zap.String("directory", dir))
+		if err := add(dir); err != nil {
+			return errors.Wrapf(err, "failed to add directory %s to the watcher for %s", dir, el.GetEventName())
+		}
+	}
+	return nil
+}
+
+// watchNewGlobDirectory re-evaluates the path glob when a new file appears, and adds it to the watcher
+// if it is a directory that may hold matching files.
+func (el *EventListener) watchNewGlobDirectory(add func(string) error, name string, log *zap.SugaredLogger) {
+	pattern := el.globDirectory()
+	if pattern == "" {
+		return
+	}
+	if matched, err := filepath.Match(pattern, filepath.Clean(name)); err != nil || !matched {
+		return
+	}
+	if info, err := os.Stat(name); err != nil || !info.IsDir() {
+		return
+	}
+	log.Infow("adding new directory matching the path glob to the watcher...", zap.String("directory", name))
+	if err := add(name); err != nil {
+		log.Errorw("failed to add directory to the watcher", zap.String("directory", name), zap.Error(err))
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/argoproj/argo-events/eventsources/common/fsevent"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestMatches(t *testing.T) {
+	el := &EventListener{
+		FileEventSource: v1alpha1.FileEventSource{
+			WatchPathConfig: v1alpha1.WatchPathConfig{
+				Directory: "/test-data/",
+				Path:      "x.txt",
+			},
+		},
+	}
+	assert.True(t, el.matches("/test-data/x.txt", nil))
+	assert.False(t, el.matches("/test-data/.x.txt.swp", nil))
+
+	el.FileEventSource.WatchPathConfig.Path = "configs/*.json"
+	assert.True(t, el.matches("/test-data/configs/a.json", nil))
+	assert.False(t, el.matches("/test-data/configs/a.yaml", nil))
+	assert.False(t, el.matches("/test-data/a.json", nil))
+
+	// a plain path holding glob characters still matches itself
+	el.FileEventSource.WatchPathConfig.Path = "report[1].txt"
+	assert.True(t, el.matches("/test-data/report[1].txt", nil))
+	assert.True(t, el.matches("/test-data/report1.txt", nil))
+
+	// the path regexp is matched against the path stripped of the directory
+	el.FileEventSource.WatchPathConfig.Path = ""
+	el.FileEventSource.WatchPathConfig.Directory = "/test-data"
+	assert.True(t, el.matches("/test-data/foo.txt", regexp.MustCompile(`^/foo.*`)))
+	assert.False(t, el.matches("/test-data/bar/foo.txt", regexp.MustCompile(`^/foo.*`)))
+	el.FileEventSource.WatchPathConfig.Directory = "/test-data/"
+	assert.True(t, el.matches("/test-data/foo.txt", regexp.MustCompile(`^foo.*`)))
+}
+
+func TestWatchGlobDirectories(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "configs", "a"), 0755))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "other"), 0755))
+
+	el := &EventListener{
+		FileEventSource: v1alpha1.FileEventSource{
+			WatchPathConfig: v1alpha1.WatchPathConfig{
+				Directory: dir,
+				Path:      "configs/*/*.json",
+			},
+		},
+	}
+	var watched []string
+	add := func(name string) error {
+		watched = append(watched, name)
+		return nil
+	}
+	log := zap.NewNop().Sugar()
+	assert.NoError(t, el.watchGlobDirectories(add, log))
+	assert.Equal(t, []string{filepath.Join(dir, "configs", "a")}, watched)
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "configs", "b"), 0755))
+	el.watchNewGlobDirectory(add, filepath.Join(dir, "configs", "b"), log)
+	el.watchNewGlobDirectory(add, filepath.Join(dir, "other"), log)
+	assert.Equal(t, []string{filepath.Join(dir, "configs", "a"), filepath.Join(dir, "configs", "b")}, watched)
+}
+
+func TestReadContent(t *testing.T) {
+	dir := t.TempDir()
+	text := filepath.Join(dir, "x.txt")
+	assert.NoError(t, ioutil.WriteFile(text, []byte("hello"), 0644))
+	binary := filepath.Join(dir, "x.bin")
+	assert.NoError(t, ioutil.WriteFile(binary, []byte{0, 1, 2}, 0644))
+
+	event := &fsevent.Event{}
+	assert.NoError(t, readContent(event, text, 1024))
+	assert.Equal(t, "hello", event.Content)
+	assert.Equal(t, fsevent.ContentEncodingText, event.ContentEncoding)
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", event.Checksum)
+	assert.False(t, event.Truncated)
+
+	event = &fsevent.Event{}
+	assert.NoError(t, readContent(event, text, 2))
+	assert.Equal(t, "he", event.Content)
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", event.Checksum)
+	assert.True(t, event.Truncated)
+
+	event = &fsevent.Event{}
+	assert.NoError(t, readContent(event, binary, 1024))
+	assert.Equal(t, "AAEC", event.Content)
+	assert.Equal(t, fsevent.ContentEncodingBase64, event.ContentEncoding)
+
+	el := &EventListener{FileEventSource: v1alpha1.FileEventSource{ReadContent: true}}
+	event = &fsevent.Event{Op: fsevent.Write}
+	el.attachContent(event, filepath.Join(dir, "removed.txt"), zap.NewNop().Sugar())
+	assert.NotEmpty(t, event.Error)
+	assert.Empty(t, event.Content)
+}
+
+func TestWatchSubdirectories(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "a", "b"), 0755))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "c"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "a", "x.txt"), []byte("hello"), 0644))
+
+	var watched []string
+	add := func(name string) error {
+		if name == filepath.Join(dir, "c") {
+			return errors.New("no space left on device")
+		}
+		watched = append(watched, name)
+		return nil
+	}
+	watchSubdirectories(add, dir, zap.NewNop().Sugar())
+	assert.Equal(t, []string{dir, filepath.Join(dir, "a"), filepath.Join(dir, "a", "b")}, watched)
+}
+
+func TestAccepts(t *testing.T) {
+	dir := t.TempDir()
+	small := filepath.Join(dir, "small.JSON")
+	large := filepath.Join(dir, "large.json")
+	assert.NoError(t, ioutil.WriteFile(small, []byte("{}"), 0600))
+	assert.NoError(t, ioutil.WriteFile(large, []byte(`{"hello": "world"}`), 0600))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "large.txt"), []byte(`{"hello": "world"}`), 0600))
+
+	log := zap.NewNop().Sugar()
+	el := &EventListener{}
+	assert.True(t, el.accepts(filepath.Join(dir, "large.txt"), fsevent.Create, log))
+
+	el.FileEventSource.Extensions = []string{"json", ".yaml"}
+	assert.True(t, el.accepts(small, fsevent.Create, log))
+	assert.True(t, el.accepts(large, fsevent.Create, log))
+	assert.False(t, el.accepts(filepath.Join(dir, "large.txt"), fsevent.Create, log))
+
+	el.FileEventSource.MinSizeBytes = 10
+	assert.False(t, el.accepts(small, fsevent.Write, log))
+	assert.True(t, el.accepts(large, fsevent.Write, log))
+	// the file no longer exists
+	assert.False(t, el.accepts(filepath.Join(dir, "missing.json"), fsevent.Create, log))
+	assert.True(t, el.accepts(filepath.Join(dir, "missing.json"), fsevent.Remove, log))
+	assert.True(t, el.accepts(filepath.Join(dir, "missing.json"), fsevent.Rename, log))
+}
+
+func TestWalkExisting(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "configs", "nested"), 0755))
+	for _, name := range []string{"a.json", "b.txt", "configs/c.json", "configs/nested/d.json"} {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte("{}"), 0600))
+	}
+	walk := func(config v1alpha1.WatchPathConfig, recursive bool) []string {
+		el := &EventListener{FileEventSource: v1alpha1.FileEventSource{WatchPathConfig: config, Recursive: recursive}}
+		var paths []string
+		var pathRegexp *regexp.Regexp
+		if config.PathRegexp != "" {
+			pathRegexp = regexp.MustCompile(config.PathRegexp)
+		}
+		el.walkExisting(pathRegexp, func(path string) {
+			paths = append(paths, path)
+		}, zap.NewNop().Sugar())
+		return paths
+	}
+
+	assert.Equal(t, []string{filepath.Join(dir, "a.json")}, walk(v1alpha1.WatchPathConfig{Directory: dir, Path: "a.json"}, false))
+	assert.Equal(t, []string{filepath.Join(dir, "configs", "c.json")}, walk(v1alpha1.WatchPathConfig{Directory: dir, Path: "configs/*.json"}, false))
+	assert.Equal(t, []string{filepath.Join(dir, "a.json")}, walk(v1alpha1.WatchPathConfig{Directory: dir, PathRegexp: `\.json$`}, false))
+	assert.Equal(t, []string{
+		filepath.Join(dir, "a.json"),
+		filepath.Join(dir, "configs", "c.json"),
+		filepath.Join(dir, "configs", "nested", "d.json"),
+	}, walk(v1alpha1.WatchPathConfig{Directory: dir, PathRegexp: `\.json$`}, true))
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// projectedDataLink is the symlink that Kubernetes atomically swaps to update the files of the projected volumes,
+// e.g. the ConfigMaps and Secrets mounted as volumes, whose files are symlinks pointing into it.
+const projectedDataLink = "..data"
+
+// symlinkFollower tracks the symlinks among the watched files along with their resolved targets, so that
+// the events of the targets are reported under the path of the links. It is not goroutine-safe, it is
+// meant to be driven by the watcher loop.
+type symlinkFollower struct {
+	// list returns the paths of the watched files which may be symlinks
+	list    func() []string
+	links   map[string]string
+	targets map[string][]string
+}
+
+func newSymlinkFollower(list func() []string) *symlinkFollower {
+	return &symlinkFollower{
+		list:    list,
+		links:   make(map[string]string),
+		targets: make(map[string][]string),
+	}
+}
+
+// resolve resolves the symlinks again. It returns the links whose target changed since the previous resolution,
+// and the directories of the new targets, which must be watched to receive the events of the targets.
+func (f *symlinkFollower) resolve() (changed []string, dirs []string) {
+	links := make(map[string]string)
+	targets := make(map[string][]string)
+	for _, path := range f.list() {
+		info, err := os.Lstat(path)
+		if err != nil || info.Mode()&os.ModeSymlink == 0 {
+			continue
+		}
+		target, err := filepath.EvalSymlinks(path)
+		if err != nil {
+			// dangling link, it is resolved again once repointed
+			continue
+		}
+		links[path] = target
+		targets[target] = append(targets[target], path)
+		if previous, ok := f.links[path]; !ok || previous != target {
+			changed = append(changed, path)
+			dirs = append(dirs, filepath.Dir(target))
+		}
+	}
+	f.links = links
+	f.targets = targets
+	sort.Strings(changed)
+	return changed, dirs
+}
+
+// linksOf returns the links pointing at the target path.
+func (f *symlinkFollower) linksOf(target string) []string {
+	return f.targets[target]
+}
+
+// isProjectedDataSwap tells whether the path is the data link of a projected volume, whose creation
+// signals that all the files of the volume have been atomically updated.
+func isProjectedDataSwap(path string) bool {
+	return filepath.Base(path) == projectedDataLink
+}
+
+// isProjectedInternal tells whether the path is one of the internal files of a projected volume mounted at
+// the directory, i.e. the timestamped data directories, their content and the temporary data link.
+func isProjectedInternal(directory, path string) bool {
+	relPath, err := filepath.Rel(directory, path)
+	if err != nil {
+		return false
+	}
+	first := strings.SplitN(filepath.ToSlash(relPath), "/", 2)[0]
+	return first != ".." && strings.HasPrefix(first, "..")
+}
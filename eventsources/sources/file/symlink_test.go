@@ -0,0 +1,81 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSymlinkFollower(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	assert.NoError(t, err)
+	mkdata := func(name string) {
+		assert.NoError(t, os.Mkdir(filepath.Join(dir, name), 0755))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name, "config.yaml"), []byte(name), 0600))
+	}
+	// the layout of a projected volume
+	mkdata("..v1")
+	assert.NoError(t, os.Symlink("..v1", filepath.Join(dir, "..data")))
+	assert.NoError(t, os.Symlink("..data/config.yaml", filepath.Join(dir, "config.yaml")))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "plain.yaml"), nil, 0600))
+
+	link := filepath.Join(dir, "config.yaml")
+	f := newSymlinkFollower(func() []string {
+		return []string{link, filepath.Join(dir, "plain.yaml")}
+	})
+
+	changed, dirs := f.resolve()
+	assert.Equal(t, []string{link}, changed)
+	assert.Equal(t, []string{filepath.Join(dir, "..v1")}, dirs)
+	assert.Contains(t, f.links, link)
+	assert.NotContains(t, f.links, filepath.Join(dir, "plain.yaml"))
+	assert.Equal(t, []string{link}, f.linksOf(filepath.Join(dir, "..v1", "config.yaml")))
+
+	changed, dirs = f.resolve()
+	assert.Empty(t, changed)
+	assert.Empty(t, dirs)
+
+	// atomic swap of the data link
+	mkdata("..v2")
+	assert.NoError(t, os.Symlink("..v2", filepath.Join(dir, "..data_tmp")))
+	assert.NoError(t, os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")))
+	changed, dirs = f.resolve()
+	assert.Equal(t, []string{link}, changed)
+	assert.Equal(t, []string{filepath.Join(dir, "..v2")}, dirs)
+	assert.Empty(t, f.linksOf(filepath.Join(dir, "..v1", "config.yaml")))
+	assert.Equal(t, []string{link}, f.linksOf(filepath.Join(dir, "..v2", "config.yaml")))
+
+	// dangling link
+	assert.NoError(t, os.RemoveAll(filepath.Join(dir, "..v2")))
+	changed, _ = f.resolve()
+	assert.Empty(t, changed)
+	assert.NotContains(t, f.links, link)
+}
+
+func TestIsProjectedVolumePath(t *testing.T) {
+	assert.True(t, isProjectedDataSwap("/etc/config/..data"))
+	assert.False(t, isProjectedDataSwap("/etc/config/..data_tmp"))
+	assert.True(t, isProjectedInternal("/etc/config", "/etc/config/..data_tmp"))
+	assert.True(t, isProjectedInternal("/etc/config", "/etc/config/..2021_01_01_00_00_00.123/config.yaml"))
+	assert.False(t, isProjectedInternal("/etc/config", "/etc/config/config.yaml"))
+	assert.False(t, isProjectedInternal("/etc/config", "/etc/other/config.yaml"))
+}
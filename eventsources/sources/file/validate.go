@@ -19,8 +19,14 @@ package file
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"time"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	"github.com/argoproj/argo-events/common"
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/eventsources/common/fsevent"
 	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
 )
 
@@ -33,9 +39,177 @@ func validate(fileEventSource *v1alpha1.FileEventSource) error {
 	if fileEventSource == nil {
 		return common.ErrNilEventSource
 	}
-	if fileEventSource.EventType == "" {
-		return fmt.Errorf("type must be specified")
+	var errs []error
+	if err := fileEventSource.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	switch fileEventSource.OutputFormat {
+	case "", outputFormatNative, outputFormatCloudEvents:
+	default:
+		errs = append(errs, fmt.Errorf("outputFormat must be either %s or %s", outputFormatNative, outputFormatCloudEvents))
+	}
+	if err := validateTrackOffset(fileEventSource); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateContentMatch(fileEventSource); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateIgnorePatterns(fileEventSource.IgnorePatterns); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateBatch(fileEventSource.Batch); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := rewatchInterval(fileEventSource.RewatchInterval); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := digestInterval(fileEventSource.DigestInterval); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := eventsourcecommon.DispatchTimeout(fileEventSource.DispatchTimeout, defaultDispatchTimeout); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateOps(fileEventSource); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateWatchMode(fileEventSource); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateConfigMapMode(fileEventSource); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateEditorAwareDebounce(fileEventSource); err != nil {
+		errs = append(errs, err)
+	}
+	if err := eventsourcecommon.ValidateIDStrategy(fileEventSource.IDStrategy); err != nil {
+		errs = append(errs, err)
+	}
+	return utilerrors.Flatten(utilerrors.NewAggregate(errs))
+}
+
+// validateTrackOffset checks the watched paths dispatch the WRITE events the offsets are attached to, the other
+// events don't track them
+func validateTrackOffset(fileEventSource *v1alpha1.FileEventSource) error {
+	if !fileEventSource.TrackOffset {
+		return nil
+	}
+	var errs []error
+	write := fsevent.Write.String()
+	// the watch path config is optional along with the paths
+	if (fileEventSource.EventType != "" || len(fileEventSource.EventTypes) > 0) &&
+		watchedOps(fileEventSource.EventType, fileEventSource.EventTypes)&fsevent.Write == 0 {
+		errs = append(errs, fmt.Errorf("trackOffset requires the types to include %s", write))
+	}
+	for i, path := range fileEventSource.Paths {
+		if watchedOps(path.EventType, path.EventTypes)&fsevent.Write == 0 {
+			errs = append(errs, fmt.Errorf("paths[%d]: trackOffset requires the types to include %s", i, write))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// validateContentMatch checks the regex and the policies of the content match, which requires tracking the offsets
+func validateContentMatch(fileEventSource *v1alpha1.FileEventSource) error {
+	contentMatch := fileEventSource.ContentMatch
+	if contentMatch == nil {
+		return nil
+	}
+	var errs []error
+	if !fileEventSource.TrackOffset {
+		errs = append(errs, fmt.Errorf("contentMatch requires trackOffset"))
+	}
+	if contentMatch.Regex == "" {
+		errs = append(errs, fmt.Errorf("contentMatch regex must be specified"))
+	} else if _, err := regexp.Compile(contentMatch.Regex); err != nil {
+		errs = append(errs, fmt.Errorf("contentMatch regex must be a valid regular expression, %w", err))
+	}
+	if !validContentMatchPolicy(contentMatch.OnMatch) {
+		errs = append(errs, fmt.Errorf("contentMatch onMatch must be either %s or %s", contentMatchDispatch, contentMatchSuppress))
 	}
-	err := fileEventSource.WatchPathConfig.Validate()
-	return err
+	if !validContentMatchPolicy(contentMatch.OnNoMatch) {
+		errs = append(errs, fmt.Errorf("contentMatch onNoMatch must be either %s or %s", contentMatchDispatch, contentMatchSuppress))
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// validateBatch checks the bounds of the batches
+func validateBatch(batch *v1alpha1.FileBatch) error {
+	if batch == nil {
+		return nil
+	}
+	var errs []error
+	if batch.MaxEvents < 0 {
+		errs = append(errs, fmt.Errorf("batch maxEvents must not be negative"))
+	}
+	if batch.MaxWait != "" {
+		if d, err := time.ParseDuration(batch.MaxWait); err != nil {
+			errs = append(errs, fmt.Errorf("batch maxWait must be a valid duration, %w", err))
+		} else if d <= 0 {
+			errs = append(errs, fmt.Errorf("batch maxWait must be positive"))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// validateConfigMapMode checks the watched paths only dispatch the updates of the projected volumes
+func validateConfigMapMode(fileEventSource *v1alpha1.FileEventSource) error {
+	if !fileEventSource.ConfigMapMode {
+		return nil
+	}
+	var errs []error
+	if watchMode(fileEventSource) == watchModePoll {
+		errs = append(errs, fmt.Errorf("configMapMode only applies to the inotify watcher, not to polling"))
+	}
+	update := fsevent.Update.String()
+	if !onlyType(fileEventSource.EventType, fileEventSource.EventTypes, fsevent.Update) {
+		errs = append(errs, fmt.Errorf("configMapMode requires the type to be %s", update))
+	}
+	for i, path := range fileEventSource.Paths {
+		if !onlyType(path.EventType, path.EventTypes, fsevent.Update) {
+			errs = append(errs, fmt.Errorf("paths[%d]: configMapMode requires the type to be %s", i, update))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// validateEditorAwareDebounce checks the watched paths dispatch the WRITE events the groups collapse into, and
+// that the events aren't grouped otherwise too
+func validateEditorAwareDebounce(fileEventSource *v1alpha1.FileEventSource) error {
+	if !fileEventSource.EditorAwareDebounce {
+		return nil
+	}
+	var errs []error
+	if watchMode(fileEventSource) == watchModePoll {
+		errs = append(errs, fmt.Errorf("editorAwareDebounce only applies to the inotify watcher, not to polling"))
+	}
+	if fileEventSource.DetectMoves {
+		errs = append(errs, fmt.Errorf("editorAwareDebounce can't be set along with detectMoves"))
+	}
+	if fileEventSource.DedupeByInode {
+		errs = append(errs, fmt.Errorf("editorAwareDebounce can't be set along with dedupeByInode"))
+	}
+	write := fsevent.Write.String()
+	if !onlyType(fileEventSource.EventType, fileEventSource.EventTypes, fsevent.Write) {
+		errs = append(errs, fmt.Errorf("editorAwareDebounce requires the type to be %s", write))
+	}
+	for i, path := range fileEventSource.Paths {
+		if !onlyType(path.EventType, path.EventTypes, fsevent.Write) {
+			errs = append(errs, fmt.Errorf("paths[%d]: editorAwareDebounce requires the type to be %s", i, write))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// onlyType tells whether the types of a watched path, if specified, are all the operation
+func onlyType(eventType string, eventTypes []string, op fsevent.Op) bool {
+	for _, t := range append([]string{eventType}, eventTypes...) {
+		if t != "" && t != op.String() {
+			return false
+		}
+	}
+	return true
+}
+
+func validContentMatchPolicy(policy string) bool {
+	return policy == "" || policy == contentMatchDispatch || policy == contentMatchSuppress
 }
@@ -34,7 +34,7 @@ func TestValidateEventSource(t *testing.T) {
 
 	err := listener.ValidateEventSource(context.Background())
 	assert.Error(t, err)
-	assert.Equal(t, "type must be specified", err.Error())
+	assert.Equal(t, "[type must be specified, directory is required]", err.Error())
 
 	content, err := ioutil.ReadFile(fmt.Sprintf("%s/%s", sources.EventSourceDir, "file.yaml"))
 	assert.Nil(t, err)
@@ -51,4 +51,151 @@ func TestValidateEventSource(t *testing.T) {
 		err := l.ValidateEventSource(context.Background())
 		assert.NoError(t, err)
 	}
+
+	l := &EventListener{
+		FileEventSource: v1alpha1.FileEventSource{
+			EventType: "CREATE",
+			WatchPathConfig: v1alpha1.WatchPathConfig{
+				Directory: "/test-data/",
+				Path:      "configs/[a-z.json",
+			},
+		},
+	}
+	err = l.ValidateEventSource(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "path must be a valid glob pattern")
+
+	l.FileEventSource.WatchPathConfig.Path = "x.txt"
+	l.FileEventSource.Extensions = []string{".json", "."}
+	err = l.ValidateEventSource(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, "extensions must not be empty", err.Error())
+
+	l.FileEventSource.Extensions = []string{".json"}
+	l.FileEventSource.MinSizeBytes = -1
+	err = l.ValidateEventSource(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, "minSizeBytes must not be negative", err.Error())
+
+	l.FileEventSource.MinSizeBytes = 0
+	l.FileEventSource.BufferSize = -1
+	err = l.ValidateEventSource(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, "bufferSize must not be negative", err.Error())
+
+	l.FileEventSource.BufferSize = 0
+	l.FileEventSource.MaxEventSizeBytes = -1
+	err = l.ValidateEventSource(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, "maxEventSizeBytes must not be negative", err.Error())
+
+	l.FileEventSource.MaxEventSizeBytes = 0
+	l.FileEventSource.IDStrategy = "sequential"
+	err = l.ValidateEventSource(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, "idStrategy must be either random or deterministic", err.Error())
+
+	l.FileEventSource.IDStrategy = ""
+	l.FileEventSource.OutputFormat = "avro"
+	err = l.ValidateEventSource(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, "outputFormat must be either native or cloudevents", err.Error())
+
+	l.FileEventSource.OutputFormat = ""
+	l.FileEventSource.MetadataPaths = map[string]string{"owner": ""}
+	err = l.ValidateEventSource(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, "metadataPaths keys and paths must not be empty", err.Error())
+
+	l.FileEventSource.MetadataPaths = nil
+	l.FileEventSource.ContentMatch = &v1alpha1.FileContentMatch{Regex: "ERROR"}
+	err = l.ValidateEventSource(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, "contentMatch requires trackOffset", err.Error())
+
+	l.FileEventSource.TrackOffset = true
+	l.FileEventSource.ContentMatch = &v1alpha1.FileContentMatch{Regex: "(ERROR", OnNoMatch: "drop"}
+	err = l.ValidateEventSource(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "contentMatch regex must be a valid regular expression")
+	assert.Contains(t, err.Error(), "contentMatch onNoMatch must be either dispatch or suppress")
+
+	// the offsets are only tracked for the WRITE events
+	l.FileEventSource.ContentMatch = nil
+	l.FileEventSource.Paths = []v1alpha1.FileWatchPath{{EventTypes: []string{"CREATE", "WRITE"}}, {EventType: "REMOVE"}}
+	err = l.ValidateEventSource(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "trackOffset requires the types to include WRITE, paths[1]: trackOffset requires the types to include WRITE]")
+	assert.NotContains(t, err.Error(), "paths[0]: trackOffset")
+
+	l.FileEventSource.Paths = nil
+	l.FileEventSource.EventTypes = []string{"WRITE"}
+	err = l.ValidateEventSource(context.Background())
+	assert.NoError(t, err)
+
+	l.FileEventSource.EventTypes = nil
+	l.FileEventSource.TrackOffset = false
+	l.FileEventSource.Batch = &v1alpha1.FileBatch{MaxEvents: -1, MaxWait: "soon"}
+	err = l.ValidateEventSource(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "batch maxEvents must not be negative")
+	assert.Contains(t, err.Error(), "batch maxWait must be a valid duration")
+
+	l.FileEventSource.Batch = nil
+	l.FileEventSource.IgnorePatterns = []string{"*.tmp", "!cache/[a-z.lock"}
+	err = l.ValidateEventSource(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `ignorePatterns "!cache/[a-z.lock" must be a valid pattern`)
+
+	l.FileEventSource.IgnorePatterns = nil
+	l.FileEventSource.ConfigMapMode = true
+	l.FileEventSource.Polling = true
+	err = l.ValidateEventSource(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "configMapMode only applies to the inotify watcher, not to polling")
+	assert.Contains(t, err.Error(), "configMapMode requires the type to be UPDATE")
+
+	l.FileEventSource.Polling = false
+	l.FileEventSource.EventType = "UPDATE"
+	err = l.ValidateEventSource(context.Background())
+	assert.NoError(t, err)
+
+	l.FileEventSource.ConfigMapMode = false
+	l.FileEventSource.EditorAwareDebounce = true
+	l.FileEventSource.DetectMoves = true
+	l.FileEventSource.DedupeByInode = true
+	l.FileEventSource.Paths = []v1alpha1.FileWatchPath{{EventType: "CREATE"}}
+	err = l.ValidateEventSource(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "editorAwareDebounce can't be set along with detectMoves")
+	assert.Contains(t, err.Error(), "editorAwareDebounce can't be set along with dedupeByInode")
+	assert.Contains(t, err.Error(), "editorAwareDebounce requires the type to be WRITE")
+	assert.Contains(t, err.Error(), "paths[0]: editorAwareDebounce requires the type to be WRITE")
+
+	l.FileEventSource.DetectMoves = false
+	l.FileEventSource.DedupeByInode = false
+	l.FileEventSource.Paths = nil
+	l.FileEventSource.EventType = "WRITE"
+	err = l.ValidateEventSource(context.Background())
+	assert.NoError(t, err)
+
+	l.FileEventSource.EditorAwareDebounce = false
+	l.FileEventSource.EventType = "UPDATE"
+	l.FileEventSource.ConfigMapMode = true
+	l.FileEventSource.RewatchInterval = "-1s"
+	err = l.ValidateEventSource(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, "rewatchInterval must be positive", err.Error())
+
+	l.FileEventSource.RewatchInterval = ""
+	l.FileEventSource.DispatchTimeout = "0s"
+	err = l.ValidateEventSource(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, "dispatchTimeout must be positive", err.Error())
+
+	l.FileEventSource.DispatchTimeout = ""
+	l.FileEventSource.DigestInterval = "0s"
+	err = l.ValidateEventSource(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, "digestInterval must be positive", err.Error())
 }
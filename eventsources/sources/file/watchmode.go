@@ -0,0 +1,126 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+const (
+	// watchModeInotify watches the directories with the notifications of the file system
+	watchModeInotify = "inotify"
+	// watchModePoll polls the directories, comparing the modification time and the size of the files
+	watchModePoll = "poll"
+	// watchModeAuto polls the directories on a network file system, and watches the other ones with inotify
+	watchModeAuto = "auto"
+)
+
+// defaultPollInterval is how often the files are compared by default when they are polled
+const defaultPollInterval = 100 * time.Millisecond
+
+// watchMode returns the watch mode of the event source, poll if the deprecated polling is set, inotify by default
+func watchMode(fileEventSource *v1alpha1.FileEventSource) string {
+	switch {
+	case fileEventSource.WatchMode != "":
+		return fileEventSource.WatchMode
+	case fileEventSource.Polling:
+		return watchModePoll
+	default:
+		return watchModeInotify
+	}
+}
+
+// polls tells whether the directory of the listener is polled rather than watched with inotify, detecting whether
+// it is on a network file system in the auto mode. A directory whose file system can't be detected is watched with
+// inotify. The options only applying to inotify, allowed along with the auto mode, are warned about once ignored.
+func (el *EventListener) polls(log *zap.SugaredLogger) bool {
+	switch watchMode(&el.FileEventSource) {
+	case watchModePoll:
+		return true
+	case watchModeAuto:
+		directory := el.FileEventSource.WatchPathConfig.Directory
+		network, err := isNetworkFS(directory)
+		if err != nil {
+			log.Warnw("failed to detect the file system of the directory, watching it with inotify", zap.String("directory", directory), zap.Error(err))
+			return false
+		}
+		if network {
+			log.Infow("the directory is on a network file system, polling it", zap.String("directory", directory))
+			if ignored := inotifyOptions(&el.FileEventSource); len(ignored) > 0 {
+				log.Warnw("the options only applying to the inotify watcher are ignored while polling", zap.Strings("options", ignored))
+			}
+		}
+		return network
+	default:
+		return false
+	}
+}
+
+// inotifyOptions returns the options set which only apply to the inotify watcher
+func inotifyOptions(fileEventSource *v1alpha1.FileEventSource) []string {
+	var options []string
+	if fileEventSource.ConfigMapMode {
+		options = append(options, "configMapMode")
+	}
+	if fileEventSource.EditorAwareDebounce {
+		options = append(options, "editorAwareDebounce")
+	}
+	if fileEventSource.FollowSymlinks {
+		options = append(options, "followSymlinks")
+	}
+	return options
+}
+
+// pollInterval returns how often the files are compared when they are polled
+func pollInterval(interval string) (time.Duration, error) {
+	if interval == "" {
+		return defaultPollInterval, nil
+	}
+	d, err := time.ParseDuration(interval)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to parse the poll interval %s", interval)
+	}
+	if d <= 0 {
+		return 0, errors.New("pollInterval must be positive")
+	}
+	return d, nil
+}
+
+// validateWatchMode checks the watch mode is known and doesn't contradict the deprecated polling, and the interval
+// of the polling
+func validateWatchMode(fileEventSource *v1alpha1.FileEventSource) error {
+	var errs []error
+	switch fileEventSource.WatchMode {
+	case "", watchModeInotify, watchModePoll, watchModeAuto:
+		if fileEventSource.Polling && fileEventSource.WatchMode != "" && fileEventSource.WatchMode != watchModePoll {
+			errs = append(errs, fmt.Errorf("polling can't be set along with the %s watchMode", fileEventSource.WatchMode))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("watchMode must be either %s, %s or %s", watchModeInotify, watchModePoll, watchModeAuto))
+	}
+	if _, err := pollInterval(fileEventSource.PollInterval); err != nil {
+		errs = append(errs, err)
+	}
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/eventsources/common/fsevent"
+	metrics "github.com/argoproj/argo-events/metrics"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestWatchMode(t *testing.T) {
+	assert.Equal(t, watchModeInotify, watchMode(&v1alpha1.FileEventSource{}))
+	assert.Equal(t, watchModePoll, watchMode(&v1alpha1.FileEventSource{Polling: true}))
+	assert.Equal(t, watchModeAuto, watchMode(&v1alpha1.FileEventSource{WatchMode: watchModeAuto}))
+	assert.Equal(t, watchModePoll, watchMode(&v1alpha1.FileEventSource{WatchMode: watchModePoll, Polling: true}))
+}
+
+func TestPolls(t *testing.T) {
+	log := zap.NewNop().Sugar()
+	el := &EventListener{FileEventSource: v1alpha1.FileEventSource{WatchPathConfig: v1alpha1.WatchPathConfig{Directory: t.TempDir()}}}
+	assert.False(t, el.polls(log))
+	el.FileEventSource.WatchMode = watchModePoll
+	assert.True(t, el.polls(log))
+
+	// the directory of a local file system is watched with inotify
+	el.FileEventSource.WatchMode = watchModeAuto
+	network, err := isNetworkFS(el.FileEventSource.WatchPathConfig.Directory)
+	assert.NoError(t, err)
+	assert.Equal(t, network, el.polls(log))
+
+	// as well as a directory whose file system can't be detected
+	el.FileEventSource.WatchPathConfig.Directory = filepath.Join(el.FileEventSource.WatchPathConfig.Directory, "missing")
+	assert.False(t, el.polls(log))
+}
+
+func TestInotifyOptions(t *testing.T) {
+	assert.Empty(t, inotifyOptions(&v1alpha1.FileEventSource{}))
+	assert.Equal(t, []string{"configMapMode", "editorAwareDebounce", "followSymlinks"}, inotifyOptions(&v1alpha1.FileEventSource{
+		ConfigMapMode:       true,
+		EditorAwareDebounce: true,
+		FollowSymlinks:      true,
+	}))
+}
+
+func TestPollInterval(t *testing.T) {
+	interval, err := pollInterval("")
+	assert.NoError(t, err)
+	assert.Equal(t, defaultPollInterval, interval)
+	interval, err = pollInterval("5s")
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Second, interval)
+	_, err = pollInterval("0s")
+	assert.EqualError(t, err, "pollInterval must be positive")
+	_, err = pollInterval("often")
+	assert.Error(t, err)
+}
+
+func TestValidateWatchMode(t *testing.T) {
+	assert.NoError(t, validateWatchMode(&v1alpha1.FileEventSource{}))
+	assert.NoError(t, validateWatchMode(&v1alpha1.FileEventSource{WatchMode: watchModeAuto, PollInterval: "1s"}))
+	assert.NoError(t, validateWatchMode(&v1alpha1.FileEventSource{WatchMode: watchModePoll, Polling: true}))
+	assert.EqualError(t, validateWatchMode(&v1alpha1.FileEventSource{WatchMode: "fanotify"}), "watchMode must be either inotify, poll or auto")
+	assert.EqualError(t, validateWatchMode(&v1alpha1.FileEventSource{WatchMode: watchModeInotify, Polling: true}), "polling can't be set along with the inotify watchMode")
+	assert.EqualError(t, validateWatchMode(&v1alpha1.FileEventSource{PollInterval: "-1s"}), "pollInterval must be positive")
+}
+
+func TestListenPolling(t *testing.T) {
+	dir := t.TempDir()
+	el := &EventListener{
+		EventSourceName: "file",
+		EventName:       "example",
+		FileEventSource: v1alpha1.FileEventSource{
+			EventTypes:      []string{"CREATE", "WRITE"},
+			WatchPathConfig: v1alpha1.WatchPathConfig{Directory: dir, Path: "*.txt"},
+			WatchMode:       watchModePoll,
+			PollInterval:    "50ms",
+		},
+		Metrics: metrics.NewMetrics("ns"),
+	}
+
+	var lock sync.Mutex
+	var ops []string
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- el.StartListening(ctx, func(data []byte, _ ...eventsourcecommon.Options) error {
+			var event fsevent.Event
+			assert.NoError(t, json.Unmarshal(data, &event))
+			lock.Lock()
+			defer lock.Unlock()
+			ops = append(ops, event.Op.String())
+			return nil
+		})
+	}()
+	received := func(op string) func() bool {
+		return func() bool {
+			lock.Lock()
+			defer lock.Unlock()
+			for _, o := range ops {
+				if o == op {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	path := filepath.Join(dir, "a.txt")
+	// the file is created once the watcher polls, so that its creation isn't part of the initial listing
+	time.Sleep(200 * time.Millisecond)
+	assert.NoError(t, os.WriteFile(path, []byte("hello"), 0600))
+	assert.Eventually(t, received("CREATE"), 10*time.Second, 50*time.Millisecond)
+
+	// the size of the file changes
+	assert.NoError(t, os.WriteFile(path, []byte("hello world"), 0600))
+	assert.Eventually(t, received("WRITE"), 10*time.Second, 50*time.Millisecond)
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("the directory is still polled after shutdown")
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	grpclib "google.golang.org/grpc"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// splitMethod splits the full name of a method, e.g. example.v1.Orders/Watch, into the names of its service and method
+func splitMethod(fullMethod string) (string, string, error) {
+	parts := strings.Split(strings.TrimPrefix(fullMethod, "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", errors.Errorf("method %s must be of the form package.Service/Method", fullMethod)
+	}
+	return parts[0], parts[1], nil
+}
+
+// resolveMethod resolves the descriptor of the server streaming method through the server reflection service.
+func resolveMethod(ctx context.Context, conn grpclib.ClientConnInterface, fullMethod string) (protoreflect.MethodDescriptor, error) {
+	service, method, err := splitMethod(fullMethod)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := rpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open the server reflection stream")
+	}
+	defer func() {
+		_ = stream.CloseSend()
+	}()
+
+	fileProtos := map[string]*descriptorpb.FileDescriptorProto{}
+	request := &rpb.ServerReflectionRequest{MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: service}}
+	for request != nil {
+		if err := stream.Send(request); err != nil {
+			return nil, errors.Wrap(err, "failed to send the server reflection request")
+		}
+		response, err := stream.Recv()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to receive the server reflection response")
+		}
+		if e := response.GetErrorResponse(); e != nil {
+			return nil, errors.Errorf("failed to resolve the descriptors of %s: %s", service, e.GetErrorMessage())
+		}
+		for _, b := range response.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			fileProto := &descriptorpb.FileDescriptorProto{}
+			if err := proto.Unmarshal(b, fileProto); err != nil {
+				return nil, errors.Wrap(err, "failed to decode a file descriptor")
+			}
+			fileProtos[fileProto.GetName()] = fileProto
+		}
+		// the dependencies not sent along are requested one at a time
+		request = nil
+		for _, fileProto := range fileProtos {
+			for _, dependency := range fileProto.GetDependency() {
+				if _, ok := fileProtos[dependency]; !ok {
+					request = &rpb.ServerReflectionRequest{MessageRequest: &rpb.ServerReflectionRequest_FileByFilename{FileByFilename: dependency}}
+				}
+			}
+		}
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	for _, fileProto := range fileProtos {
+		set.File = append(set.File, fileProto)
+	}
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build the file descriptors")
+	}
+	descriptor, err := files.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find the service %s", service)
+	}
+	serviceDescriptor, ok := descriptor.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, errors.Errorf("%s is not a service", service)
+	}
+	methodDescriptor := serviceDescriptor.Methods().ByName(protoreflect.Name(method))
+	if methodDescriptor == nil {
+		return nil, errors.Errorf("service %s has no method %s", service, method)
+	}
+	if !methodDescriptor.IsStreamingServer() || methodDescriptor.IsStreamingClient() {
+		return nil, errors.Errorf("method %s must be a server streaming method", fullMethod)
+	}
+	return methodDescriptor, nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// resumer copies the field of the last message received from the stream into the request reopening the stream.
+type resumer struct {
+	responseField protoreflect.FieldDescriptor
+	requestField  protoreflect.FieldDescriptor
+	last          protoreflect.Value
+	valid         bool
+}
+
+// newResumer returns the resumer of the stream of the method, or nil if the stream is not resumed.
+func newResumer(method protoreflect.MethodDescriptor, resume *v1alpha1.GRPCStreamResume) (*resumer, error) {
+	if resume == nil {
+		return nil, nil
+	}
+	responseField := method.Output().Fields().ByName(protoreflect.Name(resume.ResponseField))
+	if responseField == nil {
+		return nil, errors.Errorf("message %s has no field %s", method.Output().FullName(), resume.ResponseField)
+	}
+	requestField := method.Input().Fields().ByName(protoreflect.Name(resume.RequestField))
+	if requestField == nil {
+		return nil, errors.Errorf("message %s has no field %s", method.Input().FullName(), resume.RequestField)
+	}
+	if !sameType(responseField, requestField) {
+		return nil, errors.Errorf("fields %s and %s must be of the same type", responseField.FullName(), requestField.FullName())
+	}
+	return &resumer{responseField: responseField, requestField: requestField}, nil
+}
+
+// sameType returns true if the values of a field can be set to the other one.
+func sameType(a, b protoreflect.FieldDescriptor) bool {
+	if a.Kind() != b.Kind() || a.Cardinality() != b.Cardinality() || a.IsMap() || b.IsMap() || a.IsList() || b.IsList() {
+		return false
+	}
+	switch a.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return a.Message().FullName() == b.Message().FullName()
+	case protoreflect.EnumKind:
+		return a.Enum().FullName() == b.Enum().FullName()
+	}
+	return true
+}
+
+// record remembers the field of the received message, if set.
+func (r *resumer) record(message protoreflect.Message) {
+	if r == nil || !message.Has(r.responseField) {
+		return
+	}
+	r.last = message.Get(r.responseField)
+	r.valid = true
+}
+
+// apply sets the field of the request to the one of the last received message, if any.
+func (r *resumer) apply(request protoreflect.Message) {
+	if r == nil || !r.valid {
+		return
+	}
+	request.Set(r.requestField, r.last)
+}
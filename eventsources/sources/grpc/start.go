@@ -0,0 +1,236 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/argoproj/argo-events/common"
+	"github.com/argoproj/argo-events/common/logging"
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/eventsources/sources"
+	metrics "github.com/argoproj/argo-events/metrics"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// EventListener implements Eventing for the gRPC event source
+type EventListener struct {
+	EventSourceName string
+	EventName       string
+	GRPCEventSource v1alpha1.GRPCEventSource
+	Metrics         *metrics.Metrics
+}
+
+// GetEventSourceName returns name of event source
+func (el *EventListener) GetEventSourceName() string {
+	return el.EventSourceName
+}
+
+// GetEventName returns name of event
+func (el *EventListener) GetEventName() string {
+	return el.EventName
+}
+
+// GetEventSourceType return type of event server
+func (el *EventListener) GetEventSourceType() apicommon.EventSourceType {
+	return apicommon.GRPCEvent
+}
+
+// StartListening invokes the server streaming method and dispatches each message of the stream,
+// the stream is reopened once it terminates.
+func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byte, ...eventsourcecommon.Options) error) error {
+	log := logging.FromContext(ctx).
+		With(logging.LabelEventSourceType, el.GetEventSourceType(), logging.LabelEventName, el.GetEventName())
+	log.Info("started processing the gRPC event source...")
+	defer sources.Recover(el.GetEventName())
+
+	grpcEventSource := &el.GRPCEventSource
+
+	creds := insecure.NewCredentials()
+	if grpcEventSource.TLS != nil {
+		tlsConfig, err := common.GetTLSConfig(grpcEventSource.TLS, log)
+		if err != nil {
+			return errors.Wrap(err, "failed to get the tls configuration")
+		}
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
+	log.Infow("dialing the gRPC server...", zap.String("endpoint", grpcEventSource.Endpoint))
+	conn, err := grpclib.DialContext(ctx, grpcEventSource.Endpoint, grpclib.WithTransportCredentials(creds))
+	if err != nil {
+		return errors.Wrapf(err, "failed to dial the gRPC server %s", grpcEventSource.Endpoint)
+	}
+	defer conn.Close()
+
+	var method protoreflect.MethodDescriptor
+	if err := common.ConnectWithContext(ctx, grpcEventSource.ConnectionBackoff, func() error {
+		method, err = resolveMethod(ctx, conn, grpcEventSource.Method)
+		return err
+	}); err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonConnection)
+		return errors.Wrapf(err, "failed to resolve the method %s", grpcEventSource.Method)
+	}
+	resume, err := newResumer(method, grpcEventSource.Resume)
+	if err != nil {
+		return errors.Wrap(err, "failed to resume the stream")
+	}
+
+	el.Metrics.EventSourceActive(el.GetEventSourceName(), el.GetEventName(), true)
+	defer el.Metrics.EventSourceActive(el.GetEventSourceName(), el.GetEventName(), false)
+
+	// the stream is reopened once it ends, after the first duration of the backoff
+	connect := common.ConnectWithContext
+	for {
+		log.Infow("opening the stream...", zap.String("method", grpcEventSource.Method))
+		var stream grpclib.ClientStream
+		var first *dynamicpb.Message
+		var cancel context.CancelFunc
+		// the stream is open once its first message has been received, so that a server refusing or
+		// ending the streams right away is retried with the backoff
+		if err := connect(ctx, grpcEventSource.ConnectionBackoff, func() error {
+			request, err := newRequest(method, grpcEventSource.Request, resume)
+			if err != nil {
+				return err
+			}
+			streamCtx, streamCancel := context.WithCancel(ctx)
+			s, err := openStream(streamCtx, conn, method, request)
+			if err != nil {
+				streamCancel()
+				return err
+			}
+			message := dynamicpb.NewMessage(method.Output())
+			if err := s.RecvMsg(message); err != nil {
+				streamCancel()
+				if err == io.EOF {
+					return errors.New("the stream ended without any message")
+				}
+				log.Errorw("failed to open the stream, retrying...", zap.Error(err))
+				return err
+			}
+			stream, first, cancel = s, message, streamCancel
+			return nil
+		}); err != nil {
+			if ctx.Err() != nil {
+				log.Info("event source is stopped")
+				return nil
+			}
+			el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonConnection)
+			return errors.Wrapf(err, "failed to open the stream of the method %s", grpcEventSource.Method)
+		}
+
+		err := el.receive(stream, first, method, resume, dispatch, log)
+		cancel()
+		connect = common.ReconnectWithContext
+		if ctx.Err() != nil {
+			log.Info("event source is stopped")
+			return nil
+		}
+		if err == io.EOF {
+			log.Info("the stream has ended, reopening it...")
+			continue
+		}
+		log.Errorw("the stream has terminated, reopening it...", zap.Error(err))
+		el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonStream)
+	}
+}
+
+// receive dispatches the first message and the next ones of the stream until it terminates.
+func (el *EventListener) receive(stream grpclib.ClientStream, first *dynamicpb.Message, method protoreflect.MethodDescriptor, resume *resumer, dispatch func([]byte, ...eventsourcecommon.Options) error, log *zap.SugaredLogger) error {
+	message := first
+	for {
+		resume.record(message)
+		if err := el.handleOne(message, dispatch, log); err != nil {
+			log.Errorw("failed to process a gRPC message", zap.Error(err))
+			el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.ReasonOf(err))
+		}
+		message = dynamicpb.NewMessage(method.Output())
+		if err := stream.RecvMsg(message); err != nil {
+			return err
+		}
+	}
+}
+
+func (el *EventListener) handleOne(message *dynamicpb.Message, dispatch func([]byte, ...eventsourcecommon.Options) error, log *zap.SugaredLogger) error {
+	defer func(start time.Time) {
+		el.Metrics.EventProcessingDuration(el.GetEventSourceName(), el.GetEventName(), float64(time.Since(start)/time.Millisecond))
+	}(time.Now())
+
+	log.Info("received a message")
+	body, err := protojson.Marshal(message)
+	if err != nil {
+		return metrics.WithReason(errors.Wrap(err, "failed to encode the message as json"), metrics.FailureReasonMarshal)
+	}
+	eventData := &events.GRPCEventData{
+		Method:   el.GRPCEventSource.Method,
+		Message:  body,
+		Metadata: el.GRPCEventSource.Metadata,
+	}
+	eventBody, err := json.Marshal(eventData)
+	if err != nil {
+		return metrics.WithReason(errors.Wrap(err, "failed to marshal the event data"), metrics.FailureReasonMarshal)
+	}
+	log.Info("dispatching the event on the data channel...")
+	if err = dispatch(eventBody); err != nil {
+		return metrics.WithReason(errors.Wrap(err, "failed to dispatch the event"), metrics.FailureReasonDispatch)
+	}
+	return nil
+}
+
+// newRequest decodes the request of the method from its JSON encoding, resumed from the last received message.
+func newRequest(method protoreflect.MethodDescriptor, request string, resume *resumer) (*dynamicpb.Message, error) {
+	message := dynamicpb.NewMessage(method.Input())
+	if request != "" {
+		if err := protojson.Unmarshal([]byte(request), message); err != nil {
+			return nil, errors.Wrapf(err, "failed to decode the request as a %s", method.Input().FullName())
+		}
+	}
+	resume.apply(message)
+	return message, nil
+}
+
+// openStream invokes the server streaming method with the request.
+func openStream(ctx context.Context, conn grpclib.ClientConnInterface, method protoreflect.MethodDescriptor, request *dynamicpb.Message) (grpclib.ClientStream, error) {
+	fullMethod := "/" + string(method.Parent().FullName()) + "/" + string(method.Name())
+	stream, err := conn.NewStream(ctx, &grpclib.StreamDesc{ServerStreams: true}, fullMethod)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(request); err != nil {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/interop/grpc_testing"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	metrics "github.com/argoproj/argo-events/metrics"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+const streamingMethod = "grpc.testing.TestService/StreamingOutputCall"
+
+// testService streams two messages then fails the first stream, and streams the next ones until they are cancelled.
+type testService struct {
+	grpc_testing.UnimplementedTestServiceServer
+
+	lock     sync.Mutex
+	requests []*grpc_testing.StreamingOutputCallRequest
+}
+
+func (s *testService) StreamingOutputCall(request *grpc_testing.StreamingOutputCallRequest, stream grpc_testing.TestService_StreamingOutputCallServer) error {
+	s.lock.Lock()
+	s.requests = append(s.requests, request)
+	first := len(s.requests) == 1
+	s.lock.Unlock()
+	for _, body := range []string{"a", "b"} {
+		if err := stream.Send(&grpc_testing.StreamingOutputCallResponse{Payload: &grpc_testing.Payload{Body: []byte(body)}}); err != nil {
+			return err
+		}
+	}
+	if first {
+		return status.Error(codes.Unavailable, "going away")
+	}
+	<-stream.Context().Done()
+	return nil
+}
+
+func (s *testService) received() []*grpc_testing.StreamingOutputCallRequest {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]*grpc_testing.StreamingOutputCallRequest(nil), s.requests...)
+}
+
+func startServer(t *testing.T, service *testService) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpclib.NewServer()
+	grpc_testing.RegisterTestServiceServer(server, service)
+	reflection.Register(server)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+func TestSplitMethod(t *testing.T) {
+	service, method, err := splitMethod("/example.v1.Orders/Watch")
+	assert.NoError(t, err)
+	assert.Equal(t, "example.v1.Orders", service)
+	assert.Equal(t, "Watch", method)
+
+	for _, fullMethod := range []string{"Watch", "example.v1.Orders/", "/Watch", "a/b/c"} {
+		_, _, err = splitMethod(fullMethod)
+		assert.Error(t, err, fullMethod)
+	}
+}
+
+func TestResolveMethod(t *testing.T) {
+	endpoint := startServer(t, &testService{})
+	conn, err := grpclib.Dial(endpoint, grpclib.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	ctx := context.Background()
+
+	method, err := resolveMethod(ctx, conn, streamingMethod)
+	assert.NoError(t, err)
+	assert.Equal(t, "grpc.testing.StreamingOutputCallRequest", string(method.Input().FullName()))
+	assert.Equal(t, "grpc.testing.StreamingOutputCallResponse", string(method.Output().FullName()))
+
+	_, err = resolveMethod(ctx, conn, "grpc.testing.TestService/UnaryCall")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "must be a server streaming method")
+
+	_, err = resolveMethod(ctx, conn, "grpc.testing.TestService/Missing")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "has no method Missing")
+
+	_, err = resolveMethod(ctx, conn, "grpc.testing.Missing/Watch")
+	assert.Error(t, err)
+
+	_, err = newResumer(method, &v1alpha1.GRPCStreamResume{ResponseField: "payload", RequestField: "response_type"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "must be of the same type")
+	_, err = newResumer(method, &v1alpha1.GRPCStreamResume{ResponseField: "cursor", RequestField: "payload"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "has no field cursor")
+}
+
+func TestStartListening(t *testing.T) {
+	service := &testService{}
+	el := &EventListener{
+		EventSourceName: "grpc",
+		EventName:       "example",
+		GRPCEventSource: v1alpha1.GRPCEventSource{
+			Endpoint: startServer(t, service),
+			Method:   streamingMethod,
+			Request:  `{"responseStatus": {"message": "hello"}}`,
+			Resume:   &v1alpha1.GRPCStreamResume{ResponseField: "payload", RequestField: "payload"},
+			Metadata: map[string]string{"team": "orders"},
+		},
+		Metrics: metrics.NewMetrics("ns"),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var lock sync.Mutex
+	var dispatched [][]byte
+	done := make(chan error)
+	go func() {
+		done <- el.StartListening(ctx, func(data []byte, opts ...eventsourcecommon.Options) error {
+			lock.Lock()
+			defer lock.Unlock()
+			dispatched = append(dispatched, data)
+			return nil
+		})
+	}()
+
+	// the first stream fails after two messages, the second one is resumed from the last message
+	assert.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(dispatched) == 4
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	assert.NoError(t, <-done)
+
+	requests := service.received()
+	require.Len(t, requests, 2)
+	assert.Equal(t, "hello", requests[0].ResponseStatus.GetMessage())
+	assert.Nil(t, requests[0].Payload)
+	assert.Equal(t, "hello", requests[1].ResponseStatus.GetMessage())
+	assert.Equal(t, []byte("b"), requests[1].Payload.Body)
+
+	var data events.GRPCEventData
+	assert.NoError(t, json.Unmarshal(dispatched[0], &data))
+	assert.Equal(t, streamingMethod, data.Method)
+	assert.JSONEq(t, `{"payload": {"body": "YQ=="}}`, string(data.Message))
+	assert.Equal(t, "orders", data.Metadata["team"])
+}
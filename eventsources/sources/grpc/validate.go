@@ -0,0 +1,58 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/argoproj/argo-events/common"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// ValidateEventSource validates the gRPC event source
+func (listener *EventListener) ValidateEventSource(ctx context.Context) error {
+	return validate(&listener.GRPCEventSource)
+}
+
+func validate(eventSource *v1alpha1.GRPCEventSource) error {
+	if eventSource == nil {
+		return common.ErrNilEventSource
+	}
+	if eventSource.Endpoint == "" {
+		return errors.New("endpoint must be specified")
+	}
+	if eventSource.Method == "" {
+		return errors.New("method must be specified")
+	}
+	if _, _, err := splitMethod(eventSource.Method); err != nil {
+		return err
+	}
+	if eventSource.Request != "" && !json.Valid([]byte(eventSource.Request)) {
+		return errors.New("request must be valid json")
+	}
+	if eventSource.Resume != nil && (eventSource.Resume.ResponseField == "" || eventSource.Resume.RequestField == "") {
+		return errors.New("resume must specify both the responseField and the requestField")
+	}
+	if eventSource.TLS != nil {
+		return apicommon.ValidateTLSConfig(eventSource.TLS)
+	}
+	return nil
+}
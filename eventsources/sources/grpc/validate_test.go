@@ -0,0 +1,70 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-events/eventsources/sources"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestValidateEventSource(t *testing.T) {
+	listener := &EventListener{}
+
+	err := listener.ValidateEventSource(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, "endpoint must be specified", err.Error())
+
+	content, err := ioutil.ReadFile(fmt.Sprintf("%s/%s", sources.EventSourceDir, "grpc.yaml"))
+	assert.Nil(t, err)
+
+	var eventSource *v1alpha1.EventSource
+	err = yaml.Unmarshal(content, &eventSource)
+	assert.Nil(t, err)
+	assert.NotNil(t, eventSource.Spec.GRPC)
+
+	for _, value := range eventSource.Spec.GRPC {
+		l := &EventListener{
+			GRPCEventSource: value,
+		}
+		err := l.ValidateEventSource(context.Background())
+		assert.NoError(t, err)
+
+		l.GRPCEventSource.Request = "{"
+		err = l.ValidateEventSource(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, "request must be valid json", err.Error())
+
+		l.GRPCEventSource.Request = ""
+		l.GRPCEventSource.Resume.RequestField = ""
+		err = l.ValidateEventSource(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, "resume must specify both the responseField and the requestField", err.Error())
+
+		l.GRPCEventSource.Resume = nil
+		l.GRPCEventSource.Method = "Watch"
+		err = l.ValidateEventSource(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, "method Watch must be of the form package.Service/Method", err.Error())
+	}
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httppoll
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/argoproj/argo-events/common"
+	"github.com/argoproj/argo-events/common/logging"
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/eventsources/sources"
+	metrics "github.com/argoproj/argo-events/metrics"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+const (
+	// defaultInterval is how often the endpoint is polled by default
+	defaultInterval = time.Minute
+	// maxBodyBytes is the maximum size of the body of a response
+	maxBodyBytes = 10 << 20
+)
+
+// EventListener implements Eventing for the HTTP poll event source
+type EventListener struct {
+	EventSourceName     string
+	EventName           string
+	HTTPPollEventSource v1alpha1.HTTPPollEventSource
+	Metrics             *metrics.Metrics
+}
+
+// GetEventSourceName returns name of event source
+func (el *EventListener) GetEventSourceName() string {
+	return el.EventSourceName
+}
+
+// GetEventName returns name of event
+func (el *EventListener) GetEventName() string {
+	return el.EventName
+}
+
+// GetEventSourceType return type of event server
+func (el *EventListener) GetEventSourceType() apicommon.EventSourceType {
+	return apicommon.HTTPPollEvent
+}
+
+// method returns the method of the requests, GET by default
+func (el *EventListener) method() string {
+	if el.HTTPPollEventSource.Method == "" {
+		return http.MethodGet
+	}
+	return el.HTTPPollEventSource.Method
+}
+
+// response is the response of a poll
+type response struct {
+	statusCode int
+	headers    map[string]string
+	body       []byte
+	hash       string
+}
+
+// StartListening polls the endpoint on each interval and dispatches the responses
+func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byte, ...eventsourcecommon.Options) error) error {
+	log := logging.FromContext(ctx).
+		With(logging.LabelEventSourceType, el.GetEventSourceType(), logging.LabelEventName, el.GetEventName())
+	log.Info("started processing the HTTP poll event source...")
+	defer sources.Recover(el.GetEventName())
+
+	httpPollEventSource := &el.HTTPPollEventSource
+
+	interval := defaultInterval
+	if httpPollEventSource.Interval != "" {
+		d, err := time.ParseDuration(httpPollEventSource.Interval)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse the interval %s", httpPollEventSource.Interval)
+		}
+		interval = d
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if httpPollEventSource.TLS != nil {
+		tlsConfig, err := common.GetTLSConfig(httpPollEventSource.TLS, log)
+		if err != nil {
+			return errors.Wrap(err, "failed to get the tls configuration")
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+	// a poll must complete before the next one is due
+	client := &http.Client{Transport: transport, Timeout: interval}
+
+	var lastHash string
+	poll := func() {
+		res, err := el.poll(ctx, client)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Errorw("failed to poll the endpoint", zap.String("url", httpPollEventSource.URL), zap.Error(err))
+			el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.ReasonOf(err))
+			return
+		}
+		if httpPollEventSource.EmitOnChangeOnly && res.hash == lastHash {
+			log.Debugw("the body of the response is unchanged, skip it", zap.String("bodyHash", res.hash))
+			return
+		}
+		if err := el.dispatchResponse(res, dispatch, log); err != nil {
+			log.Errorw("failed to dispatch the response", zap.Error(err))
+			el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.ReasonOf(err))
+			return
+		}
+		// the response is compared with the last one dispatched, one which failed is dispatched again on the next poll
+		lastHash = res.hash
+	}
+
+	log.Infow("polling the endpoint...", zap.String("url", httpPollEventSource.URL), zap.Duration("interval", interval))
+	el.Metrics.EventSourceActive(el.GetEventSourceName(), el.GetEventName(), true)
+	defer el.Metrics.EventSourceActive(el.GetEventSourceName(), el.GetEventName(), false)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		poll()
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			log.Info("event source is stopped")
+			return nil
+		}
+	}
+}
+
+// poll sends the request to the endpoint and reads the response. The responses of any status are returned, only the
+// failures to get a response are errors.
+func (el *EventListener) poll(ctx context.Context, client *http.Client) (*response, error) {
+	httpPollEventSource := &el.HTTPPollEventSource
+	req, err := http.NewRequestWithContext(ctx, el.method(), httpPollEventSource.URL, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the request")
+	}
+	for name, value := range httpPollEventSource.Headers {
+		req.Header.Set(name, value)
+	}
+	if err := setAuth(req, httpPollEventSource); err != nil {
+		return nil, metrics.WithReason(err, metrics.FailureReasonAuth)
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, metrics.WithReason(errors.Wrap(err, "failed to send the request"), metrics.FailureReasonConnection)
+	}
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(io.LimitReader(res.Body, maxBodyBytes+1))
+	if err != nil {
+		return nil, metrics.WithReason(errors.Wrap(err, "failed to read the response body"), metrics.FailureReasonConnection)
+	}
+	if len(body) > maxBodyBytes {
+		return nil, metrics.WithReason(errors.Errorf("the response body exceeds %d bytes", maxBodyBytes), metrics.FailureReasonValidation)
+	}
+	hash := sha256.Sum256(body)
+	return &response{
+		statusCode: res.StatusCode,
+		headers:    selectHeaders(res.Header, httpPollEventSource.ResponseHeaders),
+		body:       body,
+		hash:       hex.EncodeToString(hash[:]),
+	}, nil
+}
+
+// setAuth sets the credentials of the basic auth or the bearer token, read from the mounted secrets, on the request
+func setAuth(req *http.Request, eventSource *v1alpha1.HTTPPollEventSource) error {
+	if basicAuth := eventSource.BasicAuth; basicAuth != nil {
+		var username, password string
+		if basicAuth.Username != nil {
+			u, err := common.GetSecretFromVolume(basicAuth.Username)
+			if err != nil {
+				return errors.Wrap(err, "failed to retrieve the username")
+			}
+			username = u
+		}
+		if basicAuth.Password != nil {
+			p, err := common.GetSecretFromVolume(basicAuth.Password)
+			if err != nil {
+				return errors.Wrap(err, "failed to retrieve the password")
+			}
+			password = p
+		}
+		req.SetBasicAuth(username, password)
+	}
+	if eventSource.BearerToken != nil {
+		token, err := common.GetSecretFromVolume(eventSource.BearerToken)
+		if err != nil {
+			return errors.Wrap(err, "failed to retrieve the bearer token")
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(token))
+	}
+	return nil
+}
+
+// selectHeaders returns the values of the named headers present in the response, the values of a header repeated
+// are joined by commas
+func selectHeaders(header http.Header, names []string) map[string]string {
+	if len(names) == 0 {
+		return nil
+	}
+	result := make(map[string]string)
+	for _, name := range names {
+		if values := header.Values(name); len(values) > 0 {
+			result[http.CanonicalHeaderKey(name)] = strings.Join(values, ",")
+		}
+	}
+	return result
+}
+
+// dispatchResponse dispatches the event of a response
+func (el *EventListener) dispatchResponse(res *response, dispatch func([]byte, ...eventsourcecommon.Options) error, log *zap.SugaredLogger) error {
+	defer func(start time.Time) {
+		el.Metrics.EventProcessingDuration(el.GetEventSourceName(), el.GetEventName(), float64(time.Since(start)/time.Millisecond))
+	}(time.Now())
+
+	httpPollEventSource := &el.HTTPPollEventSource
+	eventData := &events.HTTPPollEventData{
+		URL:        httpPollEventSource.URL,
+		Method:     el.method(),
+		StatusCode: res.statusCode,
+		Headers:    res.headers,
+		Body:       res.body,
+		BodyHash:   res.hash,
+		Time:       time.Now().UTC(),
+		Metadata:   httpPollEventSource.Metadata,
+	}
+	if httpPollEventSource.JSONBody {
+		if !json.Valid(res.body) {
+			return metrics.WithReason(errors.New("the response body is not valid JSON"), metrics.FailureReasonValidation)
+		}
+		eventData.Body = (*json.RawMessage)(&res.body)
+	}
+	eventBytes, err := json.Marshal(eventData)
+	if err != nil {
+		return metrics.WithReason(errors.Wrap(err, "failed to marshal the event data"), metrics.FailureReasonMarshal)
+	}
+	log.Infow("dispatching the response...", zap.Int("statusCode", res.statusCode), zap.String("bodyHash", res.hash))
+	if err = dispatch(eventBytes); err != nil {
+		return metrics.WithReason(errors.Wrap(err, "failed to dispatch the event"), metrics.FailureReasonDispatch)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httppoll
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	metrics "github.com/argoproj/argo-events/metrics"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestSelectHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Set("Etag", `"v1"`)
+	header.Add("Link", "<a>")
+	header.Add("Link", "<b>")
+	assert.Nil(t, selectHeaders(header, nil))
+	assert.Equal(t, map[string]string{"Etag": `"v1"`, "Link": "<a>,<b>"}, selectHeaders(header, []string{"etag", "Link", "X-Missing"}))
+}
+
+func TestPoll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Accept"))
+		w.Header().Set("ETag", `"v1"`)
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	el := &EventListener{HTTPPollEventSource: v1alpha1.HTTPPollEventSource{
+		URL:             server.URL,
+		Method:          http.MethodPost,
+		Headers:         map[string]string{"Accept": "application/json"},
+		ResponseHeaders: []string{"ETag"},
+	}}
+	res, err := el.poll(context.Background(), server.Client())
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, res.statusCode)
+	assert.Equal(t, map[string]string{"Etag": `"v1"`}, res.headers)
+	assert.Equal(t, `{"status":"ok"}`, string(res.body))
+	assert.Len(t, res.hash, 64)
+
+	el.HTTPPollEventSource.URL = "http://127.0.0.1:0"
+	_, err = el.poll(context.Background(), server.Client())
+	assert.Error(t, err)
+	assert.Equal(t, metrics.FailureReasonConnection, metrics.ReasonOf(err))
+}
+
+func TestStartListening(t *testing.T) {
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the body changes on the third poll only
+		if atomic.AddInt32(&polls, 1) < 3 {
+			_, _ = w.Write([]byte(`{"version":1}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"version":2}`))
+	}))
+	defer server.Close()
+
+	listen := func(emitOnChangeOnly bool) []events.HTTPPollEventData {
+		atomic.StoreInt32(&polls, 0)
+		el := &EventListener{
+			EventSourceName: "http-poll",
+			EventName:       "example",
+			HTTPPollEventSource: v1alpha1.HTTPPollEventSource{
+				URL:              server.URL,
+				Interval:         "20ms",
+				EmitOnChangeOnly: emitOnChangeOnly,
+				JSONBody:         true,
+				Metadata:         map[string]string{"team": "platform"},
+			},
+			Metrics: metrics.NewMetrics("ns"),
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+		defer cancel()
+		var dispatched []events.HTTPPollEventData
+		err := el.StartListening(ctx, func(data []byte, opts ...eventsourcecommon.Options) error {
+			var eventData events.HTTPPollEventData
+			assert.NoError(t, json.Unmarshal(data, &eventData))
+			dispatched = append(dispatched, eventData)
+			return nil
+		})
+		assert.NoError(t, err)
+		return dispatched
+	}
+
+	dispatched := listen(true)
+	// the first response, then the changed one
+	assert.Len(t, dispatched, 2)
+	assert.Equal(t, server.URL, dispatched[0].URL)
+	assert.Equal(t, http.MethodGet, dispatched[0].Method)
+	assert.Equal(t, http.StatusOK, dispatched[0].StatusCode)
+	assert.Equal(t, map[string]interface{}{"version": float64(1)}, dispatched[0].Body)
+	assert.Equal(t, map[string]interface{}{"version": float64(2)}, dispatched[1].Body)
+	assert.NotEqual(t, dispatched[0].BodyHash, dispatched[1].BodyHash)
+	assert.Equal(t, "platform", dispatched[0].Metadata["team"])
+
+	// every response is dispatched otherwise
+	assert.Greater(t, len(listen(false)), 2)
+
+	// an unchanged response is dispatched again once its dispatch failed
+	atomic.StoreInt32(&polls, 0)
+	el := &EventListener{
+		EventSourceName: "http-poll",
+		EventName:       "example",
+		HTTPPollEventSource: v1alpha1.HTTPPollEventSource{
+			URL:              server.URL,
+			Interval:         "20ms",
+			EmitOnChangeOnly: true,
+		},
+		Metrics: metrics.NewMetrics("ns"),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+	var attempts int
+	err := el.StartListening(ctx, func(data []byte, opts ...eventsourcecommon.Options) error {
+		attempts++
+		if attempts == 1 {
+			return errors.New("eventbus unavailable")
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	// the first response fails, is dispatched again on the second poll, then the changed one
+	assert.Equal(t, 3, attempts)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httppoll
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/argoproj/argo-events/common"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// ValidateEventSource validates the HTTP poll event source
+func (listener *EventListener) ValidateEventSource(ctx context.Context) error {
+	return validate(&listener.HTTPPollEventSource)
+}
+
+func validate(eventSource *v1alpha1.HTTPPollEventSource) error {
+	if eventSource == nil {
+		return common.ErrNilEventSource
+	}
+	if eventSource.URL == "" {
+		return errors.New("url must be specified")
+	}
+	if u, err := url.Parse(eventSource.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("url must be an http or https url")
+	}
+	switch eventSource.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions:
+	default:
+		return errors.Errorf("unsupported method %s", eventSource.Method)
+	}
+	if eventSource.Interval != "" {
+		d, err := time.ParseDuration(eventSource.Interval)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse interval")
+		}
+		if d <= 0 {
+			return errors.New("interval must be positive")
+		}
+	}
+	if basicAuth := eventSource.BasicAuth; basicAuth != nil && basicAuth.Username == nil {
+		return errors.New("basicAuth username must be specified")
+	}
+	if eventSource.BasicAuth != nil && eventSource.BearerToken != nil {
+		return errors.New("only one of basicAuth and bearerToken can be specified")
+	}
+	if eventSource.TLS != nil {
+		return apicommon.ValidateTLSConfig(eventSource.TLS)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httppoll
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj/argo-events/eventsources/sources"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestValidateEventSource(t *testing.T) {
+	listener := &EventListener{}
+
+	err := listener.ValidateEventSource(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, "url must be specified", err.Error())
+
+	content, err := ioutil.ReadFile(fmt.Sprintf("%s/%s", sources.EventSourceDir, "http-poll.yaml"))
+	assert.Nil(t, err)
+
+	var eventSource *v1alpha1.EventSource
+	err = yaml.Unmarshal(content, &eventSource)
+	assert.Nil(t, err)
+	assert.NotNil(t, eventSource.Spec.HTTPPoll)
+
+	for _, value := range eventSource.Spec.HTTPPoll {
+		l := &EventListener{
+			HTTPPollEventSource: value,
+		}
+		err := l.ValidateEventSource(context.Background())
+		assert.NoError(t, err)
+
+		l.HTTPPollEventSource.Method = "TRACE"
+		err = l.ValidateEventSource(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, "unsupported method TRACE", err.Error())
+
+		l.HTTPPollEventSource.Method = ""
+		l.HTTPPollEventSource.Interval = "0s"
+		err = l.ValidateEventSource(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, "interval must be positive", err.Error())
+
+		l.HTTPPollEventSource.Interval = ""
+		l.HTTPPollEventSource.BasicAuth = &apicommon.BasicAuth{}
+		err = l.ValidateEventSource(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, "basicAuth username must be specified", err.Error())
+
+		l.HTTPPollEventSource.BasicAuth.Username = &corev1.SecretKeySelector{Key: "username"}
+		l.HTTPPollEventSource.BearerToken = &corev1.SecretKeySelector{Key: "token"}
+		err = l.ValidateEventSource(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, "only one of basicAuth and bearerToken can be specified", err.Error())
+
+		l.HTTPPollEventSource.URL = "api.example.com/v1/status"
+		err = l.ValidateEventSource(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, "url must be an http or https url", err.Error())
+	}
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jetstream
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	natslib "github.com/nats-io/nats.go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/argoproj/argo-events/common"
+	"github.com/argoproj/argo-events/common/logging"
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/eventsources/sources"
+	metrics "github.com/argoproj/argo-events/metrics"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+const (
+	// defaultAckWait is how long the server waits for the acknowledgement of a message before redelivering it
+	defaultAckWait = 30 * time.Second
+	// fetchBatchSize is the maximum number of messages pulled at once
+	fetchBatchSize = 10
+)
+
+// puller pulls the messages of the durable consumer, implemented by *natslib.Subscription
+type puller interface {
+	Fetch(batch int, opts ...natslib.PullOpt) ([]*natslib.Msg, error)
+}
+
+// acknowledger acknowledges the messages to the server, implemented by *natslib.Msg
+type acknowledger interface {
+	Ack(opts ...natslib.AckOpt) error
+	Nak(opts ...natslib.AckOpt) error
+	Term(opts ...natslib.AckOpt) error
+}
+
+// EventListener implements Eventing for the NATS JetStream event source
+type EventListener struct {
+	EventSourceName      string
+	EventName            string
+	JetStreamEventSource v1alpha1.JetStreamEventSource
+	Metrics              *metrics.Metrics
+}
+
+// GetEventSourceName returns name of event source
+func (el *EventListener) GetEventSourceName() string {
+	return el.EventSourceName
+}
+
+// GetEventName returns name of event
+func (el *EventListener) GetEventName() string {
+	return el.EventName
+}
+
+// GetEventSourceType return type of event server
+func (el *EventListener) GetEventSourceType() apicommon.EventSourceType {
+	return apicommon.JetStreamEvent
+}
+
+// StartListening starts listening events
+func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byte, ...eventsourcecommon.Options) error) error {
+	log := logging.FromContext(ctx).
+		With(logging.LabelEventSourceType, el.GetEventSourceType(), logging.LabelEventName, el.GetEventName())
+	defer sources.Recover(el.GetEventName())
+
+	jsEventSource := &el.JetStreamEventSource
+
+	var opt []natslib.Option
+	if jsEventSource.TLS != nil {
+		tlsConfig, err := common.GetTLSConfig(jsEventSource.TLS, log)
+		if err != nil {
+			return errors.Wrap(err, "failed to get the tls configuration")
+		}
+		opt = append(opt, natslib.Secure(tlsConfig))
+	}
+
+	if jsEventSource.Auth != nil {
+		switch {
+		case jsEventSource.Auth.Basic != nil:
+			username, err := common.GetSecretFromVolume(jsEventSource.Auth.Basic.Username)
+			if err != nil {
+				return err
+			}
+			password, err := common.GetSecretFromVolume(jsEventSource.Auth.Basic.Password)
+			if err != nil {
+				return err
+			}
+			opt = append(opt, natslib.UserInfo(username, password))
+		case jsEventSource.Auth.Token != nil:
+			token, err := common.GetSecretFromVolume(jsEventSource.Auth.Token)
+			if err != nil {
+				return err
+			}
+			opt = append(opt, natslib.Token(token))
+		case jsEventSource.Auth.NKey != nil:
+			nkeyFile, err := common.GetSecretVolumePath(jsEventSource.Auth.NKey)
+			if err != nil {
+				return err
+			}
+			o, err := natslib.NkeyOptionFromSeed(nkeyFile)
+			if err != nil {
+				return errors.Wrap(err, "failed to get NKey")
+			}
+			opt = append(opt, o)
+		case jsEventSource.Auth.Credential != nil:
+			cFile, err := common.GetSecretVolumePath(jsEventSource.Auth.Credential)
+			if err != nil {
+				return err
+			}
+			opt = append(opt, natslib.UserCredentials(cFile))
+		}
+	}
+
+	ackWait := defaultAckWait
+	if jsEventSource.AckWait != "" {
+		d, err := time.ParseDuration(jsEventSource.AckWait)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse the ack wait %s", jsEventSource.AckWait)
+		}
+		ackWait = d
+	}
+
+	var conn *natslib.Conn
+	log.Info("connecting to nats cluster...")
+	if err := common.ConnectWithContext(ctx, jsEventSource.ConnectionBackoff, func() error {
+		var err error
+		if conn, err = natslib.Connect(jsEventSource.URL, opt...); err != nil {
+			return err
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrapf(err, "failed to connect to the nats server for event source %s", el.GetEventName())
+	}
+	// the subscription is not unsubscribed on purpose, it would delete the durable consumer created by the library.
+	defer conn.Close()
+
+	js, err := conn.JetStream()
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the jetstream context for event source %s", el.GetEventName())
+	}
+
+	if jsEventSource.JSONBody {
+		log.Info("assuming all events have a json body...")
+	}
+
+	log.Infow("binding to the durable consumer...", zap.String("stream", jsEventSource.Stream), zap.String("durableName", jsEventSource.DurableName))
+	subOpts := []natslib.SubOpt{natslib.BindStream(jsEventSource.Stream), natslib.AckWait(ackWait)}
+	if jsEventSource.MaxDeliver != 0 {
+		subOpts = append(subOpts, natslib.MaxDeliver(int(jsEventSource.MaxDeliver)))
+	}
+	sub, err := js.PullSubscribe(jsEventSource.Subject, jsEventSource.DurableName, subOpts...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to subscribe to the subject %s of the stream %s for event source %s", jsEventSource.Subject, jsEventSource.Stream, el.GetEventName())
+	}
+
+	log.Info("pulling the messages...")
+	return el.pull(ctx, sub, dispatch, log)
+}
+
+// pull fetches the messages and processes them until the context is done. A fetch which doesn't get any message
+// within the wait of the library is fetched again.
+func (el *EventListener) pull(ctx context.Context, sub puller, dispatch func([]byte, ...eventsourcecommon.Options) error, log *zap.SugaredLogger) error {
+	for {
+		msgs, err := sub.Fetch(fetchBatchSize, natslib.Context(ctx))
+		if err != nil {
+			if ctx.Err() != nil {
+				log.Info("event source is stopped")
+				return nil
+			}
+			// the library bounds the fetch of a context without deadline by its own wait, the context passed makes
+			// it fail with the deadline of that wait rather than with ErrTimeout.
+			if errors.Is(err, natslib.ErrTimeout) || errors.Is(err, context.DeadlineExceeded) {
+				continue
+			}
+			return errors.Wrapf(err, "failed to pull the messages for event source %s", el.GetEventName())
+		}
+		for _, msg := range msgs {
+			el.processOne(msg, msg, dispatch, log)
+		}
+	}
+}
+
+// processOne dispatches the message, then acknowledges it with acks, the message itself. The message is negatively
+// acknowledged if the dispatch fails so that it gets redelivered, up to the maximum number of deliveries.
+func (el *EventListener) processOne(msg *natslib.Msg, acks acknowledger, dispatch func([]byte, ...eventsourcecommon.Options) error, log *zap.SugaredLogger) {
+	defer func(start time.Time) {
+		el.Metrics.EventProcessingDuration(el.GetEventSourceName(), el.GetEventName(), float64(time.Since(start)/time.Millisecond))
+	}(time.Now())
+
+	jsEventSource := &el.JetStreamEventSource
+	eventData := &events.JetStreamEventData{
+		Subject:  msg.Subject,
+		Stream:   jsEventSource.Stream,
+		Metadata: jsEventSource.Metadata,
+	}
+	if meta, err := msg.Metadata(); err == nil {
+		eventData.Stream = meta.Stream
+		eventData.Sequence = meta.Sequence.Stream
+		eventData.NumDelivered = meta.NumDelivered
+		eventData.Timestamp = meta.Timestamp.String()
+	}
+	if jsEventSource.JSONBody {
+		eventData.Body = (*json.RawMessage)(&msg.Data)
+	} else {
+		eventData.Body = msg.Data
+	}
+
+	eventBody, err := json.Marshal(eventData)
+	if err != nil {
+		log.Errorw("failed to marshal the event data, terminating the message...", zap.Error(err))
+		el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonMarshal)
+		// the message would fail the same way on redelivery
+		if err := acks.Term(); err != nil {
+			log.Errorw("failed to terminate the message", zap.Error(err))
+		}
+		return
+	}
+	log.Infow("dispatching the event on data channel...", zap.Uint64("sequence", eventData.Sequence), zap.Uint64("numDelivered", eventData.NumDelivered))
+	if err = dispatch(eventBody); err != nil {
+		log.Errorw("failed to dispatch a JetStream event, negatively acknowledging the message...", zap.Error(err))
+		el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonDispatch)
+		if err := acks.Nak(); err != nil {
+			log.Errorw("failed to negatively acknowledge the message", zap.Error(err))
+		}
+		return
+	}
+	if err := acks.Ack(); err != nil {
+		log.Errorw("failed to acknowledge the message", zap.Error(err))
+	}
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jetstream
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	natslib "github.com/nats-io/nats.go"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	metrics "github.com/argoproj/argo-events/metrics"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// fakeAcknowledger records how the message was acknowledged
+type fakeAcknowledger struct {
+	acks []string
+}
+
+func (a *fakeAcknowledger) Ack(...natslib.AckOpt) error {
+	a.acks = append(a.acks, "ack")
+	return nil
+}
+
+func (a *fakeAcknowledger) Nak(...natslib.AckOpt) error {
+	a.acks = append(a.acks, "nak")
+	return nil
+}
+
+func (a *fakeAcknowledger) Term(...natslib.AckOpt) error {
+	a.acks = append(a.acks, "term")
+	return nil
+}
+
+// fakePuller returns the results of its fetches in order, then cancels the context
+type fakePuller struct {
+	results []fetchResult
+	cancel  context.CancelFunc
+	fetches int
+}
+
+type fetchResult struct {
+	msgs []*natslib.Msg
+	err  error
+}
+
+func (p *fakePuller) Fetch(int, ...natslib.PullOpt) ([]*natslib.Msg, error) {
+	p.fetches++
+	if len(p.results) == 0 {
+		p.cancel()
+		return nil, context.Canceled
+	}
+	result := p.results[0]
+	p.results = p.results[1:]
+	return result.msgs, result.err
+}
+
+func newTestListener(jsonBody bool) *EventListener {
+	return &EventListener{
+		EventSourceName:      "jetstream",
+		EventName:            "example",
+		JetStreamEventSource: v1alpha1.JetStreamEventSource{Stream: "orders", JSONBody: jsonBody},
+		Metrics:              metrics.NewMetrics("test-ns"),
+	}
+}
+
+func TestProcessOne(t *testing.T) {
+	msg := &natslib.Msg{
+		Subject: "orders.created",
+		Reply:   "$JS.ACK.orders.consumer.2.15.7.1634567890000000000.0",
+		Data:    []byte(`{"id":1}`),
+		// the metadata of the message is parsed from its reply subject once bound to a subscription
+		Sub: &natslib.Subscription{},
+	}
+
+	t.Run("test acknowledges the dispatched message", func(t *testing.T) {
+		var dispatched []byte
+		acks := &fakeAcknowledger{}
+		newTestListener(true).processOne(msg, acks, func(data []byte, _ ...eventsourcecommon.Options) error {
+			dispatched = data
+			return nil
+		}, zap.NewNop().Sugar())
+		assert.Equal(t, []string{"ack"}, acks.acks)
+
+		var event events.JetStreamEventData
+		assert.NoError(t, json.Unmarshal(dispatched, &event))
+		assert.Equal(t, "orders.created", event.Subject)
+		assert.Equal(t, "orders", event.Stream)
+		assert.Equal(t, uint64(15), event.Sequence)
+		assert.Equal(t, uint64(2), event.NumDelivered)
+	})
+
+	t.Run("test negatively acknowledges the message failed to be dispatched", func(t *testing.T) {
+		acks := &fakeAcknowledger{}
+		newTestListener(true).processOne(msg, acks, func([]byte, ...eventsourcecommon.Options) error {
+			return errors.New("eventbus is down")
+		}, zap.NewNop().Sugar())
+		assert.Equal(t, []string{"nak"}, acks.acks)
+	})
+
+	t.Run("test terminates the message failed to be marshaled", func(t *testing.T) {
+		dispatched := false
+		acks := &fakeAcknowledger{}
+		invalid := &natslib.Msg{Subject: "orders.created", Data: []byte("not json")}
+		newTestListener(true).processOne(invalid, acks, func([]byte, ...eventsourcecommon.Options) error {
+			dispatched = true
+			return nil
+		}, zap.NewNop().Sugar())
+		assert.False(t, dispatched)
+		assert.Equal(t, []string{"term"}, acks.acks)
+	})
+}
+
+func TestPull(t *testing.T) {
+	t.Run("test keeps pulling once the wait of an idle fetch expires", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		sub := &fakePuller{cancel: cancel, results: []fetchResult{
+			{err: context.DeadlineExceeded},
+			{err: natslib.ErrTimeout},
+			{err: context.DeadlineExceeded},
+			{msgs: []*natslib.Msg{{Subject: "orders.created", Data: []byte("hello")}}},
+		}}
+		dispatched := 0
+		err := newTestListener(false).pull(ctx, sub, func([]byte, ...eventsourcecommon.Options) error {
+			dispatched++
+			return nil
+		}, zap.NewNop().Sugar())
+		assert.NoError(t, err)
+		assert.Equal(t, 5, sub.fetches)
+		assert.Equal(t, 1, dispatched)
+	})
+
+	t.Run("test fails on the other errors of the fetch", func(t *testing.T) {
+		sub := &fakePuller{cancel: func() {}, results: []fetchResult{{err: natslib.ErrConnectionClosed}}}
+		err := newTestListener(false).pull(context.Background(), sub, func([]byte, ...eventsourcecommon.Options) error {
+			return nil
+		}, zap.NewNop().Sugar())
+		assert.True(t, errors.Is(err, natslib.ErrConnectionClosed))
+	})
+}
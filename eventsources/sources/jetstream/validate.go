@@ -0,0 +1,63 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jetstream
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/argoproj/argo-events/common"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// ValidateEventSource validates the NATS JetStream event source
+func (listener *EventListener) ValidateEventSource(ctx context.Context) error {
+	return validate(&listener.JetStreamEventSource)
+}
+
+func validate(eventSource *v1alpha1.JetStreamEventSource) error {
+	if eventSource == nil {
+		return common.ErrNilEventSource
+	}
+	if eventSource.URL == "" {
+		return errors.New("url must be specified")
+	}
+	if eventSource.Stream == "" {
+		return errors.New("stream must be specified")
+	}
+	if eventSource.Subject == "" {
+		return errors.New("subject must be specified")
+	}
+	if eventSource.DurableName == "" {
+		return errors.New("durable name must be specified")
+	}
+	if eventSource.AckWait != "" {
+		if _, err := time.ParseDuration(eventSource.AckWait); err != nil {
+			return errors.Wrap(err, "failed to parse ack wait")
+		}
+	}
+	if eventSource.MaxDeliver < -1 {
+		return errors.New("max deliver must not be less than -1, either 0 or -1 means unlimited")
+	}
+	if eventSource.TLS != nil {
+		return apicommon.ValidateTLSConfig(eventSource.TLS)
+	}
+	return nil
+}
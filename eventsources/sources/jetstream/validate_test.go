@@ -0,0 +1,75 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jetstream
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-events/eventsources/sources"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestValidateEventSource(t *testing.T) {
+	listener := &EventListener{}
+
+	err := listener.ValidateEventSource(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, "url must be specified", err.Error())
+
+	content, err := ioutil.ReadFile(fmt.Sprintf("%s/%s", sources.EventSourceDir, "jetstream.yaml"))
+	assert.Nil(t, err)
+
+	var eventSource *v1alpha1.EventSource
+	err = yaml.Unmarshal(content, &eventSource)
+	assert.Nil(t, err)
+	assert.NotNil(t, eventSource.Spec.JetStream)
+
+	for _, value := range eventSource.Spec.JetStream {
+		l := &EventListener{
+			JetStreamEventSource: value,
+		}
+		err := l.ValidateEventSource(context.Background())
+		assert.NoError(t, err)
+
+		l.JetStreamEventSource.DurableName = ""
+		err = l.ValidateEventSource(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, "durable name must be specified", err.Error())
+
+		l.JetStreamEventSource.DurableName = "argo-events"
+		l.JetStreamEventSource.AckWait = "30"
+		err = l.ValidateEventSource(context.Background())
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse ack wait")
+
+		l.JetStreamEventSource.AckWait = ""
+		for _, maxDeliver := range []int32{-1, 0, 5} {
+			l.JetStreamEventSource.MaxDeliver = maxDeliver
+			assert.NoError(t, l.ValidateEventSource(context.Background()))
+		}
+		l.JetStreamEventSource.MaxDeliver = -2
+		err = l.ValidateEventSource(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, "max deliver must not be less than -1, either 0 or -1 means unlimited", err.Error())
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Shopify/sarama"
@@ -39,6 +40,9 @@ import (
 	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
 )
 
+// sessionRetryInterval is the delay before a new consumer group session after a failed dispatch
+const sessionRetryInterval = 5 * time.Second
+
 // EventListener implements Eventing kafka event source
 type EventListener struct {
 	EventSourceName  string
@@ -115,10 +119,14 @@ func (el *EventListener) consumerGroupConsumer(ctx context.Context, log *zap.Sug
 		metrics:          el.Metrics,
 	}
 
-	urls := strings.Split(kafkaEventSource.URL, ",")
-	client, err := sarama.NewConsumerGroup(urls, kafkaEventSource.ConsumerGroup.GroupName, config)
-	if err != nil {
+	var client sarama.ConsumerGroup
+	if err := common.ConnectWithContext(ctx, kafkaEventSource.ConnectionBackoff, func() error {
+		var err error
+		client, err = sarama.NewConsumerGroup(brokers(kafkaEventSource), kafkaEventSource.ConsumerGroup.GroupName, config)
+		return err
+	}); err != nil {
 		log.Errorf("Error creating consumer group client: %v", err)
+		el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonConnection)
 		return err
 	}
 
@@ -132,12 +140,21 @@ func (el *EventListener) consumerGroupConsumer(ctx context.Context, log *zap.Sug
 			// recreated to get the new claims
 			if err := client.Consume(ctx, []string{kafkaEventSource.Topic}, &consumer); err != nil {
 				log.Errorf("Error from consumer: %v", err)
+				el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonConnection)
 			}
 			// check if context was cancelled, signaling that the consumer should stop
 			if ctx.Err() != nil {
 				log.Infof("Error from context: %v", ctx.Err())
 				return
 			}
+			if consumer.dispatchFailed() {
+				// the session ended on a failed dispatch, wait before consuming again from the committed offsets
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(sessionRetryInterval):
+				}
+			}
 			consumer.ready = make(chan bool)
 		}
 	}()
@@ -165,7 +182,7 @@ func (el *EventListener) partitionConsumer(ctx context.Context, log *zap.Sugared
 	var consumer sarama.Consumer
 
 	log.Info("connecting to Kafka cluster...")
-	if err := common.Connect(kafkaEventSource.ConnectionBackoff, func() error {
+	if err := common.ConnectWithContext(ctx, kafkaEventSource.ConnectionBackoff, func() error {
 		var err error
 
 		config, err := getSaramaConfig(kafkaEventSource, log)
@@ -173,13 +190,13 @@ func (el *EventListener) partitionConsumer(ctx context.Context, log *zap.Sugared
 			return err
 		}
 
-		urls := strings.Split(kafkaEventSource.URL, ",")
-		consumer, err = sarama.NewConsumer(urls, config)
+		consumer, err = sarama.NewConsumer(brokers(kafkaEventSource), config)
 		if err != nil {
 			return err
 		}
 		return nil
 	}); err != nil {
+		el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonConnection)
 		return errors.Wrapf(err, "failed to connect to Kafka broker for event source %s", el.GetEventName())
 	}
 
@@ -204,7 +221,7 @@ func (el *EventListener) partitionConsumer(ctx context.Context, log *zap.Sugared
 	}
 
 	log.Info("getting partition consumer...")
-	partitionConsumer, err := consumer.ConsumePartition(kafkaEventSource.Topic, partition, sarama.OffsetNewest)
+	partitionConsumer, err := consumer.ConsumePartition(kafkaEventSource.Topic, partition, initialOffset(kafkaEventSource))
 	if err != nil {
 		return errors.Wrapf(err, "failed to create consumer partition for event source %s", el.GetEventName())
 	}
@@ -215,26 +232,16 @@ func (el *EventListener) partitionConsumer(ctx context.Context, log *zap.Sugared
 		}(time.Now())
 
 		log.Info("dispatching event on the data channel...")
-		eventData := &events.KafkaEventData{
-			Topic:     msg.Topic,
-			Partition: int(msg.Partition),
-			Timestamp: msg.Timestamp.String(),
-			Metadata:  kafkaEventSource.Metadata,
-		}
-		if kafkaEventSource.JSONBody {
-			eventData.Body = (*json.RawMessage)(&msg.Value)
-		} else {
-			eventData.Body = msg.Value
-		}
-		eventBody, err := json.Marshal(eventData)
+		eventBody, err := newEventBody(kafkaEventSource, msg)
 		if err != nil {
-			return errors.Wrap(err, "failed to marshal the event data, rejecting the event...")
+			return metrics.WithReason(err, metrics.FailureReasonMarshal)
 		}
+		el.Metrics.EventPayloadSize(el.GetEventSourceName(), el.GetEventName(), float64(len(eventBody)))
 
 		kafkaID := genUniqueID(el.GetEventSourceName(), el.GetEventName(), kafkaEventSource.URL, msg.Topic, msg.Partition, msg.Offset)
 
 		if err = dispatch(eventBody, eventsourcecommon.WithID(kafkaID)); err != nil {
-			return errors.Wrap(err, "failed to dispatch a Kafka event...")
+			return metrics.WithReason(errors.Wrap(err, "failed to dispatch a Kafka event..."), metrics.FailureReasonDispatch)
 		}
 		return nil
 	}
@@ -245,9 +252,10 @@ func (el *EventListener) partitionConsumer(ctx context.Context, log *zap.Sugared
 		case msg := <-partitionConsumer.Messages():
 			if err := processOne(msg); err != nil {
 				log.Errorw("failed to process a Kafka message", zap.Error(err))
-				el.Metrics.EventProcessingFailed(el.GetEventSourceName(), el.GetEventName())
+				el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.ReasonOf(err))
 			}
 		case err := <-partitionConsumer.Errors():
+			el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonConnection)
 			return errors.Wrapf(err, "failed to consume messages for event source %s", el.GetEventName())
 
 		case <-ctx.Done():
@@ -301,7 +309,7 @@ func getSaramaConfig(kafkaEventSource *v1alpha1.KafkaEventSource, log *zap.Sugar
 	}
 
 	if kafkaEventSource.TLS != nil {
-		tlsConfig, err := common.GetTLSConfig(kafkaEventSource.TLS)
+		tlsConfig, err := common.GetTLSConfig(kafkaEventSource.TLS, log)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to get the tls configuration")
 		}
@@ -309,12 +317,59 @@ func getSaramaConfig(kafkaEventSource *v1alpha1.KafkaEventSource, log *zap.Sugar
 		config.Net.TLS.Enable = true
 	}
 
-	if kafkaEventSource.ConsumerGroup != nil {
-		if kafkaEventSource.ConsumerGroup.Oldest {
-			config.Consumer.Offsets.Initial = sarama.OffsetOldest
+	config.Consumer.Offsets.Initial = initialOffset(kafkaEventSource)
+	return config, nil
+}
+
+// brokers returns the addresses of the brokers of the URL and of the Brokers list
+func brokers(kafkaEventSource *v1alpha1.KafkaEventSource) []string {
+	var urls []string
+	if kafkaEventSource.URL != "" {
+		urls = append(urls, strings.Split(kafkaEventSource.URL, ",")...)
+	}
+	return append(urls, kafkaEventSource.Brokers...)
+}
+
+// initialOffset returns the offset to start consuming from when there is no committed offset
+func initialOffset(kafkaEventSource *v1alpha1.KafkaEventSource) int64 {
+	switch kafkaEventSource.OffsetReset {
+	case v1alpha1.KafkaOffsetResetEarliest:
+		return sarama.OffsetOldest
+	case v1alpha1.KafkaOffsetResetLatest:
+		return sarama.OffsetNewest
+	}
+	if kafkaEventSource.ConsumerGroup != nil && kafkaEventSource.ConsumerGroup.Oldest {
+		return sarama.OffsetOldest
+	}
+	return sarama.OffsetNewest
+}
+
+// newEventBody returns the payload of the event of the message
+func newEventBody(kafkaEventSource *v1alpha1.KafkaEventSource, msg *sarama.ConsumerMessage) ([]byte, error) {
+	eventData := &events.KafkaEventData{
+		Topic:     msg.Topic,
+		Partition: int(msg.Partition),
+		Offset:    msg.Offset,
+		Key:       string(msg.Key),
+		Timestamp: msg.Timestamp.String(),
+		Metadata:  kafkaEventSource.Metadata,
+	}
+	if len(msg.Headers) > 0 {
+		eventData.Headers = make(map[string]string, len(msg.Headers))
+		for _, header := range msg.Headers {
+			eventData.Headers[string(header.Key)] = string(header.Value)
 		}
 	}
-	return config, nil
+	if kafkaEventSource.JSONBody {
+		eventData.Body = (*json.RawMessage)(&msg.Value)
+	} else {
+		eventData.Body = msg.Value
+	}
+	eventBody, err := json.Marshal(eventData)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the event data, rejecting the event...")
+	}
+	return eventBody, nil
 }
 
 // Consumer represents a Sarama consumer group consumer
@@ -326,15 +381,23 @@ type Consumer struct {
 	eventSourceName  string
 	eventName        string
 	metrics          *metrics.Metrics
+	// failed is set when a session ended on a failed dispatch
+	failed int32
 }
 
 // Setup is run at the beginning of a new session, before ConsumeClaim
 func (consumer *Consumer) Setup(sarama.ConsumerGroupSession) error {
+	atomic.StoreInt32(&consumer.failed, 0)
 	// Mark the consumer as ready
 	close(consumer.ready)
 	return nil
 }
 
+// dispatchFailed tells whether the last session ended on a failed dispatch
+func (consumer *Consumer) dispatchFailed() bool {
+	return atomic.LoadInt32(&consumer.failed) == 1
+}
+
 // Cleanup is run at the end of a session, once all ConsumeClaim goroutines have exited
 func (consumer *Consumer) Cleanup(sarama.ConsumerGroupSession) error {
 	return nil
@@ -349,7 +412,13 @@ func (consumer *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, clai
 	for message := range claim.Messages() {
 		if err := consumer.processOne(session, message); err != nil {
 			consumer.logger.Errorw("failed to process a Kafka message", zap.Error(err))
-			consumer.metrics.EventProcessingFailed(consumer.eventSourceName, consumer.eventName)
+			consumer.metrics.EventProcessingFailedWithReason(consumer.eventSourceName, consumer.eventName, metrics.ReasonOf(err))
+			if metrics.ReasonOf(err) == metrics.FailureReasonDispatch {
+				// the offset is only committed once the message is dispatched, end the session to consume
+				// the message again rather than committing it with the offset of a later message
+				atomic.StoreInt32(&consumer.failed, 1)
+				return err
+			}
 			continue
 		}
 		if consumer.kafkaEventSource.LimitEventsPerSecond > 0 {
@@ -369,26 +438,16 @@ func (consumer *Consumer) processOne(session sarama.ConsumerGroupSession, messag
 	}(time.Now())
 
 	consumer.logger.Info("dispatching event on the data channel...")
-	eventData := &events.KafkaEventData{
-		Topic:     message.Topic,
-		Partition: int(message.Partition),
-		Timestamp: message.Timestamp.String(),
-		Metadata:  consumer.kafkaEventSource.Metadata,
-	}
-	if consumer.kafkaEventSource.JSONBody {
-		eventData.Body = (*json.RawMessage)(&message.Value)
-	} else {
-		eventData.Body = message.Value
-	}
-	eventBody, err := json.Marshal(eventData)
+	eventBody, err := newEventBody(consumer.kafkaEventSource, message)
 	if err != nil {
-		return errors.Wrap(err, "failed to marshal the event data, rejecting the event...")
+		return metrics.WithReason(err, metrics.FailureReasonMarshal)
 	}
+	consumer.metrics.EventPayloadSize(consumer.eventSourceName, consumer.eventName, float64(len(eventBody)))
 
 	messageID := genUniqueID(consumer.eventSourceName, consumer.eventName, consumer.kafkaEventSource.URL, message.Topic, message.Partition, message.Offset)
 
 	if err = consumer.dispatch(eventBody, eventsourcecommon.WithID(messageID)); err != nil {
-		return errors.Wrap(err, "failed to dispatch a kafka event...")
+		return metrics.WithReason(errors.Wrap(err, "failed to dispatch a kafka event..."), metrics.FailureReasonDispatch)
 	}
 	session.MarkMessage(message, "")
 	return nil
@@ -0,0 +1,84 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestBrokers(t *testing.T) {
+	eventSource := &v1alpha1.KafkaEventSource{URL: "kafka-0:9092,kafka-1:9092"}
+	assert.Equal(t, []string{"kafka-0:9092", "kafka-1:9092"}, brokers(eventSource))
+
+	eventSource.Brokers = []string{"kafka-2:9092"}
+	assert.Equal(t, []string{"kafka-0:9092", "kafka-1:9092", "kafka-2:9092"}, brokers(eventSource))
+
+	eventSource.URL = ""
+	assert.Equal(t, []string{"kafka-2:9092"}, brokers(eventSource))
+}
+
+func TestInitialOffset(t *testing.T) {
+	eventSource := &v1alpha1.KafkaEventSource{}
+	assert.Equal(t, sarama.OffsetNewest, initialOffset(eventSource))
+
+	eventSource.ConsumerGroup = &v1alpha1.KafkaConsumerGroup{GroupName: "group", Oldest: true}
+	assert.Equal(t, sarama.OffsetOldest, initialOffset(eventSource))
+
+	eventSource.OffsetReset = v1alpha1.KafkaOffsetResetLatest
+	assert.Equal(t, sarama.OffsetNewest, initialOffset(eventSource))
+
+	eventSource.ConsumerGroup = nil
+	eventSource.OffsetReset = v1alpha1.KafkaOffsetResetEarliest
+	assert.Equal(t, sarama.OffsetOldest, initialOffset(eventSource))
+}
+
+func TestNewEventBody(t *testing.T) {
+	eventSource := &v1alpha1.KafkaEventSource{JSONBody: true, Metadata: map[string]string{"env": "test"}}
+	msg := &sarama.ConsumerMessage{
+		Topic:     "topic-2",
+		Partition: 1,
+		Offset:    42,
+		Key:       []byte("order-1"),
+		Value:     []byte(`{"amount":10}`),
+		Headers:   []*sarama.RecordHeader{{Key: []byte("trace-id"), Value: []byte("abc")}},
+		Timestamp: time.Unix(0, 0).UTC(),
+	}
+	body, err := newEventBody(eventSource, msg)
+	assert.NoError(t, err)
+
+	var data events.KafkaEventData
+	assert.NoError(t, json.Unmarshal(body, &data))
+	assert.Equal(t, "topic-2", data.Topic)
+	assert.Equal(t, 1, data.Partition)
+	assert.Equal(t, int64(42), data.Offset)
+	assert.Equal(t, "order-1", data.Key)
+	assert.Equal(t, map[string]string{"trace-id": "abc"}, data.Headers)
+	assert.Equal(t, map[string]interface{}{"amount": float64(10)}, data.Body)
+	assert.Equal(t, map[string]string{"env": "test"}, data.Metadata)
+
+	msg.Value = []byte("not json")
+	_, err = newEventBody(eventSource, msg)
+	assert.Error(t, err)
+}
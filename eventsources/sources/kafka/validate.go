@@ -34,8 +34,13 @@ func validate(eventSource *v1alpha1.KafkaEventSource) error {
 	if eventSource == nil {
 		return common.ErrNilEventSource
 	}
-	if eventSource.URL == "" {
-		return fmt.Errorf("url must be specified")
+	if eventSource.URL == "" && len(eventSource.Brokers) == 0 {
+		return fmt.Errorf("url or brokers must be specified")
+	}
+	for _, broker := range eventSource.Brokers {
+		if broker == "" {
+			return fmt.Errorf("brokers must not contain an empty address")
+		}
 	}
 	if eventSource.Topic == "" {
 		return fmt.Errorf("topic must be specified")
@@ -43,10 +48,22 @@ func validate(eventSource *v1alpha1.KafkaEventSource) error {
 	if eventSource.Partition == "" && eventSource.ConsumerGroup == nil {
 		return fmt.Errorf("consumerGroup or partition must be specified")
 	}
+	switch eventSource.OffsetReset {
+	case "", v1alpha1.KafkaOffsetResetEarliest, v1alpha1.KafkaOffsetResetLatest:
+	default:
+		return fmt.Errorf("offsetReset must be either %s or %s", v1alpha1.KafkaOffsetResetEarliest, v1alpha1.KafkaOffsetResetLatest)
+	}
 	if eventSource.TLS != nil {
-		return apicommon.ValidateTLSConfig(eventSource.TLS)
+		if err := apicommon.ValidateTLSConfig(eventSource.TLS); err != nil {
+			return err
+		}
 	}
 	if eventSource.SASL != nil {
+		switch eventSource.SASL.Mechanism {
+		case "", "PLAIN", "SCRAM-SHA-256", "SCRAM-SHA-512":
+		default:
+			return fmt.Errorf("sasl mechanism must be one of PLAIN, SCRAM-SHA-256 and SCRAM-SHA-512")
+		}
 		return apicommon.ValidateSASLConfig(eventSource.SASL)
 	}
 	return nil
@@ -24,8 +24,10 @@ import (
 
 	"github.com/ghodss/yaml"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/argoproj/argo-events/eventsources/sources"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
 	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
 )
 
@@ -34,7 +36,7 @@ func TestValidateEventSource(t *testing.T) {
 
 	err := listener.ValidateEventSource(context.Background())
 	assert.Error(t, err)
-	assert.Equal(t, "url must be specified", err.Error())
+	assert.Equal(t, "url or brokers must be specified", err.Error())
 
 	content, err := ioutil.ReadFile(fmt.Sprintf("%s/%s", sources.EventSourceDir, "kafka.yaml"))
 	assert.Nil(t, err)
@@ -53,3 +55,37 @@ func TestValidateEventSource(t *testing.T) {
 		assert.NoError(t, err)
 	}
 }
+
+func TestValidateOffsetReset(t *testing.T) {
+	eventSource := &v1alpha1.KafkaEventSource{
+		Brokers:     []string{"kafka-0:9092", "kafka-1:9092"},
+		Topic:       "topic-2",
+		Partition:   "0",
+		OffsetReset: v1alpha1.KafkaOffsetResetEarliest,
+	}
+	assert.NoError(t, validate(eventSource))
+
+	eventSource.OffsetReset = "oldest"
+	err := validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "offsetReset must be either earliest or latest", err.Error())
+}
+
+func TestValidateSASLMechanism(t *testing.T) {
+	eventSource := &v1alpha1.KafkaEventSource{
+		URL:       "kafka.argo-events:9092",
+		Topic:     "topic-2",
+		Partition: "0",
+		SASL: &apicommon.SASLConfig{
+			Mechanism:      "SCRAM-SHA-512",
+			UserSecret:     &corev1.SecretKeySelector{Key: "user"},
+			PasswordSecret: &corev1.SecretKeySelector{Key: "password"},
+		},
+	}
+	assert.NoError(t, validate(eventSource))
+
+	eventSource.SASL.Mechanism = "OAUTHBEARER"
+	err := validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "sasl mechanism must be one of PLAIN, SCRAM-SHA-256 and SCRAM-SHA-512", err.Error())
+}
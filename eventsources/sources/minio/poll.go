@@ -0,0 +1,154 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package minio
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/notification"
+	"go.uber.org/zap"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+)
+
+// objectLister lists the objects of a bucket, e.g. the minio client.
+type objectLister interface {
+	ListObjects(ctx context.Context, bucketName string, opts minio.ListObjectsOptions) <-chan minio.ObjectInfo
+}
+
+// pollInterval returns the interval the bucket is polled on, zero if the bucket notifications are listened to.
+func pollInterval(interval string) (time.Duration, error) {
+	if interval == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(interval)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse pollInterval, %w", err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("pollInterval must be positive")
+	}
+	return d, nil
+}
+
+// bucketPoller dispatches the objects of a bucket created or modified between two listings.
+type bucketPoller struct {
+	el     *EventListener
+	lister objectLister
+	// etags are the ETags of the objects by key as of the previous listing, nil until the bucket is first listed
+	etags map[string]string
+}
+
+// poll lists the bucket on the interval until the context is done. The objects existing on start are remembered
+// without being dispatched.
+func (el *EventListener) poll(ctx context.Context, lister objectLister, interval time.Duration, dispatch func([]byte, ...eventsourcecommon.Options) error, log *zap.SugaredLogger) {
+	poller := &bucketPoller{el: el, lister: lister}
+	log.Infow("started polling the bucket...", zap.Duration("interval", interval))
+	poller.pollOnce(ctx, dispatch, log)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			poller.pollOnce(ctx, dispatch, log)
+		}
+	}
+}
+
+// pollOnce lists the bucket and dispatches an event per object whose key is new or whose ETag changed. An object
+// failing to be dispatched keeps its previous ETag, so that it is dispatched again on the next poll, and a failed
+// listing leaves the ETags as they were.
+func (p *bucketPoller) pollOnce(ctx context.Context, dispatch func([]byte, ...eventsourcecommon.Options) error, log *zap.SugaredLogger) {
+	objects, err := p.list(ctx)
+	if err != nil {
+		log.Errorw("failed to list the objects of the bucket", zap.Error(err))
+		return
+	}
+	etags := make(map[string]string, len(objects))
+	if p.etags == nil {
+		for key, object := range objects {
+			etags[key] = object.ETag
+		}
+		p.etags = etags
+		log.Infow("listed the existing objects of the bucket", zap.Int("objects", len(objects)))
+		return
+	}
+	keys := make([]string, 0, len(objects))
+	for key := range objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		object := objects[key]
+		previous, known := p.etags[key]
+		if known && previous == object.ETag {
+			etags[key] = object.ETag
+			continue
+		}
+		info := notification.Info{Records: []notification.Event{objectEvent(p.el.MinioEventSource.Bucket.Name, p.el.MinioEventSource.Region, object)}}
+		if err := p.el.handleOne(info, dispatch, log.With("key", key)); err != nil {
+			log.Errorw("failed to process a Minio event", zap.String("key", key), zap.Error(err))
+			p.el.Metrics.EventProcessingFailed(p.el.GetEventSourceName(), p.el.GetEventName())
+			if known {
+				etags[key] = previous
+			}
+			continue
+		}
+		etags[key] = object.ETag
+	}
+	p.etags = etags
+}
+
+// list returns the objects of the bucket by key, filtered by the prefix and the suffix.
+func (p *bucketPoller) list(ctx context.Context) (map[string]minio.ObjectInfo, error) {
+	prefix, suffix := getFilters(&p.el.MinioEventSource)
+	objects := map[string]minio.ObjectInfo{}
+	for object := range p.lister.ListObjects(ctx, p.el.MinioEventSource.Bucket.Name, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+		if strings.HasSuffix(object.Key, suffix) {
+			objects[object.Key] = object
+		}
+	}
+	return objects, nil
+}
+
+// objectEvent returns the notification of an object created or modified, in the format of the bucket notifications
+// of MinIO, so that the sensors don't tell the polled events apart from the listened ones.
+func objectEvent(bucket, region string, object minio.ObjectInfo) notification.Event {
+	var event notification.Event
+	event.EventVersion = "2.0"
+	event.EventSource = "minio:s3"
+	event.AwsRegion = region
+	event.EventTime = object.LastModified.UTC().Format(time.RFC3339)
+	event.EventName = string(notification.ObjectCreatedPut)
+	event.S3.SchemaVersion = "1.0"
+	event.S3.Bucket.Name = bucket
+	event.S3.Object.Key = object.Key
+	event.S3.Object.Size = object.Size
+	event.S3.Object.ETag = object.ETag
+	event.S3.Object.ContentType = object.ContentType
+	return event
+}
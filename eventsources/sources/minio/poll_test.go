@@ -0,0 +1,152 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package minio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/argoproj/argo-events/common/logging"
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	metrics "github.com/argoproj/argo-events/metrics"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+)
+
+// fakeLister lists the objects it holds, or fails with its error
+type fakeLister struct {
+	objects []minio.ObjectInfo
+	err     error
+	opts    minio.ListObjectsOptions
+}
+
+func (l *fakeLister) ListObjects(_ context.Context, _ string, opts minio.ListObjectsOptions) <-chan minio.ObjectInfo {
+	l.opts = opts
+	ch := make(chan minio.ObjectInfo, len(l.objects)+1)
+	for _, object := range l.objects {
+		ch <- object
+	}
+	if l.err != nil {
+		ch <- minio.ObjectInfo{Err: l.err}
+	}
+	close(ch)
+	return ch
+}
+
+func TestPollInterval(t *testing.T) {
+	interval, err := pollInterval("")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), interval)
+	interval, err = pollInterval("30s")
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Second, interval)
+	_, err = pollInterval("soon")
+	assert.Error(t, err)
+	_, err = pollInterval("-1s")
+	assert.Error(t, err)
+	assert.Equal(t, "pollInterval must be positive", err.Error())
+}
+
+func TestPollOnce(t *testing.T) {
+	el := &EventListener{
+		EventSourceName: "minio",
+		EventName:       "example",
+		MinioEventSource: apicommon.S3Artifact{
+			Bucket: &apicommon.S3Bucket{Name: "input"},
+			Region: "us-east-1",
+			Filter: &apicommon.S3Filter{Prefix: "data/", Suffix: ".csv"},
+		},
+		Metrics: metrics.NewMetrics("ns"),
+	}
+	lister := &fakeLister{objects: []minio.ObjectInfo{{Key: "data/a.csv", ETag: "a1", Size: 1}}}
+	poller := &bucketPoller{el: el, lister: lister}
+	var dispatched []string
+	var failing error
+	dispatch := func(data []byte, _ ...eventsourcecommon.Options) error {
+		if failing != nil {
+			return failing
+		}
+		var eventData events.MinioEventData
+		assert.NoError(t, json.Unmarshal(data, &eventData))
+		assert.Len(t, eventData.Notification, 1)
+		record := eventData.Notification[0]
+		assert.Equal(t, "s3:ObjectCreated:Put", record.EventName)
+		assert.Equal(t, "us-east-1", record.AwsRegion)
+		assert.Equal(t, "input", record.S3.Bucket.Name)
+		dispatched = append(dispatched, record.S3.Object.Key+"@"+record.S3.Object.ETag)
+		return nil
+	}
+	log := logging.NewArgoEventsLogger()
+	ctx := context.Background()
+
+	// the existing objects are not dispatched
+	poller.pollOnce(ctx, dispatch, log)
+	assert.Empty(t, dispatched)
+	assert.Equal(t, minio.ListObjectsOptions{Prefix: "data/", Recursive: true}, lister.opts)
+
+	// the new and modified objects are, once, the ones not matching the suffix are filtered out
+	lister.objects = []minio.ObjectInfo{{Key: "data/a.csv", ETag: "a2"}, {Key: "data/b.csv", ETag: "b1"}, {Key: "data/c.txt", ETag: "c1"}}
+	poller.pollOnce(ctx, dispatch, log)
+	assert.Equal(t, []string{"data/a.csv@a2", "data/b.csv@b1"}, dispatched)
+	poller.pollOnce(ctx, dispatch, log)
+	assert.Len(t, dispatched, 2)
+
+	// a failed listing keeps the ETags
+	lister.err = errors.New("unavailable")
+	lister.objects = []minio.ObjectInfo{{Key: "data/a.csv", ETag: "a3"}}
+	poller.pollOnce(ctx, dispatch, log)
+	assert.Equal(t, map[string]string{"data/a.csv": "a2", "data/b.csv": "b1"}, poller.etags)
+
+	// the objects failing to be dispatched are dispatched again on the next poll, the removed ones are forgotten
+	lister.err = nil
+	failing = errors.New("eventbus is down")
+	poller.pollOnce(ctx, dispatch, log)
+	assert.Len(t, dispatched, 2)
+	assert.Equal(t, map[string]string{"data/a.csv": "a2"}, poller.etags)
+	failing = nil
+	poller.pollOnce(ctx, dispatch, log)
+	assert.Equal(t, []string{"data/a.csv@a2", "data/b.csv@b1", "data/a.csv@a3"}, dispatched)
+
+	// a recreated object is dispatched again
+	lister.objects = nil
+	poller.pollOnce(ctx, dispatch, log)
+	lister.objects = []minio.ObjectInfo{{Key: "data/a.csv", ETag: "a3"}}
+	poller.pollOnce(ctx, dispatch, log)
+	assert.Equal(t, "data/a.csv@a3", dispatched[len(dispatched)-1])
+	assert.Len(t, dispatched, 4)
+}
+
+func TestNewClient(t *testing.T) {
+	eventSource := &apicommon.S3Artifact{Endpoint: "minio.example.com:9000", Region: "eu-west-1", PathStyle: true}
+	client, err := newClient(eventSource, "access", "secret", zap.NewNop().Sugar())
+	assert.NoError(t, err)
+	assert.Equal(t, "https", client.EndpointURL().Scheme)
+
+	eventSource.TLS = &apicommon.TLSConfig{InsecureSkipVerify: true}
+	_, err = newClient(eventSource, "access", "secret", zap.NewNop().Sugar())
+	assert.NoError(t, err)
+	eventSource.TLS = &apicommon.TLSConfig{InsecureSkipVerify: true, MinVersion: "0.9"}
+	_, err = newClient(eventSource, "access", "secret", zap.NewNop().Sugar())
+	assert.Error(t, err)
+}
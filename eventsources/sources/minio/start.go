@@ -81,12 +81,21 @@ func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byt
 	}
 
 	log.Info("setting up a minio client...")
-	minioClient, err := minio.New(minioEventSource.Endpoint, &minio.Options{
-		Creds: credentials.NewStaticV4(accessKey, secretKey, ""), Secure: !minioEventSource.Insecure})
+	minioClient, err := newClient(minioEventSource, accessKey, secretKey, log)
 	if err != nil {
 		return errors.Wrapf(err, "failed to create a client for event source %s", el.GetEventName())
 	}
 
+	interval, err := pollInterval(minioEventSource.PollInterval)
+	if err != nil {
+		return err
+	}
+	if interval > 0 {
+		el.poll(ctx, minioClient, interval, dispatch, log)
+		log.Info("event source is stopped")
+		return nil
+	}
+
 	prefix, suffix := getFilters(minioEventSource)
 
 	log.Info("started listening to bucket notifications...")
@@ -124,6 +133,32 @@ func (el *EventListener) handleOne(notification notification.Info, dispatch func
 	return nil
 }
 
+// newClient returns a client of the endpoint, addressing the bucket in the path of the URLs if required, and
+// connecting with the TLS configuration if specified.
+func newClient(eventSource *apicommon.S3Artifact, accessKey, secretKey string, log *zap.SugaredLogger) (*minio.Client, error) {
+	opts := &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure: !eventSource.Insecure,
+		Region: eventSource.Region,
+	}
+	if eventSource.PathStyle {
+		opts.BucketLookup = minio.BucketLookupPath
+	}
+	if eventSource.TLS != nil && !eventSource.Insecure {
+		tlsConfig, err := common.GetTLSConfig(eventSource.TLS, log)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get the tls configuration")
+		}
+		transport, err := minio.DefaultTransport(true)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
+		opts.Transport = transport
+	}
+	return minio.New(eventSource.Endpoint, opts)
+}
+
 func getFilters(eventSource *apicommon.S3Artifact) (string, string) {
 	if eventSource.Filter == nil {
 		return "", ""
@@ -19,6 +19,7 @@ package minio
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/argoproj/argo-events/common"
 	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
@@ -53,5 +54,20 @@ func validate(eventSource *apicommon.S3Artifact) error {
 			}
 		}
 	}
+	interval, err := pollInterval(eventSource.PollInterval)
+	if err != nil {
+		return err
+	}
+	if interval > 0 {
+		// the listing of the bucket only tells the objects created or modified
+		for _, event := range eventSource.Events {
+			if !strings.HasPrefix(event, "s3:ObjectCreated:") {
+				return fmt.Errorf("pollInterval only detects the s3:ObjectCreated events, not %s", event)
+			}
+		}
+	}
+	if eventSource.TLS != nil {
+		return apicommon.ValidateTLSConfig(eventSource.TLS)
+	}
 	return nil
 }
@@ -23,9 +23,11 @@ import (
 	"testing"
 
 	"github.com/argoproj/argo-events/eventsources/sources"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
 	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
 	"github.com/ghodss/yaml"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func TestValidateEventSource(t *testing.T) {
@@ -51,3 +53,32 @@ func TestValidateEventSource(t *testing.T) {
 		assert.NoError(t, err)
 	}
 }
+
+func TestValidatePolling(t *testing.T) {
+	eventSource := &apicommon.S3Artifact{
+		Endpoint:     "minio.example.com:9000",
+		Bucket:       &apicommon.S3Bucket{Name: "input"},
+		AccessKey:    &corev1.SecretKeySelector{Key: "accesskey"},
+		SecretKey:    &corev1.SecretKeySelector{Key: "secretkey"},
+		Events:       []string{"s3:ObjectCreated:Put"},
+		PollInterval: "30s",
+	}
+	assert.NoError(t, validate(eventSource))
+
+	eventSource.PollInterval = "0s"
+	err := validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "pollInterval must be positive", err.Error())
+
+	eventSource.PollInterval = "30s"
+	eventSource.Events = []string{"s3:ObjectCreated:Put", "s3:ObjectRemoved:Delete"}
+	err = validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "pollInterval only detects the s3:ObjectCreated events, not s3:ObjectRemoved:Delete", err.Error())
+
+	eventSource.Events = nil
+	eventSource.TLS = &apicommon.TLSConfig{ClientCertData: []byte("cert")}
+	err = validate(eventSource)
+	assert.Error(t, err)
+	assert.Equal(t, "invalid tls config, both clientCertSecret and clientKeySecret need to be configured", err.Error())
+}
@@ -106,7 +106,7 @@ func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byt
 	log.Info("setting up the mqtt broker client...")
 	opts := mqttlib.NewClientOptions().AddBroker(mqttEventSource.URL).SetClientID(mqttEventSource.ClientID)
 	if mqttEventSource.TLS != nil {
-		tlsConfig, err := common.GetTLSConfig(mqttEventSource.TLS)
+		tlsConfig, err := common.GetTLSConfig(mqttEventSource.TLS, log)
 		if err != nil {
 			return errors.Wrap(err, "failed to get the tls configuration")
 		}
@@ -116,7 +116,7 @@ func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byt
 	var client mqttlib.Client
 
 	log.Info("connecting to mqtt broker...")
-	if err := common.Connect(mqttEventSource.ConnectionBackoff, func() error {
+	if err := common.ConnectWithContext(ctx, mqttEventSource.ConnectionBackoff, func() error {
 		client = mqttlib.NewClient(opts)
 		if token := client.Connect(); token.Wait() && token.Error() != nil {
 			return token.Error()
@@ -0,0 +1,279 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mqttv5
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"math"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/argoproj/argo-events/common"
+	"github.com/argoproj/argo-events/common/logging"
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/eventsources/sources"
+	metrics "github.com/argoproj/argo-events/metrics"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+const (
+	// defaultSessionExpiry is how long the broker keeps a persistent session once the client is disconnected
+	defaultSessionExpiry = time.Hour
+	// keepAlive is the interval of the pings to the broker, in seconds
+	keepAlive = 30
+	// dialTimeout bounds the establishment of the network connection to the broker
+	dialTimeout = 10 * time.Second
+)
+
+var (
+	// plainSchemes are the schemes of the broker urls connected to without TLS, unless a TLS configuration is set
+	plainSchemes = map[string]bool{"tcp": true, "mqtt": true}
+	// tlsSchemes are the schemes of the broker urls connected to over TLS
+	tlsSchemes = map[string]bool{"ssl": true, "tls": true, "mqtts": true}
+)
+
+// EventListener implements Eventing for mqtt v5 event source
+type EventListener struct {
+	EventSourceName   string
+	EventName         string
+	MQTTV5EventSource v1alpha1.MQTTV5EventSource
+	Metrics           *metrics.Metrics
+}
+
+// GetEventSourceName returns name of event source
+func (el *EventListener) GetEventSourceName() string {
+	return el.EventSourceName
+}
+
+// GetEventName returns name of event
+func (el *EventListener) GetEventName() string {
+	return el.EventName
+}
+
+// GetEventSourceType return type of event server
+func (el *EventListener) GetEventSourceType() apicommon.EventSourceType {
+	return apicommon.MQTTV5Event
+}
+
+// StartListening starts listening events
+func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byte, ...eventsourcecommon.Options) error) error {
+	log := logging.FromContext(ctx).
+		With(logging.LabelEventSourceType, el.GetEventSourceType(), logging.LabelEventName, el.GetEventName())
+	defer sources.Recover(el.GetEventName())
+
+	log.Info("starting MQTT v5 event source...")
+	mqttEventSource := &el.MQTTV5EventSource
+	if err := validate(mqttEventSource); err != nil {
+		return errors.Wrap(err, "invalid mqtt v5 event source")
+	}
+
+	if mqttEventSource.JSONBody {
+		log.Info("assuming all events have a json body...")
+	}
+
+	var tlsConfig *tls.Config
+	if mqttEventSource.TLS != nil {
+		c, err := common.GetTLSConfig(mqttEventSource.TLS, log)
+		if err != nil {
+			return errors.Wrap(err, "failed to get the tls configuration")
+		}
+		tlsConfig = c
+	}
+
+	var sessionExpiry time.Duration
+	if mqttEventSource.PersistentSession {
+		sessionExpiry = defaultSessionExpiry
+		if mqttEventSource.SessionExpiry != "" {
+			d, err := time.ParseDuration(mqttEventSource.SessionExpiry)
+			if err != nil {
+				return errors.Wrapf(err, "failed to parse the session expiry %s", mqttEventSource.SessionExpiry)
+			}
+			sessionExpiry = d
+		}
+		log.Infow("resuming the persistent session of the client", zap.String("clientId", mqttEventSource.ClientID), zap.Duration("sessionExpiry", sessionExpiry))
+	}
+
+	log.Info("setting up the message handler...")
+	// the message is acknowledged once the handler returns, so that a message of QoS 1 or 2 which wasn't dispatched,
+	// e.g. the event source crashed, is redelivered to a persistent session.
+	handler := func(msg *paho.Publish) {
+		defer func(start time.Time) {
+			el.Metrics.EventProcessingDuration(el.GetEventSourceName(), el.GetEventName(), float64(time.Since(start)/time.Millisecond))
+		}(time.Now())
+
+		eventData := &events.MQTTV5EventData{
+			Topic:     msg.Topic,
+			QoS:       int(msg.QoS),
+			Retained:  msg.Retain,
+			MessageID: int(msg.PacketID),
+			Metadata:  mqttEventSource.Metadata,
+		}
+		if props := msg.Properties; props != nil {
+			eventData.ContentType = props.ContentType
+			eventData.ResponseTopic = props.ResponseTopic
+			eventData.CorrelationData = props.CorrelationData
+			if len(props.User) > 0 {
+				eventData.UserProperties = make(map[string]string, len(props.User))
+				for _, property := range props.User {
+					eventData.UserProperties[property.Key] = property.Value
+				}
+			}
+		}
+		if mqttEventSource.JSONBody {
+			body := msg.Payload
+			eventData.Body = (*json.RawMessage)(&body)
+		} else {
+			eventData.Body = msg.Payload
+		}
+
+		eventBody, err := json.Marshal(eventData)
+		if err != nil {
+			log.Errorw("failed to marshal the event data, rejecting the event...", zap.Error(err))
+			el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonMarshal)
+			return
+		}
+		log.Info("dispatching event on the data channel...")
+		if err = dispatch(eventBody); err != nil {
+			log.Errorw("failed to dispatch MQTT v5 event...", zap.Error(err))
+			el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonDispatch)
+		}
+	}
+
+	for {
+		// lost receives the reason the connection was lost, once
+		lost := make(chan error, 1)
+		var client *paho.Client
+		log.Info("connecting to mqtt broker...")
+		if err := common.ConnectWithContext(ctx, mqttEventSource.ConnectionBackoff, func() error {
+			c, err := el.connect(ctx, tlsConfig, sessionExpiry, handler, lost, log)
+			if err != nil {
+				log.Errorw("failed to connect to the mqtt broker", zap.Error(err))
+				return err
+			}
+			client = c
+			return nil
+		}); err != nil {
+			el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonConnection)
+			return errors.Wrapf(err, "failed to connect to the mqtt broker for event source %s", el.GetEventName())
+		}
+		el.Metrics.EventSourceActive(el.GetEventSourceName(), el.GetEventName(), true)
+
+		select {
+		case <-ctx.Done():
+			el.Metrics.EventSourceActive(el.GetEventSourceName(), el.GetEventName(), false)
+			if !mqttEventSource.PersistentSession {
+				log.Info("event source is stopped, unsubscribing the client...")
+				unsubscribeCtx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+				if _, err := client.Unsubscribe(unsubscribeCtx, &paho.Unsubscribe{Topics: []string{mqttEventSource.Topic}}); err != nil {
+					log.Errorw("failed to unsubscribe client", zap.Error(err))
+				}
+				cancel()
+			}
+			log.Info("event source is stopped, disconnecting the client...")
+			if err := client.Disconnect(&paho.Disconnect{ReasonCode: 0}); err != nil {
+				log.Errorw("failed to disconnect client", zap.Error(err))
+			}
+			return nil
+		case err := <-lost:
+			el.Metrics.EventSourceActive(el.GetEventSourceName(), el.GetEventName(), false)
+			log.Errorw("lost the connection to the mqtt broker, reconnecting...", zap.Error(err))
+			el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonConnection)
+		}
+	}
+}
+
+// connect connects a new client to the broker and subscribes to the topic. The reason the connection is lost
+// afterwards is sent to lost.
+func (el *EventListener) connect(ctx context.Context, tlsConfig *tls.Config, sessionExpiry time.Duration, handler paho.MessageHandler, lost chan<- error, log *zap.SugaredLogger) (*paho.Client, error) {
+	mqttEventSource := &el.MQTTV5EventSource
+	conn, err := dial(ctx, mqttEventSource.Broker, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	notify := func(err error) {
+		select {
+		case lost <- err:
+		default:
+		}
+	}
+	client := paho.NewClient(paho.ClientConfig{
+		Conn:   conn,
+		Router: paho.NewSingleHandlerRouter(handler),
+		OnClientError: func(err error) {
+			notify(err)
+		},
+		OnServerDisconnect: func(d *paho.Disconnect) {
+			notify(errors.Errorf("disconnected by the broker with the reason code %d", d.ReasonCode))
+		},
+	})
+
+	connect := &paho.Connect{
+		ClientID:   mqttEventSource.ClientID,
+		KeepAlive:  keepAlive,
+		CleanStart: !mqttEventSource.PersistentSession,
+	}
+	if sessionExpiry > 0 {
+		seconds := uint32(math.MaxUint32)
+		if s := sessionExpiry / time.Second; s < math.MaxUint32 {
+			seconds = uint32(s)
+		}
+		connect.Properties = &paho.ConnectProperties{SessionExpiryInterval: &seconds}
+	}
+	connack, err := client.Connect(ctx, connect)
+	if err != nil {
+		_ = conn.Close()
+		return nil, errors.Wrapf(err, "failed to connect to the broker %s", mqttEventSource.Broker)
+	}
+	if connack.SessionPresent {
+		log.Info("resumed the session of the client")
+	}
+
+	log.Infow("subscribing to the topic...", zap.String("topic", mqttEventSource.Topic), zap.Int32("qos", mqttEventSource.QoS))
+	if _, err := client.Subscribe(ctx, &paho.Subscribe{
+		Subscriptions: map[string]paho.SubscribeOptions{
+			mqttEventSource.Topic: {QoS: byte(mqttEventSource.QoS)},
+		},
+	}); err != nil {
+		_ = client.Disconnect(&paho.Disconnect{ReasonCode: 0})
+		return nil, errors.Wrapf(err, "failed to subscribe to the topic %s", mqttEventSource.Topic)
+	}
+	return client, nil
+}
+
+// dial opens the network connection to the broker, over TLS if the scheme of the url requires it or a TLS
+// configuration is set.
+func dial(ctx context.Context, broker string, tlsConfig *tls.Config) (net.Conn, error) {
+	u, err := url.Parse(broker)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid broker url %s", broker)
+	}
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	if tlsConfig != nil || tlsSchemes[u.Scheme] {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: tlsConfig}
+		return tlsDialer.DialContext(ctx, "tcp", u.Host)
+	}
+	return dialer.DialContext(ctx, "tcp", u.Host)
+}
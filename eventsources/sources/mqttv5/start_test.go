@@ -0,0 +1,220 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mqttv5
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/eclipse/paho.golang/packets"
+	"github.com/stretchr/testify/assert"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/metrics"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// fakeBroker accepts the MQTT v5 connections, grants the subscriptions and publishes a message to each subscriber.
+// The packets received from the clients are recorded.
+type fakeBroker struct {
+	addr     string
+	received chan packets.ControlPacket
+	// drops is the number of connections closed once the message is acknowledged
+	drops int32
+}
+
+func newFakeBroker(t *testing.T, drops int32) *fakeBroker {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	b := &fakeBroker{addr: listener.Addr().String(), received: make(chan packets.ControlPacket, 100), drops: drops}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go b.serve(conn)
+		}
+	}()
+	return b
+}
+
+func (b *fakeBroker) serve(conn net.Conn) {
+	defer conn.Close()
+	for {
+		packet, err := packets.ReadPacket(conn)
+		if err != nil {
+			return
+		}
+		b.received <- *packet
+		var reply io.WriterTo
+		switch content := packet.Content.(type) {
+		case *packets.Connect:
+			reply = packets.NewControlPacket(packets.CONNACK)
+		case *packets.Subscribe:
+			suback := packets.NewControlPacket(packets.SUBACK)
+			suback.Content.(*packets.Suback).PacketID = content.PacketID
+			suback.Content.(*packets.Suback).Reasons = []byte{packets.SubackGrantedQoS1}
+			if _, err := suback.WriteTo(conn); err != nil {
+				return
+			}
+			// the publish packet sets the flags of the fixed header from its QoS
+			publish := packets.NewControlPacket(packets.PUBLISH).Content.(*packets.Publish)
+			reply = publish
+			publish.Topic, publish.QoS, publish.PacketID, publish.Payload = "devices/1/telemetry", 1, 7, []byte(`{"temperature":21}`)
+			publish.Properties.ContentType = "application/json"
+			publish.Properties.ResponseTopic = "devices/1/commands"
+			publish.Properties.User = []packets.User{{Key: "device", Value: "1"}, {Key: "site", Value: "paris"}}
+		case *packets.Puback:
+			if atomic.AddInt32(&b.drops, -1) >= 0 {
+				return
+			}
+		case *packets.Unsubscribe:
+			unsuback := packets.NewControlPacket(packets.UNSUBACK)
+			unsuback.Content.(*packets.Unsuback).PacketID = content.PacketID
+			unsuback.Content.(*packets.Unsuback).Reasons = []byte{0}
+			reply = unsuback
+		case *packets.Pingreq:
+			reply = packets.NewControlPacket(packets.PINGRESP)
+		case *packets.Disconnect:
+			return
+		}
+		if reply != nil {
+			if _, err := reply.WriteTo(conn); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// next returns the next packet of the type received by the broker, skipping the others
+func (b *fakeBroker) next(t *testing.T, packetType byte) packets.ControlPacket {
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case packet := <-b.received:
+			if packet.Type == packetType {
+				return packet
+			}
+		case <-timeout:
+			t.Fatalf("no packet of type %d received", packetType)
+			return packets.ControlPacket{}
+		}
+	}
+}
+
+func startListening(t *testing.T, source v1alpha1.MQTTV5EventSource) (context.CancelFunc, <-chan []byte, <-chan error) {
+	el := &EventListener{EventSourceName: "mqttv5", EventName: "example", MQTTV5EventSource: source, Metrics: metrics.NewMetrics("ns")}
+	ctx, cancel := context.WithCancel(context.Background())
+	dispatched := make(chan []byte, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- el.StartListening(ctx, func(data []byte, opts ...eventsourcecommon.Options) error {
+			dispatched <- data
+			return nil
+		})
+	}()
+	return cancel, dispatched, done
+}
+
+func nextEvent(t *testing.T, dispatched <-chan []byte) events.MQTTV5EventData {
+	var event events.MQTTV5EventData
+	select {
+	case data := <-dispatched:
+		assert.NoError(t, json.Unmarshal(data, &event))
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event dispatched")
+	}
+	return event
+}
+
+func TestStartListening(t *testing.T) {
+	broker := newFakeBroker(t, 0)
+	cancel, dispatched, done := startListening(t, v1alpha1.MQTTV5EventSource{
+		Broker:   fmt.Sprintf("tcp://%s", broker.addr),
+		Topic:    "devices/+/telemetry",
+		QoS:      1,
+		ClientID: "argo-events",
+		JSONBody: true,
+		Metadata: map[string]string{"fleet": "iot"},
+	})
+
+	connect := broker.next(t, packets.CONNECT).Content.(*packets.Connect)
+	assert.Equal(t, "argo-events", connect.ClientID)
+	assert.True(t, connect.CleanStart)
+	subscribe := broker.next(t, packets.SUBSCRIBE).Content.(*packets.Subscribe)
+	assert.Equal(t, byte(1), subscribe.Subscriptions["devices/+/telemetry"].QoS)
+
+	event := nextEvent(t, dispatched)
+	assert.Equal(t, "devices/1/telemetry", event.Topic)
+	assert.Equal(t, 1, event.QoS)
+	assert.Equal(t, 7, event.MessageID)
+	assert.Equal(t, "application/json", event.ContentType)
+	assert.Equal(t, "devices/1/commands", event.ResponseTopic)
+	assert.Equal(t, map[string]string{"device": "1", "site": "paris"}, event.UserProperties)
+	assert.Equal(t, map[string]interface{}{"temperature": float64(21)}, event.Body)
+	assert.Equal(t, map[string]string{"fleet": "iot"}, event.Metadata)
+	// the message is acknowledged once dispatched
+	assert.Equal(t, uint16(7), broker.next(t, packets.PUBACK).Content.(*packets.Puback).PacketID)
+
+	// a clean session is unsubscribed from when the event source stops
+	cancel()
+	assert.NoError(t, <-done)
+	assert.Equal(t, []string{"devices/+/telemetry"}, broker.next(t, packets.UNSUBSCRIBE).Content.(*packets.Unsubscribe).Topics)
+	broker.next(t, packets.DISCONNECT)
+}
+
+func TestStartListeningPersistentSession(t *testing.T) {
+	broker := newFakeBroker(t, 1)
+	cancel, dispatched, done := startListening(t, v1alpha1.MQTTV5EventSource{
+		Broker:            fmt.Sprintf("tcp://%s", broker.addr),
+		Topic:             "devices/+/telemetry",
+		QoS:               1,
+		ClientID:          "argo-events",
+		PersistentSession: true,
+		SessionExpiry:     "24h",
+		ConnectionBackoff: &apicommon.Backoff{Steps: 5, Duration: &apicommon.Int64OrString{Type: apicommon.String, StrVal: "10ms"}},
+	})
+
+	connect := broker.next(t, packets.CONNECT).Content.(*packets.Connect)
+	assert.False(t, connect.CleanStart)
+	assert.Equal(t, uint32(24*60*60), *connect.Properties.SessionExpiryInterval)
+	nextEvent(t, dispatched)
+
+	// the broker drops the connection once the message is acknowledged, the client reconnects to the session
+	broker.next(t, packets.PUBACK)
+	connect = broker.next(t, packets.CONNECT).Content.(*packets.Connect)
+	assert.False(t, connect.CleanStart)
+	nextEvent(t, dispatched)
+
+	// a persistent session keeps its subscription when the event source stops
+	cancel()
+	assert.NoError(t, <-done)
+	broker.next(t, packets.DISCONNECT)
+	for len(broker.received) > 0 {
+		assert.NotEqual(t, packets.UNSUBSCRIBE, (<-broker.received).Type)
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mqttv5
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/argoproj/argo-events/common"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// ValidateEventSource validates the mqtt v5 event source
+func (listener *EventListener) ValidateEventSource(ctx context.Context) error {
+	return validate(&listener.MQTTV5EventSource)
+}
+
+func validate(eventSource *v1alpha1.MQTTV5EventSource) error {
+	if eventSource == nil {
+		return common.ErrNilEventSource
+	}
+	if eventSource.Broker == "" {
+		return fmt.Errorf("broker must be specified")
+	}
+	u, err := url.Parse(eventSource.Broker)
+	if err != nil {
+		return fmt.Errorf("invalid broker url %s: %w", eventSource.Broker, err)
+	}
+	if !plainSchemes[u.Scheme] && !tlsSchemes[u.Scheme] {
+		return fmt.Errorf("unsupported scheme %q of the broker url, it must be one of tcp, mqtt, ssl, tls or mqtts", u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("broker url %s must specify the host", eventSource.Broker)
+	}
+	if eventSource.Topic == "" {
+		return fmt.Errorf("topic must be specified")
+	}
+	if eventSource.QoS < 0 || eventSource.QoS > 2 {
+		return fmt.Errorf("qos must be 0, 1 or 2")
+	}
+	if eventSource.ClientID == "" {
+		return fmt.Errorf("client id must be specified")
+	}
+	if eventSource.SessionExpiry != "" {
+		if !eventSource.PersistentSession {
+			return fmt.Errorf("sessionExpiry must be set along with persistentSession")
+		}
+		expiry, err := time.ParseDuration(eventSource.SessionExpiry)
+		if err != nil {
+			return fmt.Errorf("failed to parse the session expiry %s: %w", eventSource.SessionExpiry, err)
+		}
+		if expiry < time.Second {
+			return fmt.Errorf("session expiry must be at least 1s")
+		}
+	}
+	if eventSource.TLS != nil {
+		return apicommon.ValidateTLSConfig(eventSource.TLS)
+	}
+	return nil
+}
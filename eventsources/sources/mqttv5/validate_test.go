@@ -0,0 +1,86 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mqttv5
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-events/eventsources/sources"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestValidateEventSource(t *testing.T) {
+	listener := &EventListener{}
+
+	err := listener.ValidateEventSource(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, "broker must be specified", err.Error())
+
+	content, err := ioutil.ReadFile(fmt.Sprintf("%s/%s", sources.EventSourceDir, "mqttv5.yaml"))
+	assert.Nil(t, err)
+
+	var eventSource *v1alpha1.EventSource
+	err = yaml.Unmarshal(content, &eventSource)
+	assert.Nil(t, err)
+	assert.NotNil(t, eventSource.Spec.MQTTV5)
+
+	for _, value := range eventSource.Spec.MQTTV5 {
+		l := &EventListener{
+			MQTTV5EventSource: value,
+		}
+		err := l.ValidateEventSource(context.Background())
+		assert.NoError(t, err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() *v1alpha1.MQTTV5EventSource {
+		return &v1alpha1.MQTTV5EventSource{Broker: "tcp://broker:1883", Topic: "devices/+/telemetry", ClientID: "argo-events"}
+	}
+	assert.NoError(t, validate(valid()))
+
+	tests := []struct {
+		name   string
+		modify func(*v1alpha1.MQTTV5EventSource)
+		err    string
+	}{
+		{"unsupported scheme", func(s *v1alpha1.MQTTV5EventSource) { s.Broker = "ws://broker:1883" }, `unsupported scheme "ws" of the broker url, it must be one of tcp, mqtt, ssl, tls or mqtts`},
+		{"no host", func(s *v1alpha1.MQTTV5EventSource) { s.Broker = "tcp://" }, "broker url tcp:// must specify the host"},
+		{"no topic", func(s *v1alpha1.MQTTV5EventSource) { s.Topic = "" }, "topic must be specified"},
+		{"invalid qos", func(s *v1alpha1.MQTTV5EventSource) { s.QoS = 3 }, "qos must be 0, 1 or 2"},
+		{"no client id", func(s *v1alpha1.MQTTV5EventSource) { s.ClientID = "" }, "client id must be specified"},
+		{"session expiry of a clean session", func(s *v1alpha1.MQTTV5EventSource) { s.SessionExpiry = "1h" }, "sessionExpiry must be set along with persistentSession"},
+		{"short session expiry", func(s *v1alpha1.MQTTV5EventSource) {
+			s.PersistentSession, s.SessionExpiry = true, "10ms"
+		}, "session expiry must be at least 1s"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			eventSource := valid()
+			test.modify(eventSource)
+			err := validate(eventSource)
+			assert.Error(t, err)
+			assert.Equal(t, test.err, err.Error())
+		})
+	}
+}
@@ -68,7 +68,7 @@ func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byt
 
 	var opt []natslib.Option
 	if natsEventSource.TLS != nil {
-		tlsConfig, err := common.GetTLSConfig(natsEventSource.TLS)
+		tlsConfig, err := common.GetTLSConfig(natsEventSource.TLS, log)
 		if err != nil {
 			return errors.Wrap(err, "failed to get the tls configuration")
 		}
@@ -114,7 +114,7 @@ func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byt
 
 	var conn *natslib.Conn
 	log.Info("connecting to nats cluster...")
-	if err := common.Connect(natsEventSource.ConnectionBackoff, func() error {
+	if err := common.ConnectWithContext(ctx, natsEventSource.ConnectionBackoff, func() error {
 		var err error
 		if conn, err = natslib.Connect(natsEventSource.URL, opt...); err != nil {
 			return err
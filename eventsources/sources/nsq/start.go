@@ -84,7 +84,7 @@ func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byt
 	config := nsq.NewConfig()
 
 	if nsqEventSource.TLS != nil {
-		tlsConfig, err := common.GetTLSConfig(nsqEventSource.TLS)
+		tlsConfig, err := common.GetTLSConfig(nsqEventSource.TLS, log)
 		if err != nil {
 			return errors.Wrap(err, "failed to get the tls configuration")
 		}
@@ -92,7 +92,10 @@ func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byt
 		config.TlsV1 = true
 	}
 
-	if err := common.Connect(nsqEventSource.ConnectionBackoff, func() error {
+	maxInFlight := maxInFlight(nsqEventSource)
+	config.MaxInFlight = maxInFlight
+
+	if err := common.ConnectWithContext(ctx, nsqEventSource.ConnectionBackoff, func() error {
 		var err error
 		if consumer, err = nsq.NewConsumer(nsqEventSource.Topic, nsqEventSource.Channel, config); err != nil {
 			return err
@@ -106,10 +109,20 @@ func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byt
 		log.Info("assuming all events have a json body...")
 	}
 
-	consumer.AddHandler(&messageHandler{eventSourceName: el.EventSourceName, eventName: el.EventName, dispatch: dispatch, logger: log, isJSON: nsqEventSource.JSONBody, metadata: nsqEventSource.Metadata, metrics: el.Metrics})
+	// the messages in flight are dispatched concurrently
+	log.Infow("handling the messages...", zap.Int("maxInFlight", maxInFlight))
+	consumer.AddConcurrentHandlers(&messageHandler{eventSourceName: el.EventSourceName, eventName: el.EventName, dispatch: dispatch, logger: log, isJSON: nsqEventSource.JSONBody, metadata: nsqEventSource.Metadata, metrics: el.Metrics}, maxInFlight)
 
-	if err := consumer.ConnectToNSQLookupd(nsqEventSource.HostAddress); err != nil {
-		return errors.Wrapf(err, "lookup failed for host %s for event source %s", nsqEventSource.HostAddress, el.GetEventName())
+	if len(nsqEventSource.NSQD) > 0 {
+		log.Infow("connecting to the nsqd...", zap.Strings("nsqd", nsqEventSource.NSQD))
+		if err := consumer.ConnectToNSQDs(nsqEventSource.NSQD); err != nil {
+			return errors.Wrapf(err, "failed to connect to the nsqd %v for event source %s", nsqEventSource.NSQD, el.GetEventName())
+		}
+	} else {
+		addresses := lookupdAddresses(nsqEventSource)
+		if err := consumer.ConnectToNSQLookupds(addresses); err != nil {
+			return errors.Wrapf(err, "lookup failed for hosts %v for event source %s", addresses, el.GetEventName())
+		}
 	}
 
 	<-ctx.Done()
@@ -118,18 +131,40 @@ func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byt
 	return nil
 }
 
-// HandleMessage implements the Handler interface.
+// maxInFlight returns the maximum number of messages in flight, 1 by default
+func maxInFlight(eventSource *v1alpha1.NSQEventSource) int {
+	if eventSource.MaxInFlight <= 0 {
+		return 1
+	}
+	return int(eventSource.MaxInFlight)
+}
+
+// lookupdAddresses returns the addresses of the nsqlookupd, HostAddress first
+func lookupdAddresses(eventSource *v1alpha1.NSQEventSource) []string {
+	var addresses []string
+	if eventSource.HostAddress != "" {
+		addresses = append(addresses, eventSource.HostAddress)
+	}
+	return append(addresses, eventSource.NSQLookupd...)
+}
+
+// HandleMessage implements the Handler interface. The message is finished (FIN) once dispatched, and requeued (REQ)
+// with a backoff if the dispatch fails. A message whose event data can't be marshaled is finished, it would never be.
 func (h *messageHandler) HandleMessage(m *nsq.Message) error {
 	defer func(start time.Time) {
 		h.metrics.EventProcessingDuration(h.eventSourceName, h.eventName, float64(time.Since(start)/time.Millisecond))
 	}(time.Now())
 
-	h.logger.Info("received a message")
+	m.DisableAutoResponse()
+	log := h.logger.With("messageId", string(m.ID[:]), "attempts", m.Attempts)
+	log.Info("received a message")
 
 	eventData := &events.NSQEventData{
 		Body:        m.Body,
 		Timestamp:   strconv.Itoa(int(m.Timestamp)),
 		NSQDAddress: m.NSQDAddress,
+		ID:          string(m.ID[:]),
+		Attempts:    m.Attempts,
 		Metadata:    h.metadata,
 	}
 	if h.isJSON {
@@ -138,15 +173,20 @@ func (h *messageHandler) HandleMessage(m *nsq.Message) error {
 
 	eventBody, err := json.Marshal(eventData)
 	if err != nil {
-		h.logger.Errorw("failed to marshal the event data. rejecting the event...", zap.Error(err))
-		h.metrics.EventProcessingFailed(h.eventSourceName, h.eventName)
+		log.Errorw("failed to marshal the event data. rejecting the event...", zap.Error(err))
+		h.metrics.EventProcessingFailedWithReason(h.eventSourceName, h.eventName, metrics.FailureReasonMarshal)
+		m.Finish()
 		return err
 	}
 
-	h.logger.Info("dispatching the event on the data channel...")
+	log.Info("dispatching the event on the data channel...")
 	if err = h.dispatch(eventBody); err != nil {
-		h.metrics.EventProcessingFailed(h.eventSourceName, h.eventName)
+		log.Errorw("failed to dispatch the event, requeuing the message...", zap.Error(err))
+		h.metrics.EventProcessingFailedWithReason(h.eventSourceName, h.eventName, metrics.FailureReasonDispatch)
+		// the delay grows with the attempts, and the consumer backs off
+		m.Requeue(-1)
 		return err
 	}
+	m.Finish()
 	return nil
 }
@@ -0,0 +1,113 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nsq
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/nsqio/go-nsq"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/metrics"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// fakeDelegate records the responses to the messages
+type fakeDelegate struct {
+	finished bool
+	requeued bool
+	backoff  bool
+}
+
+func (d *fakeDelegate) OnFinish(*nsq.Message) {
+	d.finished = true
+}
+
+func (d *fakeDelegate) OnRequeue(_ *nsq.Message, _ time.Duration, backoff bool) {
+	d.requeued = true
+	d.backoff = backoff
+}
+
+func (d *fakeDelegate) OnTouch(*nsq.Message) {}
+
+func newMessage(body string, delegate nsq.MessageDelegate) *nsq.Message {
+	var id nsq.MessageID
+	copy(id[:], "0123456789abcdef")
+	m := nsq.NewMessage(id, []byte(body))
+	m.Attempts = 3
+	m.NSQDAddress = "nsqd:4150"
+	m.Delegate = delegate
+	return m
+}
+
+func TestHandleMessage(t *testing.T) {
+	var dispatched []byte
+	h := &messageHandler{
+		eventSourceName: "nsq",
+		eventName:       "example",
+		metrics:         metrics.NewMetrics("ns"),
+		logger:          zap.NewNop().Sugar(),
+		isJSON:          true,
+		dispatch: func(data []byte, _ ...eventsourcecommon.Options) error {
+			dispatched = data
+			return nil
+		},
+	}
+
+	delegate := &fakeDelegate{}
+	assert.NoError(t, h.HandleMessage(newMessage(`{"a":1}`, delegate)))
+	assert.True(t, delegate.finished)
+	assert.False(t, delegate.requeued)
+	var eventData events.NSQEventData
+	assert.NoError(t, json.Unmarshal(dispatched, &eventData))
+	assert.Equal(t, "0123456789abcdef", eventData.ID)
+	assert.Equal(t, uint16(3), eventData.Attempts)
+	assert.Equal(t, "nsqd:4150", eventData.NSQDAddress)
+	assert.Equal(t, map[string]interface{}{"a": float64(1)}, eventData.Body)
+
+	h.dispatch = func([]byte, ...eventsourcecommon.Options) error {
+		return errors.New("eventbus unavailable")
+	}
+	delegate = &fakeDelegate{}
+	assert.Error(t, h.HandleMessage(newMessage(`{"a":1}`, delegate)))
+	assert.False(t, delegate.finished)
+	assert.True(t, delegate.requeued)
+	assert.True(t, delegate.backoff)
+
+	// a message which can't be marshaled would never be, it isn't requeued
+	delegate = &fakeDelegate{}
+	assert.Error(t, h.HandleMessage(newMessage(`not json`, delegate)))
+	assert.True(t, delegate.finished)
+	assert.False(t, delegate.requeued)
+}
+
+func TestMaxInFlight(t *testing.T) {
+	assert.Equal(t, 1, maxInFlight(&v1alpha1.NSQEventSource{}))
+	assert.Equal(t, 10, maxInFlight(&v1alpha1.NSQEventSource{MaxInFlight: 10}))
+}
+
+func TestLookupdAddresses(t *testing.T) {
+	assert.Equal(t, []string{"nsqlookupd:4161", "nsqlookupd-1:4161"},
+		lookupdAddresses(&v1alpha1.NSQEventSource{HostAddress: "nsqlookupd:4161", NSQLookupd: []string{"nsqlookupd-1:4161"}}))
+	assert.Equal(t, []string{"nsqlookupd-1:4161"}, lookupdAddresses(&v1alpha1.NSQEventSource{NSQLookupd: []string{"nsqlookupd-1:4161"}}))
+}
@@ -35,7 +35,11 @@ func validate(eventSource *v1alpha1.NSQEventSource) error {
 	if eventSource == nil {
 		return common.ErrNilEventSource
 	}
-	if eventSource.HostAddress == "" {
+	if len(eventSource.NSQD) > 0 {
+		if eventSource.HostAddress != "" || len(eventSource.NSQLookupd) > 0 {
+			return errors.New("nsqd can't be specified along with host address or nsqLookupd")
+		}
+	} else if eventSource.HostAddress == "" && len(eventSource.NSQLookupd) == 0 {
 		return errors.New("host address must be specified")
 	}
 	if eventSource.Topic == "" {
@@ -44,6 +48,9 @@ func validate(eventSource *v1alpha1.NSQEventSource) error {
 	if eventSource.Channel == "" {
 		return errors.New("channel must be specified")
 	}
+	if eventSource.MaxInFlight < 0 {
+		return errors.New("maxInFlight must not be negative")
+	}
 	if eventSource.TLS != nil {
 		return apicommon.ValidateTLSConfig(eventSource.TLS)
 	}
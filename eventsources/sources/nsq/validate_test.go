@@ -36,6 +36,24 @@ func TestValidateEventSource(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, "host address must be specified", err.Error())
 
+	listener.NSQEventSource = v1alpha1.NSQEventSource{NSQD: []string{"nsqd:4150"}, HostAddress: "nsqlookupd:4161", Topic: "hello", Channel: "my-channel"}
+	err = listener.ValidateEventSource(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, "nsqd can't be specified along with host address or nsqLookupd", err.Error())
+
+	listener.NSQEventSource.HostAddress = ""
+	listener.NSQEventSource.MaxInFlight = -1
+	err = listener.ValidateEventSource(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, "maxInFlight must not be negative", err.Error())
+
+	listener.NSQEventSource.MaxInFlight = 10
+	assert.NoError(t, listener.ValidateEventSource(context.Background()))
+
+	listener.NSQEventSource.NSQD = nil
+	listener.NSQEventSource.NSQLookupd = []string{"nsqlookupd-0:4161", "nsqlookupd-1:4161"}
+	assert.NoError(t, listener.ValidateEventSource(context.Background()))
+
 	content, err := ioutil.ReadFile(fmt.Sprintf("%s/%s", sources.EventSourceDir, "nsq.yaml"))
 	assert.Nil(t, err)
 
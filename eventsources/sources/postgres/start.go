@@ -0,0 +1,235 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/argoproj/argo-events/common"
+	"github.com/argoproj/argo-events/common/logging"
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/eventsources/sources"
+	metrics "github.com/argoproj/argo-events/metrics"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+const (
+	// connectTimeout bounds the connection to the database, the TLS handshake included
+	connectTimeout = 10 * time.Second
+	// pingInterval is the interval of the pings which detect a connection lost while no notification is sent
+	pingInterval = 90 * time.Second
+	// listenerReconnectInterval is the interval the driver waits for before reconnecting on its own. The event
+	// source closes the lost connections and reconnects with its backoff before the driver does.
+	listenerReconnectInterval = time.Minute
+)
+
+// EventListener implements Eventing for the PostgreSQL event source
+type EventListener struct {
+	EventSourceName     string
+	EventName           string
+	PostgresEventSource v1alpha1.PostgresEventSource
+	Metrics             *metrics.Metrics
+}
+
+// GetEventSourceName returns name of event source
+func (el *EventListener) GetEventSourceName() string {
+	return el.EventSourceName
+}
+
+// GetEventName returns name of event
+func (el *EventListener) GetEventName() string {
+	return el.EventName
+}
+
+// GetEventSourceType return type of event server
+func (el *EventListener) GetEventSourceType() apicommon.EventSourceType {
+	return apicommon.PostgresEvent
+}
+
+// StartListening starts listening events
+func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byte, ...eventsourcecommon.Options) error) error {
+	log := logging.FromContext(ctx).
+		With(logging.LabelEventSourceType, el.GetEventSourceType(), logging.LabelEventName, el.GetEventName())
+	defer sources.Recover(el.GetEventName())
+
+	log.Info("starting PostgreSQL event source...")
+	postgresEventSource := &el.PostgresEventSource
+	if err := validate(postgresEventSource); err != nil {
+		return errors.Wrap(err, "invalid postgres event source")
+	}
+
+	if postgresEventSource.JSONBody {
+		log.Info("assuming all events have a json body...")
+	}
+
+	dsn, err := common.GetSecretFromVolume(postgresEventSource.DSN)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the dsn")
+	}
+	dsn, dialer, err := connectionSettings(dsn, postgresEventSource.SSLMode, postgresEventSource.TLS, log)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the connection settings")
+	}
+
+	for {
+		var listener *pq.Listener
+		var lost <-chan error
+		log.Infow("connecting to the database...", zap.Strings("channels", postgresEventSource.Channels))
+		if err := common.ConnectWithContext(ctx, postgresEventSource.ConnectionBackoff, func() error {
+			l, disconnected, err := el.connect(dsn, dialer)
+			if err != nil {
+				log.Errorw("failed to connect to the database", zap.Error(err))
+				return err
+			}
+			listener, lost = l, disconnected
+			return nil
+		}); err != nil {
+			el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonConnection)
+			return errors.Wrapf(err, "failed to connect to the database for event source %s", el.GetEventName())
+		}
+		log.Info("listening to the notifications of the database")
+		el.Metrics.EventSourceActive(el.GetEventSourceName(), el.GetEventName(), true)
+		err := el.consume(ctx, listener, lost, dispatch, log)
+		el.Metrics.EventSourceActive(el.GetEventSourceName(), el.GetEventName(), false)
+		if ctx.Err() != nil {
+			log.Info("event source is stopped, closing the connection to the database...")
+			return nil
+		}
+		log.Errorw("lost the connection to the database, reconnecting...", zap.Error(err))
+		el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonConnection)
+	}
+}
+
+// connect connects to the database and issues LISTEN on the channels, as on every reconnection. The returned
+// channel receives the error the connection is lost with.
+func (el *EventListener) connect(dsn string, dialer pq.Dialer) (*pq.Listener, <-chan error, error) {
+	connected := make(chan error, 1)
+	lost := make(chan error, 1)
+	notify := func(c chan error, err error) {
+		select {
+		case c <- err:
+		default:
+		}
+	}
+	listener := pq.NewDialListener(dialer, dsn, listenerReconnectInterval, listenerReconnectInterval, func(event pq.ListenerEventType, err error) {
+		switch event {
+		case pq.ListenerEventConnected:
+			notify(connected, nil)
+		case pq.ListenerEventConnectionAttemptFailed:
+			notify(connected, err)
+		case pq.ListenerEventDisconnected:
+			if err == nil {
+				err = errors.New("the connection was closed")
+			}
+			notify(lost, err)
+		}
+	})
+	var err error
+	select {
+	case err = <-connected:
+	case <-time.After(connectTimeout):
+		err = errors.New("timed out connecting to the database")
+	}
+	if err != nil {
+		closeListener(listener)
+		return nil, nil, err
+	}
+	for _, channel := range el.PostgresEventSource.Channels {
+		if err := listener.Listen(channel); err != nil {
+			closeListener(listener)
+			return nil, nil, errors.Wrapf(err, "failed to listen on the channel %s", channel)
+		}
+	}
+	return listener, lost, nil
+}
+
+// closeListener closes the listener, and drains the notifications it may still deliver so that it can stop.
+func closeListener(listener *pq.Listener) {
+	_ = listener.Close()
+	go func() {
+		for range listener.Notify {
+		}
+	}()
+}
+
+// consume dispatches the notifications until the connection is lost or the event source is stopped. The connection
+// is pinged every ping interval so that it's known to be lost even when no notification is sent.
+func (el *EventListener) consume(ctx context.Context, listener *pq.Listener, lost <-chan error, dispatch func([]byte, ...eventsourcecommon.Options) error, log *zap.SugaredLogger) error {
+	defer closeListener(listener)
+
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-lost:
+			return err
+		case <-ticker.C:
+			if err := listener.Ping(); err != nil {
+				return errors.Wrap(err, "failed to ping the database")
+			}
+		case notification, ok := <-listener.Notify:
+			if !ok {
+				return errors.New("the listener was closed")
+			}
+			if notification != nil {
+				el.handleOne(notification, dispatch, log)
+			}
+		}
+	}
+}
+
+func (el *EventListener) handleOne(notification *pq.Notification, dispatch func([]byte, ...eventsourcecommon.Options) error, log *zap.SugaredLogger) {
+	defer func(start time.Time) {
+		el.Metrics.EventProcessingDuration(el.GetEventSourceName(), el.GetEventName(), float64(time.Since(start)/time.Millisecond))
+	}(time.Now())
+
+	log.Infow("received a notification", zap.String("channel", notification.Channel), zap.Int("pid", notification.BePid))
+	postgresEventSource := &el.PostgresEventSource
+	eventData := &events.PostgresEventData{
+		Channel:  notification.Channel,
+		PID:      notification.BePid,
+		Metadata: postgresEventSource.Metadata,
+	}
+	if postgresEventSource.JSONBody {
+		body := json.RawMessage(notification.Extra)
+		eventData.Body = &body
+	} else {
+		eventData.Body = notification.Extra
+	}
+
+	eventBody, err := json.Marshal(eventData)
+	if err != nil {
+		log.Errorw("failed to marshal the event data, rejecting the event...", zap.Error(err))
+		el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonMarshal)
+		return
+	}
+	log.Info("dispatching event on the data channel...")
+	if err = dispatch(eventBody); err != nil {
+		log.Errorw("failed to dispatch PostgreSQL event...", zap.Error(err))
+		el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonDispatch)
+	}
+}
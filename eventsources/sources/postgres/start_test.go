@@ -0,0 +1,76 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"encoding/json"
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/metrics"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestHandleOne(t *testing.T) {
+	el := &EventListener{
+		EventSourceName:     "postgres",
+		EventName:           "example",
+		PostgresEventSource: v1alpha1.PostgresEventSource{Channels: []string{"orders"}, Metadata: map[string]string{"team": "a"}},
+		Metrics:             metrics.NewMetrics("ns"),
+	}
+	var payloads [][]byte
+	dispatch := func(data []byte, _ ...eventsourcecommon.Options) error {
+		payloads = append(payloads, data)
+		return nil
+	}
+	notification := &pq.Notification{BePid: 42, Channel: "orders", Extra: `{"id":1}`}
+
+	el.handleOne(notification, dispatch, zap.NewNop().Sugar())
+	var eventData events.PostgresEventData
+	assert.NoError(t, json.Unmarshal(payloads[0], &eventData))
+	assert.Equal(t, events.PostgresEventData{Channel: "orders", PID: 42, Body: `{"id":1}`, Metadata: map[string]string{"team": "a"}}, eventData)
+
+	el.PostgresEventSource.JSONBody = true
+	el.handleOne(notification, dispatch, zap.NewNop().Sugar())
+	assert.NoError(t, json.Unmarshal(payloads[1], &eventData))
+	assert.Equal(t, map[string]interface{}{"id": float64(1)}, eventData.Body)
+
+	// a payload which isn't JSON is rejected
+	el.handleOne(&pq.Notification{Channel: "orders", Extra: "new"}, dispatch, zap.NewNop().Sugar())
+	assert.Len(t, payloads, 2)
+}
+
+func TestConnectFailed(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	address := listener.Addr().(*net.TCPAddr)
+	// nothing listens on the port anymore
+	assert.NoError(t, listener.Close())
+
+	el := &EventListener{PostgresEventSource: v1alpha1.PostgresEventSource{Channels: []string{"orders"}}}
+	dsn := "host=127.0.0.1 port=" + strconv.Itoa(address.Port) + " user=argo dbname=app sslmode=disable"
+	_, _, err = el.connect(dsn, &sslDialer{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "connection refused")
+}
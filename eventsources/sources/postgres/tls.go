@@ -0,0 +1,167 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/argoproj/argo-events/common"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+)
+
+// SSL modes, as in libpq
+const (
+	sslModeDisable    = "disable"
+	sslModeRequire    = "require"
+	sslModeVerifyCA   = "verify-ca"
+	sslModeVerifyFull = "verify-full"
+)
+
+// sslRequest is the message asking the server to switch the connection to TLS, its length followed by its code,
+// see https://www.postgresql.org/docs/current/protocol-flow.html#id-1.10.5.7.11
+var sslRequest = []byte{0, 0, 0, 8, 0x04, 0xd2, 0x16, 0x2f}
+
+// connectionSettings returns the DSN and the dialer to connect to the database with. The DSN is returned as is when
+// neither the SSL mode nor the TLS configuration are set, its sslmode then applies. Otherwise the TLS is negotiated
+// by the dialer, with the configuration from common.GetTLSConfig, and the sslmode of the DSN is disabled.
+func connectionSettings(dsn, sslMode string, tlsConfig *apicommon.TLSConfig, log *zap.SugaredLogger) (string, pq.Dialer, error) {
+	if sslMode == "" && tlsConfig == nil {
+		return dsn, &sslDialer{}, nil
+	}
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		var err error
+		if dsn, err = pq.ParseURL(dsn); err != nil {
+			return "", nil, errors.Wrap(err, "failed to parse the dsn")
+		}
+	}
+	// the last setting of a key wins
+	dsn += " sslmode=" + sslModeDisable
+	if sslMode == "" {
+		sslMode = sslModeVerifyFull
+		if tlsConfig.InsecureSkipVerify {
+			sslMode = sslModeRequire
+		}
+	}
+	if sslMode == sslModeDisable {
+		return dsn, &sslDialer{}, nil
+	}
+
+	config := &tls.Config{}
+	if tlsConfig != nil {
+		var err error
+		if config, err = common.GetTLSConfig(tlsConfig, log); err != nil {
+			return "", nil, errors.Wrap(err, "failed to get the tls configuration")
+		}
+	}
+	switch sslMode {
+	case sslModeRequire:
+		config.InsecureSkipVerify = true
+	case sslModeVerifyCA:
+		// the certificate chain is verified, not the host name
+		config.InsecureSkipVerify = true
+		config.VerifyPeerCertificate = verifyCertificateChain(config.RootCAs)
+	}
+	return dsn, &sslDialer{tlsConfig: config}, nil
+}
+
+// verifyCertificateChain returns the verification of the certificate chain of the server against the roots, the
+// system roots if nil.
+func verifyCertificateChain(roots *x509.CertPool) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return errors.New("the server sent no certificate")
+		}
+		certs := make([]*x509.Certificate, len(rawCerts))
+		for i, raw := range rawCerts {
+			cert, err := x509.ParseCertificate(raw)
+			if err != nil {
+				return errors.Wrap(err, "failed to parse the certificate of the server")
+			}
+			certs[i] = cert
+		}
+		intermediates := x509.NewCertPool()
+		for _, cert := range certs[1:] {
+			intermediates.AddCert(cert)
+		}
+		_, err := certs[0].Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates})
+		return err
+	}
+}
+
+// sslDialer dials the database, switching the connection to TLS if the TLS configuration is set.
+type sslDialer struct {
+	tlsConfig *tls.Config
+}
+
+func (d *sslDialer) Dial(network, address string) (net.Conn, error) {
+	return d.DialTimeout(network, address, connectTimeout)
+}
+
+func (d *sslDialer) DialTimeout(network, address string, timeout time.Duration) (net.Conn, error) {
+	conn, err := net.DialTimeout(network, address, timeout)
+	if err != nil || d.tlsConfig == nil {
+		return conn, err
+	}
+	tlsConn, err := d.startTLS(conn, address, timeout)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return tlsConn, nil
+}
+
+// startTLS asks the server to switch the connection to TLS and makes the handshake.
+func (d *sslDialer) startTLS(conn net.Conn, address string, timeout time.Duration) (net.Conn, error) {
+	if timeout > 0 {
+		if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := conn.Write(sslRequest); err != nil {
+		return nil, errors.Wrap(err, "failed to request tls")
+	}
+	reply := make([]byte, 1)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return nil, errors.Wrap(err, "failed to read the reply to the tls request")
+	}
+	if reply[0] != 'S' {
+		return nil, errors.New("the server does not support tls")
+	}
+	config := d.tlsConfig.Clone()
+	if config.ServerName == "" {
+		if host, _, err := net.SplitHostPort(address); err == nil {
+			config.ServerName = host
+		}
+	}
+	tlsConn := tls.Client(conn, config)
+	if err := tlsConn.Handshake(); err != nil {
+		return nil, errors.Wrap(err, "failed the tls handshake")
+	}
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		return nil, err
+	}
+	return tlsConn, nil
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/argoproj/argo-events/common"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// ValidateEventSource validates the postgres event source
+func (listener *EventListener) ValidateEventSource(ctx context.Context) error {
+	return validate(&listener.PostgresEventSource)
+}
+
+func validate(eventSource *v1alpha1.PostgresEventSource) error {
+	if eventSource == nil {
+		return common.ErrNilEventSource
+	}
+	if eventSource.DSN == nil {
+		return fmt.Errorf("dsn must be specified")
+	}
+	if len(eventSource.Channels) == 0 {
+		return fmt.Errorf("at least one channel must be specified")
+	}
+	for _, channel := range eventSource.Channels {
+		if channel == "" {
+			return fmt.Errorf("channels must not be empty")
+		}
+	}
+	switch eventSource.SSLMode {
+	case "", sslModeDisable, sslModeRequire:
+	case sslModeVerifyCA, sslModeVerifyFull:
+		if eventSource.TLS != nil && eventSource.TLS.InsecureSkipVerify {
+			return fmt.Errorf("sslMode %s verifies the certificates, it conflicts with insecureSkipVerify", eventSource.SSLMode)
+		}
+	default:
+		return fmt.Errorf("sslMode must be one of %s, %s, %s or %s", sslModeDisable, sslModeRequire, sslModeVerifyCA, sslModeVerifyFull)
+	}
+	if eventSource.TLS != nil {
+		return apicommon.ValidateTLSConfig(eventSource.TLS)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj/argo-events/eventsources/sources"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestValidateEventSource(t *testing.T) {
+	listener := &EventListener{}
+
+	err := listener.ValidateEventSource(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, "dsn must be specified", err.Error())
+
+	content, err := ioutil.ReadFile(fmt.Sprintf("%s/%s", sources.EventSourceDir, "postgres.yaml"))
+	assert.Nil(t, err)
+
+	var eventSource *v1alpha1.EventSource
+	err = yaml.Unmarshal(content, &eventSource)
+	assert.Nil(t, err)
+	assert.NotNil(t, eventSource.Spec.Postgres)
+
+	for _, value := range eventSource.Spec.Postgres {
+		l := &EventListener{
+			PostgresEventSource: value,
+		}
+		err := l.ValidateEventSource(context.Background())
+		assert.NoError(t, err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() *v1alpha1.PostgresEventSource {
+		return &v1alpha1.PostgresEventSource{
+			DSN:      &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "postgres"}, Key: "dsn"},
+			Channels: []string{"orders"},
+		}
+	}
+	assert.NoError(t, validate(valid()))
+
+	tests := []struct {
+		name   string
+		modify func(*v1alpha1.PostgresEventSource)
+		err    string
+	}{
+		{"no channel", func(s *v1alpha1.PostgresEventSource) { s.Channels = nil }, "at least one channel must be specified"},
+		{"empty channel", func(s *v1alpha1.PostgresEventSource) { s.Channels = []string{"orders", ""} }, "channels must not be empty"},
+		{"unknown ssl mode", func(s *v1alpha1.PostgresEventSource) { s.SSLMode = "prefer" }, "sslMode must be one of disable, require, verify-ca or verify-full"},
+		{"insecure verification", func(s *v1alpha1.PostgresEventSource) {
+			s.SSLMode = sslModeVerifyFull
+			s.TLS = &apicommon.TLSConfig{InsecureSkipVerify: true}
+		}, "sslMode verify-full verifies the certificates, it conflicts with insecureSkipVerify"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			eventSource := valid()
+			test.modify(eventSource)
+			err := validate(eventSource)
+			assert.Error(t, err)
+			assert.Equal(t, test.err, err.Error())
+		})
+	}
+}
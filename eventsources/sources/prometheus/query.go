@@ -0,0 +1,157 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// maxResponseBytes bounds the size of the query responses read
+const maxResponseBytes = 16 << 20
+
+// sample is a sample of the result of an instant query
+type sample struct {
+	labels    map[string]string
+	value     float64
+	timestamp time.Time
+}
+
+// queryResponse is the response of the instant query API
+type queryResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// query evaluates the PromQL query at the current time against the Prometheus server
+func query(ctx context.Context, client *http.Client, endpoint, q string) ([]sample, error) {
+	form := url.Values{"query": []string{q}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(endpoint, "/")+"/api/v1/query", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the query request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query prometheus")
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the query response")
+	}
+	return parseQueryResponse(resp.StatusCode, body)
+}
+
+// parseQueryResponse parses the samples of an instant vector or a scalar result
+func parseQueryResponse(statusCode int, body []byte) ([]sample, error) {
+	var resp queryResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, errors.Errorf("unexpected query response with status %d", statusCode)
+	}
+	if resp.Status != "success" {
+		return nil, errors.Errorf("query failed with status %d: %s: %s", statusCode, resp.ErrorType, resp.Error)
+	}
+	switch resp.Data.ResultType {
+	case "vector":
+		var vector []struct {
+			Metric map[string]string `json:"metric"`
+			Value  []interface{}     `json:"value"`
+		}
+		if err := json.Unmarshal(resp.Data.Result, &vector); err != nil {
+			return nil, errors.Wrap(err, "failed to parse the vector result")
+		}
+		samples := make([]sample, 0, len(vector))
+		for _, v := range vector {
+			s, err := parseSample(v.Value)
+			if err != nil {
+				return nil, err
+			}
+			s.labels = v.Metric
+			if s.labels == nil {
+				s.labels = map[string]string{}
+			}
+			samples = append(samples, s)
+		}
+		return samples, nil
+	case "scalar":
+		var value []interface{}
+		if err := json.Unmarshal(resp.Data.Result, &value); err != nil {
+			return nil, errors.Wrap(err, "failed to parse the scalar result")
+		}
+		s, err := parseSample(value)
+		if err != nil {
+			return nil, err
+		}
+		s.labels = map[string]string{}
+		return []sample{s}, nil
+	default:
+		return nil, errors.Errorf("unsupported result type %s, the query must return an instant vector or a scalar", resp.Data.ResultType)
+	}
+}
+
+// parseSample parses a [<unix time>, "<value>"] pair
+func parseSample(pair []interface{}) (sample, error) {
+	if len(pair) != 2 {
+		return sample{}, errors.New("malformed sample")
+	}
+	ts, ok := pair[0].(float64)
+	if !ok {
+		return sample{}, errors.New("malformed sample timestamp")
+	}
+	str, ok := pair[1].(string)
+	if !ok {
+		return sample{}, errors.New("malformed sample value")
+	}
+	value, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return sample{}, errors.Wrap(err, "malformed sample value")
+	}
+	sec := int64(ts)
+	return sample{value: value, timestamp: time.Unix(sec, int64((ts-float64(sec))*1e9)).UTC()}, nil
+}
+
+// seriesKey identifies the series of the labels
+func seriesKey(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name)
+		b.WriteByte('=')
+		b.WriteString(strconv.Quote(labels[name]))
+		b.WriteByte(',')
+	}
+	return b.String()
+}
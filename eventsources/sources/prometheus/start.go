@@ -0,0 +1,211 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/argoproj/argo-events/common"
+	"github.com/argoproj/argo-events/common/logging"
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/eventsources/sources"
+	metrics "github.com/argoproj/argo-events/metrics"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+const (
+	// defaultInterval is how often the query is evaluated by default
+	defaultInterval = time.Minute
+
+	comparisonGreater = ">"
+	comparisonLess    = "<"
+	comparisonEqual   = "=="
+)
+
+// EventListener implements Eventing for the Prometheus event source
+type EventListener struct {
+	EventSourceName       string
+	EventName             string
+	PrometheusEventSource v1alpha1.PrometheusEventSource
+	Metrics               *metrics.Metrics
+}
+
+// GetEventSourceName returns name of event source
+func (el *EventListener) GetEventSourceName() string {
+	return el.EventSourceName
+}
+
+// GetEventName returns name of event
+func (el *EventListener) GetEventName() string {
+	return el.EventName
+}
+
+// GetEventSourceType return type of event server
+func (el *EventListener) GetEventSourceType() apicommon.EventSourceType {
+	return apicommon.PrometheusEvent
+}
+
+// StartListening evaluates the query on each interval and dispatches the samples crossing the threshold
+func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byte, ...eventsourcecommon.Options) error) error {
+	log := logging.FromContext(ctx).
+		With(logging.LabelEventSourceType, el.GetEventSourceType(), logging.LabelEventName, el.GetEventName())
+	log.Info("started processing the Prometheus event source...")
+	defer sources.Recover(el.GetEventName())
+
+	prometheusEventSource := &el.PrometheusEventSource
+
+	interval := defaultInterval
+	if prometheusEventSource.Interval != "" {
+		d, err := time.ParseDuration(prometheusEventSource.Interval)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse the interval %s", prometheusEventSource.Interval)
+		}
+		interval = d
+	}
+	threshold, err := prometheusEventSource.Threshold.Float64()
+	if err != nil {
+		return errors.Wrap(err, "failed to parse the threshold")
+	}
+	comparison := prometheusEventSource.Comparison
+	if comparison == "" {
+		comparison = comparisonGreater
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if prometheusEventSource.TLS != nil {
+		tlsConfig, err := common.GetTLSConfig(prometheusEventSource.TLS, log)
+		if err != nil {
+			return errors.Wrap(err, "failed to get the tls configuration")
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+	// an evaluation must complete before the next one is due
+	client := &http.Client{Transport: transport, Timeout: interval}
+
+	crossed := newCrossings()
+	evaluate := func() {
+		samples, err := query(ctx, client, prometheusEventSource.Endpoint, prometheusEventSource.Query)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Errorw("failed to evaluate the query", zap.Error(err))
+			el.Metrics.EventProcessingFailed(el.GetEventSourceName(), el.GetEventName())
+			return
+		}
+		for _, s := range crossed.update(samples, func(value float64) bool {
+			return compare(value, comparison, threshold)
+		}) {
+			el.dispatchSample(s, comparison, threshold, dispatch, log)
+		}
+	}
+
+	log.Infow("evaluating the query...", zap.String("query", prometheusEventSource.Query), zap.Duration("interval", interval))
+	el.Metrics.EventSourceActive(el.GetEventSourceName(), el.GetEventName(), true)
+	defer el.Metrics.EventSourceActive(el.GetEventSourceName(), el.GetEventName(), false)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		evaluate()
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			log.Info("event source is stopped")
+			return nil
+		}
+	}
+}
+
+// dispatchSample dispatches the event of a sample which started comparing to the threshold
+func (el *EventListener) dispatchSample(s sample, comparison string, threshold float64, dispatch func([]byte, ...eventsourcecommon.Options) error, log *zap.SugaredLogger) {
+	defer func(start time.Time) {
+		el.Metrics.EventProcessingDuration(el.GetEventSourceName(), el.GetEventName(), float64(time.Since(start)/time.Millisecond))
+	}(time.Now())
+
+	eventBytes, err := json.Marshal(&events.PrometheusEventData{
+		Query:      el.PrometheusEventSource.Query,
+		Value:      s.value,
+		Labels:     s.labels,
+		Timestamp:  s.timestamp,
+		Comparison: comparison,
+		Threshold:  threshold,
+		Metadata:   el.PrometheusEventSource.Metadata,
+	})
+	if err != nil {
+		log.Errorw("failed to marshal the event data", zap.Error(err))
+		el.Metrics.EventProcessingFailed(el.GetEventSourceName(), el.GetEventName())
+		return
+	}
+	log.Infow("dispatching the sample crossing the threshold...", zap.Any("labels", s.labels), zap.Float64("value", s.value))
+	if err = dispatch(eventBytes); err != nil {
+		log.Errorw("failed to dispatch the event", zap.Error(err))
+		el.Metrics.EventProcessingFailed(el.GetEventSourceName(), el.GetEventName())
+	}
+}
+
+// compare tells whether the value compares to the threshold, NaN never does
+func compare(value float64, comparison string, threshold float64) bool {
+	if math.IsNaN(value) {
+		return false
+	}
+	switch comparison {
+	case comparisonLess:
+		return value < threshold
+	case comparisonEqual:
+		return value == threshold
+	default:
+		return value > threshold
+	}
+}
+
+// crossings remembers the series whose sample compared to the threshold at the last evaluation, so that
+// a single event is dispatched each time a series crosses the threshold rather than on every evaluation.
+type crossings struct {
+	matching map[string]bool
+}
+
+func newCrossings() *crossings {
+	return &crossings{matching: make(map[string]bool)}
+}
+
+// update records the samples of an evaluation and returns the ones which started comparing to the threshold.
+// A series which stops comparing, or disappears from the result, crosses again on its next comparing sample.
+func (c *crossings) update(samples []sample, matches func(float64) bool) []sample {
+	var result []sample
+	matching := make(map[string]bool)
+	for _, s := range samples {
+		if !matches(s.value) {
+			continue
+		}
+		key := seriesKey(s.labels)
+		matching[key] = true
+		if !c.matching[key] {
+			result = append(result, s)
+		}
+	}
+	c.matching = matching
+	return result
+}
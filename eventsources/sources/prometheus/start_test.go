@@ -0,0 +1,149 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	metrics "github.com/argoproj/argo-events/metrics"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestParseQueryResponse(t *testing.T) {
+	t.Run("vector", func(t *testing.T) {
+		samples, err := parseQueryResponse(http.StatusOK, []byte(`{"status":"success","data":{"resultType":"vector","result":[
+			{"metric":{"job":"api"},"value":[1600000000.5,"0.75"]},
+			{"metric":{"job":"web"},"value":[1600000000.5,"NaN"]}]}}`))
+		assert.NoError(t, err)
+		assert.Len(t, samples, 2)
+		assert.Equal(t, map[string]string{"job": "api"}, samples[0].labels)
+		assert.Equal(t, 0.75, samples[0].value)
+		assert.Equal(t, time.Unix(1600000000, 5e8).UTC(), samples[0].timestamp)
+		assert.True(t, math.IsNaN(samples[1].value))
+	})
+
+	t.Run("scalar", func(t *testing.T) {
+		samples, err := parseQueryResponse(http.StatusOK, []byte(`{"status":"success","data":{"resultType":"scalar","result":[1600000000,"2"]}}`))
+		assert.NoError(t, err)
+		assert.Len(t, samples, 1)
+		assert.Equal(t, 2.0, samples[0].value)
+		assert.Empty(t, samples[0].labels)
+	})
+
+	t.Run("matrix", func(t *testing.T) {
+		_, err := parseQueryResponse(http.StatusOK, []byte(`{"status":"success","data":{"resultType":"matrix","result":[]}}`))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported result type matrix")
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := parseQueryResponse(http.StatusBadRequest, []byte(`{"status":"error","errorType":"bad_data","error":"parse error"}`))
+		assert.Error(t, err)
+		assert.Equal(t, "query failed with status 400: bad_data: parse error", err.Error())
+
+		_, err = parseQueryResponse(http.StatusBadGateway, []byte(`<html></html>`))
+		assert.Error(t, err)
+		assert.Equal(t, "unexpected query response with status 502", err.Error())
+	})
+}
+
+func TestCompare(t *testing.T) {
+	assert.True(t, compare(2, comparisonGreater, 1))
+	assert.False(t, compare(1, comparisonGreater, 1))
+	assert.True(t, compare(0, comparisonLess, 1))
+	assert.False(t, compare(1, comparisonLess, 1))
+	assert.True(t, compare(1, comparisonEqual, 1))
+	assert.False(t, compare(math.NaN(), comparisonLess, 1))
+	assert.True(t, compare(2, "", 1))
+}
+
+func TestCrossings(t *testing.T) {
+	c := newCrossings()
+	matches := func(value float64) bool { return compare(value, comparisonGreater, 1) }
+	api := func(value float64) sample { return sample{labels: map[string]string{"job": "api"}, value: value} }
+	web := func(value float64) sample { return sample{labels: map[string]string{"job": "web"}, value: value} }
+
+	assert.Len(t, c.update([]sample{api(2), web(0)}, matches), 1)
+	// still above the threshold, no new crossing
+	assert.Empty(t, c.update([]sample{api(3), web(0)}, matches))
+	crossed := c.update([]sample{api(3), web(2)}, matches)
+	assert.Len(t, crossed, 1)
+	assert.Equal(t, "web", crossed[0].labels["job"])
+	// back below the threshold, then above again
+	assert.Empty(t, c.update([]sample{api(0), web(2)}, matches))
+	assert.Len(t, c.update([]sample{api(2), web(2)}, matches), 1)
+	// a series missing from the result crosses again when it comes back
+	assert.Empty(t, c.update([]sample{web(2)}, matches))
+	assert.Len(t, c.update([]sample{api(2), web(2)}, matches), 1)
+}
+
+func TestStartListening(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/query", r.URL.Path)
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "up", r.PostForm.Get("query"))
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[
+			{"metric":{"job":"api"},"value":[1600000000,"0"]},
+			{"metric":{"job":"web"},"value":[1600000000,"1"]}]}}`))
+	}))
+	defer server.Close()
+
+	threshold := apicommon.NewAmount("0")
+	el := &EventListener{
+		EventSourceName: "prometheus",
+		EventName:       "example",
+		PrometheusEventSource: v1alpha1.PrometheusEventSource{
+			Endpoint:   server.URL,
+			Query:      "up",
+			Interval:   "20ms",
+			Threshold:  &threshold,
+			Comparison: comparisonEqual,
+			Metadata:   map[string]string{"severity": "critical"},
+		},
+		Metrics: metrics.NewMetrics("ns"),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	var dispatched [][]byte
+	err := el.StartListening(ctx, func(data []byte, opts ...eventsourcecommon.Options) error {
+		dispatched = append(dispatched, data)
+		return nil
+	})
+	assert.NoError(t, err)
+	// the series is dispatched once however many times it is evaluated
+	assert.Len(t, dispatched, 1)
+
+	var data events.PrometheusEventData
+	assert.NoError(t, json.Unmarshal(dispatched[0], &data))
+	assert.Equal(t, "up", data.Query)
+	assert.Equal(t, 0.0, data.Value)
+	assert.Equal(t, map[string]string{"job": "api"}, data.Labels)
+	assert.Equal(t, comparisonEqual, data.Comparison)
+	assert.Equal(t, "critical", data.Metadata["severity"])
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prometheus
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/argoproj/argo-events/common"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// ValidateEventSource validates the Prometheus event source
+func (listener *EventListener) ValidateEventSource(ctx context.Context) error {
+	return validate(&listener.PrometheusEventSource)
+}
+
+func validate(eventSource *v1alpha1.PrometheusEventSource) error {
+	if eventSource == nil {
+		return common.ErrNilEventSource
+	}
+	if eventSource.Endpoint == "" {
+		return errors.New("endpoint must be specified")
+	}
+	if u, err := url.Parse(eventSource.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("endpoint must be an http or https url")
+	}
+	if eventSource.Query == "" {
+		return errors.New("query must be specified")
+	}
+	if eventSource.Interval != "" {
+		d, err := time.ParseDuration(eventSource.Interval)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse interval")
+		}
+		if d <= 0 {
+			return errors.New("interval must be positive")
+		}
+	}
+	if eventSource.Threshold == nil {
+		return errors.New("threshold must be specified")
+	}
+	if _, err := eventSource.Threshold.Float64(); err != nil {
+		return errors.Wrap(err, "failed to parse threshold")
+	}
+	switch eventSource.Comparison {
+	case "", comparisonGreater, comparisonLess, comparisonEqual:
+	default:
+		return errors.Errorf("comparison must be one of %s, %s or %s", comparisonGreater, comparisonLess, comparisonEqual)
+	}
+	if eventSource.TLS != nil {
+		return apicommon.ValidateTLSConfig(eventSource.TLS)
+	}
+	return nil
+}
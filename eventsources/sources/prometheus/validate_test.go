@@ -0,0 +1,80 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-events/eventsources/sources"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestValidateEventSource(t *testing.T) {
+	listener := &EventListener{}
+
+	err := listener.ValidateEventSource(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, "endpoint must be specified", err.Error())
+
+	content, err := ioutil.ReadFile(fmt.Sprintf("%s/%s", sources.EventSourceDir, "prometheus.yaml"))
+	assert.Nil(t, err)
+
+	var eventSource *v1alpha1.EventSource
+	err = yaml.Unmarshal(content, &eventSource)
+	assert.Nil(t, err)
+	assert.NotNil(t, eventSource.Spec.Prometheus)
+
+	for _, value := range eventSource.Spec.Prometheus {
+		l := &EventListener{
+			PrometheusEventSource: value,
+		}
+		err := l.ValidateEventSource(context.Background())
+		assert.NoError(t, err)
+
+		threshold, err := l.PrometheusEventSource.Threshold.Float64()
+		assert.NoError(t, err)
+		assert.Equal(t, 0.5, threshold)
+
+		l.PrometheusEventSource.Comparison = ">="
+		err = l.ValidateEventSource(context.Background())
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "comparison must be one of")
+
+		l.PrometheusEventSource.Comparison = "<"
+		l.PrometheusEventSource.Interval = "0s"
+		err = l.ValidateEventSource(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, "interval must be positive", err.Error())
+
+		l.PrometheusEventSource.Interval = ""
+		l.PrometheusEventSource.Threshold = nil
+		err = l.ValidateEventSource(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, "threshold must be specified", err.Error())
+
+		l.PrometheusEventSource.Endpoint = "prometheus:9090"
+		err = l.ValidateEventSource(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, "endpoint must be an http or https url", err.Error())
+	}
+}
@@ -130,7 +130,7 @@ func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byt
 
 	var client pulsar.Client
 
-	if err := common.Connect(pulsarEventSource.ConnectionBackoff, func() error {
+	if err := common.ConnectWithContext(ctx, pulsarEventSource.ConnectionBackoff, func() error {
 		var err error
 		if client, err = pulsar.NewClient(clientOpt); err != nil {
 			return err
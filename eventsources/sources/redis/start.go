@@ -82,7 +82,7 @@ func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byt
 	}
 
 	if redisEventSource.TLS != nil {
-		tlsConfig, err := common.GetTLSConfig(redisEventSource.TLS)
+		tlsConfig, err := common.GetTLSConfig(redisEventSource.TLS, log)
 		if err != nil {
 			return errors.Wrap(err, "failed to get the tls configuration")
 		}
@@ -0,0 +1,305 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redisstream
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/argoproj/argo-events/common"
+	"github.com/argoproj/argo-events/common/logging"
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/eventsources/sources"
+	metrics "github.com/argoproj/argo-events/metrics"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+const (
+	// defaultStartID makes a new consumer group read the entries added after its creation only
+	defaultStartID = "$"
+	// readCount is the maximum number of entries read or claimed at once
+	readCount = 10
+	// readBlock is how long a read waits for new entries, it bounds the time to notice the event source stopping
+	readBlock = time.Second
+)
+
+// EventListener implements Eventing for the Redis Streams event source
+type EventListener struct {
+	EventSourceName        string
+	EventName              string
+	RedisStreamEventSource v1alpha1.RedisStreamEventSource
+	Metrics                *metrics.Metrics
+}
+
+// GetEventSourceName returns name of event source
+func (el *EventListener) GetEventSourceName() string {
+	return el.EventSourceName
+}
+
+// GetEventName returns name of event
+func (el *EventListener) GetEventName() string {
+	return el.EventName
+}
+
+// GetEventSourceType return type of event server
+func (el *EventListener) GetEventSourceType() apicommon.EventSourceType {
+	return apicommon.RedisStreamEvent
+}
+
+// StartListening reads the entries of the stream as a member of the consumer group
+func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byte, ...eventsourcecommon.Options) error) error {
+	log := logging.FromContext(ctx).
+		With(logging.LabelEventSourceType, el.GetEventSourceType(), logging.LabelEventName, el.GetEventName())
+	log.Info("started processing the Redis Streams event source...")
+	defer sources.Recover(el.GetEventName())
+
+	redisEventSource := &el.RedisStreamEventSource
+
+	opt := &redis.Options{
+		Addr: redisEventSource.HostAddress,
+		DB:   int(redisEventSource.DB),
+	}
+
+	log.Info("retrieving password if it has been configured...")
+	if redisEventSource.Password != nil {
+		password, err := common.GetSecretFromVolume(redisEventSource.Password)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find the secret password %s", redisEventSource.Password.Name)
+		}
+		opt.Password = password
+	}
+
+	if redisEventSource.TLS != nil {
+		tlsConfig, err := common.GetTLSConfig(redisEventSource.TLS, log)
+		if err != nil {
+			return errors.Wrap(err, "failed to get the tls configuration")
+		}
+		opt.TLSConfig = tlsConfig
+	}
+
+	consumer := redisEventSource.Consumer
+	if consumer == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return errors.Wrap(err, "failed to get the host name to use as consumer name")
+		}
+		consumer = hostname
+	}
+
+	var claimIdle time.Duration
+	if redisEventSource.MaxClaimIdle != "" {
+		d, err := time.ParseDuration(redisEventSource.MaxClaimIdle)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse the max claim idle %s", redisEventSource.MaxClaimIdle)
+		}
+		claimIdle = d
+	}
+
+	log.Info("setting up a redis client...")
+	client := redis.NewClient(opt)
+	defer client.Close()
+
+	if status := client.Ping(); status.Err() != nil {
+		return errors.Wrapf(status.Err(), "failed to connect to host %s and db %d for event source %s", redisEventSource.HostAddress, redisEventSource.DB, el.GetEventName())
+	}
+
+	startID := redisEventSource.StartID
+	if startID == "" {
+		startID = defaultStartID
+	}
+	log.Infow("creating the consumer group if it doesn't exist...", zap.String("stream", redisEventSource.Stream), zap.String("group", redisEventSource.Group))
+	if err := client.XGroupCreateMkStream(redisEventSource.Stream, redisEventSource.Group, startID).Err(); err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return errors.Wrapf(err, "failed to create the consumer group %s of the stream %s", redisEventSource.Group, redisEventSource.Stream)
+	}
+
+	process := func(message redis.XMessage) {
+		if err := el.handleOne(client, message, dispatch, log); err != nil {
+			log.With("id", message.ID).Errorw("failed to process a Redis stream entry", zap.Error(err))
+			el.Metrics.EventProcessingFailed(el.GetEventSourceName(), el.GetEventName())
+		}
+	}
+
+	el.Metrics.EventSourceActive(el.GetEventSourceName(), el.GetEventName(), true)
+	defer el.Metrics.EventSourceActive(el.GetEventSourceName(), el.GetEventName(), false)
+
+	// the entries delivered to this consumer but not acknowledged, e.g. before a restart, are read first,
+	// from the pending entries list, then the new ones are read.
+	pendingID := "0"
+	claimCursor := "0-0"
+	var lastClaim time.Time
+	log.Infow("reading the stream...", zap.String("consumer", consumer))
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("event source is stopped")
+			return nil
+		default:
+		}
+
+		if claimIdle > 0 && time.Since(lastClaim) >= claimIdle {
+			lastClaim = time.Now()
+			next, messages, err := el.autoClaim(client, consumer, claimIdle, claimCursor)
+			if err != nil {
+				log.Errorw("failed to claim the pending entries of the group", zap.Error(err))
+			} else {
+				claimCursor = next
+				if len(messages) > 0 {
+					log.Infow("claimed idle pending entries", zap.Int("count", len(messages)))
+				}
+				for _, message := range messages {
+					process(message)
+				}
+			}
+		}
+
+		id := ">"
+		if pendingID != "" {
+			id = pendingID
+		}
+		streams, err := client.XReadGroup(&redis.XReadGroupArgs{
+			Group:    redisEventSource.Group,
+			Consumer: consumer,
+			Streams:  []string{redisEventSource.Stream, id},
+			Count:    readCount,
+			Block:    readBlock,
+		}).Result()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to read the stream %s", redisEventSource.Stream)
+		}
+		read := 0
+		for _, stream := range streams {
+			for _, message := range stream.Messages {
+				read++
+				process(message)
+				if pendingID != "" {
+					// an entry failing again stays pending, the next read starts after it
+					pendingID = message.ID
+				}
+			}
+		}
+		if pendingID != "" && read == 0 {
+			pendingID = ""
+		}
+	}
+}
+
+// autoClaim transfers up to readCount pending entries of the group idle for longer than minIdle to the consumer,
+// starting from the cursor. It returns the cursor of the next call along with the claimed entries.
+func (el *EventListener) autoClaim(client *redis.Client, consumer string, minIdle time.Duration, cursor string) (string, []redis.XMessage, error) {
+	redisEventSource := &el.RedisStreamEventSource
+	reply, err := client.Do("XAUTOCLAIM", redisEventSource.Stream, redisEventSource.Group, consumer,
+		int64(minIdle/time.Millisecond), cursor, "COUNT", readCount).Result()
+	if err != nil {
+		return "", nil, err
+	}
+	next, messages, deleted, err := parseAutoClaim(reply)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(deleted) > 0 {
+		// the entries deleted from the stream while pending can't be processed anymore
+		if err := client.XAck(redisEventSource.Stream, redisEventSource.Group, deleted...).Err(); err != nil {
+			return "", nil, errors.Wrap(err, "failed to acknowledge the deleted entries")
+		}
+	}
+	return next, messages, nil
+}
+
+// parseAutoClaim parses the reply of XAUTOCLAIM into the next cursor, the claimed entries and the IDs of
+// the claimed entries which have been deleted from the stream.
+func parseAutoClaim(reply interface{}) (string, []redis.XMessage, []string, error) {
+	fields, ok := reply.([]interface{})
+	if !ok || len(fields) < 2 {
+		return "", nil, nil, errors.Errorf("unexpected XAUTOCLAIM reply %v", reply)
+	}
+	next, ok := fields[0].(string)
+	if !ok {
+		return "", nil, nil, errors.Errorf("unexpected XAUTOCLAIM cursor %v", fields[0])
+	}
+	entries, ok := fields[1].([]interface{})
+	if !ok {
+		return "", nil, nil, errors.Errorf("unexpected XAUTOCLAIM entries %v", fields[1])
+	}
+	var messages []redis.XMessage
+	var deleted []string
+	for _, e := range entries {
+		entry, ok := e.([]interface{})
+		if !ok || len(entry) != 2 {
+			return "", nil, nil, errors.Errorf("unexpected XAUTOCLAIM entry %v", e)
+		}
+		id, ok := entry[0].(string)
+		if !ok {
+			return "", nil, nil, errors.Errorf("unexpected XAUTOCLAIM entry ID %v", entry[0])
+		}
+		pairs, ok := entry[1].([]interface{})
+		if !ok {
+			// Redis 6.2 returns the entries deleted from the stream without values
+			deleted = append(deleted, id)
+			continue
+		}
+		values := make(map[string]interface{}, len(pairs)/2)
+		for i := 0; i+1 < len(pairs); i += 2 {
+			field, ok := pairs[i].(string)
+			if !ok {
+				return "", nil, nil, errors.Errorf("unexpected XAUTOCLAIM entry field %v", pairs[i])
+			}
+			values[field] = pairs[i+1]
+		}
+		messages = append(messages, redis.XMessage{ID: id, Values: values})
+	}
+	return next, messages, deleted, nil
+}
+
+func (el *EventListener) handleOne(client *redis.Client, message redis.XMessage, dispatch func([]byte, ...eventsourcecommon.Options) error, log *zap.SugaredLogger) error {
+	defer func(start time.Time) {
+		el.Metrics.EventProcessingDuration(el.GetEventSourceName(), el.GetEventName(), float64(time.Since(start)/time.Millisecond))
+	}(time.Now())
+
+	redisEventSource := &el.RedisStreamEventSource
+	log.With("id", message.ID).Info("received a stream entry")
+	eventData := &events.RedisStreamEventData{
+		Stream:   redisEventSource.Stream,
+		ID:       message.ID,
+		Values:   message.Values,
+		Metadata: redisEventSource.Metadata,
+	}
+	eventBody, err := json.Marshal(eventData)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the event data, rejecting the event...")
+	}
+	log.With("id", message.ID).Info("dispatching the event on the data channel...")
+	if err = dispatch(eventBody); err != nil {
+		// the entry stays pending, it is read again on restart or claimed by another consumer
+		return errors.Wrap(err, "failed to dispatch a Redis stream event")
+	}
+	if err = client.XAck(redisEventSource.Stream, redisEventSource.Group, message.ID).Err(); err != nil {
+		return errors.Wrapf(err, "failed to acknowledge the entry %s", message.ID)
+	}
+	return nil
+}
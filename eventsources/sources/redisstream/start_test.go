@@ -0,0 +1,51 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redisstream
+
+import (
+	"testing"
+
+	"github.com/go-redis/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAutoClaim(t *testing.T) {
+	reply := []interface{}{
+		"1631234567890-3",
+		[]interface{}{
+			[]interface{}{"1631234567890-0", []interface{}{"sensor", "kitchen", "temp", "21"}},
+			[]interface{}{"1631234567890-1", nil},
+		},
+		[]interface{}{},
+	}
+	next, messages, deleted, err := parseAutoClaim(reply)
+	assert.NoError(t, err)
+	assert.Equal(t, "1631234567890-3", next)
+	assert.Equal(t, []redis.XMessage{{ID: "1631234567890-0", Values: map[string]interface{}{"sensor": "kitchen", "temp": "21"}}}, messages)
+	assert.Equal(t, []string{"1631234567890-1"}, deleted)
+
+	next, messages, deleted, err = parseAutoClaim([]interface{}{"0-0", []interface{}{}})
+	assert.NoError(t, err)
+	assert.Equal(t, "0-0", next)
+	assert.Empty(t, messages)
+	assert.Empty(t, deleted)
+
+	_, _, _, err = parseAutoClaim("OK")
+	assert.Error(t, err)
+	_, _, _, err = parseAutoClaim([]interface{}{"0-0", []interface{}{"malformed"}})
+	assert.Error(t, err)
+}
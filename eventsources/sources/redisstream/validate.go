@@ -0,0 +1,61 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redisstream
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/argoproj/argo-events/common"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// ValidateEventSource validates the Redis Streams event source
+func (listener *EventListener) ValidateEventSource(ctx context.Context) error {
+	return validate(&listener.RedisStreamEventSource)
+}
+
+func validate(eventSource *v1alpha1.RedisStreamEventSource) error {
+	if eventSource == nil {
+		return common.ErrNilEventSource
+	}
+	if eventSource.HostAddress == "" {
+		return errors.New("host address must be specified")
+	}
+	if eventSource.Stream == "" {
+		return errors.New("stream must be specified")
+	}
+	if eventSource.Group == "" {
+		return errors.New("consumer group must be specified")
+	}
+	if eventSource.MaxClaimIdle != "" {
+		d, err := time.ParseDuration(eventSource.MaxClaimIdle)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse max claim idle")
+		}
+		if d <= 0 {
+			return errors.New("max claim idle must be positive")
+		}
+	}
+	if eventSource.TLS != nil {
+		return apicommon.ValidateTLSConfig(eventSource.TLS)
+	}
+	return nil
+}
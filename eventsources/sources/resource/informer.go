@@ -0,0 +1,196 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// informers shares an informer between the event sources watching the same resources with the same selectors, so
+// that a single watch is opened on the K8s API
+var informers = eventsourcecommon.NewPool()
+
+// listOptions returns the options listing and watching the resources of the event source, the label selector
+// combines the labels of the filter with the label selector
+func listOptions(eventSource *v1alpha1.ResourceEventSource) (metav1.ListOptions, error) {
+	options := metav1.ListOptions{}
+	selector := labels.Everything()
+	if eventSource.LabelSelector != "" {
+		sel, err := labels.Parse(eventSource.LabelSelector)
+		if err != nil {
+			return options, errors.Wrapf(err, "failed to parse the label selector %q", eventSource.LabelSelector)
+		}
+		selector = sel
+	}
+	if eventSource.Filter != nil && eventSource.Filter.Labels != nil {
+		sel, err := LabelSelector(eventSource.Filter.Labels)
+		if err != nil {
+			return options, errors.Wrap(err, "failed to create the label selector of the filter")
+		}
+		requirements, _ := sel.Requirements()
+		selector = selector.Add(requirements...)
+	}
+	options.LabelSelector = selector.String()
+	if eventSource.FieldSelector != "" {
+		sel, err := fields.ParseSelector(eventSource.FieldSelector)
+		if err != nil {
+			return options, errors.Wrapf(err, "failed to parse the field selector %q", eventSource.FieldSelector)
+		}
+		options.FieldSelector = sel.String()
+	}
+	return options, nil
+}
+
+// sharedInformer runs an informer whose events are fanned out to the handlers of the event sources sharing it
+type sharedInformer struct {
+	informer cache.SharedIndexInformer
+	stopCh   chan struct{}
+
+	lock        sync.RWMutex
+	subscribers map[int]*subscriber
+	nextID      int
+}
+
+func newSharedInformer(client dynamic.Interface, gvr schema.GroupVersionResource, namespace string, options metav1.ListOptions) *sharedInformer {
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(client, 0, namespace, func(op *metav1.ListOptions) {
+		op.LabelSelector = options.LabelSelector
+		op.FieldSelector = options.FieldSelector
+	})
+	s := &sharedInformer{
+		informer:    factory.ForResource(gvr).Informer(),
+		stopCh:      make(chan struct{}),
+		subscribers: make(map[int]*subscriber),
+	}
+	s.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			s.each(func(h cache.ResourceEventHandler) { h.OnAdd(obj) })
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			s.each(func(h cache.ResourceEventHandler) { h.OnUpdate(oldObj, newObj) })
+		},
+		DeleteFunc: func(obj interface{}) {
+			s.each(func(h cache.ResourceEventHandler) { h.OnDelete(obj) })
+		},
+	})
+	go s.informer.Run(s.stopCh)
+	return s
+}
+
+// each queues the notification to the handlers of all the event sources
+func (s *sharedInformer) each(f func(h cache.ResourceEventHandler)) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	for _, sub := range s.subscribers {
+		sub.push(f)
+	}
+}
+
+// subscribe adds the handler of an event source and returns the function removing it. Like the handlers added to a
+// running informer, the handler is first notified of the addition of the resources already listed.
+func (s *sharedInformer) subscribe(handler cache.ResourceEventHandler) func() {
+	sub := newSubscriber(handler)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	// the listed resources are queued before the subscriber is added, ahead of the next notifications
+	for _, obj := range s.informer.GetStore().List() {
+		obj := obj
+		sub.push(func(h cache.ResourceEventHandler) { h.OnAdd(obj) })
+	}
+	id := s.nextID
+	s.nextID++
+	s.subscribers[id] = sub
+	return func() {
+		s.lock.Lock()
+		delete(s.subscribers, id)
+		s.lock.Unlock()
+		sub.stop()
+	}
+}
+
+// Close stops the informer once released by the last event source
+func (s *sharedInformer) Close() error {
+	close(s.stopCh)
+	return nil
+}
+
+// subscriber notifies the handler of an event source in order, from its own goroutine, so that an event source slow
+// to process the notifications doesn't hold back the informer and the other event sources sharing it
+type subscriber struct {
+	handler cache.ResourceEventHandler
+
+	lock    sync.Mutex
+	cond    *sync.Cond
+	pending []func(h cache.ResourceEventHandler)
+	stopped bool
+}
+
+func newSubscriber(handler cache.ResourceEventHandler) *subscriber {
+	sub := &subscriber{handler: handler}
+	sub.cond = sync.NewCond(&sub.lock)
+	go sub.run()
+	return sub
+}
+
+// push queues a notification, the queue is unbounded so that the informer is never blocked
+func (sub *subscriber) push(f func(h cache.ResourceEventHandler)) {
+	sub.lock.Lock()
+	defer sub.lock.Unlock()
+	if sub.stopped {
+		return
+	}
+	sub.pending = append(sub.pending, f)
+	sub.cond.Signal()
+}
+
+func (sub *subscriber) run() {
+	for {
+		sub.lock.Lock()
+		for len(sub.pending) == 0 && !sub.stopped {
+			sub.cond.Wait()
+		}
+		if sub.stopped {
+			sub.lock.Unlock()
+			return
+		}
+		f := sub.pending[0]
+		sub.pending[0] = nil
+		sub.pending = sub.pending[1:]
+		sub.lock.Unlock()
+		f(sub.handler)
+	}
+}
+
+// stop discards the pending notifications and stops notifying the handler
+func (sub *subscriber) stop() {
+	sub.lock.Lock()
+	defer sub.lock.Unlock()
+	sub.stopped = true
+	sub.pending = nil
+	sub.cond.Broadcast()
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestListOptions(t *testing.T) {
+	options, err := listOptions(&v1alpha1.ResourceEventSource{})
+	assert.NoError(t, err)
+	assert.Equal(t, metav1.ListOptions{}, options)
+
+	options, err = listOptions(&v1alpha1.ResourceEventSource{
+		LabelSelector: "app=nginx",
+		FieldSelector: "status.phase=Running",
+		Filter: &v1alpha1.ResourceFilter{
+			Labels: []v1alpha1.Selector{
+				{Key: "tier", Operation: "!=", Value: "backend"},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "app=nginx,tier!=backend", options.LabelSelector)
+	assert.Equal(t, "status.phase=Running", options.FieldSelector)
+}
+
+func TestResynced(t *testing.T) {
+	obj := func(resourceVersion string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetResourceVersion(resourceVersion)
+		return u
+	}
+	assert.True(t, resynced(obj("1"), obj("1")))
+	assert.False(t, resynced(obj("1"), obj("2")))
+}
+
+func TestSharedInformer(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, corev1.AddToScheme(scheme))
+	pod := &corev1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Name: "fake", Namespace: "fake"},
+	}
+	client := dynamicfake.NewSimpleDynamicClient(scheme, pod)
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+	informer := newSharedInformer(client, gvr, "fake", metav1.ListOptions{})
+	defer informer.Close()
+	assert.True(t, cache.WaitForCacheSync(make(chan struct{}), informer.informer.HasSynced))
+
+	added := make(chan string, 10)
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			added <- obj.(*unstructured.Unstructured).GetName()
+		},
+	}
+	unsubscribe := informer.subscribe(handler)
+	// the resources listed before subscribing are notified to the handler
+	select {
+	case name := <-added:
+		assert.Equal(t, "fake", name)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the listed pod is not notified")
+	}
+
+	unsubscribe()
+	informer.each(func(h cache.ResourceEventHandler) {
+		t.Fatal("the handler is not removed")
+	})
+}
+
+func TestSharedInformerSlowSubscriber(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, corev1.AddToScheme(scheme))
+	pod := &corev1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Name: "fake", Namespace: "fake"},
+	}
+	client := dynamicfake.NewSimpleDynamicClient(scheme, pod)
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+	informer := newSharedInformer(client, gvr, "fake", metav1.ListOptions{})
+	defer informer.Close()
+	assert.True(t, cache.WaitForCacheSync(make(chan struct{}), informer.informer.HasSynced))
+
+	// the first event source never completes the processing of a notification
+	blocked := make(chan struct{})
+	defer close(blocked)
+	unsubscribeSlow := informer.subscribe(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			<-blocked
+		},
+	})
+	defer unsubscribeSlow()
+
+	added := make(chan string, 10)
+	unsubscribe := informer.subscribe(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			added <- obj.(*unstructured.Unstructured).GetName()
+		},
+	})
+	defer unsubscribe()
+
+	other := &unstructured.Unstructured{}
+	other.SetAPIVersion("v1")
+	other.SetKind("Pod")
+	other.SetName("other")
+	other.SetNamespace("fake")
+	_, err := client.Resource(gvr).Namespace("fake").Create(context.Background(), other, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	for _, expected := range []string{"fake", "other"} {
+		select {
+		case name := <-added:
+			assert.Equal(t, expected, name)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("the pod %s is not notified", expected)
+		}
+	}
+}
@@ -19,6 +19,7 @@ package resource
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"os"
 	"regexp"
 	"strings"
@@ -33,7 +34,6 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/client-go/dynamic"
-	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/tools/cache"
 
 	"github.com/argoproj/argo-events/common"
@@ -82,17 +82,6 @@ func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byt
 		With(logging.LabelEventSourceType, el.GetEventSourceType(), logging.LabelEventName, el.GetEventName())
 	defer sources.Recover(el.GetEventName())
 
-	log.Info("setting up a K8s client")
-	kubeConfig, _ := os.LookupEnv(common.EnvVarKubeConfig)
-	restConfig, err := common.GetClientConfig(kubeConfig)
-	if err != nil {
-		return errors.Wrapf(err, "failed to get a K8s rest config for the event source %s", el.GetEventName())
-	}
-	client, err := dynamic.NewForConfig(restConfig)
-	if err != nil {
-		return errors.Wrapf(err, "failed to set up a dynamic K8s client for the event source %s", el.GetEventName())
-	}
-
 	resourceEventSource := &el.ResourceEventSource
 
 	gvr := schema.GroupVersionResource{
@@ -101,30 +90,82 @@ func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byt
 		Resource: resourceEventSource.Resource,
 	}
 
-	client.Resource(gvr)
-
-	options := &metav1.ListOptions{}
+	log.Info("configuring the label and field selectors...")
+	options, err := listOptions(resourceEventSource)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create the list options for the event source %s", el.GetEventName())
+	}
 
-	log.Info("configuring label selectors if filters are selected...")
-	if resourceEventSource.Filter != nil && resourceEventSource.Filter.Labels != nil {
-		sel, err := LabelSelector(resourceEventSource.Filter.Labels)
-		if err != nil {
-			return errors.Wrapf(err, "failed to create the label selector for the event source %s", el.GetEventName())
+	handlerFuncs := cache.ResourceEventHandlerFuncs{}
+	informerEventCh := make(chan *InformerEvent)
+	notify := func(event *InformerEvent) {
+		select {
+		case informerEventCh <- event:
+		case <-ctx.Done():
 		}
-		options.LabelSelector = sel.String()
 	}
 
-	tweakListOptions := func(op *metav1.ListOptions) {
-		*op = *options
+	for _, eventType := range resourceEventSource.EventTypes {
+		switch eventType {
+		case v1alpha1.ADD:
+			handlerFuncs.AddFunc = func(obj interface{}) {
+				log.Info("detected create event")
+				notify(&InformerEvent{
+					Obj:  obj,
+					Type: v1alpha1.ADD,
+				})
+			}
+		case v1alpha1.UPDATE:
+			handlerFuncs.UpdateFunc = func(oldObj, newObj interface{}) {
+				log.Info("detected update event")
+				if resynced(oldObj, newObj) {
+					log.Infof("rejecting update event with identical resource versions: %s", newObj.(*unstructured.Unstructured).GetResourceVersion())
+					return
+				}
+				notify(&InformerEvent{
+					Obj:    newObj,
+					OldObj: oldObj,
+					Type:   v1alpha1.UPDATE,
+				})
+			}
+		case v1alpha1.DELETE:
+			handlerFuncs.DeleteFunc = func(obj interface{}) {
+				log.Info("detected delete event")
+				// the final state of a resource whose deletion is missed while disconnected from the K8s API
+				if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+					obj = tombstone.Obj
+				}
+				notify(&InformerEvent{
+					Obj:  obj,
+					Type: v1alpha1.DELETE,
+				})
+			}
+		default:
+			return errors.Errorf("unknown event type: %s", string(eventType))
+		}
 	}
 
-	log.Info("setting up informer factory...")
-	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(client, 0, resourceEventSource.Namespace, tweakListOptions)
-
-	informer := factory.ForResource(gvr)
+	log.Info("setting up the shared informer...")
+	key := eventsourcecommon.PoolKey(resourceEventSource.Namespace, gvr.String(), options.LabelSelector, options.FieldSelector)
+	pooled, release, err := informers.Acquire(key, func() (io.Closer, error) {
+		kubeConfig, _ := os.LookupEnv(common.EnvVarKubeConfig)
+		restConfig, err := common.GetClientConfig(kubeConfig)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get a K8s rest config")
+		}
+		client, err := dynamic.NewForConfig(restConfig)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to set up a dynamic K8s client")
+		}
+		return newSharedInformer(client, gvr, resourceEventSource.Namespace, options), nil
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to set up the informer for the event source %s", el.GetEventName())
+	}
+	defer func() {
+		_ = release()
+	}()
 
-	informerEventCh := make(chan *InformerEvent)
-	stopCh := make(chan struct{})
 	startTime := time.Now()
 
 	processOne := func(event *InformerEvent) error {
@@ -138,14 +179,14 @@ func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byt
 
 		objBody, err := json.Marshal(event.Obj)
 		if err != nil {
-			return errors.Wrap(err, "failed to marshal the resource, rejecting the event...")
+			return metrics.WithReason(errors.Wrap(err, "failed to marshal the resource, rejecting the event..."), metrics.FailureReasonMarshal)
 		}
 
 		var oldObjBody []byte
 		if event.OldObj != nil {
 			oldObjBody, err = json.Marshal(event.OldObj)
 			if err != nil {
-				return errors.Wrap(err, "failed to marshal the resource, rejecting the event...")
+				return metrics.WithReason(errors.Wrap(err, "failed to marshal the resource, rejecting the event..."), metrics.FailureReasonMarshal)
 			}
 		}
 
@@ -161,11 +202,11 @@ func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byt
 
 		eventBody, err := json.Marshal(eventData)
 		if err != nil {
-			return errors.Wrap(err, "failed to marshal the event. rejecting the event...")
+			return metrics.WithReason(errors.Wrap(err, "failed to marshal the event. rejecting the event..."), metrics.FailureReasonMarshal)
 		}
 
 		if err = dispatch(eventBody); err != nil {
-			return errors.Wrap(err, "failed to dispatch a resource event")
+			return metrics.WithReason(errors.Wrap(err, "failed to dispatch a resource event"), metrics.FailureReasonDispatch)
 		}
 		return nil
 	}
@@ -177,73 +218,37 @@ func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byt
 			case event := <-informerEventCh:
 				if err := processOne(event); err != nil {
 					log.Errorw("failed to process a Resource event", zap.Error(err))
-					el.Metrics.EventProcessingFailed(el.GetEventSourceName(), el.GetEventName())
+					el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.ReasonOf(err))
 				}
-			case <-stopCh:
+			case <-ctx.Done():
 				return
 			}
 		}
 	}()
 
-	handlerFuncs := cache.ResourceEventHandlerFuncs{}
-
-	for _, eventType := range resourceEventSource.EventTypes {
-		switch eventType {
-		case v1alpha1.ADD:
-			handlerFuncs.AddFunc = func(obj interface{}) {
-				log.Info("detected create event")
-				informerEventCh <- &InformerEvent{
-					Obj:  obj,
-					Type: v1alpha1.ADD,
-				}
-			}
-		case v1alpha1.UPDATE:
-			handlerFuncs.UpdateFunc = func(oldObj, newObj interface{}) {
-				log.Info("detected update event")
-				uNewObj := newObj.(*unstructured.Unstructured)
-				uOldObj := oldObj.(*unstructured.Unstructured)
-				if uNewObj.GetResourceVersion() == uOldObj.GetResourceVersion() {
-					log.Infof("rejecting update event with identical resource versions: %s", uNewObj.GetResourceVersion())
-					return
-				}
-				informerEventCh <- &InformerEvent{
-					Obj:    newObj,
-					OldObj: oldObj,
-					Type:   v1alpha1.UPDATE,
-				}
-			}
-		case v1alpha1.DELETE:
-			handlerFuncs.DeleteFunc = func(obj interface{}) {
-				log.Info("detected delete event")
-				informerEventCh <- &InformerEvent{
-					Obj:  obj,
-					Type: v1alpha1.DELETE,
-				}
-			}
-		default:
-			stopCh <- struct{}{}
-			return errors.Errorf("unknown event type: %s", string(eventType))
-		}
-	}
-
-	sharedInformer := informer.Informer()
-	sharedInformer.AddEventHandler(handlerFuncs)
-
-	doneCh := make(chan struct{})
-
 	log.Info("running informer...")
-	sharedInformer.Run(doneCh)
+	unsubscribe := pooled.(*sharedInformer).subscribe(handlerFuncs)
+	defer unsubscribe()
 
 	<-ctx.Done()
-	doneCh <- struct{}{}
-	stopCh <- struct{}{}
-
 	log.Info("event source is stopped")
-	close(informerEventCh)
-
 	return nil
 }
 
+// resynced tells whether an update is the resync of an unchanged resource, e.g. once the informer lists the
+// resources again, rather than an actual change
+func resynced(oldObj, newObj interface{}) bool {
+	uOldObj, ok := oldObj.(*unstructured.Unstructured)
+	if !ok {
+		return false
+	}
+	uNewObj, ok := newObj.(*unstructured.Unstructured)
+	if !ok {
+		return false
+	}
+	return uNewObj.GetResourceVersion() == uOldObj.GetResourceVersion()
+}
+
 // LabelReq returns label requirements
 func LabelReq(sel v1alpha1.Selector) (*labels.Requirement, error) {
 	op := selection.Equals
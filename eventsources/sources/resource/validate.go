@@ -56,6 +56,9 @@ func validate(eventSource *v1alpha1.ResourceEventSource) error {
 			}
 		}
 	}
+	if _, err := listOptions(eventSource); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -24,6 +24,7 @@ import (
 
 	"github.com/ghodss/yaml"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/argoproj/argo-events/eventsources/sources"
 	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
@@ -53,3 +54,27 @@ func TestValidateEventSource(t *testing.T) {
 		assert.NoError(t, err)
 	}
 }
+
+func TestValidateSelectors(t *testing.T) {
+	eventSource := &v1alpha1.ResourceEventSource{
+		GroupVersionResource: metav1.GroupVersionResource{
+			Resource: "pods",
+			Version:  "v1",
+		},
+		EventTypes:    []v1alpha1.ResourceEventType{v1alpha1.ADD},
+		LabelSelector: "app=nginx,tier in (frontend,backend)",
+		FieldSelector: "status.phase=Running",
+	}
+	assert.NoError(t, validate(eventSource))
+
+	eventSource.LabelSelector = "app in nginx"
+	err := validate(eventSource)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse the label selector")
+
+	eventSource.LabelSelector = ""
+	eventSource.FieldSelector = "status.phase"
+	err = validate(eventSource)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse the field selector")
+}
@@ -2,11 +2,42 @@ package sources
 
 import (
 	"fmt"
+	"runtime/debug"
 )
 
-// Recover recovers from panics in event sources
+// PanicError is the error of an event source which panicked
+type PanicError struct {
+	// EventName is the name of the event which panicked
+	EventName string
+	// Value is the value the event source panicked with
+	Value interface{}
+	// Stack is the stack of the panic
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("event source %s panicked: %v", e.EventName, e.Value)
+}
+
+// Recover recovers from panics in event sources. It panics again with a PanicError holding the stack of the panic,
+// so that the eventing server, which recovers it with RecoverError, can restart the event source.
 func Recover(eventName string) {
 	if r := recover(); r != nil {
+		if _, ok := r.(*PanicError); ok {
+			panic(r)
+		}
 		fmt.Printf("recovered event source %s from panic. recover: %v", eventName, r)
+		panic(&PanicError{EventName: eventName, Value: r, Stack: debug.Stack()})
+	}
+}
+
+// RecoverError recovers from the panic of an event source into a PanicError set to err
+func RecoverError(eventName string, err *error) {
+	if r := recover(); r != nil {
+		panicErr, ok := r.(*PanicError)
+		if !ok {
+			panicErr = &PanicError{EventName: eventName, Value: r, Stack: debug.Stack()}
+		}
+		*err = panicErr
 	}
 }
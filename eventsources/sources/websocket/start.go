@@ -0,0 +1,251 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/argoproj/argo-events/common"
+	"github.com/argoproj/argo-events/common/logging"
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/eventsources/sources"
+	metrics "github.com/argoproj/argo-events/metrics"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+const (
+	// defaultPingInterval is the default interval of the pings sent to the server
+	defaultPingInterval = 30 * time.Second
+	// handshakeTimeout bounds the opening handshake with the server
+	handshakeTimeout = 10 * time.Second
+	// writeTimeout bounds the writing of a message or a ping to the server
+	writeTimeout = 10 * time.Second
+)
+
+const (
+	messageTypeText   = "text"
+	messageTypeBinary = "binary"
+)
+
+// EventListener implements Eventing for the WebSocket event source
+type EventListener struct {
+	EventSourceName      string
+	EventName            string
+	WebSocketEventSource v1alpha1.WebSocketEventSource
+	Metrics              *metrics.Metrics
+}
+
+// GetEventSourceName returns name of event source
+func (el *EventListener) GetEventSourceName() string {
+	return el.EventSourceName
+}
+
+// GetEventName returns name of event
+func (el *EventListener) GetEventName() string {
+	return el.EventName
+}
+
+// GetEventSourceType return type of event server
+func (el *EventListener) GetEventSourceType() apicommon.EventSourceType {
+	return apicommon.WebSocketEvent
+}
+
+// StartListening starts listening events
+func (el *EventListener) StartListening(ctx context.Context, dispatch func([]byte, ...eventsourcecommon.Options) error) error {
+	log := logging.FromContext(ctx).
+		With(logging.LabelEventSourceType, el.GetEventSourceType(), logging.LabelEventName, el.GetEventName())
+	defer sources.Recover(el.GetEventName())
+
+	log.Info("starting WebSocket event source...")
+	webSocketEventSource := &el.WebSocketEventSource
+	if err := validate(webSocketEventSource); err != nil {
+		return errors.Wrap(err, "invalid websocket event source")
+	}
+
+	if webSocketEventSource.JSONBody {
+		log.Info("assuming all events have a json body...")
+	}
+
+	dialer := &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: handshakeTimeout,
+	}
+	if webSocketEventSource.TLS != nil {
+		tlsConfig, err := common.GetTLSConfig(webSocketEventSource.TLS, log)
+		if err != nil {
+			return errors.Wrap(err, "failed to get the tls configuration")
+		}
+		dialer.TLSClientConfig = tlsConfig
+	}
+
+	pingInterval := defaultPingInterval
+	if webSocketEventSource.PingInterval != "" {
+		d, err := time.ParseDuration(webSocketEventSource.PingInterval)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse the ping interval %s", webSocketEventSource.PingInterval)
+		}
+		pingInterval = d
+	}
+
+	// the connection is made again once lost, after the first duration of the backoff
+	connect := common.ConnectWithContext
+	for {
+		var conn *websocket.Conn
+		log.Infow("connecting to the websocket...", zap.String("url", webSocketEventSource.URL))
+		if err := connect(ctx, webSocketEventSource.ConnectionBackoff, func() error {
+			c, err := el.connect(ctx, dialer)
+			if err != nil {
+				log.Errorw("failed to connect to the websocket", zap.Error(err))
+				return err
+			}
+			conn = c
+			return nil
+		}); err != nil {
+			if ctx.Err() != nil {
+				log.Info("event source is stopped")
+				return nil
+			}
+			el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonConnection)
+			return errors.Wrapf(err, "failed to connect to the websocket for event source %s", el.GetEventName())
+		}
+		log.Info("connected to the websocket")
+		el.Metrics.EventSourceActive(el.GetEventSourceName(), el.GetEventName(), true)
+		err := el.consume(ctx, conn, pingInterval, dispatch, log)
+		el.Metrics.EventSourceActive(el.GetEventSourceName(), el.GetEventName(), false)
+		if ctx.Err() != nil {
+			log.Info("event source is stopped, closing the websocket...")
+			return nil
+		}
+		log.Errorw("lost the connection to the websocket, reconnecting...", zap.Error(err))
+		el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonConnection)
+		connect = common.ReconnectWithContext
+	}
+}
+
+// connect dials the websocket and sends the initial message, if any.
+func (el *EventListener) connect(ctx context.Context, dialer *websocket.Dialer) (*websocket.Conn, error) {
+	webSocketEventSource := &el.WebSocketEventSource
+	conn, resp, err := dialer.DialContext(ctx, webSocketEventSource.URL, nil)
+	if err != nil {
+		if resp != nil {
+			return nil, errors.Wrapf(err, "failed to dial %s, status %s", webSocketEventSource.URL, resp.Status)
+		}
+		return nil, errors.Wrapf(err, "failed to dial %s", webSocketEventSource.URL)
+	}
+	if webSocketEventSource.InitialMessage != "" {
+		_ = conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(webSocketEventSource.InitialMessage)); err != nil {
+			_ = conn.Close()
+			return nil, errors.Wrap(err, "failed to send the initial message")
+		}
+	}
+	return conn, nil
+}
+
+// consume dispatches the messages received on the connection until it's lost or the event source is stopped.
+// A ping is sent every ping interval, the connection is considered lost if nothing, a pong included, is received
+// within twice the interval.
+func (el *EventListener) consume(ctx context.Context, conn *websocket.Conn, pingInterval time.Duration, dispatch func([]byte, ...eventsourcecommon.Options) error, log *zap.SugaredLogger) error {
+	defer conn.Close()
+
+	extendDeadline := func() error {
+		return conn.SetReadDeadline(time.Now().Add(2 * pingInterval))
+	}
+	conn.SetPongHandler(func(string) error {
+		return extendDeadline()
+	})
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(pingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				// the close message is best effort, closing the connection stops the reading anyway
+				_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(writeTimeout))
+				_ = conn.Close()
+				return
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout)); err != nil {
+					log.Errorw("failed to ping the websocket", zap.Error(err))
+				}
+			}
+		}
+	}()
+
+	for {
+		// the deadline is extended before every read so that a slow dispatching doesn't count against the server
+		if err := extendDeadline(); err != nil {
+			return err
+		}
+		messageType, payload, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		el.handleOne(messageType, payload, dispatch, log)
+	}
+}
+
+func (el *EventListener) handleOne(messageType int, payload []byte, dispatch func([]byte, ...eventsourcecommon.Options) error, log *zap.SugaredLogger) {
+	defer func(start time.Time) {
+		el.Metrics.EventProcessingDuration(el.GetEventSourceName(), el.GetEventName(), float64(time.Since(start)/time.Millisecond))
+	}(time.Now())
+
+	webSocketEventSource := &el.WebSocketEventSource
+	eventData := &events.WebSocketEventData{
+		URL:      webSocketEventSource.URL,
+		Metadata: webSocketEventSource.Metadata,
+	}
+	switch {
+	case webSocketEventSource.JSONBody:
+		eventData.Body = (*json.RawMessage)(&payload)
+	case messageType == websocket.TextMessage:
+		eventData.Body = string(payload)
+	default:
+		eventData.Body = payload
+	}
+	if messageType == websocket.TextMessage {
+		eventData.MessageType = messageTypeText
+	} else {
+		eventData.MessageType = messageTypeBinary
+	}
+
+	eventBody, err := json.Marshal(eventData)
+	if err != nil {
+		log.Errorw("failed to marshal the event data, rejecting the event...", zap.Error(err))
+		el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonMarshal)
+		return
+	}
+	log.Info("dispatching event on the data channel...")
+	if err = dispatch(eventBody); err != nil {
+		log.Errorw("failed to dispatch WebSocket event...", zap.Error(err))
+		el.Metrics.EventProcessingFailedWithReason(el.GetEventSourceName(), el.GetEventName(), metrics.FailureReasonDispatch)
+	}
+}
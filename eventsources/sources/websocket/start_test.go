@@ -0,0 +1,173 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+
+	eventsourcecommon "github.com/argoproj/argo-events/eventsources/common"
+	"github.com/argoproj/argo-events/metrics"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/events"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// fakeServer accepts the websocket connections, records the messages it receives and sends a text and a binary
+// message to each client.
+type fakeServer struct {
+	url      string
+	received chan string
+	pings    chan struct{}
+	// drops is the number of connections closed once the messages are sent
+	drops int32
+}
+
+func newFakeServer(t *testing.T, drops int32) *fakeServer {
+	s := &fakeServer{received: make(chan string, 10), pings: make(chan struct{}, 10), drops: drops}
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.SetPingHandler(func(data string) error {
+			select {
+			case s.pings <- struct{}{}:
+			default:
+			}
+			return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+		})
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		s.received <- string(message)
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(`{"price":42}`)); err != nil {
+			return
+		}
+		if err := conn.WriteMessage(websocket.BinaryMessage, []byte{0, 1, 2}); err != nil {
+			return
+		}
+		if atomic.AddInt32(&s.drops, -1) >= 0 {
+			return
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	s.url = "ws" + strings.TrimPrefix(server.URL, "http")
+	return s
+}
+
+func startListening(t *testing.T, source v1alpha1.WebSocketEventSource) (context.CancelFunc, <-chan []byte, <-chan error) {
+	el := &EventListener{EventSourceName: "websocket", EventName: "example", WebSocketEventSource: source, Metrics: metrics.NewMetrics("ns")}
+	ctx, cancel := context.WithCancel(context.Background())
+	dispatched := make(chan []byte, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- el.StartListening(ctx, func(data []byte, opts ...eventsourcecommon.Options) error {
+			dispatched <- data
+			return nil
+		})
+	}()
+	return cancel, dispatched, done
+}
+
+func nextEvent(t *testing.T, dispatched <-chan []byte) events.WebSocketEventData {
+	var event events.WebSocketEventData
+	select {
+	case data := <-dispatched:
+		assert.NoError(t, json.Unmarshal(data, &event))
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event dispatched")
+	}
+	return event
+}
+
+func nextMessage(t *testing.T, server *fakeServer) string {
+	select {
+	case message := <-server.received:
+		return message
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message received")
+		return ""
+	}
+}
+
+func TestStartListening(t *testing.T) {
+	server := newFakeServer(t, 0)
+	cancel, dispatched, done := startListening(t, v1alpha1.WebSocketEventSource{
+		URL:            server.url,
+		InitialMessage: `{"subscribe":"prices"}`,
+		PingInterval:   "1s",
+		Metadata:       map[string]string{"feed": "prices"},
+	})
+
+	assert.Equal(t, `{"subscribe":"prices"}`, nextMessage(t, server))
+	event := nextEvent(t, dispatched)
+	assert.Equal(t, server.url, event.URL)
+	assert.Equal(t, messageTypeText, event.MessageType)
+	assert.Equal(t, `{"price":42}`, event.Body)
+	assert.Equal(t, map[string]string{"feed": "prices"}, event.Metadata)
+	event = nextEvent(t, dispatched)
+	assert.Equal(t, messageTypeBinary, event.MessageType)
+	// the binary bodies are base64 encoded
+	assert.Equal(t, "AAEC", event.Body)
+
+	select {
+	case <-server.pings:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no ping received")
+	}
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestStartListeningReconnects(t *testing.T) {
+	server := newFakeServer(t, 1)
+	cancel, dispatched, done := startListening(t, v1alpha1.WebSocketEventSource{
+		URL:               server.url,
+		InitialMessage:    "subscribe",
+		JSONBody:          true,
+		ConnectionBackoff: &apicommon.Backoff{Steps: 5, Duration: &apicommon.Int64OrString{Type: apicommon.String, StrVal: "10ms"}},
+	})
+
+	assert.Equal(t, "subscribe", nextMessage(t, server))
+	assert.Equal(t, map[string]interface{}{"price": float64(42)}, nextEvent(t, dispatched).Body)
+	// the binary message isn't JSON so it's rejected, then the server drops the connection and the initial message
+	// is sent again once reconnected
+	assert.Equal(t, "subscribe", nextMessage(t, server))
+	assert.Equal(t, map[string]interface{}{"price": float64(42)}, nextEvent(t, dispatched).Body)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
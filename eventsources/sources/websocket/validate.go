@@ -0,0 +1,65 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package websocket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/argoproj/argo-events/common"
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+// ValidateEventSource validates the websocket event source
+func (listener *EventListener) ValidateEventSource(ctx context.Context) error {
+	return validate(&listener.WebSocketEventSource)
+}
+
+func validate(eventSource *v1alpha1.WebSocketEventSource) error {
+	if eventSource == nil {
+		return common.ErrNilEventSource
+	}
+	if eventSource.URL == "" {
+		return fmt.Errorf("url must be specified")
+	}
+	u, err := url.Parse(eventSource.URL)
+	if err != nil {
+		return fmt.Errorf("invalid url %s: %w", eventSource.URL, err)
+	}
+	if u.Scheme != "ws" && u.Scheme != "wss" {
+		return fmt.Errorf("unsupported scheme %q of the url, it must be either ws or wss", u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("url %s must specify the host", eventSource.URL)
+	}
+	if eventSource.PingInterval != "" {
+		interval, err := time.ParseDuration(eventSource.PingInterval)
+		if err != nil {
+			return fmt.Errorf("failed to parse the ping interval %s: %w", eventSource.PingInterval, err)
+		}
+		if interval < time.Second {
+			return fmt.Errorf("ping interval must be at least 1s")
+		}
+	}
+	if eventSource.TLS != nil {
+		return apicommon.ValidateTLSConfig(eventSource.TLS)
+	}
+	return nil
+}
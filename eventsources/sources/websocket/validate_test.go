@@ -0,0 +1,81 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package websocket
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-events/eventsources/sources"
+	"github.com/argoproj/argo-events/pkg/apis/eventsource/v1alpha1"
+)
+
+func TestValidateEventSource(t *testing.T) {
+	listener := &EventListener{}
+
+	err := listener.ValidateEventSource(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, "url must be specified", err.Error())
+
+	content, err := ioutil.ReadFile(fmt.Sprintf("%s/%s", sources.EventSourceDir, "websocket.yaml"))
+	assert.Nil(t, err)
+
+	var eventSource *v1alpha1.EventSource
+	err = yaml.Unmarshal(content, &eventSource)
+	assert.Nil(t, err)
+	assert.NotNil(t, eventSource.Spec.WebSocket)
+
+	for _, value := range eventSource.Spec.WebSocket {
+		l := &EventListener{
+			WebSocketEventSource: value,
+		}
+		err := l.ValidateEventSource(context.Background())
+		assert.NoError(t, err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() *v1alpha1.WebSocketEventSource {
+		return &v1alpha1.WebSocketEventSource{URL: "wss://feed.example.com/prices", PingInterval: "30s"}
+	}
+	assert.NoError(t, validate(valid()))
+
+	tests := []struct {
+		name   string
+		modify func(*v1alpha1.WebSocketEventSource)
+		err    string
+	}{
+		{"unsupported scheme", func(s *v1alpha1.WebSocketEventSource) { s.URL = "https://feed.example.com" }, `unsupported scheme "https" of the url, it must be either ws or wss`},
+		{"no host", func(s *v1alpha1.WebSocketEventSource) { s.URL = "ws://" }, "url ws:// must specify the host"},
+		{"invalid ping interval", func(s *v1alpha1.WebSocketEventSource) { s.PingInterval = "often" }, `failed to parse the ping interval often: time: invalid duration "often"`},
+		{"short ping interval", func(s *v1alpha1.WebSocketEventSource) { s.PingInterval = "10ms" }, "ping interval must be at least 1s"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			eventSource := valid()
+			test.modify(eventSource)
+			err := validate(eventSource)
+			assert.Error(t, err)
+			assert.Equal(t, test.err, err.Error())
+		})
+	}
+}
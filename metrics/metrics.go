@@ -3,6 +3,7 @@ package metrics
 import (
 	"context"
 	"net/http"
+	"strconv"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -19,16 +20,30 @@ const (
 	labelEventName       = "event_name"
 	labelSensorName      = "sensor_name"
 	labelTriggerName     = "trigger_name"
+	labelReason          = "reason"
+	labelSuccess         = "success"
+	labelTopic           = "topic"
 )
 
 // Metrics represents EventSource metrics information
 type Metrics struct {
 	namespace               string
 	runningEventServices    *prometheus.GaugeVec
+	eventSourceActive       *prometheus.GaugeVec
 	eventsSent              *prometheus.CounterVec
 	eventsSentFailed        *prometheus.CounterVec
 	eventsProcessingFailed  *prometheus.CounterVec
 	eventProcessingDuration *prometheus.SummaryVec
+	eventPayloadSize        *prometheus.HistogramVec
+	eventsDropped           *prometheus.CounterVec
+	eventsFiltered          *prometheus.CounterVec
+	resubscriptions         *prometheus.CounterVec
+	eventSourceRestarts     *prometheus.CounterVec
+	bufferHighWaterMark     *prometheus.GaugeVec
+	connectionAttempts      *prometheus.CounterVec
+	connectionLatency       *prometheus.HistogramVec
+	dispatchRetries         *prometheus.CounterVec
+	reconnectAttempts       *prometheus.CounterVec
 	actionTriggered         *prometheus.CounterVec
 	actionFailed            *prometheus.CounterVec
 	actionDuration          *prometheus.SummaryVec
@@ -46,6 +61,14 @@ func NewMetrics(namespace string) *Metrics {
 				labelNamespace: namespace,
 			},
 		}, []string{labelEventSourceName}),
+		eventSourceActive: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: prefix,
+			Name:      "event_source_active",
+			Help:      "Whether a configured event in the EventSource object is actively subscribed (1) or not (0). https://argoproj.github.io/argo-events/metrics/#argo_events_event_source_active",
+			ConstLabels: prometheus.Labels{
+				labelNamespace: namespace,
+			},
+		}, []string{labelEventSourceName, labelEventName}),
 		eventsSent: prometheus.NewCounterVec(prometheus.CounterOpts{
 			Namespace: prefix,
 			Name:      "events_sent_total",
@@ -69,7 +92,7 @@ func NewMetrics(namespace string) *Metrics {
 			ConstLabels: prometheus.Labels{
 				labelNamespace: namespace,
 			},
-		}, []string{labelEventSourceName, labelEventName}),
+		}, []string{labelEventSourceName, labelEventName, labelTopic, labelReason}),
 		eventProcessingDuration: prometheus.NewSummaryVec(prometheus.SummaryOpts{
 			Namespace: prefix,
 			Name:      "event_processing_duration_milliseconds",
@@ -77,6 +100,89 @@ func NewMetrics(namespace string) *Metrics {
 			ConstLabels: prometheus.Labels{
 				labelNamespace: namespace,
 			},
+		}, []string{labelEventSourceName, labelEventName, labelTopic}),
+		eventPayloadSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: prefix,
+			Name:      "event_payload_size_bytes",
+			Help:      "Histogram of the event payload sizes. https://argoproj.github.io/argo-events/metrics/#argo_events_event_payload_size_bytes",
+			ConstLabels: prometheus.Labels{
+				labelNamespace: namespace,
+			},
+			// 64 bytes to 16 MiB
+			Buckets: prometheus.ExponentialBuckets(64, 4, 10),
+		}, []string{labelEventSourceName, labelEventName}),
+		eventsDropped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: prefix,
+			Name:      "events_dropped_total",
+			Help:      "How many events have been dropped by the event source, e.g. by its rate limiting or while its buffer is full. https://argoproj.github.io/argo-events/metrics/#argo_events_events_dropped_total",
+			ConstLabels: prometheus.Labels{
+				labelNamespace: namespace,
+			},
+		}, []string{labelEventSourceName, labelEventName}),
+		eventsFiltered: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: prefix,
+			Name:      "events_filtered_total",
+			Help:      "How many events have been filtered out by the event source. https://argoproj.github.io/argo-events/metrics/#argo_events_events_filtered_total",
+			ConstLabels: prometheus.Labels{
+				labelNamespace: namespace,
+			},
+		}, []string{labelEventSourceName, labelEventName}),
+		resubscriptions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: prefix,
+			Name:      "event_source_resubscriptions_total",
+			Help:      "How many times the event source subscribed again after reconnecting. https://argoproj.github.io/argo-events/metrics/#argo_events_event_source_resubscriptions_total",
+			ConstLabels: prometheus.Labels{
+				labelNamespace: namespace,
+			},
+		}, []string{labelEventSourceName, labelEventName}),
+		eventSourceRestarts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: prefix,
+			Name:      "event_source_restarts_total",
+			Help:      "How many times the event source was restarted after panicking. https://argoproj.github.io/argo-events/metrics/#argo_events_event_source_restarts_total",
+			ConstLabels: prometheus.Labels{
+				labelNamespace: namespace,
+			},
+		}, []string{labelEventSourceName, labelEventName}),
+		bufferHighWaterMark: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: prefix,
+			Name:      "event_buffer_high_water_mark",
+			Help:      "The highest number of events buffered by the event source before being dispatched. https://argoproj.github.io/argo-events/metrics/#argo_events_event_buffer_high_water_mark",
+			ConstLabels: prometheus.Labels{
+				labelNamespace: namespace,
+			},
+		}, []string{labelEventSourceName, labelEventName}),
+		connectionAttempts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: prefix,
+			Name:      "event_source_connection_attempts_total",
+			Help:      "How many times the event source attempted to connect to its source, by success. https://argoproj.github.io/argo-events/metrics/#argo_events_event_source_connection_attempts_total",
+			ConstLabels: prometheus.Labels{
+				labelNamespace: namespace,
+			},
+		}, []string{labelEventSourceName, labelEventName, labelSuccess}),
+		connectionLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: prefix,
+			Name:      "event_source_connection_latency_seconds",
+			Help:      "Histogram of the durations of the successful connections of the event source to its source. https://argoproj.github.io/argo-events/metrics/#argo_events_event_source_connection_latency_seconds",
+			ConstLabels: prometheus.Labels{
+				labelNamespace: namespace,
+			},
+			Buckets: prometheus.DefBuckets,
+		}, []string{labelEventSourceName, labelEventName}),
+		dispatchRetries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: prefix,
+			Name:      "event_dispatch_retries_total",
+			Help:      "How many times the event source retried dispatching an event to the eventbus. https://argoproj.github.io/argo-events/metrics/#argo_events_event_dispatch_retries_total",
+			ConstLabels: prometheus.Labels{
+				labelNamespace: namespace,
+			},
+		}, []string{labelEventSourceName, labelEventName}),
+		reconnectAttempts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: prefix,
+			Name:      "event_source_reconnect_attempts_total",
+			Help:      "How many times the event source attempted to reconnect to its source after losing the connection. https://argoproj.github.io/argo-events/metrics/#argo_events_event_source_reconnect_attempts_total",
+			ConstLabels: prometheus.Labels{
+				labelNamespace: namespace,
+			},
 		}, []string{labelEventSourceName, labelEventName}),
 		actionTriggered: prometheus.NewCounterVec(prometheus.CounterOpts{
 			Namespace: prefix,
@@ -107,10 +213,21 @@ func NewMetrics(namespace string) *Metrics {
 
 func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
 	m.runningEventServices.Collect(ch)
+	m.eventSourceActive.Collect(ch)
 	m.eventsSent.Collect(ch)
 	m.eventsSentFailed.Collect(ch)
 	m.eventsProcessingFailed.Collect(ch)
 	m.eventProcessingDuration.Collect(ch)
+	m.eventPayloadSize.Collect(ch)
+	m.eventsDropped.Collect(ch)
+	m.eventsFiltered.Collect(ch)
+	m.resubscriptions.Collect(ch)
+	m.eventSourceRestarts.Collect(ch)
+	m.bufferHighWaterMark.Collect(ch)
+	m.connectionAttempts.Collect(ch)
+	m.connectionLatency.Collect(ch)
+	m.dispatchRetries.Collect(ch)
+	m.reconnectAttempts.Collect(ch)
 	m.actionTriggered.Collect(ch)
 	m.actionFailed.Collect(ch)
 	m.actionDuration.Collect(ch)
@@ -118,10 +235,21 @@ func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
 
 func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
 	m.runningEventServices.Describe(ch)
+	m.eventSourceActive.Describe(ch)
 	m.eventsSent.Describe(ch)
 	m.eventsSentFailed.Describe(ch)
 	m.eventsProcessingFailed.Describe(ch)
 	m.eventProcessingDuration.Describe(ch)
+	m.eventPayloadSize.Describe(ch)
+	m.eventsDropped.Describe(ch)
+	m.eventsFiltered.Describe(ch)
+	m.resubscriptions.Describe(ch)
+	m.eventSourceRestarts.Describe(ch)
+	m.bufferHighWaterMark.Describe(ch)
+	m.connectionAttempts.Describe(ch)
+	m.connectionLatency.Describe(ch)
+	m.dispatchRetries.Describe(ch)
+	m.reconnectAttempts.Describe(ch)
 	m.actionTriggered.Describe(ch)
 	m.actionFailed.Describe(ch)
 	m.actionDuration.Describe(ch)
@@ -135,6 +263,14 @@ func (m *Metrics) DecRunningServices(eventSourceName string) {
 	m.runningEventServices.WithLabelValues(eventSourceName).Dec()
 }
 
+func (m *Metrics) EventSourceActive(eventSourceName, eventName string, active bool) {
+	value := 0.0
+	if active {
+		value = 1
+	}
+	m.eventSourceActive.WithLabelValues(eventSourceName, eventName).Set(value)
+}
+
 func (m *Metrics) EventSent(eventSourceName, eventName string) {
 	m.eventsSent.WithLabelValues(eventSourceName, eventName).Inc()
 }
@@ -143,12 +279,74 @@ func (m *Metrics) EventSentFailed(eventSourceName, eventName string) {
 	m.eventsSentFailed.WithLabelValues(eventSourceName, eventName).Inc()
 }
 
+// EventProcessingFailed counts a processing failure the event source doesn't classify, under the unknown reason
 func (m *Metrics) EventProcessingFailed(eventSourceName, eventName string) {
-	m.eventsProcessingFailed.WithLabelValues(eventSourceName, eventName).Inc()
+	m.EventProcessingFailedWithReason(eventSourceName, eventName, FailureReasonUnknown)
+}
+
+// EventProcessingFailedWithReason counts a processing failure under its reason
+func (m *Metrics) EventProcessingFailedWithReason(eventSourceName, eventName string, reason FailureReason) {
+	m.EventProcessingFailedWithTopic(eventSourceName, eventName, "", reason)
+}
+
+// EventProcessingFailedWithTopic counts a processing failure under its reason and the topic label of the message, see
+// TopicLabels. An empty topic label leaves the failure unlabeled.
+func (m *Metrics) EventProcessingFailedWithTopic(eventSourceName, eventName, topic string, reason FailureReason) {
+	m.eventsProcessingFailed.WithLabelValues(eventSourceName, eventName, topic, string(reason)).Inc()
 }
 
 func (m *Metrics) EventProcessingDuration(eventSourceName, eventName string, num float64) {
-	m.eventProcessingDuration.WithLabelValues(eventSourceName, eventName).Observe(num)
+	m.EventProcessingDurationWithTopic(eventSourceName, eventName, "", num)
+}
+
+// EventProcessingDurationWithTopic observes the processing duration of an event under the topic label of the message
+// it originates from, see TopicLabels. An empty topic label leaves the duration unlabeled.
+func (m *Metrics) EventProcessingDurationWithTopic(eventSourceName, eventName, topic string, num float64) {
+	m.eventProcessingDuration.WithLabelValues(eventSourceName, eventName, topic).Observe(num)
+}
+
+func (m *Metrics) EventPayloadSize(eventSourceName, eventName string, bytes float64) {
+	m.eventPayloadSize.WithLabelValues(eventSourceName, eventName).Observe(bytes)
+}
+
+func (m *Metrics) EventsDropped(eventSourceName, eventName string) {
+	m.eventsDropped.WithLabelValues(eventSourceName, eventName).Inc()
+}
+
+func (m *Metrics) EventsFiltered(eventSourceName, eventName string) {
+	m.eventsFiltered.WithLabelValues(eventSourceName, eventName).Inc()
+}
+
+func (m *Metrics) Resubscribed(eventSourceName, eventName string) {
+	m.resubscriptions.WithLabelValues(eventSourceName, eventName).Inc()
+}
+
+func (m *Metrics) EventSourceRestarted(eventSourceName, eventName string) {
+	m.eventSourceRestarts.WithLabelValues(eventSourceName, eventName).Inc()
+}
+
+func (m *Metrics) BufferHighWaterMark(eventSourceName, eventName string, buffered int) {
+	m.bufferHighWaterMark.WithLabelValues(eventSourceName, eventName).Set(float64(buffered))
+}
+
+// ConnectionAttempt counts an attempt of the event source to connect to its source, under whether it succeeded
+func (m *Metrics) ConnectionAttempt(eventSourceName, eventName string, success bool) {
+	m.connectionAttempts.WithLabelValues(eventSourceName, eventName, strconv.FormatBool(success)).Inc()
+}
+
+// ConnectionLatency observes how long a successful connection of the event source to its source took
+func (m *Metrics) ConnectionLatency(eventSourceName, eventName string, seconds float64) {
+	m.connectionLatency.WithLabelValues(eventSourceName, eventName).Observe(seconds)
+}
+
+// DispatchRetried counts a retry of the dispatch of an event to the eventbus
+func (m *Metrics) DispatchRetried(eventSourceName, eventName string) {
+	m.dispatchRetries.WithLabelValues(eventSourceName, eventName).Inc()
+}
+
+// ReconnectAttempted counts an attempt of the event source to reconnect to its source after losing the connection
+func (m *Metrics) ReconnectAttempted(eventSourceName, eventName string) {
+	m.reconnectAttempts.WithLabelValues(eventSourceName, eventName).Inc()
 }
 
 func (m *Metrics) ActionTriggered(sensorName, triggerName string) {
@@ -2,11 +2,14 @@ package metrics
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/argoproj/argo-events/common/logging"
@@ -21,3 +24,142 @@ func TestRun(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, resp.StatusCode, 200)
 }
+
+func TestEventSourceActive(t *testing.T) {
+	m := NewMetrics("test-ns")
+	m.EventSourceActive("test-source", "test-event", true)
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.eventSourceActive.WithLabelValues("test-source", "test-event")))
+	m.EventSourceActive("test-source", "test-event", false)
+	assert.Equal(t, 0.0, testutil.ToFloat64(m.eventSourceActive.WithLabelValues("test-source", "test-event")))
+}
+
+func TestEventPayloadSize(t *testing.T) {
+	m := NewMetrics("test-ns")
+	m.EventPayloadSize("test-source", "test-event", 100)
+	m.EventPayloadSize("test-source", "test-event", 1<<20)
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(m)
+	families, err := registry.Gather()
+	assert.NoError(t, err)
+	var found bool
+	for _, family := range families {
+		if family.GetName() != "argo_events_event_payload_size_bytes" {
+			continue
+		}
+		found = true
+		histogram := family.GetMetric()[0].GetHistogram()
+		assert.Equal(t, uint64(2), histogram.GetSampleCount())
+		assert.Equal(t, float64(100+1<<20), histogram.GetSampleSum())
+		buckets := histogram.GetBucket()
+		assert.Equal(t, 10, len(buckets))
+		assert.Equal(t, float64(64), buckets[0].GetUpperBound())
+		assert.Equal(t, float64(16<<20), buckets[len(buckets)-1].GetUpperBound())
+	}
+	assert.True(t, found)
+}
+
+func TestEventsDropped(t *testing.T) {
+	m := NewMetrics("test-ns")
+	m.EventsDropped("test-source", "test-event")
+	m.EventsDropped("test-source", "test-event")
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.eventsDropped.WithLabelValues("test-source", "test-event")))
+}
+
+func TestEventsFiltered(t *testing.T) {
+	m := NewMetrics("test-ns")
+	m.EventsFiltered("test-source", "test-event")
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.eventsFiltered.WithLabelValues("test-source", "test-event")))
+}
+
+func TestResubscribed(t *testing.T) {
+	m := NewMetrics("test-ns")
+	m.Resubscribed("test-source", "test-event")
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.resubscriptions.WithLabelValues("test-source", "test-event")))
+}
+
+func TestConnectionAttempt(t *testing.T) {
+	m := NewMetrics("test-ns")
+	m.ConnectionAttempt("test-source", "test-event", false)
+	m.ConnectionAttempt("test-source", "test-event", false)
+	m.ConnectionAttempt("test-source", "test-event", true)
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.connectionAttempts.WithLabelValues("test-source", "test-event", "false")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.connectionAttempts.WithLabelValues("test-source", "test-event", "true")))
+}
+
+func TestConnectionLatency(t *testing.T) {
+	m := NewMetrics("test-ns")
+	m.ConnectionLatency("test-source", "test-event", 0.2)
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(m)
+	families, err := registry.Gather()
+	assert.NoError(t, err)
+	var found bool
+	for _, family := range families {
+		if family.GetName() != "argo_events_event_source_connection_latency_seconds" {
+			continue
+		}
+		found = true
+		histogram := family.GetMetric()[0].GetHistogram()
+		assert.Equal(t, uint64(1), histogram.GetSampleCount())
+		assert.Equal(t, 0.2, histogram.GetSampleSum())
+	}
+	assert.True(t, found)
+}
+
+func TestDispatchRetried(t *testing.T) {
+	m := NewMetrics("test-ns")
+	m.DispatchRetried("test-source", "test-event")
+	m.DispatchRetried("test-source", "test-event")
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.dispatchRetries.WithLabelValues("test-source", "test-event")))
+}
+
+func TestReconnectAttempted(t *testing.T) {
+	m := NewMetrics("test-ns")
+	m.ReconnectAttempted("test-source", "test-event")
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.reconnectAttempts.WithLabelValues("test-source", "test-event")))
+}
+
+func TestEventProcessingFailed(t *testing.T) {
+	m := NewMetrics("test-ns")
+	m.EventProcessingFailed("test-source", "test-event")
+	m.EventProcessingFailedWithReason("test-source", "test-event", FailureReasonDispatch)
+	m.EventProcessingFailedWithReason("test-source", "test-event", FailureReasonDispatch)
+	m.EventProcessingFailedWithTopic("test-source", "test-event", "orders/eu", FailureReasonDispatch)
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.eventsProcessingFailed.WithLabelValues("test-source", "test-event", "", "unknown")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.eventsProcessingFailed.WithLabelValues("test-source", "test-event", "", "dispatch")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.eventsProcessingFailed.WithLabelValues("test-source", "test-event", "orders/eu", "dispatch")))
+}
+
+func TestEventProcessingDurationWithTopic(t *testing.T) {
+	m := NewMetrics("test-ns")
+	m.EventProcessingDuration("test-source", "test-event", 10)
+	m.EventProcessingDurationWithTopic("test-source", "test-event", "orders/eu", 20)
+	m.EventProcessingDurationWithTopic("test-source", "test-event", "orders/eu", 30)
+	// the unlabeled and the orders/eu series
+	assert.Equal(t, 2, testutil.CollectAndCount(m.eventProcessingDuration, "argo_events_event_processing_duration_milliseconds"))
+}
+
+func TestTopicLabels(t *testing.T) {
+	var disabled *TopicLabels
+	assert.Equal(t, "", disabled.Label("orders/eu"))
+
+	labels := NewTopicLabels(2)
+	assert.Equal(t, "", labels.Label(""))
+	assert.Equal(t, "orders/eu", labels.Label("orders/eu"))
+	assert.Equal(t, "orders/us", labels.Label("orders/us"))
+	assert.Equal(t, OtherTopic, labels.Label("orders/ap"))
+	// the topics seen before the cap is reached keep their label
+	assert.Equal(t, "orders/eu", labels.Label("orders/eu"))
+	assert.Equal(t, OtherTopic, labels.Label("orders/sa"))
+}
+
+func TestReasonOf(t *testing.T) {
+	assert.Nil(t, WithReason(nil, FailureReasonMarshal))
+	assert.Equal(t, FailureReasonUnknown, ReasonOf(errors.New("boom")))
+	err := WithReason(errors.New("boom"), FailureReasonMarshal)
+	assert.Equal(t, "boom", err.Error())
+	assert.Equal(t, FailureReasonMarshal, ReasonOf(err))
+	assert.Equal(t, FailureReasonMarshal, ReasonOf(fmt.Errorf("failed to process: %w", err)))
+}
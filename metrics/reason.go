@@ -0,0 +1,81 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"errors"
+)
+
+// FailureReason classifies the event processing failures
+type FailureReason string
+
+// Possible reasons of the event processing failures
+const (
+	// FailureReasonMarshal is a failure to build the event payload
+	FailureReasonMarshal FailureReason = "marshal"
+	// FailureReasonDispatch is a failure to dispatch the event to the eventbus
+	FailureReasonDispatch FailureReason = "dispatch"
+	// FailureReasonDispatchTimeout is a dispatch to the eventbus which didn't complete within the dispatch timeout
+	FailureReasonDispatchTimeout FailureReason = "dispatch-timeout"
+	// FailureReasonConnection is a failure to connect to the source, or a lost connection
+	FailureReasonConnection FailureReason = "connection"
+	// FailureReasonAuth is a failure to get the credentials or to authenticate to the source
+	FailureReasonAuth FailureReason = "auth"
+	// FailureReasonSubscribe is a failure to subscribe to the source
+	FailureReasonSubscribe FailureReason = "subscribe"
+	// FailureReasonStream is the termination of a stream of the source with an error
+	FailureReasonStream FailureReason = "stream"
+	// FailureReasonDecompress is a failure to decompress the payload of a message
+	FailureReasonDecompress FailureReason = "decompress"
+	// FailureReasonValidation is a payload failing the validation, e.g. a body which isn't valid JSON
+	FailureReasonValidation FailureReason = "validation"
+	// FailureReasonWatch is a failure to watch the source, e.g. a watched directory which has been removed
+	FailureReasonWatch FailureReason = "watch"
+	// FailureReasonAck is a failure to publish the acknowledgement of a message whose event is dispatched
+	FailureReasonAck FailureReason = "ack"
+	// FailureReasonTooLarge is an event rejected because it exceeds the maximum event size
+	FailureReasonTooLarge FailureReason = "too-large"
+	// FailureReasonUnknown is the reason of the failures the event source doesn't classify
+	FailureReasonUnknown FailureReason = "unknown"
+)
+
+// reasonError is an error classified with the reason of the processing failure
+type reasonError struct {
+	error
+	reason FailureReason
+}
+
+func (e *reasonError) Unwrap() error {
+	return e.error
+}
+
+// WithReason classifies the error with the reason of the processing failure, see ReasonOf
+func WithReason(err error, reason FailureReason) error {
+	if err == nil {
+		return nil
+	}
+	return &reasonError{error: err, reason: reason}
+}
+
+// ReasonOf returns the reason the error, or any error it wraps, is classified with, or FailureReasonUnknown
+func ReasonOf(err error) FailureReason {
+	var re *reasonError
+	if errors.As(err, &re) {
+		return re.reason
+	}
+	return FailureReasonUnknown
+}
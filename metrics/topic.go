@@ -0,0 +1,55 @@
+/*
+Copyright 2021 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"sync"
+)
+
+// OtherTopic is the topic label of the topics beyond the cap of TopicLabels
+const OtherTopic = "other"
+
+// TopicLabels bounds the cardinality of the topic label of the metrics: the first topics seen, up to the cap, are
+// labeled as themselves, and the next ones are all labeled as OtherTopic. A nil TopicLabels leaves the topics
+// unlabeled.
+type TopicLabels struct {
+	lock   sync.Mutex
+	max    int
+	topics map[string]struct{}
+}
+
+// NewTopicLabels returns TopicLabels labeling up to max topics as themselves
+func NewTopicLabels(max int) *TopicLabels {
+	return &TopicLabels{max: max, topics: make(map[string]struct{})}
+}
+
+// Label returns the topic label of the topic
+func (l *TopicLabels) Label(topic string) string {
+	if l == nil || topic == "" {
+		return ""
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if _, ok := l.topics[topic]; ok {
+		return topic
+	}
+	if len(l.topics) >= l.max {
+		return OtherTopic
+	}
+	l.topics[topic] = struct{}{}
+	return topic
+}
@@ -51,6 +51,15 @@ var (
 	GenericEvent         EventSourceType = "generic"
 	BitbucketServerEvent EventSourceType = "bitbucketserver"
 	BitbucketEvent       EventSourceType = "bitbucket"
+	JetStreamEvent       EventSourceType = "jetstream"
+	RedisStreamEvent     EventSourceType = "redisStream"
+	PrometheusEvent      EventSourceType = "prometheus"
+	GRPCEvent            EventSourceType = "grpc"
+	MQTTV5Event          EventSourceType = "mqttv5"
+	WebSocketEvent       EventSourceType = "websocket"
+	PostgresEvent        EventSourceType = "postgres"
+	HTTPPollEvent        EventSourceType = "httpPoll"
+	AzureServiceBusEvent EventSourceType = "azureServiceBus"
 )
 
 var (
@@ -64,6 +73,7 @@ var (
 		AzureEventsHub,
 		NATSEvent,
 		MQTTEvent,
+		MQTTV5Event,
 		MinioEvent,
 		EmitterEvent,
 		NSQEvent,
@@ -73,6 +83,12 @@ var (
 		HDFSEvent,
 		FileEvent,
 		GenericEvent,
+		WebSocketEvent,
+		PostgresEvent,
+		HTTPPollEvent,
+		AzureServiceBusEvent,
+		PrometheusEvent,
+		GRPCEvent,
 	}
 )
 
@@ -133,8 +149,65 @@ type TLSConfig struct {
 	// ClientKeySecret refers to the secret that contains the client key
 	ClientKeySecret *corev1.SecretKeySelector `json:"clientKeySecret,omitempty" protobuf:"bytes,3,opt,name=clientKeySecret"`
 	// If true, skips creation of TLSConfig with certs and creates an empty TLSConfig. (Defaults to false)
+	// The certificate of the server isn't verified, which is logged as a warning, it must not be used in production.
 	// +optional
 	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty" protobuf:"varint,4,opt,name=insecureSkipVerify"`
+	// CACertData is the PEM encoded CA cert, takes precedence over CACertSecret if both are set
+	// +optional
+	CACertData []byte `json:"caCertData,omitempty" protobuf:"bytes,5,opt,name=caCertData"`
+	// ClientCertData is the PEM encoded client cert, takes precedence over ClientCertSecret if both are set
+	// +optional
+	ClientCertData []byte `json:"clientCertData,omitempty" protobuf:"bytes,6,opt,name=clientCertData"`
+	// ClientKeyData is the PEM encoded client key, takes precedence over ClientKeySecret if both are set
+	// +optional
+	ClientKeyData []byte `json:"clientKeyData,omitempty" protobuf:"bytes,7,opt,name=clientKeyData"`
+	// MinVersion is the minimum TLS version to accept.
+	// Possible values: 1.0, 1.1, 1.2, 1.3 (defaults to the crypto/tls default).
+	// +optional
+	MinVersion string `json:"minVersion,omitempty" protobuf:"bytes,8,opt,name=minVersion"`
+	// CipherSuites is the list of enabled cipher suites for TLS versions up to 1.2, by their
+	// crypto/tls names, e.g. TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256 (defaults to the crypto/tls default).
+	// +optional
+	CipherSuites []string `json:"cipherSuites,omitempty" protobuf:"bytes,9,rep,name=cipherSuites"`
+	// ServerName overrides the host name the certificate of the server is verified against, which is also sent as
+	// SNI, e.g. when connecting through a load balancer whose certificate doesn't match the host of the broker.
+	// The system CAs verify the certificate if no CA cert is set. Can't be set along with InsecureSkipVerify.
+	// +optional
+	ServerName string `json:"serverName,omitempty" protobuf:"bytes,10,opt,name=serverName"`
+}
+
+// SecretBackend selects where the secrets referenced by the event sources are resolved from.
+// The mounted K8s secrets are used if no backend is set.
+type SecretBackend struct {
+	// Vault resolves the secrets from HashiCorp Vault
+	// +optional
+	Vault *VaultSecretBackend `json:"vault,omitempty" protobuf:"bytes,1,opt,name=vault"`
+}
+
+// VaultSecretBackend resolves the secrets from a HashiCorp Vault KV secrets engine. The name of a
+// secret key selector is the path of the secret within the engine, and the key is the field of the secret.
+type VaultSecretBackend struct {
+	// Address of the Vault server, e.g. https://vault.vault.svc:8200
+	Address string `json:"address" protobuf:"bytes,1,opt,name=address"`
+	// MountPath is the path the KV secrets engine is mounted at. Defaults to "secret".
+	// +optional
+	MountPath string `json:"mountPath,omitempty" protobuf:"bytes,2,opt,name=mountPath"`
+	// KVVersion is the version of the KV secrets engine, 1 or 2. Defaults to 2.
+	// +optional
+	KVVersion int32 `json:"kvVersion,omitempty" protobuf:"varint,3,opt,name=kvVersion"`
+	// Role to log in as with the Kubernetes auth method, using the service account token of the pod.
+	// Either Role or TokenSecret must be set.
+	// +optional
+	Role string `json:"role,omitempty" protobuf:"bytes,4,opt,name=role"`
+	// AuthPath is the path the Kubernetes auth method is mounted at. Defaults to "kubernetes".
+	// +optional
+	AuthPath string `json:"authPath,omitempty" protobuf:"bytes,5,opt,name=authPath"`
+	// TokenSecret refers to the K8s secret that holds a Vault token, used if no Role is set.
+	// +optional
+	TokenSecret *corev1.SecretKeySelector `json:"tokenSecret,omitempty" protobuf:"bytes,6,opt,name=tokenSecret"`
+	// TLS configuration for the Vault client.
+	// +optional
+	TLS *TLSConfig `json:"tls,omitempty" protobuf:"bytes,7,opt,name=tls"`
 }
 
 // SASLConfig refers to SASL configuration for a client
@@ -158,12 +231,17 @@ type Backoff struct {
 	// Duration is multiplied by factor each iteration
 	// +optional
 	Factor *Amount `json:"factor,omitempty" protobuf:"bytes,2,opt,name=factor"`
-	// The amount of jitter applied each iteration
+	// The amount of jitter applied each iteration, i.e. each retry interval is randomly
+	// extended by up to jitter * interval. A jitter of 0 disables randomization.
 	// +optional
 	Jitter *Amount `json:"jitter,omitempty" protobuf:"bytes,3,opt,name=jitter"`
 	// Exit with error after this many steps
 	// +optional
 	Steps int32 `json:"steps,omitempty" protobuf:"varint,4,opt,name=steps"`
+	// MaxElapsedTime is the wall-clock ceiling of the retries in nanoseconds or strings like "1m", "1h".
+	// Exit with a terminal error once it is exceeded, even if steps remain. No ceiling if not set.
+	// +optional
+	MaxElapsedTime *Int64OrString `json:"maxElapsedTime,omitempty" protobuf:"bytes,5,opt,name=maxElapsedTime"`
 }
 
 func (b Backoff) GetSteps() int {
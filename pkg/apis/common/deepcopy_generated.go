@@ -64,6 +64,11 @@ func (in *Backoff) DeepCopyInto(out *Backoff) {
 		*out = new(Amount)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MaxElapsedTime != nil {
+		in, out := &in.MaxElapsedTime, &out.MaxElapsedTime
+		*out = new(Int64OrString)
+		**out = **in
+	}
 	return
 }
 
@@ -222,6 +227,11 @@ func (in *S3Artifact) DeepCopyInto(out *S3Artifact) {
 			(*out)[key] = val
 		}
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -293,6 +303,27 @@ func (in *SASLConfig) DeepCopy() *SASLConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretBackend) DeepCopyInto(out *SecretBackend) {
+	*out = *in
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultSecretBackend)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretBackend.
+func (in *SecretBackend) DeepCopy() *SecretBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecureHeader) DeepCopyInto(out *SecureHeader) {
 	*out = *in
@@ -355,6 +386,26 @@ func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
 		*out = new(v1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CACertData != nil {
+		in, out := &in.CACertData, &out.CACertData
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClientCertData != nil {
+		in, out := &in.ClientCertData, &out.ClientCertData
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClientKeyData != nil {
+		in, out := &in.ClientKeyData, &out.ClientKeyData
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -393,3 +444,29 @@ func (in *ValueFromSource) DeepCopy() *ValueFromSource {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultSecretBackend) DeepCopyInto(out *VaultSecretBackend) {
+	*out = *in
+	if in.TokenSecret != nil {
+		in, out := &in.TokenSecret, &out.TokenSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecretBackend.
+func (in *VaultSecretBackend) DeepCopy() *VaultSecretBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultSecretBackend)
+	in.DeepCopyInto(out)
+	return out
+}
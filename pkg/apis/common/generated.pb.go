@@ -352,10 +352,38 @@ func (m *SASLConfig) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_SASLConfig proto.InternalMessageInfo
 
+func (m *SecretBackend) Reset()      { *m = SecretBackend{} }
+func (*SecretBackend) ProtoMessage() {}
+func (*SecretBackend) Descriptor() ([]byte, []int) {
+	return fileDescriptor_02aae6165a434fa7, []int{11}
+}
+func (m *SecretBackend) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SecretBackend) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *SecretBackend) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SecretBackend.Merge(m, src)
+}
+func (m *SecretBackend) XXX_Size() int {
+	return m.Size()
+}
+func (m *SecretBackend) XXX_DiscardUnknown() {
+	xxx_messageInfo_SecretBackend.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SecretBackend proto.InternalMessageInfo
+
 func (m *SecureHeader) Reset()      { *m = SecureHeader{} }
 func (*SecureHeader) ProtoMessage() {}
 func (*SecureHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02aae6165a434fa7, []int{11}
+	return fileDescriptor_02aae6165a434fa7, []int{12}
 }
 func (m *SecureHeader) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -383,7 +411,7 @@ var xxx_messageInfo_SecureHeader proto.InternalMessageInfo
 func (m *Status) Reset()      { *m = Status{} }
 func (*Status) ProtoMessage() {}
 func (*Status) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02aae6165a434fa7, []int{12}
+	return fileDescriptor_02aae6165a434fa7, []int{13}
 }
 func (m *Status) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -411,7 +439,7 @@ var xxx_messageInfo_Status proto.InternalMessageInfo
 func (m *TLSConfig) Reset()      { *m = TLSConfig{} }
 func (*TLSConfig) ProtoMessage() {}
 func (*TLSConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02aae6165a434fa7, []int{13}
+	return fileDescriptor_02aae6165a434fa7, []int{14}
 }
 func (m *TLSConfig) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -439,7 +467,7 @@ var xxx_messageInfo_TLSConfig proto.InternalMessageInfo
 func (m *ValueFromSource) Reset()      { *m = ValueFromSource{} }
 func (*ValueFromSource) ProtoMessage() {}
 func (*ValueFromSource) Descriptor() ([]byte, []int) {
-	return fileDescriptor_02aae6165a434fa7, []int{14}
+	return fileDescriptor_02aae6165a434fa7, []int{15}
 }
 func (m *ValueFromSource) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -464,6 +492,34 @@ func (m *ValueFromSource) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ValueFromSource proto.InternalMessageInfo
 
+func (m *VaultSecretBackend) Reset()      { *m = VaultSecretBackend{} }
+func (*VaultSecretBackend) ProtoMessage() {}
+func (*VaultSecretBackend) Descriptor() ([]byte, []int) {
+	return fileDescriptor_02aae6165a434fa7, []int{16}
+}
+func (m *VaultSecretBackend) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *VaultSecretBackend) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *VaultSecretBackend) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VaultSecretBackend.Merge(m, src)
+}
+func (m *VaultSecretBackend) XXX_Size() int {
+	return m.Size()
+}
+func (m *VaultSecretBackend) XXX_DiscardUnknown() {
+	xxx_messageInfo_VaultSecretBackend.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VaultSecretBackend proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*Amount)(nil), "github.com.argoproj.argo_events.pkg.apis.common.Amount")
 	proto.RegisterType((*Backoff)(nil), "github.com.argoproj.argo_events.pkg.apis.common.Backoff")
@@ -479,10 +535,12 @@ func init() {
 	proto.RegisterType((*S3Bucket)(nil), "github.com.argoproj.argo_events.pkg.apis.common.S3Bucket")
 	proto.RegisterType((*S3Filter)(nil), "github.com.argoproj.argo_events.pkg.apis.common.S3Filter")
 	proto.RegisterType((*SASLConfig)(nil), "github.com.argoproj.argo_events.pkg.apis.common.SASLConfig")
+	proto.RegisterType((*SecretBackend)(nil), "github.com.argoproj.argo_events.pkg.apis.common.SecretBackend")
 	proto.RegisterType((*SecureHeader)(nil), "github.com.argoproj.argo_events.pkg.apis.common.SecureHeader")
 	proto.RegisterType((*Status)(nil), "github.com.argoproj.argo_events.pkg.apis.common.Status")
 	proto.RegisterType((*TLSConfig)(nil), "github.com.argoproj.argo_events.pkg.apis.common.TLSConfig")
 	proto.RegisterType((*ValueFromSource)(nil), "github.com.argoproj.argo_events.pkg.apis.common.ValueFromSource")
+	proto.RegisterType((*VaultSecretBackend)(nil), "github.com.argoproj.argo_events.pkg.apis.common.VaultSecretBackend")
 }
 
 func init() {
@@ -490,91 +548,114 @@ func init() {
 }
 
 var fileDescriptor_02aae6165a434fa7 = []byte{
-	// 1340 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x96, 0xdd, 0x6e, 0x1b, 0xc5,
-	0x17, 0xc0, 0xb3, 0x71, 0xe2, 0x78, 0x4f, 0x92, 0x26, 0xff, 0x69, 0x2f, 0xac, 0x48, 0xb5, 0xa3,
-	0xfd, 0x0b, 0x94, 0x42, 0xbb, 0x56, 0x3f, 0x04, 0x6d, 0x91, 0x0a, 0x5e, 0x93, 0x8a, 0xb4, 0x09,
-	0xad, 0x66, 0xdb, 0x5c, 0xb4, 0x42, 0x68, 0xb2, 0x1e, 0x3b, 0x5b, 0x7b, 0x3f, 0x34, 0x3b, 0x4e,
-	0xeb, 0x3b, 0x10, 0x0f, 0x00, 0x6f, 0xc0, 0x13, 0xf0, 0x1e, 0xbd, 0xac, 0xb8, 0x69, 0xaf, 0x0c,
-	0x5d, 0x5e, 0x02, 0xf5, 0x0a, 0xcd, 0xc7, 0xae, 0xd7, 0x6e, 0x10, 0x6c, 0xc4, 0xdd, 0xfa, 0xcc,
-	0x39, 0xbf, 0x33, 0x73, 0x3e, 0x0d, 0x9f, 0xf7, 0x7d, 0x7e, 0x3c, 0x3a, 0xb2, 0xbd, 0x28, 0x68,
-	0x11, 0xd6, 0x8f, 0x62, 0x16, 0x3d, 0x93, 0x1f, 0x57, 0xe8, 0x09, 0x0d, 0x79, 0xd2, 0x8a, 0x07,
-	0xfd, 0x16, 0x89, 0xfd, 0xa4, 0xe5, 0x45, 0x41, 0x10, 0x85, 0xad, 0x3e, 0x0d, 0x29, 0x23, 0x9c,
-	0x76, 0xed, 0x98, 0x45, 0x3c, 0x42, 0xad, 0x29, 0xc0, 0xce, 0x00, 0xf2, 0xe3, 0x5b, 0x05, 0xb0,
-	0xe3, 0x41, 0xdf, 0x16, 0x00, 0x5b, 0x01, 0xb6, 0xae, 0x14, 0x3c, 0xf6, 0xa3, 0x7e, 0xd4, 0x92,
-	0x9c, 0xa3, 0x51, 0x4f, 0xfe, 0x92, 0x3f, 0xe4, 0x97, 0xe2, 0x6f, 0x59, 0x83, 0x9b, 0x89, 0xed,
-	0x47, 0xe2, 0x0e, 0x2d, 0x2f, 0x62, 0xb4, 0x75, 0x72, 0x75, 0xfe, 0x0e, 0x5b, 0x37, 0xa6, 0x3a,
-	0x01, 0xf1, 0x8e, 0xfd, 0x90, 0xb2, 0xf1, 0xf4, 0xe2, 0x01, 0xe5, 0xe4, 0x14, 0x2b, 0xeb, 0x12,
-	0x54, 0xdb, 0x41, 0x34, 0x0a, 0x39, 0x6a, 0xc2, 0xf2, 0x09, 0x19, 0x8e, 0x68, 0xdd, 0xd8, 0x36,
-	0x76, 0xd6, 0x1c, 0x33, 0x9d, 0x34, 0x97, 0x0f, 0x85, 0x00, 0x2b, 0xb9, 0xf5, 0xeb, 0x22, 0xac,
-	0x38, 0xc4, 0x1b, 0x44, 0xbd, 0x1e, 0x3a, 0x86, 0x5a, 0x77, 0xc4, 0x08, 0xf7, 0xa3, 0x50, 0xea,
-	0xaf, 0x5e, 0xbb, 0x63, 0x97, 0x8c, 0x81, 0xbd, 0x17, 0xf2, 0x4f, 0x6e, 0x3c, 0x60, 0x2e, 0x67,
-	0x7e, 0xd8, 0x77, 0xd6, 0xd2, 0x49, 0xb3, 0xf6, 0xa5, 0x66, 0xe2, 0x9c, 0x8e, 0x9e, 0x42, 0xb5,
-	0x47, 0x3c, 0x1e, 0xb1, 0xfa, 0xa2, 0xf4, 0xf3, 0x69, 0x69, 0x3f, 0xea, 0x7d, 0x0e, 0xa4, 0x93,
-	0x66, 0xf5, 0xae, 0x44, 0x61, 0x8d, 0x14, 0xf0, 0x67, 0x3e, 0xe7, 0x94, 0xd5, 0x2b, 0xff, 0x01,
-	0xfc, 0x9e, 0x44, 0x61, 0x8d, 0x44, 0xff, 0x87, 0xe5, 0x84, 0xd3, 0x38, 0xa9, 0x2f, 0x6d, 0x1b,
-	0x3b, 0xcb, 0xce, 0xfa, 0xcb, 0x49, 0x73, 0x41, 0x04, 0xd5, 0x15, 0x42, 0xac, 0xce, 0xac, 0x5f,
-	0x0c, 0x30, 0x1d, 0x92, 0xf8, 0x5e, 0x7b, 0xc4, 0x8f, 0xd1, 0x03, 0xa8, 0x8d, 0x12, 0xca, 0x42,
-	0x12, 0x50, 0x1d, 0xd6, 0x0f, 0x6c, 0x95, 0x56, 0xe1, 0xd4, 0x16, 0xa9, 0xb7, 0x4f, 0xae, 0xda,
-	0x2e, 0xf5, 0x18, 0xe5, 0xf7, 0xe9, 0xd8, 0xa5, 0x43, 0x2a, 0x1e, 0xa2, 0xa2, 0xf7, 0x58, 0x9b,
-	0xe2, 0x1c, 0x22, 0x80, 0x31, 0x49, 0x92, 0xe7, 0x11, 0xeb, 0xea, 0xf8, 0x95, 0x01, 0x3e, 0xd4,
-	0xa6, 0x38, 0x87, 0x58, 0xaf, 0x17, 0xc1, 0xec, 0x44, 0x61, 0xd7, 0x97, 0xc9, 0xb9, 0x0a, 0x4b,
-	0x7c, 0x1c, 0xab, 0xbb, 0x9a, 0xce, 0x45, 0xfd, 0xc2, 0xa5, 0x47, 0xe3, 0x98, 0xbe, 0x9b, 0x34,
-	0xd7, 0x73, 0x45, 0x21, 0xc0, 0x52, 0x15, 0xed, 0x43, 0x35, 0xe1, 0x84, 0x8f, 0x12, 0x79, 0x1f,
-	0xd3, 0xb9, 0xa1, 0x8d, 0xaa, 0xae, 0x94, 0xbe, 0x9b, 0x34, 0x4f, 0x29, 0x76, 0x3b, 0x27, 0x29,
-	0x2d, 0xac, 0x19, 0xe8, 0x04, 0xd0, 0x90, 0x24, 0xfc, 0x11, 0x23, 0x61, 0xa2, 0x3c, 0xf9, 0x01,
-	0xd5, 0xc9, 0xfc, 0xa8, 0xf0, 0xd2, 0xbc, 0x23, 0xa6, 0x09, 0x14, 0x1d, 0x21, 0xde, 0x2e, 0x2c,
-	0x9c, 0x2d, 0x7d, 0x0b, 0xb4, 0xff, 0x1e, 0x0d, 0x9f, 0xe2, 0x01, 0x7d, 0x08, 0x55, 0x46, 0x49,
-	0x12, 0x85, 0x32, 0xb9, 0xa6, 0x73, 0x2e, 0x7b, 0x05, 0x96, 0x52, 0xac, 0x4f, 0xd1, 0x25, 0x58,
-	0x09, 0x68, 0x92, 0x90, 0x3e, 0xad, 0x2f, 0x4b, 0xc5, 0x0d, 0xad, 0xb8, 0x72, 0xa0, 0xc4, 0x38,
-	0x3b, 0xb7, 0x7e, 0x34, 0x60, 0x7d, 0xa6, 0x25, 0xd0, 0x4e, 0x21, 0xba, 0x15, 0xe7, 0xc2, 0x5c,
-	0x74, 0x97, 0x0a, 0x41, 0xbd, 0x0c, 0x35, 0x5f, 0x98, 0x1e, 0x92, 0xa1, 0x0c, 0x6b, 0xc5, 0xd9,
-	0xd4, 0xda, 0xb5, 0x3d, 0x2d, 0xc7, 0xb9, 0x86, 0xb8, 0x7c, 0xc2, 0x99, 0xd0, 0xad, 0xcc, 0x5e,
-	0xde, 0x95, 0x52, 0xac, 0x4f, 0xad, 0x3f, 0x17, 0xa1, 0x76, 0x40, 0x39, 0xe9, 0x12, 0x4e, 0xd0,
-	0xf7, 0x06, 0xac, 0x92, 0x30, 0x8c, 0xb8, 0x6c, 0xcb, 0xa4, 0x6e, 0x6c, 0x57, 0x76, 0x56, 0xaf,
-	0xdd, 0x2b, 0xdd, 0x30, 0x19, 0xd0, 0x6e, 0x4f, 0x61, 0xbb, 0x21, 0x67, 0x63, 0xe7, 0xbc, 0xbe,
-	0xc6, 0x6a, 0xe1, 0x04, 0x17, 0x7d, 0xa2, 0x00, 0xaa, 0x43, 0x72, 0x44, 0x87, 0xa2, 0x76, 0x84,
-	0xf7, 0xdd, 0xb3, 0x7b, 0xdf, 0x97, 0x1c, 0xe5, 0x38, 0x7f, 0xbf, 0x12, 0x62, 0xed, 0x64, 0xeb,
-	0x0e, 0x6c, 0xce, 0x5f, 0x12, 0x6d, 0x42, 0x65, 0x40, 0xc7, 0xaa, 0xe0, 0xb1, 0xf8, 0x44, 0x17,
-	0xb2, 0xb9, 0x29, 0xeb, 0x59, 0x0f, 0xcb, 0xdb, 0x8b, 0x37, 0x8d, 0xad, 0x5b, 0xb0, 0x5a, 0x70,
-	0x53, 0xc6, 0xd4, 0xfa, 0x18, 0x6a, 0x98, 0x26, 0xd1, 0x88, 0x79, 0xf4, 0x9f, 0x07, 0xf3, 0xab,
-	0x65, 0x00, 0xf7, 0x7a, 0x9b, 0x71, 0x5f, 0x8c, 0x35, 0x51, 0x0c, 0x34, 0xec, 0xc6, 0x91, 0x1f,
-	0x72, 0xdd, 0x98, 0x79, 0x31, 0xec, 0x6a, 0x39, 0xce, 0x35, 0xd0, 0x37, 0x50, 0x3d, 0x1a, 0x79,
-	0x03, 0xca, 0xf5, 0x7c, 0xb8, 0x55, 0x3a, 0xa6, 0xee, 0x75, 0x47, 0x02, 0xd4, 0x10, 0x54, 0xdf,
-	0x58, 0x43, 0x55, 0xa3, 0xf4, 0xc5, 0x9a, 0xa8, 0xcc, 0x37, 0x8a, 0x90, 0x62, 0x7d, 0xaa, 0x2a,
-	0x38, 0xa1, 0xde, 0x88, 0x51, 0xd9, 0x52, 0xb5, 0x62, 0x05, 0x2b, 0x39, 0xce, 0x35, 0x10, 0x06,
-	0x93, 0x78, 0x1e, 0x4d, 0x92, 0xfb, 0x74, 0x2c, 0x1b, 0xeb, 0x5f, 0xcf, 0xb5, 0xf5, 0x74, 0xd2,
-	0x34, 0xdb, 0x99, 0x2d, 0x9e, 0x62, 0x04, 0x33, 0xc9, 0xd4, 0xeb, 0xd5, 0xd2, 0xcc, 0x5c, 0x8c,
-	0xa7, 0x18, 0x64, 0x41, 0x55, 0x05, 0xad, 0xbe, 0xb2, 0x5d, 0xd9, 0x31, 0x55, 0x84, 0x76, 0xa5,
-	0x04, 0xeb, 0x13, 0x91, 0x80, 0x9e, 0x3f, 0x14, 0x3b, 0xa8, 0x76, 0xe6, 0x04, 0xdc, 0x95, 0x00,
-	0xbd, 0xe2, 0xe4, 0x37, 0xd6, 0x50, 0xf4, 0x1c, 0x6a, 0x81, 0x2e, 0xfa, 0xba, 0x29, 0xbb, 0x66,
-	0xef, 0x0c, 0x0e, 0xb2, 0xe2, 0xca, 0x1b, 0x48, 0x75, 0x4e, 0x9e, 0xa3, 0x4c, 0x8c, 0x73, 0x67,
-	0x5b, 0x9f, 0xc1, 0xfa, 0x8c, 0x72, 0xa9, 0xfa, 0xbf, 0x0f, 0xb5, 0xac, 0xac, 0xd0, 0xc5, 0x82,
-	0x9d, 0xb3, 0xaa, 0x3d, 0x56, 0x44, 0xa4, 0x25, 0x64, 0x1b, 0x96, 0xe4, 0xbe, 0x54, 0xeb, 0x64,
-	0x2d, 0x9b, 0x92, 0x5f, 0x8b, 0x45, 0x28, 0x4f, 0xac, 0x27, 0x02, 0xa6, 0xc2, 0x22, 0xea, 0x31,
-	0x66, 0xb4, 0xe7, 0xbf, 0xd0, 0xbc, 0xbc, 0x1e, 0x1f, 0x4a, 0x29, 0xd6, 0xa7, 0x72, 0x46, 0x8e,
-	0x7a, 0x42, 0x6f, 0x71, 0x6e, 0x46, 0x4a, 0x29, 0xd6, 0xa7, 0xd6, 0x6f, 0x06, 0x80, 0xdb, 0x76,
-	0xf7, 0x3b, 0x51, 0xd8, 0xf3, 0xfb, 0xa8, 0x05, 0x66, 0x40, 0xbd, 0x63, 0x12, 0xfa, 0x49, 0xa0,
-	0x3d, 0xfc, 0x4f, 0x5b, 0x9a, 0x07, 0xd9, 0x01, 0x9e, 0xea, 0xa0, 0x3d, 0x58, 0x12, 0xcb, 0xba,
-	0xdc, 0x72, 0x3e, 0x97, 0x4e, 0x9a, 0x20, 0xb6, 0xbd, 0x3a, 0xc2, 0x12, 0x81, 0x1e, 0x17, 0x76,
-	0x7d, 0xa5, 0x0c, 0x0e, 0xa5, 0x93, 0xe6, 0xb9, 0x6c, 0xd7, 0x6b, 0xe4, 0x74, 0xe3, 0xff, 0x6c,
-	0xc0, 0x9a, 0x2b, 0xdb, 0xee, 0x2b, 0x4a, 0xba, 0x94, 0xe5, 0x01, 0x37, 0xfe, 0x2e, 0xe0, 0x28,
-	0x00, 0x53, 0xa6, 0xf2, 0x2e, 0x8b, 0x02, 0xfd, 0xb2, 0x2f, 0x4a, 0x17, 0xdd, 0x61, 0x46, 0x70,
-	0xe5, 0x18, 0x54, 0x5d, 0x96, 0x0b, 0xf1, 0xd4, 0x83, 0xf5, 0x02, 0xf4, 0x9f, 0x07, 0x14, 0x02,
-	0x78, 0xd9, 0x3f, 0x85, 0x6c, 0x45, 0xdd, 0x2e, 0xed, 0x39, 0xff, 0xb3, 0xe1, 0x20, 0xfd, 0x38,
-	0xc8, 0x45, 0x09, 0x2e, 0x78, 0xb0, 0x7e, 0xa8, 0x80, 0xf9, 0x68, 0xdf, 0xd5, 0xc9, 0x7f, 0x0a,
-	0x6b, 0x1e, 0xe9, 0x50, 0xc6, 0x55, 0x0c, 0xcb, 0xfd, 0x83, 0xdb, 0x4c, 0x27, 0xcd, 0xb5, 0x4e,
-	0x7b, 0x6a, 0x8e, 0x67, 0x60, 0xa8, 0x0f, 0x9b, 0xde, 0xd0, 0xa7, 0x21, 0x2f, 0x38, 0x28, 0x55,
-	0x34, 0x17, 0xd2, 0x49, 0x73, 0xb3, 0x33, 0x87, 0xc0, 0xef, 0x41, 0x51, 0x17, 0x36, 0x94, 0x4c,
-	0x1a, 0x4b, 0x3f, 0xa5, 0xaa, 0xe9, 0x7c, 0x3a, 0x69, 0x6e, 0x74, 0x66, 0x09, 0x78, 0x1e, 0x89,
-	0xee, 0x01, 0xca, 0xa6, 0xb9, 0x3b, 0xf0, 0xe3, 0x43, 0xca, 0xfc, 0xde, 0x58, 0x4f, 0xfe, 0xfc,
-	0xcf, 0xd8, 0xde, 0x7b, 0x1a, 0xf8, 0x14, 0x2b, 0xeb, 0xb5, 0x01, 0x1b, 0x73, 0xd5, 0x22, 0x72,
-	0x91, 0x8f, 0x61, 0x4c, 0x7b, 0x67, 0xc8, 0x85, 0x5b, 0x30, 0xc7, 0x33, 0x30, 0xd4, 0x87, 0x0d,
-	0x4f, 0xa6, 0xfc, 0x80, 0xc4, 0x9a, 0xaf, 0x52, 0xb1, 0x73, 0x1a, 0xbf, 0x53, 0x50, 0x9d, 0x8b,
-	0xd2, 0x2c, 0x04, 0xcf, 0x53, 0x9d, 0xcb, 0x2f, 0xdf, 0x36, 0x16, 0x5e, 0xbd, 0x6d, 0x2c, 0xbc,
-	0x79, 0xdb, 0x58, 0xf8, 0x2e, 0x6d, 0x18, 0x2f, 0xd3, 0x86, 0xf1, 0x2a, 0x6d, 0x18, 0x6f, 0xd2,
-	0x86, 0xf1, 0x7b, 0xda, 0x30, 0x7e, 0xfa, 0xa3, 0xb1, 0xf0, 0xa4, 0xaa, 0xea, 0xf6, 0xaf, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0xba, 0x27, 0x54, 0x26, 0xc7, 0x0e, 0x00, 0x00,
+	// 1701 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xdb, 0x6e, 0x1b, 0xc7,
+	0x19, 0xd6, 0x8a, 0x22, 0x45, 0xfe, 0xa2, 0x0e, 0x99, 0xf8, 0x62, 0x21, 0x20, 0xa4, 0xc0, 0xa2,
+	0x85, 0xd2, 0x26, 0x24, 0xec, 0x18, 0x8d, 0xe3, 0x02, 0x69, 0xb9, 0x8c, 0x8c, 0xca, 0x96, 0x1a,
+	0x63, 0x56, 0x16, 0x8a, 0x04, 0x45, 0x31, 0x5e, 0x0e, 0xa9, 0x0d, 0xf7, 0x84, 0x99, 0x59, 0xc5,
+	0xec, 0x55, 0xfb, 0x04, 0x2d, 0xfa, 0x02, 0x05, 0x7a, 0xdf, 0xd7, 0x28, 0x7c, 0x55, 0xe4, 0x2e,
+	0xb9, 0x62, 0x6b, 0xf6, 0x25, 0x8a, 0x5c, 0x15, 0x73, 0xd8, 0xe5, 0x2e, 0xa5, 0xa2, 0x58, 0xc1,
+	0x57, 0x5c, 0xfe, 0x87, 0xef, 0x9f, 0xf9, 0xcf, 0x03, 0x3f, 0x9f, 0xfa, 0xe2, 0x2a, 0x7d, 0xd9,
+	0xf7, 0xe2, 0x70, 0x40, 0xd8, 0x34, 0x4e, 0x58, 0xfc, 0x95, 0xfa, 0xf8, 0x90, 0x5e, 0xd3, 0x48,
+	0xf0, 0x41, 0x32, 0x9b, 0x0e, 0x48, 0xe2, 0xf3, 0x81, 0x17, 0x87, 0x61, 0x1c, 0x0d, 0xa6, 0x34,
+	0xa2, 0x8c, 0x08, 0x3a, 0xee, 0x27, 0x2c, 0x16, 0x31, 0x1a, 0xac, 0x00, 0xfa, 0x19, 0x80, 0xfa,
+	0xf8, 0xad, 0x06, 0xe8, 0x27, 0xb3, 0x69, 0x5f, 0x02, 0xf4, 0x35, 0xc0, 0xe1, 0x87, 0x05, 0x8b,
+	0xd3, 0x78, 0x1a, 0x0f, 0x14, 0xce, 0xcb, 0x74, 0xa2, 0xfe, 0xa9, 0x3f, 0xea, 0x4b, 0xe3, 0x1f,
+	0xf6, 0x66, 0x8f, 0x78, 0xdf, 0x8f, 0xe5, 0x19, 0x06, 0x5e, 0xcc, 0xe8, 0xe0, 0xfa, 0xfe, 0xfa,
+	0x19, 0x0e, 0x1f, 0xae, 0x64, 0x42, 0xe2, 0x5d, 0xf9, 0x11, 0x65, 0xf3, 0xd5, 0xc1, 0x43, 0x2a,
+	0xc8, 0x2d, 0x5a, 0xbd, 0xf7, 0xa1, 0x31, 0x0c, 0xe3, 0x34, 0x12, 0xa8, 0x0b, 0xf5, 0x6b, 0x12,
+	0xa4, 0xd4, 0xb6, 0x8e, 0xac, 0xe3, 0xb6, 0xd3, 0x5a, 0x2e, 0xba, 0xf5, 0x4b, 0x49, 0xc0, 0x9a,
+	0xde, 0xfb, 0x47, 0x0d, 0xb6, 0x1d, 0xe2, 0xcd, 0xe2, 0xc9, 0x04, 0x5d, 0x41, 0x73, 0x9c, 0x32,
+	0x22, 0xfc, 0x38, 0x52, 0xf2, 0x3b, 0x0f, 0x3e, 0xed, 0x57, 0xf4, 0x41, 0xff, 0x34, 0x12, 0x3f,
+	0x7d, 0xf8, 0x39, 0x73, 0x05, 0xf3, 0xa3, 0xa9, 0xd3, 0x5e, 0x2e, 0xba, 0xcd, 0xcf, 0x0c, 0x26,
+	0xce, 0xd1, 0xd1, 0x97, 0xd0, 0x98, 0x10, 0x4f, 0xc4, 0xcc, 0xde, 0x54, 0x76, 0x3e, 0xae, 0x6c,
+	0x47, 0xdf, 0xcf, 0x81, 0xe5, 0xa2, 0xdb, 0x78, 0xa2, 0xa0, 0xb0, 0x81, 0x94, 0xe0, 0x5f, 0xf9,
+	0x42, 0x50, 0x66, 0xd7, 0xde, 0x02, 0xf8, 0x53, 0x05, 0x85, 0x0d, 0x24, 0xfa, 0x01, 0xd4, 0xb9,
+	0xa0, 0x09, 0xb7, 0xb7, 0x8e, 0xac, 0xe3, 0xba, 0xb3, 0xfb, 0x7a, 0xd1, 0xdd, 0x90, 0x4e, 0x75,
+	0x25, 0x11, 0x6b, 0x1e, 0xfa, 0x1d, 0xec, 0x85, 0xe4, 0xd5, 0x49, 0x40, 0x12, 0x4e, 0xc7, 0x17,
+	0x7e, 0x48, 0xed, 0xfa, 0x5b, 0x71, 0x27, 0x5a, 0x2e, 0xba, 0x7b, 0xe7, 0x25, 0x64, 0xbc, 0x66,
+	0xa9, 0xf7, 0x37, 0x0b, 0x5a, 0x0e, 0xe1, 0xbe, 0x37, 0x4c, 0xc5, 0x15, 0xfa, 0x1c, 0x9a, 0x29,
+	0xa7, 0x2c, 0x22, 0x21, 0x35, 0x21, 0xfd, 0x61, 0x5f, 0xa7, 0x94, 0x34, 0xd3, 0x97, 0x69, 0xd7,
+	0xbf, 0xbe, 0xdf, 0x77, 0xa9, 0xc7, 0xa8, 0x78, 0x46, 0xe7, 0x2e, 0x0d, 0xa8, 0x74, 0xa2, 0x8e,
+	0xdc, 0x0b, 0xa3, 0x8a, 0x73, 0x10, 0x09, 0x98, 0x10, 0xce, 0xbf, 0x8e, 0xd9, 0xd8, 0xde, 0xac,
+	0x0c, 0xf8, 0xdc, 0xa8, 0xe2, 0x1c, 0xa4, 0xf7, 0xed, 0x26, 0xb4, 0x46, 0x71, 0x34, 0xf6, 0x55,
+	0x62, 0xdc, 0x87, 0x2d, 0x31, 0x4f, 0xf4, 0x59, 0x5b, 0xce, 0x7b, 0xc6, 0xbb, 0x5b, 0x17, 0xf3,
+	0x84, 0x7e, 0xbf, 0xe8, 0xee, 0xe6, 0x82, 0x92, 0x80, 0x95, 0x28, 0x3a, 0x83, 0x06, 0x17, 0x44,
+	0xa4, 0x5c, 0x9d, 0xa7, 0xe5, 0x3c, 0x34, 0x4a, 0x0d, 0x57, 0x51, 0xbf, 0x5f, 0x74, 0x6f, 0x29,
+	0xb4, 0x7e, 0x8e, 0xa4, 0xa5, 0xb0, 0xc1, 0x40, 0xd7, 0x80, 0x02, 0xc2, 0xc5, 0x05, 0x23, 0x11,
+	0xd7, 0x96, 0x64, 0xf8, 0x74, 0x22, 0xfd, 0xb8, 0x70, 0xd3, 0xbc, 0x1a, 0x57, 0x21, 0x93, 0xd5,
+	0x28, 0xef, 0x2e, 0x35, 0x9c, 0x43, 0x73, 0x0a, 0x74, 0x76, 0x03, 0x0d, 0xdf, 0x62, 0x01, 0xfd,
+	0x08, 0x1a, 0x8c, 0x12, 0x1e, 0x47, 0x2a, 0xb1, 0x5a, 0xce, 0x5e, 0x76, 0x0b, 0xac, 0xa8, 0xd8,
+	0x70, 0xd1, 0xfb, 0xb0, 0x1d, 0x52, 0xce, 0xc9, 0x54, 0xe7, 0x54, 0xcb, 0xd9, 0x37, 0x82, 0xdb,
+	0xe7, 0x9a, 0x8c, 0x33, 0x7e, 0xef, 0x8f, 0x16, 0xec, 0x96, 0xf2, 0x07, 0x1d, 0x17, 0xbc, 0x5b,
+	0x73, 0xee, 0xad, 0x79, 0x77, 0xab, 0xe0, 0xd4, 0x0f, 0xa0, 0xe9, 0x4b, 0xd5, 0x4b, 0x12, 0x28,
+	0xb7, 0xd6, 0x9c, 0x03, 0x23, 0xdd, 0x3c, 0x35, 0x74, 0x9c, 0x4b, 0xc8, 0xc3, 0x73, 0xc1, 0xa4,
+	0x6c, 0xad, 0x7c, 0x78, 0x57, 0x51, 0xb1, 0xe1, 0xf6, 0xfe, 0xb3, 0x09, 0xcd, 0x73, 0x2a, 0xc8,
+	0x98, 0x08, 0x82, 0xfe, 0x60, 0xc1, 0x0e, 0x89, 0xa2, 0x58, 0xa8, 0x96, 0xc0, 0x6d, 0xeb, 0xa8,
+	0x76, 0xbc, 0xf3, 0xe0, 0x69, 0xe5, 0x12, 0xc9, 0x00, 0xfb, 0xc3, 0x15, 0xd8, 0x49, 0x24, 0xd8,
+	0xdc, 0x79, 0xd7, 0x1c, 0x63, 0xa7, 0xc0, 0xc1, 0x45, 0x9b, 0x28, 0x84, 0x46, 0x40, 0x5e, 0xd2,
+	0x40, 0xe6, 0x8e, 0xb4, 0x7e, 0x72, 0x77, 0xeb, 0x67, 0x0a, 0x47, 0x1b, 0xce, 0xef, 0xaf, 0x89,
+	0xd8, 0x18, 0x39, 0xfc, 0x14, 0x0e, 0xd6, 0x0f, 0x89, 0x0e, 0xa0, 0x36, 0xa3, 0x73, 0x9d, 0xf0,
+	0x58, 0x7e, 0xa2, 0x7b, 0x59, 0xcf, 0x56, 0xf9, 0x6c, 0x1a, 0xf5, 0xe3, 0xcd, 0x47, 0xd6, 0xe1,
+	0x27, 0xb0, 0x53, 0x30, 0x53, 0x45, 0xb5, 0xf7, 0x13, 0x68, 0x62, 0xca, 0xe3, 0x94, 0x79, 0xf4,
+	0xff, 0x0f, 0x85, 0x3f, 0x6f, 0x03, 0xb8, 0x1f, 0x0d, 0x99, 0xf0, 0x65, 0x4b, 0x95, 0xc9, 0x40,
+	0xa3, 0x71, 0x12, 0xfb, 0x91, 0x30, 0x85, 0x99, 0x27, 0xc3, 0x89, 0xa1, 0xe3, 0x5c, 0x02, 0xfd,
+	0x06, 0x1a, 0x2f, 0x53, 0x6f, 0x46, 0x85, 0xe9, 0x0f, 0x9f, 0x54, 0xf6, 0xa9, 0xfb, 0x91, 0xa3,
+	0x00, 0x74, 0x03, 0xd6, 0xdf, 0xd8, 0x80, 0xea, 0x42, 0x99, 0xca, 0x11, 0x55, 0x5b, 0x2f, 0x94,
+	0xa9, 0xaf, 0x0b, 0x45, 0xfe, 0xea, 0x0c, 0xe6, 0xd4, 0x4b, 0x19, 0x55, 0x25, 0xd5, 0x2c, 0x66,
+	0xb0, 0xa6, 0xe3, 0x5c, 0x02, 0x61, 0x68, 0x11, 0xcf, 0xa3, 0x9c, 0x3f, 0xa3, 0x73, 0xbb, 0x5e,
+	0xa5, 0xaf, 0xed, 0x2e, 0x17, 0xdd, 0xd6, 0x30, 0xd3, 0xc5, 0x2b, 0x18, 0x89, 0xc9, 0x33, 0x71,
+	0xbb, 0x51, 0x19, 0x33, 0x27, 0xe3, 0x15, 0x0c, 0xea, 0x41, 0x43, 0x3b, 0xcd, 0xde, 0x3e, 0xaa,
+	0x1d, 0xb7, 0xb4, 0x87, 0x4e, 0x14, 0x05, 0x1b, 0x8e, 0x0c, 0xc0, 0xc4, 0x0f, 0xe4, 0xfc, 0x6b,
+	0xde, 0x39, 0x00, 0x4f, 0x14, 0x80, 0x19, 0xaf, 0xea, 0x1b, 0x1b, 0x50, 0xf4, 0x35, 0x34, 0x43,
+	0x93, 0xf4, 0x76, 0x4b, 0x55, 0xcd, 0xe9, 0x1d, 0x0c, 0x64, 0xc9, 0x95, 0x17, 0x90, 0xae, 0x9c,
+	0x3c, 0x46, 0x19, 0x19, 0xe7, 0xc6, 0xd0, 0x23, 0x68, 0x27, 0x71, 0x10, 0x9c, 0x46, 0x82, 0xb2,
+	0x6b, 0x12, 0xd8, 0xa0, 0xe2, 0x9f, 0x75, 0xb1, 0xf6, 0xf3, 0x02, 0x0f, 0x97, 0x24, 0xd1, 0x00,
+	0x5a, 0x09, 0x11, 0x57, 0xae, 0x98, 0x07, 0xd4, 0xde, 0x51, 0xc9, 0xf0, 0x8e, 0x51, 0x6b, 0x3d,
+	0xcf, 0x18, 0x78, 0x25, 0x83, 0x5e, 0x40, 0x4d, 0x04, 0xdc, 0x6e, 0x2b, 0xff, 0x3d, 0xae, 0x7c,
+	0xbd, 0x8b, 0x33, 0x77, 0x14, 0x47, 0x13, 0x7f, 0xea, 0x6c, 0x2f, 0x17, 0xdd, 0xda, 0xc5, 0x99,
+	0x8b, 0x25, 0xde, 0xe1, 0xcf, 0x60, 0xb7, 0x74, 0xdd, 0x4a, 0x15, 0xfc, 0x0c, 0x9a, 0x59, 0x61,
+	0xa0, 0xf7, 0x0a, 0x7a, 0xce, 0x8e, 0xb9, 0x4a, 0x4d, 0xe6, 0x8a, 0x02, 0x39, 0x82, 0x2d, 0x35,
+	0xf1, 0xf5, 0x40, 0x6c, 0x67, 0x7d, 0xfe, 0x57, 0x72, 0x94, 0x2b, 0x4e, 0xef, 0x0b, 0x09, 0xa6,
+	0x03, 0x2b, 0x2b, 0x2a, 0x61, 0x74, 0xe2, 0xbf, 0xb2, 0xad, 0x72, 0x45, 0x3d, 0x57, 0x54, 0x6c,
+	0xb8, 0x52, 0x8e, 0xa7, 0x13, 0x29, 0xb7, 0xb9, 0xd6, 0xe5, 0x15, 0x15, 0x1b, 0x6e, 0xef, 0x9f,
+	0x16, 0x80, 0x3b, 0x74, 0xcf, 0xb4, 0x0b, 0xa4, 0xf3, 0x43, 0xea, 0x5d, 0x91, 0xc8, 0xe7, 0xa1,
+	0xb1, 0x90, 0x3b, 0xff, 0x3c, 0x63, 0xe0, 0x95, 0x0c, 0x3a, 0x85, 0x2d, 0xb9, 0x6e, 0x54, 0x5b,
+	0x2f, 0xf6, 0x96, 0x8b, 0x2e, 0xc8, 0x7d, 0x45, 0xb3, 0xb0, 0x82, 0x40, 0x2f, 0x0a, 0xdb, 0x4a,
+	0xad, 0x0a, 0x9c, 0xda, 0xb4, 0xb2, 0x6d, 0xc5, 0x40, 0xae, 0x76, 0x96, 0x14, 0x76, 0x35, 0x4d,
+	0x6e, 0xce, 0x34, 0x1a, 0xa3, 0xb1, 0x8c, 0x5a, 0x1a, 0x08, 0xb3, 0x63, 0x8d, 0x2a, 0x67, 0xcc,
+	0xa5, 0xd4, 0x2e, 0x61, 0x66, 0x6d, 0x39, 0x0d, 0x04, 0xd6, 0xe0, 0xbd, 0xbf, 0x58, 0xd0, 0x76,
+	0x55, 0xbf, 0xfa, 0x25, 0x25, 0x63, 0xca, 0xf2, 0x38, 0x5b, 0xff, 0x2b, 0xce, 0x28, 0x84, 0x96,
+	0xca, 0xa0, 0x27, 0x2c, 0x0e, 0x8d, 0x43, 0x7f, 0x71, 0x87, 0xc3, 0x19, 0x04, 0x57, 0xcd, 0x0f,
+	0xdd, 0x9e, 0x72, 0x22, 0x5e, 0x59, 0xe8, 0xbd, 0x02, 0xb3, 0x75, 0xa1, 0x08, 0xc0, 0xcb, 0x56,
+	0xac, 0x6c, 0xb6, 0x57, 0x2f, 0xa4, 0x7c, 0x4b, 0x73, 0x90, 0xb9, 0x1c, 0xe4, 0x24, 0x8e, 0x0b,
+	0x16, 0x7a, 0x7f, 0xaf, 0x43, 0x2b, 0x2f, 0x3b, 0xf4, 0x25, 0xb4, 0x3d, 0x32, 0xa2, 0xcc, 0xb8,
+	0xb4, 0xda, 0xea, 0x7b, 0x20, 0xbb, 0xc9, 0x68, 0xb8, 0x52, 0xc7, 0x25, 0x30, 0x34, 0x85, 0x03,
+	0x2f, 0xf0, 0x69, 0x24, 0x0a, 0x06, 0x2a, 0xe5, 0xea, 0xbd, 0xe5, 0xa2, 0x7b, 0x30, 0x5a, 0x83,
+	0xc0, 0x37, 0x40, 0xd1, 0x18, 0xf6, 0x35, 0x4d, 0x29, 0x2b, 0x3b, 0x95, 0x92, 0xf8, 0xdd, 0xe5,
+	0xa2, 0xbb, 0x3f, 0x2a, 0x23, 0xe0, 0x75, 0x48, 0xf4, 0x14, 0x50, 0x36, 0x06, 0xdd, 0x99, 0x9f,
+	0x5c, 0x52, 0xe6, 0x4f, 0xe6, 0x66, 0x64, 0xe6, 0x5b, 0xec, 0xe9, 0x0d, 0x09, 0x7c, 0x8b, 0x16,
+	0xea, 0x03, 0x68, 0x57, 0x7d, 0x26, 0xa7, 0x43, 0x5d, 0xad, 0x17, 0xaa, 0x32, 0x47, 0xc3, 0x8c,
+	0x8a, 0x0b, 0x12, 0xe8, 0x31, 0xec, 0xad, 0x6e, 0xad, 0x74, 0x1a, 0x4a, 0x47, 0x95, 0xdf, 0xa8,
+	0xc4, 0xc1, 0x6b, 0x92, 0xe8, 0x63, 0xd8, 0xcd, 0xaf, 0xa2, 0x54, 0xb7, 0x95, 0xea, 0x3b, 0x4b,
+	0xf9, 0x4e, 0x28, 0x32, 0x70, 0x59, 0x0e, 0x3d, 0x00, 0x08, 0xfd, 0xe8, 0x92, 0x32, 0x2e, 0xb7,
+	0x88, 0xa6, 0xaa, 0x9d, 0x3c, 0xbd, 0xce, 0x73, 0x0e, 0x2e, 0x48, 0xa1, 0x87, 0xd0, 0xf6, 0xfc,
+	0xe4, 0x8a, 0x32, 0x37, 0xf5, 0x05, 0xe5, 0x6a, 0xf0, 0xb5, 0x4c, 0xa6, 0x14, 0xe8, 0xb8, 0x24,
+	0x25, 0x2d, 0x71, 0xca, 0xae, 0x29, 0x93, 0x15, 0x69, 0x43, 0xd9, 0x92, 0x9b, 0x73, 0x70, 0x41,
+	0xaa, 0xf7, 0xad, 0x05, 0xfb, 0x6b, 0x05, 0x27, 0xd3, 0x39, 0x5f, 0x01, 0x30, 0x9d, 0xdc, 0x21,
+	0x9d, 0xdd, 0x82, 0x3a, 0x2e, 0x81, 0xa1, 0x29, 0xec, 0x7b, 0xaa, 0x6a, 0xce, 0x49, 0x62, 0xf0,
+	0x75, 0x36, 0x1f, 0xdf, 0x86, 0x3f, 0x2a, 0x88, 0xae, 0x25, 0x5a, 0x19, 0x04, 0xaf, 0xa3, 0xf6,
+	0xfe, 0x5a, 0x03, 0x74, 0xb3, 0xcf, 0xc9, 0x17, 0x0d, 0x19, 0x8f, 0x19, 0xe5, 0xdc, 0xb6, 0xca,
+	0x2f, 0x9a, 0xa1, 0x26, 0xe3, 0x8c, 0xaf, 0x46, 0x89, 0x7c, 0x99, 0xcb, 0x99, 0x6d, 0x6f, 0xae,
+	0x8d, 0x92, 0x8c, 0x81, 0x57, 0x32, 0x52, 0x61, 0x76, 0x9d, 0x45, 0xba, 0xa6, 0x5e, 0xec, 0xb9,
+	0xc2, 0xb3, 0xcb, 0x2c, 0xd0, 0x2b, 0x19, 0xd9, 0x51, 0x59, 0x1c, 0x50, 0x7b, 0xab, 0xdc, 0x51,
+	0x71, 0x1c, 0x50, 0xac, 0x38, 0x72, 0xaf, 0x24, 0xa9, 0xb8, 0x52, 0x47, 0xa8, 0x97, 0x97, 0xe1,
+	0xa1, 0xa1, 0xe3, 0x5c, 0x02, 0xfd, 0x1a, 0x76, 0x44, 0x3c, 0xa3, 0x91, 0x29, 0xdf, 0x4a, 0x5b,
+	0xe0, 0xbe, 0x7c, 0xba, 0x5c, 0xac, 0xb4, 0x71, 0x11, 0x2a, 0x5b, 0x51, 0xb6, 0xdf, 0xee, 0x8a,
+	0xe2, 0x7c, 0xf0, 0xfa, 0x4d, 0x67, 0xe3, 0x9b, 0x37, 0x9d, 0x8d, 0xef, 0xde, 0x74, 0x36, 0x7e,
+	0xbf, 0xec, 0x58, 0xaf, 0x97, 0x1d, 0xeb, 0x9b, 0x65, 0xc7, 0xfa, 0x6e, 0xd9, 0xb1, 0xfe, 0xb5,
+	0xec, 0x58, 0x7f, 0xfa, 0x77, 0x67, 0xe3, 0x8b, 0x86, 0x46, 0xf9, 0xef, 0x00, 0x11, 0x77, 0x41,
+	0xb4, 0x64, 0x13, 0x00, 0x00,
 }
 
 func (m *Amount) Marshal() (dAtA []byte, err error) {
@@ -627,6 +708,18 @@ func (m *Backoff) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.MaxElapsedTime != nil {
+		{
+			size, err := m.MaxElapsedTime.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintGenerated(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
 	i = encodeVarintGenerated(dAtA, i, uint64(m.Steps))
 	i--
 	dAtA[i] = 0x20
@@ -924,6 +1017,31 @@ func (m *S3Artifact) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.TLS != nil {
+		{
+			size, err := m.TLS.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintGenerated(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x62
+	}
+	i--
+	if m.PathStyle {
+		dAtA[i] = 1
+	} else {
+		dAtA[i] = 0
+	}
+	i--
+	dAtA[i] = 0x58
+	i -= len(m.PollInterval)
+	copy(dAtA[i:], m.PollInterval)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.PollInterval)))
+	i--
+	dAtA[i] = 0x52
 	if len(m.Metadata) > 0 {
 		keysForMetadata := make([]string, 0, len(m.Metadata))
 		for k := range m.Metadata {
@@ -1144,6 +1262,41 @@ func (m *SASLConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *SecretBackend) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SecretBackend) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SecretBackend) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Vault != nil {
+		{
+			size, err := m.Vault.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintGenerated(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *SecureHeader) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1241,6 +1394,46 @@ func (m *TLSConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	i -= len(m.ServerName)
+	copy(dAtA[i:], m.ServerName)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.ServerName)))
+	i--
+	dAtA[i] = 0x52
+	if len(m.CipherSuites) > 0 {
+		for iNdEx := len(m.CipherSuites) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.CipherSuites[iNdEx])
+			copy(dAtA[i:], m.CipherSuites[iNdEx])
+			i = encodeVarintGenerated(dAtA, i, uint64(len(m.CipherSuites[iNdEx])))
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
+	i -= len(m.MinVersion)
+	copy(dAtA[i:], m.MinVersion)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.MinVersion)))
+	i--
+	dAtA[i] = 0x42
+	if m.ClientKeyData != nil {
+		i -= len(m.ClientKeyData)
+		copy(dAtA[i:], m.ClientKeyData)
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.ClientKeyData)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.ClientCertData != nil {
+		i -= len(m.ClientCertData)
+		copy(dAtA[i:], m.ClientCertData)
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.ClientCertData)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.CACertData != nil {
+		i -= len(m.CACertData)
+		copy(dAtA[i:], m.CACertData)
+		i = encodeVarintGenerated(dAtA, i, uint64(len(m.CACertData)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	i--
 	if m.InsecureSkipVerify {
 		dAtA[i] = 1
@@ -1335,6 +1528,76 @@ func (m *ValueFromSource) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *VaultSecretBackend) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VaultSecretBackend) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VaultSecretBackend) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TLS != nil {
+		{
+			size, err := m.TLS.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintGenerated(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.TokenSecret != nil {
+		{
+			size, err := m.TokenSecret.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintGenerated(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	i -= len(m.AuthPath)
+	copy(dAtA[i:], m.AuthPath)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.AuthPath)))
+	i--
+	dAtA[i] = 0x2a
+	i -= len(m.Role)
+	copy(dAtA[i:], m.Role)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Role)))
+	i--
+	dAtA[i] = 0x22
+	i = encodeVarintGenerated(dAtA, i, uint64(m.KVVersion))
+	i--
+	dAtA[i] = 0x18
+	i -= len(m.MountPath)
+	copy(dAtA[i:], m.MountPath)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.MountPath)))
+	i--
+	dAtA[i] = 0x12
+	i -= len(m.Address)
+	copy(dAtA[i:], m.Address)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Address)))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintGenerated(dAtA []byte, offset int, v uint64) int {
 	offset -= sovGenerated(v)
 	base := offset
@@ -1378,6 +1641,10 @@ func (m *Backoff) Size() (n int) {
 		n += 1 + l + sovGenerated(uint64(l))
 	}
 	n += 1 + sovGenerated(uint64(m.Steps))
+	if m.MaxElapsedTime != nil {
+		l = m.MaxElapsedTime.Size()
+		n += 1 + l + sovGenerated(uint64(l))
+	}
 	return n
 }
 
@@ -1509,6 +1776,13 @@ func (m *S3Artifact) Size() (n int) {
 			n += mapEntrySize + 1 + sovGenerated(uint64(mapEntrySize))
 		}
 	}
+	l = len(m.PollInterval)
+	n += 1 + l + sovGenerated(uint64(l))
+	n += 2
+	if m.TLS != nil {
+		l = m.TLS.Size()
+		n += 1 + l + sovGenerated(uint64(l))
+	}
 	return n
 }
 
@@ -1557,6 +1831,19 @@ func (m *SASLConfig) Size() (n int) {
 	return n
 }
 
+func (m *SecretBackend) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Vault != nil {
+		l = m.Vault.Size()
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	return n
+}
+
 func (m *SecureHeader) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1606,6 +1893,28 @@ func (m *TLSConfig) Size() (n int) {
 		n += 1 + l + sovGenerated(uint64(l))
 	}
 	n += 2
+	if m.CACertData != nil {
+		l = len(m.CACertData)
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	if m.ClientCertData != nil {
+		l = len(m.ClientCertData)
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	if m.ClientKeyData != nil {
+		l = len(m.ClientKeyData)
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	l = len(m.MinVersion)
+	n += 1 + l + sovGenerated(uint64(l))
+	if len(m.CipherSuites) > 0 {
+		for _, s := range m.CipherSuites {
+			l = len(s)
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	l = len(m.ServerName)
+	n += 1 + l + sovGenerated(uint64(l))
 	return n
 }
 
@@ -1626,6 +1935,32 @@ func (m *ValueFromSource) Size() (n int) {
 	return n
 }
 
+func (m *VaultSecretBackend) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Address)
+	n += 1 + l + sovGenerated(uint64(l))
+	l = len(m.MountPath)
+	n += 1 + l + sovGenerated(uint64(l))
+	n += 1 + sovGenerated(uint64(m.KVVersion))
+	l = len(m.Role)
+	n += 1 + l + sovGenerated(uint64(l))
+	l = len(m.AuthPath)
+	n += 1 + l + sovGenerated(uint64(l))
+	if m.TokenSecret != nil {
+		l = m.TokenSecret.Size()
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	if m.TLS != nil {
+		l = m.TLS.Size()
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	return n
+}
+
 func sovGenerated(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -1651,6 +1986,7 @@ func (this *Backoff) String() string {
 		`Factor:` + strings.Replace(this.Factor.String(), "Amount", "Amount", 1) + `,`,
 		`Jitter:` + strings.Replace(this.Jitter.String(), "Amount", "Amount", 1) + `,`,
 		`Steps:` + fmt.Sprintf("%v", this.Steps) + `,`,
+		`MaxElapsedTime:` + strings.Replace(this.MaxElapsedTime.String(), "Int64OrString", "Int64OrString", 1) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1757,6 +2093,9 @@ func (this *S3Artifact) String() string {
 		`Events:` + fmt.Sprintf("%v", this.Events) + `,`,
 		`Filter:` + strings.Replace(this.Filter.String(), "S3Filter", "S3Filter", 1) + `,`,
 		`Metadata:` + mapStringForMetadata + `,`,
+		`PollInterval:` + fmt.Sprintf("%v", this.PollInterval) + `,`,
+		`PathStyle:` + fmt.Sprintf("%v", this.PathStyle) + `,`,
+		`TLS:` + strings.Replace(this.TLS.String(), "TLSConfig", "TLSConfig", 1) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1795,6 +2134,16 @@ func (this *SASLConfig) String() string {
 	}, "")
 	return s
 }
+func (this *SecretBackend) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&SecretBackend{`,
+		`Vault:` + strings.Replace(this.Vault.String(), "VaultSecretBackend", "VaultSecretBackend", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func (this *SecureHeader) String() string {
 	if this == nil {
 		return "nil"
@@ -1830,6 +2179,12 @@ func (this *TLSConfig) String() string {
 		`ClientCertSecret:` + strings.Replace(fmt.Sprintf("%v", this.ClientCertSecret), "SecretKeySelector", "v1.SecretKeySelector", 1) + `,`,
 		`ClientKeySecret:` + strings.Replace(fmt.Sprintf("%v", this.ClientKeySecret), "SecretKeySelector", "v1.SecretKeySelector", 1) + `,`,
 		`InsecureSkipVerify:` + fmt.Sprintf("%v", this.InsecureSkipVerify) + `,`,
+		`CACertData:` + valueToStringGenerated(this.CACertData) + `,`,
+		`ClientCertData:` + valueToStringGenerated(this.ClientCertData) + `,`,
+		`ClientKeyData:` + valueToStringGenerated(this.ClientKeyData) + `,`,
+		`MinVersion:` + fmt.Sprintf("%v", this.MinVersion) + `,`,
+		`CipherSuites:` + fmt.Sprintf("%v", this.CipherSuites) + `,`,
+		`ServerName:` + fmt.Sprintf("%v", this.ServerName) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1845,6 +2200,22 @@ func (this *ValueFromSource) String() string {
 	}, "")
 	return s
 }
+func (this *VaultSecretBackend) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&VaultSecretBackend{`,
+		`Address:` + fmt.Sprintf("%v", this.Address) + `,`,
+		`MountPath:` + fmt.Sprintf("%v", this.MountPath) + `,`,
+		`KVVersion:` + fmt.Sprintf("%v", this.KVVersion) + `,`,
+		`Role:` + fmt.Sprintf("%v", this.Role) + `,`,
+		`AuthPath:` + fmt.Sprintf("%v", this.AuthPath) + `,`,
+		`TokenSecret:` + strings.Replace(fmt.Sprintf("%v", this.TokenSecret), "SecretKeySelector", "v1.SecretKeySelector", 1) + `,`,
+		`TLS:` + strings.Replace(this.TLS.String(), "TLSConfig", "TLSConfig", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func valueToStringGenerated(v interface{}) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -2093,6 +2464,42 @@ func (m *Backoff) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxElapsedTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MaxElapsedTime == nil {
+				m.MaxElapsedTime = &Int64OrString{}
+			}
+			if err := m.MaxElapsedTime.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenerated(dAtA[iNdEx:])
@@ -3371,14 +3778,102 @@ func (m *S3Artifact) Unmarshal(dAtA []byte) error {
 			}
 			m.Metadata[mapkey] = mapvalue
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipGenerated(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PollInterval", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthGenerated
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PollInterval = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PathStyle", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.PathStyle = bool(v != 0)
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TLS", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TLS == nil {
+				m.TLS = &TLSConfig{}
+			}
+			if err := m.TLS.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGenerated
 			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
@@ -3774,6 +4269,92 @@ func (m *SASLConfig) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *SecretBackend) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SecretBackend: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SecretBackend: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Vault", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Vault == nil {
+				m.Vault = &VaultSecretBackend{}
+			}
+			if err := m.Vault.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *SecureHeader) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -4133,61 +4714,45 @@ func (m *TLSConfig) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.InsecureSkipVerify = bool(v != 0)
-		default:
-			iNdEx = preIndex
-			skippy, err := skipGenerated(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CACertData", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthGenerated
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			if byteLen < 0 {
+				return ErrInvalidLengthGenerated
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ValueFromSource) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowGenerated
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.CACertData = append(m.CACertData[:0], dAtA[iNdEx:postIndex]...)
+			if m.CACertData == nil {
+				m.CACertData = []byte{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ValueFromSource: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ValueFromSource: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SecretKeyRef", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientCertData", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -4197,33 +4762,31 @@ func (m *ValueFromSource) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenerated
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.SecretKeyRef == nil {
-				m.SecretKeyRef = &v1.SecretKeySelector{}
-			}
-			if err := m.SecretKeyRef.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.ClientCertData = append(m.ClientCertData[:0], dAtA[iNdEx:postIndex]...)
+			if m.ClientCertData == nil {
+				m.ClientCertData = []byte{}
 			}
 			iNdEx = postIndex
-		case 2:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConfigMapKeyRef", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientKeyData", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -4233,22 +4796,238 @@ func (m *ValueFromSource) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenerated
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ConfigMapKeyRef == nil {
+			m.ClientKeyData = append(m.ClientKeyData[:0], dAtA[iNdEx:postIndex]...)
+			if m.ClientKeyData == nil {
+				m.ClientKeyData = []byte{}
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinVersion", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MinVersion = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CipherSuites", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CipherSuites = append(m.CipherSuites, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServerName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ServerName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ValueFromSource) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ValueFromSource: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ValueFromSource: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SecretKeyRef", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SecretKeyRef == nil {
+				m.SecretKeyRef = &v1.SecretKeySelector{}
+			}
+			if err := m.SecretKeyRef.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigMapKeyRef", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConfigMapKeyRef == nil {
 				m.ConfigMapKeyRef = &v1.ConfigMapKeySelector{}
 			}
 			if err := m.ConfigMapKeyRef.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
@@ -4276,6 +5055,275 @@ func (m *ValueFromSource) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *VaultSecretBackend) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VaultSecretBackend: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VaultSecretBackend: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MountPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MountPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KVVersion", wireType)
+			}
+			m.KVVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.KVVersion |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Role", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Role = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AuthPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AuthPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenSecret", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TokenSecret == nil {
+				m.TokenSecret = &v1.SecretKeySelector{}
+			}
+			if err := m.TokenSecret.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TLS", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TLS == nil {
+				m.TLS = &TLSConfig{}
+			}
+			if err := m.TLS.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipGenerated(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
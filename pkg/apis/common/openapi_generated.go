@@ -30,21 +30,23 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"github.com/argoproj/argo-events/pkg/apis/common.Amount":          schema_argo_events_pkg_apis_common_Amount(ref),
-		"github.com/argoproj/argo-events/pkg/apis/common.Backoff":         schema_argo_events_pkg_apis_common_Backoff(ref),
-		"github.com/argoproj/argo-events/pkg/apis/common.BasicAuth":       schema_argo_events_pkg_apis_common_BasicAuth(ref),
-		"github.com/argoproj/argo-events/pkg/apis/common.Condition":       schema_argo_events_pkg_apis_common_Condition(ref),
-		"github.com/argoproj/argo-events/pkg/apis/common.Int64OrString":   schema_argo_events_pkg_apis_common_Int64OrString(ref),
-		"github.com/argoproj/argo-events/pkg/apis/common.Metadata":        schema_argo_events_pkg_apis_common_Metadata(ref),
-		"github.com/argoproj/argo-events/pkg/apis/common.Resource":        schema_argo_events_pkg_apis_common_Resource(ref),
-		"github.com/argoproj/argo-events/pkg/apis/common.S3Artifact":      schema_argo_events_pkg_apis_common_S3Artifact(ref),
-		"github.com/argoproj/argo-events/pkg/apis/common.S3Bucket":        schema_argo_events_pkg_apis_common_S3Bucket(ref),
-		"github.com/argoproj/argo-events/pkg/apis/common.S3Filter":        schema_argo_events_pkg_apis_common_S3Filter(ref),
-		"github.com/argoproj/argo-events/pkg/apis/common.SASLConfig":      schema_argo_events_pkg_apis_common_SASLConfig(ref),
-		"github.com/argoproj/argo-events/pkg/apis/common.SecureHeader":    schema_argo_events_pkg_apis_common_SecureHeader(ref),
-		"github.com/argoproj/argo-events/pkg/apis/common.Status":          schema_argo_events_pkg_apis_common_Status(ref),
-		"github.com/argoproj/argo-events/pkg/apis/common.TLSConfig":       schema_argo_events_pkg_apis_common_TLSConfig(ref),
-		"github.com/argoproj/argo-events/pkg/apis/common.ValueFromSource": schema_argo_events_pkg_apis_common_ValueFromSource(ref),
+		"github.com/argoproj/argo-events/pkg/apis/common.Amount":             schema_argo_events_pkg_apis_common_Amount(ref),
+		"github.com/argoproj/argo-events/pkg/apis/common.Backoff":            schema_argo_events_pkg_apis_common_Backoff(ref),
+		"github.com/argoproj/argo-events/pkg/apis/common.BasicAuth":          schema_argo_events_pkg_apis_common_BasicAuth(ref),
+		"github.com/argoproj/argo-events/pkg/apis/common.Condition":          schema_argo_events_pkg_apis_common_Condition(ref),
+		"github.com/argoproj/argo-events/pkg/apis/common.Int64OrString":      schema_argo_events_pkg_apis_common_Int64OrString(ref),
+		"github.com/argoproj/argo-events/pkg/apis/common.Metadata":           schema_argo_events_pkg_apis_common_Metadata(ref),
+		"github.com/argoproj/argo-events/pkg/apis/common.Resource":           schema_argo_events_pkg_apis_common_Resource(ref),
+		"github.com/argoproj/argo-events/pkg/apis/common.S3Artifact":         schema_argo_events_pkg_apis_common_S3Artifact(ref),
+		"github.com/argoproj/argo-events/pkg/apis/common.S3Bucket":           schema_argo_events_pkg_apis_common_S3Bucket(ref),
+		"github.com/argoproj/argo-events/pkg/apis/common.S3Filter":           schema_argo_events_pkg_apis_common_S3Filter(ref),
+		"github.com/argoproj/argo-events/pkg/apis/common.SASLConfig":         schema_argo_events_pkg_apis_common_SASLConfig(ref),
+		"github.com/argoproj/argo-events/pkg/apis/common.SecretBackend":      schema_argo_events_pkg_apis_common_SecretBackend(ref),
+		"github.com/argoproj/argo-events/pkg/apis/common.SecureHeader":       schema_argo_events_pkg_apis_common_SecureHeader(ref),
+		"github.com/argoproj/argo-events/pkg/apis/common.Status":             schema_argo_events_pkg_apis_common_Status(ref),
+		"github.com/argoproj/argo-events/pkg/apis/common.TLSConfig":          schema_argo_events_pkg_apis_common_TLSConfig(ref),
+		"github.com/argoproj/argo-events/pkg/apis/common.ValueFromSource":    schema_argo_events_pkg_apis_common_ValueFromSource(ref),
+		"github.com/argoproj/argo-events/pkg/apis/common.VaultSecretBackend": schema_argo_events_pkg_apis_common_VaultSecretBackend(ref),
 	}
 }
 
@@ -81,7 +83,7 @@ func schema_argo_events_pkg_apis_common_Backoff(ref common.ReferenceCallback) co
 					},
 					"jitter": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The amount of jitter applied each iteration",
+							Description: "The amount of jitter applied each iteration, i.e. each retry interval is randomly extended by up to jitter * interval. A jitter of 0 disables randomization.",
 							Ref:         ref("github.com/argoproj/argo-events/pkg/apis/common.Amount"),
 						},
 					},
@@ -92,6 +94,12 @@ func schema_argo_events_pkg_apis_common_Backoff(ref common.ReferenceCallback) co
 							Format:      "int32",
 						},
 					},
+					"maxElapsedTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxElapsedTime is the wall-clock ceiling of the retries in nanoseconds or strings like \"1m\", \"1h\". Exit with a terminal error once it is exceeded, even if steps remain. No ceiling if not set.",
+							Ref:         ref("github.com/argoproj/argo-events/pkg/apis/common.Int64OrString"),
+						},
+					},
 				},
 			},
 		},
@@ -321,12 +329,32 @@ func schema_argo_events_pkg_apis_common_S3Artifact(ref common.ReferenceCallback)
 							},
 						},
 					},
+					"pollInterval": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PollInterval polls the bucket on this interval, e.g. 30s, listing its objects with ListObjectsV2 rather than listening to the bucket notifications of MinIO, so that the S3-compatible stores without the listen API can be watched. An event is dispatched per object created or modified since the previous poll, the objects being told apart by their ETag. Only applies to the event sources.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"pathStyle": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PathStyle addresses the bucket in the path of the URLs, e.g. https://endpoint/bucket, rather than in the host, as most of the S3-compatible stores require.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"tls": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TLS configuration of the connection to the endpoint, unless insecure.",
+							Ref:         ref("github.com/argoproj/argo-events/pkg/apis/common.TLSConfig"),
+						},
+					},
 				},
 				Required: []string{"endpoint", "bucket", "accessKey", "secretKey"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/argoproj/argo-events/pkg/apis/common.S3Bucket", "github.com/argoproj/argo-events/pkg/apis/common.S3Filter", "k8s.io/api/core/v1.SecretKeySelector"},
+			"github.com/argoproj/argo-events/pkg/apis/common.S3Bucket", "github.com/argoproj/argo-events/pkg/apis/common.S3Filter", "github.com/argoproj/argo-events/pkg/apis/common.TLSConfig", "k8s.io/api/core/v1.SecretKeySelector"},
 	}
 }
 
@@ -419,6 +447,27 @@ func schema_argo_events_pkg_apis_common_SASLConfig(ref common.ReferenceCallback)
 	}
 }
 
+func schema_argo_events_pkg_apis_common_SecretBackend(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SecretBackend selects where the secrets referenced by the event sources are resolved from. The mounted K8s secrets are used if no backend is set.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"vault": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Vault resolves the secrets from HashiCorp Vault",
+							Ref:         ref("github.com/argoproj/argo-events/pkg/apis/common.VaultSecretBackend"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/argoproj/argo-events/pkg/apis/common.VaultSecretBackend"},
+	}
+}
+
 func schema_argo_events_pkg_apis_common_SecureHeader(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -508,11 +557,61 @@ func schema_argo_events_pkg_apis_common_TLSConfig(ref common.ReferenceCallback)
 					},
 					"insecureSkipVerify": {
 						SchemaProps: spec.SchemaProps{
-							Description: "If true, skips creation of TLSConfig with certs and creates an empty TLSConfig. (Defaults to false)",
+							Description: "If true, skips creation of TLSConfig with certs and creates an empty TLSConfig. (Defaults to false) The certificate of the server isn't verified, which is logged as a warning, it must not be used in production.",
 							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
+					"caCertData": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CACertData is the PEM encoded CA cert, takes precedence over CACertSecret if both are set",
+							Type:        []string{"string"},
+							Format:      "byte",
+						},
+					},
+					"clientCertData": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClientCertData is the PEM encoded client cert, takes precedence over ClientCertSecret if both are set",
+							Type:        []string{"string"},
+							Format:      "byte",
+						},
+					},
+					"clientKeyData": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClientKeyData is the PEM encoded client key, takes precedence over ClientKeySecret if both are set",
+							Type:        []string{"string"},
+							Format:      "byte",
+						},
+					},
+					"minVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MinVersion is the minimum TLS version to accept. Possible values: 1.0, 1.1, 1.2, 1.3 (defaults to the crypto/tls default).",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"cipherSuites": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CipherSuites is the list of enabled cipher suites for TLS versions up to 1.2, by their crypto/tls names, e.g. TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256 (defaults to the crypto/tls default).",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"serverName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServerName overrides the host name the certificate of the server is verified against, which is also sent as SNI, e.g. when connecting through a load balancer whose certificate doesn't match the host of the broker. The system CAs verify the certificate if no CA cert is set. Can't be set along with InsecureSkipVerify.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -545,3 +644,67 @@ func schema_argo_events_pkg_apis_common_ValueFromSource(ref common.ReferenceCall
 			"k8s.io/api/core/v1.ConfigMapKeySelector", "k8s.io/api/core/v1.SecretKeySelector"},
 	}
 }
+
+func schema_argo_events_pkg_apis_common_VaultSecretBackend(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VaultSecretBackend resolves the secrets from a HashiCorp Vault KV secrets engine. The name of a secret key selector is the path of the secret within the engine, and the key is the field of the secret.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"address": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Address of the Vault server, e.g. https://vault.vault.svc:8200",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"mountPath": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MountPath is the path the KV secrets engine is mounted at. Defaults to \"secret\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"kvVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KVVersion is the version of the KV secrets engine, 1 or 2. Defaults to 2.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"role": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Role to log in as with the Kubernetes auth method, using the service account token of the pod. Either Role or TokenSecret must be set.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"authPath": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AuthPath is the path the Kubernetes auth method is mounted at. Defaults to \"kubernetes\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"tokenSecret": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TokenSecret refers to the K8s secret that holds a Vault token, used if no Role is set.",
+							Ref:         ref("k8s.io/api/core/v1.SecretKeySelector"),
+						},
+					},
+					"tls": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TLS configuration for the Vault client.",
+							Ref:         ref("github.com/argoproj/argo-events/pkg/apis/common.TLSConfig"),
+						},
+					},
+				},
+				Required: []string{"address"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/argoproj/argo-events/pkg/apis/common.TLSConfig", "k8s.io/api/core/v1.SecretKeySelector"},
+	}
+}
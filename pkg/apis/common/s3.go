@@ -32,6 +32,20 @@ type S3Artifact struct {
 	Events   []string          `json:"events,omitempty" protobuf:"bytes,7,rep,name=events"`
 	Filter   *S3Filter         `json:"filter,omitempty" protobuf:"bytes,8,opt,name=filter"`
 	Metadata map[string]string `json:"metadata,omitempty" protobuf:"bytes,9,opt,name=metadata"`
+
+	// PollInterval polls the bucket on this interval, e.g. 30s, listing its objects with ListObjectsV2 rather than
+	// listening to the bucket notifications of MinIO, so that the S3-compatible stores without the listen API can
+	// be watched. An event is dispatched per object created or modified since the previous poll, the objects being
+	// told apart by their ETag. Only applies to the event sources.
+	// +optional
+	PollInterval string `json:"pollInterval,omitempty" protobuf:"bytes,10,opt,name=pollInterval"`
+	// PathStyle addresses the bucket in the path of the URLs, e.g. https://endpoint/bucket, rather than in the host,
+	// as most of the S3-compatible stores require.
+	// +optional
+	PathStyle bool `json:"pathStyle,omitempty" protobuf:"varint,11,opt,name=pathStyle"`
+	// TLS configuration of the connection to the endpoint, unless insecure.
+	// +optional
+	TLS *TLSConfig `json:"tls,omitempty" protobuf:"bytes,12,opt,name=tls"`
 }
 
 // S3Bucket contains information to describe an S3 Bucket
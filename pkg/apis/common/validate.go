@@ -1,6 +1,7 @@
 package common
 
 import (
+	"crypto/tls"
 	fmt "fmt"
 )
 
@@ -10,25 +11,35 @@ func ValidateTLSConfig(tlsConfig *TLSConfig) error {
 		return nil
 	}
 
+	if _, err := TLSVersion(tlsConfig.MinVersion); err != nil {
+		return err
+	}
+	if _, err := TLSCipherSuites(tlsConfig.CipherSuites); err != nil {
+		return err
+	}
+
 	if tlsConfig.InsecureSkipVerify {
+		if tlsConfig.ServerName != "" {
+			return fmt.Errorf("invalid tls config, serverName can't be set along with insecureSkipVerify, the certificate isn't verified against it")
+		}
 		return nil
 	}
 
 	var caCertSet, clientCertSet, clientKeySet bool
 
-	if tlsConfig.CACertSecret != nil {
+	if tlsConfig.CACertSecret != nil || len(tlsConfig.CACertData) > 0 {
 		caCertSet = true
 	}
 
-	if tlsConfig.ClientCertSecret != nil {
+	if tlsConfig.ClientCertSecret != nil || len(tlsConfig.ClientCertData) > 0 {
 		clientCertSet = true
 	}
 
-	if tlsConfig.ClientKeySecret != nil {
+	if tlsConfig.ClientKeySecret != nil || len(tlsConfig.ClientKeyData) > 0 {
 		clientKeySet = true
 	}
 
-	if !caCertSet && !clientCertSet && !clientKeySet {
+	if !caCertSet && !clientCertSet && !clientKeySet && tlsConfig.ServerName == "" {
 		return fmt.Errorf("invalid tls config, please configure either caCertSecret, or clientCertSecret and clientKeySecret, or both")
 	}
 
@@ -38,6 +49,44 @@ func ValidateTLSConfig(tlsConfig *TLSConfig) error {
 	return nil
 }
 
+// TLSVersion maps a TLS version name to its crypto/tls constant, 0 means the crypto/tls default.
+func TLSVersion(version string) (uint16, error) {
+	switch version {
+	case "":
+		return 0, nil
+	case "1.0":
+		return tls.VersionTLS10, nil
+	case "1.1":
+		return tls.VersionTLS11, nil
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("invalid tls config, unknown minVersion %q, possible values are 1.0, 1.1, 1.2 and 1.3", version)
+	}
+}
+
+// TLSCipherSuites maps cipher suite names to their crypto/tls IDs, nil means the crypto/tls default.
+func TLSCipherSuites(names []string) ([]uint16, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	known := map[string]uint16{}
+	for _, suite := range append(tls.CipherSuites(), tls.InsecureCipherSuites()...) {
+		known[suite.Name] = suite.ID
+	}
+	result := make([]uint16, 0, len(names))
+	for _, name := range names {
+		id, ok := known[name]
+		if !ok {
+			return nil, fmt.Errorf("invalid tls config, unknown cipher suite %q", name)
+		}
+		result = append(result, id)
+	}
+	return result, nil
+}
+
 func ValidateBasicAuth(auth *BasicAuth) error {
 	if auth == nil {
 		return nil
@@ -69,3 +118,25 @@ func ValidateSASLConfig(saslConfig *SASLConfig) error {
 
 	return nil
 }
+
+func ValidateSecretBackend(backend *SecretBackend) error {
+	if backend == nil || backend.Vault == nil {
+		return nil
+	}
+	vault := backend.Vault
+	if vault.Address == "" {
+		return fmt.Errorf("invalid vault secret backend, address must be specified")
+	}
+	switch vault.KVVersion {
+	case 0, 1, 2:
+	default:
+		return fmt.Errorf("invalid vault secret backend, kvVersion must be either 1 or 2")
+	}
+	if vault.Role == "" && vault.TokenSecret == nil {
+		return fmt.Errorf("invalid vault secret backend, either role or tokenSecret must be specified")
+	}
+	if vault.TLS != nil {
+		return ValidateTLSConfig(vault.TLS)
+	}
+	return nil
+}
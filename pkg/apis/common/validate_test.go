@@ -70,6 +70,19 @@ func TestValidateTLSConfig(t *testing.T) {
 		assert.Nil(t, err)
 	})
 
+	t.Run("test serverName along with insecureSkipVerify", func(t *testing.T) {
+		c := &TLSConfig{InsecureSkipVerify: true, ServerName: "broker.example.com"}
+		err := ValidateTLSConfig(c)
+		assert.NotNil(t, err)
+		assert.True(t, strings.Contains(err.Error(), "serverName can't be set along with insecureSkipVerify"))
+	})
+
+	t.Run("test only serverName is set", func(t *testing.T) {
+		c := &TLSConfig{ServerName: "broker.example.com"}
+		err := ValidateTLSConfig(c)
+		assert.Nil(t, err)
+	})
+
 	t.Run("test clientKeySecret is set, clientCertSecret is empty", func(t *testing.T) {
 		c := fakeTLSConfig(t, false)
 		c.CACertSecret = nil
@@ -99,6 +112,52 @@ func TestValidateTLSConfig(t *testing.T) {
 		err := ValidateTLSConfig(c)
 		assert.Nil(t, err)
 	})
+
+	t.Run("test min version and cipher suites", func(t *testing.T) {
+		c := fakeTLSConfig(t, false)
+		c.MinVersion = "1.2"
+		c.CipherSuites = []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}
+		err := ValidateTLSConfig(c)
+		assert.Nil(t, err)
+	})
+
+	t.Run("test unknown min version", func(t *testing.T) {
+		c := &TLSConfig{InsecureSkipVerify: true, MinVersion: "TLS1.3"}
+		err := ValidateTLSConfig(c)
+		assert.NotNil(t, err)
+		assert.True(t, strings.Contains(err.Error(), "unknown minVersion \"TLS1.3\""))
+	})
+
+	t.Run("test unknown cipher suite", func(t *testing.T) {
+		c := fakeTLSConfig(t, false)
+		c.CipherSuites = []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_FAKE_CIPHER"}
+		err := ValidateTLSConfig(c)
+		assert.NotNil(t, err)
+		assert.True(t, strings.Contains(err.Error(), "unknown cipher suite \"TLS_FAKE_CIPHER\""))
+	})
+}
+
+func TestValidateTLSConfigInlineData(t *testing.T) {
+	t.Run("test clientCertData is set, client key is empty", func(t *testing.T) {
+		c := &TLSConfig{ClientCertData: []byte("cert")}
+		err := ValidateTLSConfig(c)
+		assert.NotNil(t, err)
+		assert.True(t, strings.Contains(err.Error(), "both clientCertSecret and clientKeySecret need to be configured"))
+	})
+
+	t.Run("test only caCertData is set", func(t *testing.T) {
+		c := &TLSConfig{CACertData: []byte("ca")}
+		err := ValidateTLSConfig(c)
+		assert.Nil(t, err)
+	})
+
+	t.Run("test clientCertData with clientKeySecret", func(t *testing.T) {
+		c := fakeTLSConfig(t, false)
+		c.ClientCertSecret = nil
+		c.ClientCertData = []byte("cert")
+		err := ValidateTLSConfig(c)
+		assert.Nil(t, err)
+	})
 }
 
 func TestValidateSASLConfig(t *testing.T) {
@@ -137,3 +196,30 @@ func TestValidateSASLConfig(t *testing.T) {
 		assert.Nil(t, err)
 	})
 }
+
+func TestValidateSecretBackend(t *testing.T) {
+	assert.Nil(t, ValidateSecretBackend(nil))
+	assert.Nil(t, ValidateSecretBackend(&SecretBackend{}))
+
+	vault := &VaultSecretBackend{Address: "https://vault:8200", Role: "argo-events"}
+	assert.Nil(t, ValidateSecretBackend(&SecretBackend{Vault: vault}))
+
+	vault.Role = ""
+	err := ValidateSecretBackend(&SecretBackend{Vault: vault})
+	assert.NotNil(t, err)
+	assert.True(t, strings.Contains(err.Error(), "either role or tokenSecret must be specified"))
+
+	vault.TokenSecret = &corev1.SecretKeySelector{Key: "token"}
+	assert.Nil(t, ValidateSecretBackend(&SecretBackend{Vault: vault}))
+
+	vault.KVVersion = 3
+	err = ValidateSecretBackend(&SecretBackend{Vault: vault})
+	assert.NotNil(t, err)
+	assert.True(t, strings.Contains(err.Error(), "kvVersion must be either 1 or 2"))
+
+	vault.KVVersion = 1
+	vault.Address = ""
+	err = ValidateSecretBackend(&SecretBackend{Vault: vault})
+	assert.NotNil(t, err)
+	assert.True(t, strings.Contains(err.Error(), "address must be specified"))
+}
@@ -54,6 +54,12 @@ type AMQPEventData struct {
 	Exchange string `json:"exchange"`
 	// RoutingKey is basic.publish routing key
 	RoutingKey string `json:"routingKey"`
+	// DeliveryTag is the tag of the delivery on the channel it was received on
+	DeliveryTag uint64 `json:"deliveryTag"`
+	// Redelivered tells whether the message was delivered before, e.g. requeued after a failed dispatch
+	Redelivered bool `json:"redelivered"`
+	// Headers are the application headers of the message
+	Headers map[string]interface{} `json:"headers,omitempty"`
 	// Body represents the messsage body
 	Body interface{} `json:"body"`
 	// Metadata holds the user defined metadata which will passed along the event payload.
@@ -82,6 +88,14 @@ type SQSEventData struct {
 	// (https://docs.aws.amazon.com/AWSSimpleQueueService/latest/SQSDeveloperGuide/sqs-message-attributes.html)
 	// in the Amazon Simple Queue Service Developer Guide.
 	MessageAttributes map[string]*sqslib.MessageAttributeValue `json:"messageAttributes"`
+	// MessageGroupId is the group of the message, the messages of a group are received in order.
+	// Only set for FIFO queues.
+	MessageGroupId string `json:"messageGroupId,omitempty"`
+	// MessageDeduplicationId is the token used to deduplicate the message sent within a 5 minutes interval.
+	// Only set for FIFO queues.
+	MessageDeduplicationId string `json:"messageDeduplicationId,omitempty"`
+	// SequenceNumber is the sequence number of the message within its group. Only set for FIFO queues.
+	SequenceNumber string `json:"sequenceNumber,omitempty"`
 	// The message's contents (not URL-encoded).
 	Body interface{} `json:"body"`
 	// Metadata holds the user defined metadata which will passed along the event payload.
@@ -101,6 +115,30 @@ type AzureEventsHubEventData struct {
 	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
+// AzureServiceBusEventData represents the event data generated by the Azure Service Bus eventsource.
+type AzureServiceBusEventData struct {
+	// MessageID of the message.
+	MessageID string `json:"messageId"`
+	// SessionID of the message, set on the messages of the session enabled entities.
+	SessionID string `json:"sessionId,omitempty"`
+	// CorrelationID of the message.
+	CorrelationID string `json:"correlationId,omitempty"`
+	// ApplicationProperties are the user defined properties of the message.
+	ApplicationProperties map[string]interface{} `json:"applicationProperties,omitempty"`
+	// Body of the message.
+	Body interface{} `json:"body"`
+	// DeliveryCount is the number of deliveries of the message, this one included.
+	DeliveryCount uint32 `json:"deliveryCount"`
+	// SequenceNumber is the number assigned to the message by Service Bus.
+	SequenceNumber int64 `json:"sequenceNumber,omitempty"`
+	// EnqueuedTime is when the message was enqueued.
+	EnqueuedTime *time.Time `json:"enqueuedTime,omitempty"`
+	// EntityPath is the path of the queue, or of the topic subscription, the message is received from.
+	EntityPath string `json:"entityPath"`
+	// Metadata holds the user defined metadata which will passed along the event payload.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
 // BitbucketEventData represents the event data generated by the Bitbucket Server eventsource.
 type BitbucketEventData struct {
 	// Headers from the Bitbucket Server http request.
@@ -132,14 +170,88 @@ type CalendarEventData struct {
 
 // EmitterEventData represents the event data generated by the Emitter eventsource.
 type EmitterEventData struct {
+	// Type of the event, either "message", "presence", "connection", "heartbeat", "lastwill" or "error"
+	Type string `json:"type"`
 	// Topic name
 	Topic string `json:"topic"`
+	// Channel is the name of the configured channel the event originates from
+	Channel string `json:"channel,omitempty"`
+	// TopicParams holds the topic segments matched by the wildcards of the channel, keyed by their position
+	// in the channel, e.g. {"1": "kitchen"} for the channel sensor/+/temp/. Only set for events of type "message"
+	// and "lastwill", it is omitted if the channel has no wildcard.
+	TopicParams map[string]string `json:"topicParams,omitempty"`
+	// MessageID is the unique ID for the message, only set for events of type "message" and "lastwill"
+	MessageID int `json:"messageId,omitempty"`
+	// Retained is true for messages retained by the broker, which are delivered
+	// right after subscribing rather than being a live publish. It is omitted for the live publishes.
+	Retained bool `json:"retained,omitempty"`
 	// Body represents the message body
 	Body interface{} `json:"body"`
+	// SchemaID is the schema registry ID of the schema of the body, only set for the payloads encoded with a
+	// schema registry wire format, e.g. confluent-avro
+	SchemaID int32 `json:"schemaId,omitempty"`
+	// Presence holds the presence notification, only set for events of type "presence"
+	Presence *EmitterPresenceData `json:"presence,omitempty"`
+	// Connection holds the connection state transition, only set for events of type "connection"
+	Connection *EmitterConnectionData `json:"connection,omitempty"`
+	// Heartbeat holds the heartbeat, only set for events of type "heartbeat"
+	Heartbeat *HeartbeatData `json:"heartbeat,omitempty"`
+	// Error holds the failure, only set for events of type "error"
+	Error *EmitterErrorData `json:"error,omitempty"`
+	// Broker is the URI of the broker the event originates from, only set if the origin is included
+	Broker string `json:"broker,omitempty"`
+	// ClientID is the ID of the client which received the event, only set if the origin is included
+	ClientID string `json:"clientId,omitempty"`
 	// Metadata holds the user defined metadata which will passed along the event payload.
 	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
+// EmitterPresenceData represents a presence notification received from the Emitter broker.
+type EmitterPresenceData struct {
+	// Event is the presence event name, i.e. status, subscribe or unsubscribe
+	Event string `json:"event"`
+	// Time is the unix timestamp of the presence event
+	Time int `json:"time"`
+	// Count is the number of subscribers of the channel, only set for status events
+	Count int `json:"count,omitempty"`
+	// Who is the list of subscribers the event refers to
+	Who []EmitterPresenceInfo `json:"who"`
+}
+
+// EmitterConnectionData represents a connection state transition of the Emitter client.
+type EmitterConnectionData struct {
+	// State is the new state of the connection, either connected or disconnected
+	State string `json:"state"`
+	// Broker is the URI of the broker
+	Broker string `json:"broker"`
+	// Time is the time of the state transition
+	Time time.Time `json:"time"`
+	// Error is the reason of the disconnection if any
+	Error string `json:"error,omitempty"`
+}
+
+// EmitterErrorData represents a failure of the Emitter client to connect to the brokers or to subscribe to a channel.
+type EmitterErrorData struct {
+	// Stage is what failed, either connect or subscribe
+	Stage string `json:"stage"`
+	// Broker is the URI of the broker
+	Broker string `json:"broker"`
+	// Error is the reason of the failure
+	Error string `json:"error"`
+	// Fatal tells whether the event source stops on the failure
+	Fatal bool `json:"fatal"`
+	// Time is the time of the failure
+	Time time.Time `json:"time"`
+}
+
+// EmitterPresenceInfo describes a subscriber of an Emitter channel.
+type EmitterPresenceInfo struct {
+	// ID is the subscriber connection ID
+	ID string `json:"id"`
+	// Username is the subscriber username if any
+	Username string `json:"username,omitempty"`
+}
+
 // PubSubEventData represents the event data generated by the GCP PubSub eventsource.
 type PubSubEventData struct {
 	// ID of the message
@@ -181,6 +293,12 @@ type KafkaEventData struct {
 	Topic string `json:"topic"`
 	// Partition refers to the Kafka partition
 	Partition int `json:"partition"`
+	// Offset of the message in the partition
+	Offset int64 `json:"offset"`
+	// Key refers to the message key
+	Key string `json:"key,omitempty"`
+	// Headers refers to the message headers
+	Headers map[string]string `json:"headers,omitempty"`
 	// Body refers to the message value
 	Body interface{} `json:"body"`
 	// Timestamp of the message
@@ -208,6 +326,54 @@ type MQTTEventData struct {
 	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
+// MQTTV5EventData represents the event data generated by the MQTT v5 eventsource.
+type MQTTV5EventData struct {
+	// Topic refers to the MQTT topic name.
+	Topic string `json:"topic"`
+	// QoS is the quality of service the message was delivered with.
+	QoS int `json:"qos"`
+	// Retained tells whether the message was retained by the broker.
+	Retained bool `json:"retained"`
+	// MessageID is the packet identifier of the message, 0 for QoS 0.
+	MessageID int `json:"messageId"`
+	// ContentType is the content type of the payload set by the publisher, if any.
+	ContentType string `json:"contentType,omitempty"`
+	// ResponseTopic is the topic the publisher expects a response on, if any.
+	ResponseTopic string `json:"responseTopic,omitempty"`
+	// CorrelationData is the correlation data of the request, if any.
+	CorrelationData []byte `json:"correlationData,omitempty"`
+	// UserProperties are the user properties of the message, the last value wins for a key set several times.
+	UserProperties map[string]string `json:"userProperties,omitempty"`
+	// Body is the message payload.
+	Body interface{} `json:"body"`
+	// Metadata holds the user defined metadata which will passed along the event payload.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// WebSocketEventData represents the event data generated by the WebSocket eventsource.
+type WebSocketEventData struct {
+	// URL is the url of the WebSocket the message was received from.
+	URL string `json:"url"`
+	// MessageType is the type of the frame of the message, either text or binary.
+	MessageType string `json:"messageType"`
+	// Body is the message payload.
+	Body interface{} `json:"body"`
+	// Metadata holds the user defined metadata which will passed along the event payload.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// PostgresEventData represents the event data generated by the PostgreSQL eventsource.
+type PostgresEventData struct {
+	// Channel is the channel the notification was sent on.
+	Channel string `json:"channel"`
+	// PID is the process ID of the database backend which sent the notification.
+	PID int `json:"pid"`
+	// Body is the payload of the notification.
+	Body interface{} `json:"body"`
+	// Metadata holds the user defined metadata which will passed along the event payload.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
 // NATSEventData represents the event data generated by the NATS eventsource.
 type NATSEventData struct {
 	// Name of the subject.
@@ -218,6 +384,24 @@ type NATSEventData struct {
 	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
+// JetStreamEventData represents the event data generated by the NATS JetStream eventsource.
+type JetStreamEventData struct {
+	// Name of the subject.
+	Subject string `json:"subject"`
+	// Stream is the name of the stream the message was consumed from.
+	Stream string `json:"stream"`
+	// Sequence is the sequence number of the message in the stream.
+	Sequence uint64 `json:"sequence"`
+	// NumDelivered is the number of times the message has been delivered, greater than 1 for a redelivery.
+	NumDelivered uint64 `json:"numDelivered"`
+	// Timestamp of the message.
+	Timestamp string `json:"timestamp"`
+	// Message data.
+	Body interface{} `json:"body"`
+	// Metadata holds the user defined metadata which will passed along the event payload.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
 // NSQEventData represents the event data generated by the NSQ eventsource.
 type NSQEventData struct {
 	// Body is the message data.
@@ -226,6 +410,11 @@ type NSQEventData struct {
 	Timestamp string `json:"timestamp"`
 	// NSQDAddress is the address of the nsq host.
 	NSQDAddress string `json:"nsqdAddress"`
+	// ID is the ID of the message.
+	ID string `json:"id"`
+	// Attempts is the number of times the message has been delivered, 1 on the first delivery, so that the
+	// messages which keep failing can be dropped.
+	Attempts uint16 `json:"attempts"`
 	// Metadata holds the user defined metadata which will passed along the event payload.
 	Metadata map[string]string `json:"metadata,omitempty"`
 }
@@ -242,6 +431,66 @@ type RedisEventData struct {
 	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
+// RedisStreamEventData represents the event data generated by the Redis Streams eventsource.
+type RedisStreamEventData struct {
+	// Stream is the name of the stream the entry was read from.
+	Stream string `json:"stream"`
+	// ID of the entry in the stream.
+	ID string `json:"id"`
+	// Values holds the field-value pairs of the entry.
+	Values map[string]interface{} `json:"values"`
+	// Metadata holds the user defined metadata which will passed along the event payload.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// PrometheusEventData represents the event data generated by the Prometheus eventsource.
+type PrometheusEventData struct {
+	// Query is the PromQL query the sample results from.
+	Query string `json:"query"`
+	// Value of the sample.
+	Value float64 `json:"value"`
+	// Labels of the series of the sample, empty for a scalar result.
+	Labels map[string]string `json:"labels"`
+	// Timestamp of the sample.
+	Timestamp time.Time `json:"timestamp"`
+	// Comparison of the value to the threshold.
+	Comparison string `json:"comparison"`
+	// Threshold the value is compared to.
+	Threshold float64 `json:"threshold"`
+	// Metadata holds the user defined metadata which will passed along the event payload.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// GRPCEventData represents the event data generated by the gRPC eventsource.
+type GRPCEventData struct {
+	// Method is the full name of the server streaming method the message has been received from.
+	Method string `json:"method"`
+	// Message is the JSON encoding of the streamed message.
+	Message json.RawMessage `json:"message"`
+	// Metadata holds the user defined metadata which will passed along the event payload.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// HTTPPollEventData represents the event data generated by the HTTP poll eventsource.
+type HTTPPollEventData struct {
+	// URL of the polled endpoint.
+	URL string `json:"url"`
+	// Method of the request.
+	Method string `json:"method"`
+	// StatusCode of the response.
+	StatusCode int `json:"statusCode"`
+	// Headers holds the selected headers of the response, the values of a header repeated are joined by commas.
+	Headers map[string]string `json:"headers,omitempty"`
+	// Body of the response.
+	Body interface{} `json:"body"`
+	// BodyHash is the hex encoded SHA-256 hash of the body, which tells whether it changed between the polls.
+	BodyHash string `json:"bodyHash"`
+	// Time of the poll.
+	Time time.Time `json:"time"`
+	// Metadata holds the user defined metadata which will passed along the event payload.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
 // ResourceEventData represents the event data generated by the Resource eventsource.
 type ResourceEventData struct {
 	// EventType of the type of the event.
@@ -344,3 +593,29 @@ type GenericEventData struct {
 	// Metadata holds the user defined metadata which will passed along the event payload.
 	Metadata map[string]string `json:"metadata,omitempty"`
 }
+
+// HeartbeatData represents a heartbeat an event source dispatches periodically to tell it is alive.
+type HeartbeatData struct {
+	// Time is the time of the heartbeat
+	Time time.Time `json:"time"`
+	// Sequence numbers the heartbeats from 1 since the event source started, a gap tells heartbeats were lost
+	Sequence uint64 `json:"sequence"`
+}
+
+// EventEnvelopeSpecVersion is the version of the EventEnvelope specification
+const EventEnvelopeSpecVersion = "1.0"
+
+// EventEnvelope is the payload shared by the event sources, wrapping the data specific to the event source in the
+// same fields, so that the consumers can parse the events of the different event sources alike.
+type EventEnvelope struct {
+	// SpecVersion is the version of the envelope specification the event conforms to
+	SpecVersion string `json:"specVersion"`
+	// Source is the name of the event source the event originates from
+	Source string `json:"source"`
+	// EventName is the name of the event within the event source
+	EventName string `json:"eventName"`
+	// Time is when the event source produced the event
+	Time time.Time `json:"time"`
+	// Data is the data specific to the event source, e.g. an EmitterEventData
+	Data interface{} `json:"data"`
+}
@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -15,6 +16,24 @@ func TestGetReplicas(t *testing.T) {
 	assert.Equal(t, ep.GetReplicas(), int32(2))
 }
 
+func TestGetMaxPanicRestarts(t *testing.T) {
+	ep := EventSourceSpec{}
+	assert.Equal(t, int32(5), ep.GetMaxPanicRestarts())
+	ep.MaxPanicRestarts = convertInt(t, -1)
+	assert.Equal(t, int32(0), ep.GetMaxPanicRestarts())
+	ep.MaxPanicRestarts = convertInt(t, 2)
+	assert.Equal(t, int32(2), ep.GetMaxPanicRestarts())
+}
+
+func TestGetTerminationGracePeriod(t *testing.T) {
+	ep := EventSourceSpec{}
+	assert.Equal(t, DefaultTerminationGracePeriod, ep.GetTerminationGracePeriod())
+	ep.TerminationGracePeriod = "invalid"
+	assert.Equal(t, DefaultTerminationGracePeriod, ep.GetTerminationGracePeriod())
+	ep.TerminationGracePeriod = "10s"
+	assert.Equal(t, 10*time.Second, ep.GetTerminationGracePeriod())
+}
+
 func convertInt(t *testing.T, num int) *int32 {
 	t.Helper()
 	r := int32(num)
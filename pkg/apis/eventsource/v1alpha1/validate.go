@@ -17,9 +17,25 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+	"net"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/pkg/errors"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	apicommon "github.com/argoproj/argo-events/pkg/apis/common"
 )
 
+// EmitterBrokerSchemes are the schemes of the broker URIs supported by the emitter client
+var EmitterBrokerSchemes = map[string]bool{
+	"tcp": true, "mqtt": true, "ssl": true, "tls": true, "tcps": true, "mqtts": true, "ws": true, "wss": true,
+}
+
 // ValidateEventSource validates a generic event source
 func ValidateEventSource(eventSource *EventSource) error {
 	if eventSource == nil {
@@ -27,3 +43,213 @@ func ValidateEventSource(eventSource *EventSource) error {
 	}
 	return nil
 }
+
+// Validate checks the required fields, the mutually exclusive options and the formats of the emitter event source,
+// and returns all the errors found, aggregated.
+func (e *EmitterEventSource) Validate() error {
+	var errs []error
+	if e.ConnectionStringSecret == nil {
+		if e.Broker == "" && len(e.Brokers) == 0 {
+			errs = append(errs, errors.New("broker url must be specified"))
+		} else if e.Broker != "" {
+			if err := validateEmitterBroker(e.Broker); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	for i, broker := range e.Brokers {
+		if broker == "" {
+			errs = append(errs, errors.Errorf("broker url must be specified for brokers[%d]", i))
+		} else if err := validateEmitterBroker(broker); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(e.Channels) == 0 || e.ChannelName != "" || e.ChannelKey != "" {
+		if e.ChannelName == "" {
+			errs = append(errs, errors.New("channel name must be specified"))
+		} else if e.ChannelKey == "" && e.KeyGen == nil {
+			errs = append(errs, errors.New("channel key secret selector must be specified"))
+		}
+	}
+	names := map[string]bool{e.ChannelName: true}
+	for i, channel := range e.Channels {
+		if channel.Name == "" {
+			errs = append(errs, errors.Errorf("channel name must be specified for channels[%d]", i))
+			continue
+		}
+		if channel.Key == "" && e.KeyGen == nil {
+			errs = append(errs, errors.Errorf("channel key must be specified for channels[%d]", i))
+		}
+		if names[channel.Name] {
+			errs = append(errs, errors.Errorf("channel %s is specified more than once", channel.Name))
+		}
+		names[channel.Name] = true
+	}
+	if dl := e.DeadLetterChannel; dl != nil {
+		if dl.Name == "" || dl.Key == "" {
+			errs = append(errs, errors.New("dead letter channel name and key must be specified"))
+		} else if names[dl.Name] {
+			errs = append(errs, errors.Errorf("dead letter channel %s must not be subscribed", dl.Name))
+		}
+	}
+	if e.DrainTimeout != "" {
+		if _, err := time.ParseDuration(e.DrainTimeout); err != nil {
+			errs = append(errs, errors.Wrap(err, "failed to parse drain timeout"))
+		}
+	}
+	if e.ConnectTimeout != "" {
+		if connectTimeout, err := time.ParseDuration(e.ConnectTimeout); err != nil {
+			errs = append(errs, errors.Wrap(err, "failed to parse connect timeout"))
+		} else if connectTimeout <= 0 {
+			errs = append(errs, errors.New("connect timeout must be positive"))
+		}
+	}
+	if e.KeepAlive != "" {
+		if keepAlive, err := time.ParseDuration(e.KeepAlive); err != nil {
+			errs = append(errs, errors.Wrap(err, "failed to parse keep alive"))
+		} else if keepAlive < time.Second {
+			errs = append(errs, errors.New("keep alive must be at least 1s"))
+		}
+	}
+	if e.MaxEventsPerSecond < 0 {
+		errs = append(errs, errors.New("maxEventsPerSecond must not be negative"))
+	}
+	if opts := e.SubscriptionOptions; opts != nil && opts.WithHistory && opts.Last <= 0 {
+		errs = append(errs, errors.New("last must be greater than 0 when history is enabled"))
+	}
+	if opts := e.SubscriptionOptions; opts != nil && !opts.WithHistory && opts.Last != 0 {
+		// the broker replays nothing without the history, don't let the last messages be silently ignored
+		errs = append(errs, errors.New("last requires withHistory to be enabled"))
+	}
+	if e.TLS != nil {
+		if err := apicommon.ValidateTLSConfig(e.TLS); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if e.ValidateJSON && !e.JSONBody {
+		errs = append(errs, errors.New("jsonBody must be set along with validateJSON"))
+	}
+	if err := e.Heartbeat.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// validateEmitterBroker checks the broker is a URI with a supported scheme, or a host and port
+func validateEmitterBroker(broker string) error {
+	hostPort := broker
+	if strings.Contains(broker, "://") {
+		u, err := url.Parse(broker)
+		if err != nil {
+			return errors.Errorf("broker url %s is not valid", broker)
+		}
+		if !EmitterBrokerSchemes[u.Scheme] {
+			return errors.Errorf("broker url scheme %q is not supported", u.Scheme)
+		}
+		hostPort = u.Host
+	}
+	host, port, err := net.SplitHostPort(hostPort)
+	if err != nil || host == "" {
+		return errors.Errorf("broker url %s must specify the host and the port", broker)
+	}
+	if p, err := strconv.Atoi(port); err != nil || p <= 0 || p > 65535 {
+		return errors.Errorf("broker url port %s is not valid", port)
+	}
+	return nil
+}
+
+// Validate checks the required fields, the mutually exclusive options and the formats of the file event source,
+// and returns all the errors found, aggregated.
+func (f *FileEventSource) Validate() error {
+	var errs []error
+	if len(f.Paths) == 0 || f.EventType != "" || len(f.EventTypes) > 0 || f.WatchPathConfig != (WatchPathConfig{}) {
+		errs = append(errs, validateFileWatchPath(f.EventType, f.EventTypes, &f.WatchPathConfig)...)
+	}
+	names := map[string]bool{}
+	for i := range f.Paths {
+		path := &f.Paths[i]
+		for _, err := range validateFileWatchPath(path.EventType, path.EventTypes, &path.WatchPathConfig) {
+			errs = append(errs, fmt.Errorf("paths[%d]: %w", i, err))
+		}
+		if path.Name != "" {
+			if names[path.Name] {
+				errs = append(errs, fmt.Errorf("watch path %s is specified more than once", path.Name))
+			}
+			names[path.Name] = true
+		}
+	}
+	if f.MaxContentBytes < 0 {
+		errs = append(errs, errors.New("maxContentBytes must not be negative"))
+	}
+	if f.DebounceMillis < 0 {
+		errs = append(errs, errors.New("debounceMillis must not be negative"))
+	}
+	for _, ext := range f.Extensions {
+		if strings.TrimPrefix(ext, ".") == "" {
+			errs = append(errs, errors.New("extensions must not be empty"))
+			break
+		}
+	}
+	if f.MinSizeBytes < 0 {
+		errs = append(errs, errors.New("minSizeBytes must not be negative"))
+	}
+	if f.BufferSize < 0 {
+		errs = append(errs, errors.New("bufferSize must not be negative"))
+	}
+	if f.MaxEventSizeBytes < 0 {
+		errs = append(errs, errors.New("maxEventSizeBytes must not be negative"))
+	}
+	if f.RefillRate < 0 {
+		errs = append(errs, errors.New("refillRate must not be negative"))
+	}
+	if f.MaxBurst < 0 {
+		errs = append(errs, errors.New("maxBurst must not be negative"))
+	} else if f.MaxBurst > 0 && f.RefillRate == 0 {
+		errs = append(errs, errors.New("refillRate must be specified along with maxBurst"))
+	}
+	for key, path := range f.MetadataPaths {
+		if key == "" || path == "" {
+			errs = append(errs, errors.New("metadataPaths keys and paths must not be empty"))
+			break
+		}
+	}
+	if err := f.Heartbeat.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// validateFileWatchPath checks the types of the file operations and the path watched by a file event source
+func validateFileWatchPath(eventType string, eventTypes []string, config *WatchPathConfig) []error {
+	var errs []error
+	if eventType == "" && len(eventTypes) == 0 {
+		errs = append(errs, errors.New("type must be specified"))
+	}
+	for _, t := range eventTypes {
+		if t == "" {
+			errs = append(errs, errors.New("eventTypes must not be empty"))
+			break
+		}
+	}
+	if err := config.Validate(); err != nil {
+		errs = append(errs, err)
+	} else if _, err := filepath.Match(config.Path, ""); err != nil {
+		errs = append(errs, fmt.Errorf("path must be a valid glob pattern, %w", err))
+	}
+	return errs
+}
+
+// Validate checks the interval of the heartbeat events, if enabled
+func (h *Heartbeat) Validate() error {
+	if h == nil || !h.Enabled || h.Interval == "" {
+		return nil
+	}
+	interval, err := time.ParseDuration(h.Interval)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse heartbeat interval")
+	}
+	if interval < time.Second {
+		return errors.New("heartbeat interval must be at least 1s")
+	}
+	return nil
+}
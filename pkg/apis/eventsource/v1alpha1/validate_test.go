@@ -0,0 +1,160 @@
+/*
+Copyright 2018 BlackRock, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestEmitterEventSourceValidate(t *testing.T) {
+	eventSource := &EmitterEventSource{
+		Broker:      "tcp://broker.argo-events.svc:4000",
+		ChannelName: "hello",
+		ChannelKey:  "hello_key",
+	}
+	assert.NoError(t, eventSource.Validate())
+
+	eventSource.Broker = "broker.argo-events.svc:4000"
+	assert.NoError(t, eventSource.Validate())
+
+	for broker, message := range map[string]string{
+		"http://broker.argo-events.svc:4000": `broker url scheme "http" is not supported`,
+		"tcp://broker.argo-events.svc":       "broker url tcp://broker.argo-events.svc must specify the host and the port",
+		"broker.argo-events.svc":             "broker url broker.argo-events.svc must specify the host and the port",
+		"tcp://broker.argo-events.svc:0":     "broker url port 0 is not valid",
+	} {
+		eventSource.Broker = broker
+		err := eventSource.Validate()
+		assert.Error(t, err)
+		assert.Equal(t, message, err.Error())
+	}
+
+	eventSource.Broker = "tcp://broker.argo-events.svc:4000"
+	eventSource.ValidateJSON = true
+	err := eventSource.Validate()
+	assert.Error(t, err)
+	assert.Equal(t, "jsonBody must be set along with validateJSON", err.Error())
+	eventSource.JSONBody = true
+	assert.NoError(t, eventSource.Validate())
+
+	eventSource.SubscriptionOptions = &EmitterSubscriptionOptions{Last: 10}
+	err = eventSource.Validate()
+	assert.Error(t, err)
+	assert.Equal(t, "last requires withHistory to be enabled", err.Error())
+	eventSource.SubscriptionOptions.WithHistory = true
+	assert.NoError(t, eventSource.Validate())
+	eventSource.SubscriptionOptions = nil
+
+	// the brokers to fail over to are enough on their own
+	eventSource.Broker = ""
+	eventSource.Brokers = []string{"tcp://broker-0.argo-events.svc:4000", "broker-1.argo-events.svc:4000"}
+	assert.NoError(t, eventSource.Validate())
+	eventSource.Brokers = append(eventSource.Brokers, "", "ws://broker-2.argo-events.svc")
+	err = eventSource.Validate()
+	assert.Error(t, err)
+	assert.Equal(t, "[broker url must be specified for brokers[2], broker url ws://broker-2.argo-events.svc must specify the host and the port]", err.Error())
+	eventSource.Brokers = nil
+
+	// the broker of the connection string isn't known before it is resolved
+	eventSource.ConnectionStringSecret = &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "emitter"}, Key: "uri"}
+	assert.NoError(t, eventSource.Validate())
+
+	// all the errors are reported at once
+	eventSource = &EmitterEventSource{
+		Channels:       []EmitterChannel{{Name: "hello"}, {Key: "key"}},
+		ConnectTimeout: "0s",
+		KeepAlive:      "10",
+	}
+	err = eventSource.Validate()
+	assert.Error(t, err)
+	assert.Equal(t, "[broker url must be specified, channel key must be specified for channels[0], channel name must be specified for channels[1], "+
+		"connect timeout must be positive, failed to parse keep alive: time: missing unit in duration \"10\"]", err.Error())
+}
+
+func TestFileEventSourceValidate(t *testing.T) {
+	eventSource := &FileEventSource{
+		EventType:       "CREATE",
+		WatchPathConfig: WatchPathConfig{Directory: "/bin/", Path: "*.json"},
+	}
+	assert.NoError(t, eventSource.Validate())
+
+	eventSource.WatchPathConfig.Path = "[.json"
+	eventSource.MaxContentBytes = -1
+	eventSource.Extensions = []string{".", ""}
+	err := eventSource.Validate()
+	assert.Error(t, err)
+	assert.Equal(t, "[path must be a valid glob pattern, syntax error in pattern, maxContentBytes must not be negative, extensions must not be empty]", err.Error())
+
+	eventSource = &FileEventSource{
+		EventType:       "CREATE",
+		WatchPathConfig: WatchPathConfig{Directory: "/bin/", Path: "*.json"},
+		RefillRate:      10,
+		MaxBurst:        100,
+	}
+	assert.NoError(t, eventSource.Validate())
+	eventSource.RefillRate = 0
+	err = eventSource.Validate()
+	assert.Error(t, err)
+	assert.Equal(t, "refillRate must be specified along with maxBurst", err.Error())
+
+	// the watch path config is optional along with the paths, which are validated each
+	eventSource = &FileEventSource{
+		Paths: []FileWatchPath{
+			{Name: "data", EventType: "CREATE", WatchPathConfig: WatchPathConfig{Directory: "/data/", Path: "*.json"}},
+			{EventType: "WRITE", WatchPathConfig: WatchPathConfig{Directory: "/logs/", PathRegexp: `\.log$`}},
+		},
+	}
+	assert.NoError(t, eventSource.Validate())
+	eventSource.Paths = append(eventSource.Paths,
+		FileWatchPath{Name: "data", WatchPathConfig: WatchPathConfig{Directory: "logs", Path: "*.log"}})
+	eventSource.EventType = "REMOVE"
+	err = eventSource.Validate()
+	assert.Error(t, err)
+	assert.Equal(t, "[directory is required, paths[2]: type must be specified, paths[2]: directory must be an absolute file path, "+
+		"watch path data is specified more than once]", err.Error())
+
+	// the types can be specified as a list rather than a single type
+	eventSource = &FileEventSource{
+		EventTypes:      []string{"CREATE", "WRITE"},
+		WatchPathConfig: WatchPathConfig{Directory: "/bin/", Path: "*.json"},
+		Paths: []FileWatchPath{
+			{EventTypes: []string{"REMOVE"}, WatchPathConfig: WatchPathConfig{Directory: "/data/", Path: "*.json"}},
+		},
+	}
+	assert.NoError(t, eventSource.Validate())
+	eventSource.EventTypes = []string{"CREATE", ""}
+	err = eventSource.Validate()
+	assert.Error(t, err)
+	assert.Equal(t, "eventTypes must not be empty", err.Error())
+
+	eventSource = &FileEventSource{
+		EventType:       "CREATE",
+		WatchPathConfig: WatchPathConfig{Directory: "/bin/", Path: "*.json"},
+		Heartbeat:       &Heartbeat{Enabled: true},
+	}
+	assert.NoError(t, eventSource.Validate())
+	eventSource.Heartbeat.Interval = "100ms"
+	err = eventSource.Validate()
+	assert.Error(t, err)
+	assert.Equal(t, "heartbeat interval must be at least 1s", err.Error())
+	// the interval isn't used unless enabled
+	eventSource.Heartbeat.Enabled = false
+	assert.NoError(t, eventSource.Validate())
+}
@@ -25,4 +25,25 @@ type WebhookContext struct {
 	// AuthSecret holds a secret selector that contains a bearer token for authentication
 	// +optional
 	AuthSecret *corev1.SecretKeySelector `json:"authSecret,omitempty" protobuf:"bytes,8,opt,name=authSecret"`
+	// SignatureValidation validates the HMAC signature of the request payload, requests with
+	// a missing or mismatching signature are rejected
+	// +optional
+	SignatureValidation *WebhookSignatureValidation `json:"signatureValidation,omitempty" protobuf:"bytes,9,opt,name=signatureValidation"`
+}
+
+// WebhookSignatureValidation holds the configuration to validate the HMAC signature of a request payload
+type WebhookSignatureValidation struct {
+	// Header is the name of the request header that holds the signature, e.g. X-Hub-Signature-256.
+	// A leading "<algorithm>=" in the header value, like "sha256=", is ignored.
+	Header string `json:"header" protobuf:"bytes,1,opt,name=header"`
+	// Secret refers to the K8s secret that holds the key used to sign the payload
+	Secret *corev1.SecretKeySelector `json:"secret" protobuf:"bytes,2,opt,name=secret"`
+	// Algorithm is the hash algorithm of the HMAC, one of sha1, sha256 and sha512.
+	// Defaults to sha256.
+	// +optional
+	Algorithm string `json:"algorithm,omitempty" protobuf:"bytes,3,opt,name=algorithm"`
+	// Encoding of the signature in the header, either hex or base64.
+	// Defaults to hex.
+	// +optional
+	Encoding string `json:"encoding,omitempty" protobuf:"bytes,4,opt,name=encoding"`
 }
@@ -197,6 +197,49 @@ func (in *AzureEventsHubEventSource) DeepCopy() *AzureEventsHubEventSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureServiceBusEventSource) DeepCopyInto(out *AzureServiceBusEventSource) {
+	*out = *in
+	if in.ConnectionString != nil {
+		in, out := &in.ConnectionString, &out.ConnectionString
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(common.TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConnectionBackoff != nil {
+		in, out := &in.ConnectionBackoff, &out.ConnectionBackoff
+		*out = new(common.Backoff)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(EventSourceFilter)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureServiceBusEventSource.
+func (in *AzureServiceBusEventSource) DeepCopy() *AzureServiceBusEventSource {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureServiceBusEventSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BitbucketAuth) DeepCopyInto(out *BitbucketAuth) {
 	*out = *in
@@ -431,6 +474,64 @@ func (in *ConfigMapPersistence) DeepCopy() *ConfigMapPersistence {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmitterAckResponse) DeepCopyInto(out *EmitterAckResponse) {
+	*out = *in
+	if in.AckChannel != nil {
+		in, out := &in.AckChannel, &out.AckChannel
+		*out = new(EmitterChannel)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmitterAckResponse.
+func (in *EmitterAckResponse) DeepCopy() *EmitterAckResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(EmitterAckResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmitterChannel) DeepCopyInto(out *EmitterChannel) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmitterChannel.
+func (in *EmitterChannel) DeepCopy() *EmitterChannel {
+	if in == nil {
+		return nil
+	}
+	out := new(EmitterChannel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmitterDispatchRetry) DeepCopyInto(out *EmitterDispatchRetry) {
+	*out = *in
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(common.Backoff)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmitterDispatchRetry.
+func (in *EmitterDispatchRetry) DeepCopy() *EmitterDispatchRetry {
+	if in == nil {
+		return nil
+	}
+	out := new(EmitterDispatchRetry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EmitterEventSource) DeepCopyInto(out *EmitterEventSource) {
 	*out = *in
@@ -466,6 +567,66 @@ func (in *EmitterEventSource) DeepCopyInto(out *EmitterEventSource) {
 		*out = new(EventSourceFilter)
 		**out = **in
 	}
+	if in.SubscriptionOptions != nil {
+		in, out := &in.SubscriptionOptions, &out.SubscriptionOptions
+		*out = new(EmitterSubscriptionOptions)
+		**out = **in
+	}
+	if in.Channels != nil {
+		in, out := &in.Channels, &out.Channels
+		*out = make([]EmitterChannel, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeadLetterChannel != nil {
+		in, out := &in.DeadLetterChannel, &out.DeadLetterChannel
+		*out = new(EmitterChannel)
+		**out = **in
+	}
+	if in.ConnectionStringSecret != nil {
+		in, out := &in.ConnectionStringSecret, &out.ConnectionStringSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KeyGen != nil {
+		in, out := &in.KeyGen, &out.KeyGen
+		*out = new(EmitterKeyGen)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Brokers != nil {
+		in, out := &in.Brokers, &out.Brokers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Heartbeat != nil {
+		in, out := &in.Heartbeat, &out.Heartbeat
+		*out = new(Heartbeat)
+		**out = **in
+	}
+	if in.TopicAllow != nil {
+		in, out := &in.TopicAllow, &out.TopicAllow
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TopicDeny != nil {
+		in, out := &in.TopicDeny, &out.TopicDeny
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DispatchRetry != nil {
+		in, out := &in.DispatchRetry, &out.DispatchRetry
+		*out = new(EmitterDispatchRetry)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AckResponse != nil {
+		in, out := &in.AckResponse, &out.AckResponse
+		*out = new(EmitterAckResponse)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SampleRate != nil {
+		in, out := &in.SampleRate, &out.SampleRate
+		*out = new(common.Amount)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -479,6 +640,43 @@ func (in *EmitterEventSource) DeepCopy() *EmitterEventSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmitterKeyGen) DeepCopyInto(out *EmitterKeyGen) {
+	*out = *in
+	if in.MasterKey != nil {
+		in, out := &in.MasterKey, &out.MasterKey
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmitterKeyGen.
+func (in *EmitterKeyGen) DeepCopy() *EmitterKeyGen {
+	if in == nil {
+		return nil
+	}
+	out := new(EmitterKeyGen)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmitterSubscriptionOptions) DeepCopyInto(out *EmitterSubscriptionOptions) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmitterSubscriptionOptions.
+func (in *EmitterSubscriptionOptions) DeepCopy() *EmitterSubscriptionOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(EmitterSubscriptionOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EventPersistence) DeepCopyInto(out *EventPersistence) {
 	*out = *in
@@ -782,6 +980,79 @@ func (in *EventSourceSpec) DeepCopyInto(out *EventSourceSpec) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.JetStream != nil {
+		in, out := &in.JetStream, &out.JetStream
+		*out = make(map[string]JetStreamEventSource, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.RedisStream != nil {
+		in, out := &in.RedisStream, &out.RedisStream
+		*out = make(map[string]RedisStreamEventSource, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.SecretBackend != nil {
+		in, out := &in.SecretBackend, &out.SecretBackend
+		*out = new(common.SecretBackend)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = make(map[string]PrometheusEventSource, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.GRPC != nil {
+		in, out := &in.GRPC, &out.GRPC
+		*out = make(map[string]GRPCEventSource, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.MQTTV5 != nil {
+		in, out := &in.MQTTV5, &out.MQTTV5
+		*out = make(map[string]MQTTV5EventSource, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.WebSocket != nil {
+		in, out := &in.WebSocket, &out.WebSocket
+		*out = make(map[string]WebSocketEventSource, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Postgres != nil {
+		in, out := &in.Postgres, &out.Postgres
+		*out = make(map[string]PostgresEventSource, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.HTTPPoll != nil {
+		in, out := &in.HTTPPoll, &out.HTTPPoll
+		*out = make(map[string]HTTPPollEventSource, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.AzureServiceBus != nil {
+		in, out := &in.AzureServiceBus, &out.AzureServiceBus
+		*out = make(map[string]AzureServiceBusEventSource, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.MaxPanicRestarts != nil {
+		in, out := &in.MaxPanicRestarts, &out.MaxPanicRestarts
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -812,6 +1083,38 @@ func (in *EventSourceStatus) DeepCopy() *EventSourceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileBatch) DeepCopyInto(out *FileBatch) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileBatch.
+func (in *FileBatch) DeepCopy() *FileBatch {
+	if in == nil {
+		return nil
+	}
+	out := new(FileBatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileContentMatch) DeepCopyInto(out *FileContentMatch) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileContentMatch.
+func (in *FileContentMatch) DeepCopy() *FileContentMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(FileContentMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FileEventSource) DeepCopyInto(out *FileEventSource) {
 	*out = *in
@@ -828,6 +1131,57 @@ func (in *FileEventSource) DeepCopyInto(out *FileEventSource) {
 		*out = new(EventSourceFilter)
 		**out = **in
 	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MetadataPaths != nil {
+		in, out := &in.MetadataPaths, &out.MetadataPaths
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]FileWatchPath, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Heartbeat != nil {
+		in, out := &in.Heartbeat, &out.Heartbeat
+		*out = new(Heartbeat)
+		**out = **in
+	}
+	if in.ContentMatch != nil {
+		in, out := &in.ContentMatch, &out.ContentMatch
+		*out = new(FileContentMatch)
+		**out = **in
+	}
+	if in.Batch != nil {
+		in, out := &in.Batch, &out.Batch
+		*out = new(FileBatch)
+		**out = **in
+	}
+	if in.IgnorePatterns != nil {
+		in, out := &in.IgnorePatterns, &out.IgnorePatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EventTypes != nil {
+		in, out := &in.EventTypes, &out.EventTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OpNameMapping != nil {
+		in, out := &in.OpNameMapping, &out.OpNameMapping
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -841,6 +1195,87 @@ func (in *FileEventSource) DeepCopy() *FileEventSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileWatchPath) DeepCopyInto(out *FileWatchPath) {
+	*out = *in
+	out.WatchPathConfig = in.WatchPathConfig
+	if in.EventTypes != nil {
+		in, out := &in.EventTypes, &out.EventTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileWatchPath.
+func (in *FileWatchPath) DeepCopy() *FileWatchPath {
+	if in == nil {
+		return nil
+	}
+	out := new(FileWatchPath)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCEventSource) DeepCopyInto(out *GRPCEventSource) {
+	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(common.TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConnectionBackoff != nil {
+		in, out := &in.ConnectionBackoff, &out.ConnectionBackoff
+		*out = new(common.Backoff)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resume != nil {
+		in, out := &in.Resume, &out.Resume
+		*out = new(GRPCStreamResume)
+		**out = **in
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(EventSourceFilter)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCEventSource.
+func (in *GRPCEventSource) DeepCopy() *GRPCEventSource {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCEventSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCStreamResume) DeepCopyInto(out *GRPCStreamResume) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCStreamResume.
+func (in *GRPCStreamResume) DeepCopy() *GRPCStreamResume {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCStreamResume)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GenericEventSource) DeepCopyInto(out *GenericEventSource) {
 	*out = *in
@@ -985,16 +1420,120 @@ func (in *GitlabEventSource) DeepCopyInto(out *GitlabEventSource) {
 			(*out)[key] = val
 		}
 	}
-	if in.Projects != nil {
-		in, out := &in.Projects, &out.Projects
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.SecretToken != nil {
-		in, out := &in.SecretToken, &out.SecretToken
-		*out = new(v1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
-	}
+	if in.Projects != nil {
+		in, out := &in.Projects, &out.Projects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecretToken != nil {
+		in, out := &in.SecretToken, &out.SecretToken
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(EventSourceFilter)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitlabEventSource.
+func (in *GitlabEventSource) DeepCopy() *GitlabEventSource {
+	if in == nil {
+		return nil
+	}
+	out := new(GitlabEventSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HDFSEventSource) DeepCopyInto(out *HDFSEventSource) {
+	*out = *in
+	out.WatchPathConfig = in.WatchPathConfig
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.KrbCCacheSecret != nil {
+		in, out := &in.KrbCCacheSecret, &out.KrbCCacheSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KrbKeytabSecret != nil {
+		in, out := &in.KrbKeytabSecret, &out.KrbKeytabSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KrbConfigConfigMap != nil {
+		in, out := &in.KrbConfigConfigMap, &out.KrbConfigConfigMap
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(EventSourceFilter)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HDFSEventSource.
+func (in *HDFSEventSource) DeepCopy() *HDFSEventSource {
+	if in == nil {
+		return nil
+	}
+	out := new(HDFSEventSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPPollEventSource) DeepCopyInto(out *HTTPPollEventSource) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(common.BasicAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BearerToken != nil {
+		in, out := &in.BearerToken, &out.BearerToken
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResponseHeaders != nil {
+		in, out := &in.ResponseHeaders, &out.ResponseHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(common.TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Filter != nil {
 		in, out := &in.Filter, &out.Filter
 		*out = new(EventSourceFilter)
@@ -1003,38 +1542,43 @@ func (in *GitlabEventSource) DeepCopyInto(out *GitlabEventSource) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitlabEventSource.
-func (in *GitlabEventSource) DeepCopy() *GitlabEventSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPPollEventSource.
+func (in *HTTPPollEventSource) DeepCopy() *HTTPPollEventSource {
 	if in == nil {
 		return nil
 	}
-	out := new(GitlabEventSource)
+	out := new(HTTPPollEventSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HDFSEventSource) DeepCopyInto(out *HDFSEventSource) {
+func (in *Heartbeat) DeepCopyInto(out *Heartbeat) {
 	*out = *in
-	out.WatchPathConfig = in.WatchPathConfig
-	if in.Addresses != nil {
-		in, out := &in.Addresses, &out.Addresses
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.KrbCCacheSecret != nil {
-		in, out := &in.KrbCCacheSecret, &out.KrbCCacheSecret
-		*out = new(v1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Heartbeat.
+func (in *Heartbeat) DeepCopy() *Heartbeat {
+	if in == nil {
+		return nil
 	}
-	if in.KrbKeytabSecret != nil {
-		in, out := &in.KrbKeytabSecret, &out.KrbKeytabSecret
-		*out = new(v1.SecretKeySelector)
+	out := new(Heartbeat)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JetStreamEventSource) DeepCopyInto(out *JetStreamEventSource) {
+	*out = *in
+	if in.ConnectionBackoff != nil {
+		in, out := &in.ConnectionBackoff, &out.ConnectionBackoff
+		*out = new(common.Backoff)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.KrbConfigConfigMap != nil {
-		in, out := &in.KrbConfigConfigMap, &out.KrbConfigConfigMap
-		*out = new(v1.ConfigMapKeySelector)
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(common.TLSConfig)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Metadata != nil {
@@ -1044,6 +1588,11 @@ func (in *HDFSEventSource) DeepCopyInto(out *HDFSEventSource) {
 			(*out)[key] = val
 		}
 	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(NATSAuth)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Filter != nil {
 		in, out := &in.Filter, &out.Filter
 		*out = new(EventSourceFilter)
@@ -1052,12 +1601,12 @@ func (in *HDFSEventSource) DeepCopyInto(out *HDFSEventSource) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HDFSEventSource.
-func (in *HDFSEventSource) DeepCopy() *HDFSEventSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JetStreamEventSource.
+func (in *JetStreamEventSource) DeepCopy() *JetStreamEventSource {
 	if in == nil {
 		return nil
 	}
-	out := new(HDFSEventSource)
+	out := new(JetStreamEventSource)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1113,6 +1662,11 @@ func (in *KafkaEventSource) DeepCopyInto(out *KafkaEventSource) {
 		*out = new(EventSourceFilter)
 		**out = **in
 	}
+	if in.Brokers != nil {
+		in, out := &in.Brokers, &out.Brokers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1164,6 +1718,44 @@ func (in *MQTTEventSource) DeepCopy() *MQTTEventSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MQTTV5EventSource) DeepCopyInto(out *MQTTV5EventSource) {
+	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(common.TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConnectionBackoff != nil {
+		in, out := &in.ConnectionBackoff, &out.ConnectionBackoff
+		*out = new(common.Backoff)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(EventSourceFilter)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MQTTV5EventSource.
+func (in *MQTTV5EventSource) DeepCopy() *MQTTV5EventSource {
+	if in == nil {
+		return nil
+	}
+	out := new(MQTTV5EventSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NATSAuth) DeepCopyInto(out *NATSAuth) {
 	*out = *in
@@ -1268,6 +1860,16 @@ func (in *NSQEventSource) DeepCopyInto(out *NSQEventSource) {
 		*out = new(EventSourceFilter)
 		**out = **in
 	}
+	if in.NSQLookupd != nil {
+		in, out := &in.NSQLookupd, &out.NSQLookupd
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NSQD != nil {
+		in, out := &in.NSQD, &out.NSQD
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1302,6 +1904,92 @@ func (in *OwnedRepositories) DeepCopy() *OwnedRepositories {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresEventSource) DeepCopyInto(out *PostgresEventSource) {
+	*out = *in
+	if in.DSN != nil {
+		in, out := &in.DSN, &out.DSN
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Channels != nil {
+		in, out := &in.Channels, &out.Channels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(common.TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConnectionBackoff != nil {
+		in, out := &in.ConnectionBackoff, &out.ConnectionBackoff
+		*out = new(common.Backoff)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(EventSourceFilter)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresEventSource.
+func (in *PostgresEventSource) DeepCopy() *PostgresEventSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresEventSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusEventSource) DeepCopyInto(out *PrometheusEventSource) {
+	*out = *in
+	if in.Threshold != nil {
+		in, out := &in.Threshold, &out.Threshold
+		*out = new(common.Amount)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(common.TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(EventSourceFilter)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusEventSource.
+func (in *PrometheusEventSource) DeepCopy() *PrometheusEventSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusEventSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PubSubEventSource) DeepCopyInto(out *PubSubEventSource) {
 	*out = *in
@@ -1431,6 +2119,44 @@ func (in *RedisEventSource) DeepCopy() *RedisEventSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisStreamEventSource) DeepCopyInto(out *RedisStreamEventSource) {
+	*out = *in
+	if in.Password != nil {
+		in, out := &in.Password, &out.Password
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(common.TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(EventSourceFilter)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisStreamEventSource.
+func (in *RedisStreamEventSource) DeepCopy() *RedisStreamEventSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisStreamEventSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceEventSource) DeepCopyInto(out *ResourceEventSource) {
 	*out = *in
@@ -1835,6 +2561,44 @@ func (in *WatchPathConfig) DeepCopy() *WatchPathConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebSocketEventSource) DeepCopyInto(out *WebSocketEventSource) {
+	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(common.TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConnectionBackoff != nil {
+		in, out := &in.ConnectionBackoff, &out.ConnectionBackoff
+		*out = new(common.Backoff)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(EventSourceFilter)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSocketEventSource.
+func (in *WebSocketEventSource) DeepCopy() *WebSocketEventSource {
+	if in == nil {
+		return nil
+	}
+	out := new(WebSocketEventSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebhookContext) DeepCopyInto(out *WebhookContext) {
 	*out = *in
@@ -1860,6 +2624,11 @@ func (in *WebhookContext) DeepCopyInto(out *WebhookContext) {
 		*out = new(v1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SignatureValidation != nil {
+		in, out := &in.SignatureValidation, &out.SignatureValidation
+		*out = new(WebhookSignatureValidation)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1872,3 +2641,24 @@ func (in *WebhookContext) DeepCopy() *WebhookContext {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookSignatureValidation) DeepCopyInto(out *WebhookSignatureValidation) {
+	*out = *in
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookSignatureValidation.
+func (in *WebhookSignatureValidation) DeepCopy() *WebhookSignatureValidation {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookSignatureValidation)
+	in.DeepCopyInto(out)
+	return out
+}
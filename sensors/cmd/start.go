@@ -67,6 +67,7 @@ func Start() {
 
 	logger = logger.With("sensorName", sensor.Name)
 	ctx := logging.WithLogger(signals.SetupSignalHandler(), logger)
+	logging.ReloadLevelOnSignal(ctx, logger)
 	m := metrics.NewMetrics(sensor.Namespace)
 	go m.Run(ctx, fmt.Sprintf(":%d", common.SensorMetricsPort))
 
@@ -54,7 +54,7 @@ func NewHTTPTrigger(httpClients map[string]*http.Client, sensor *v1alpha1.Sensor
 		client = &http.Client{}
 
 		if httptrigger.TLS != nil {
-			tlsConfig, err := common.GetTLSConfig(httptrigger.TLS)
+			tlsConfig, err := common.GetTLSConfig(httptrigger.TLS, logger)
 			if err != nil {
 				return nil, errors.Wrap(err, "failed to get the tls configuration")
 			}
@@ -82,7 +82,7 @@ func NewKafkaTrigger(sensor *v1alpha1.Sensor, trigger *v1alpha1.Trigger, kafkaPr
 		}
 
 		if kafkatrigger.TLS != nil {
-			tlsConfig, err := common.GetTLSConfig(kafkatrigger.TLS)
+			tlsConfig, err := common.GetTLSConfig(kafkatrigger.TLS, logger)
 			if err != nil {
 				return nil, errors.Wrap(err, "failed to get the tls configuration")
 			}
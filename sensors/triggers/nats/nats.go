@@ -53,7 +53,7 @@ func NewNATSTrigger(sensor *v1alpha1.Sensor, trigger *v1alpha1.Trigger, natsConn
 		opts.Url = natstrigger.URL
 
 		if natstrigger.TLS != nil {
-			tlsConfig, err := common.GetTLSConfig(natstrigger.TLS)
+			tlsConfig, err := common.GetTLSConfig(natstrigger.TLS, logger)
 			if err != nil {
 				return nil, errors.Wrap(err, "failed to get the tls configuration")
 			}